@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"lazy-todo/internal/backup"
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/storage"
+)
+
+// runBackup implements `lazy-todo backup [--list]`
+func runBackup(args []string) {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	list := fs.Bool("list", false, i18n.T("Lister les sauvegardes au lieu d'en créer une"))
+	fs.Parse(args)
+
+	backups := openBackups(*filePath, *configPath, *lang)
+	if *list {
+		names, err := backups.List()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+			os.Exit(1)
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return
+	}
+
+	name, err := backups.Run(time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de sauvegarde distante: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	fmt.Println(name)
+}
+
+// runRestore implements `lazy-todo restore [--list | --from name] [name]`:
+// --list and --from work on the local backups, a name alone on the remote
+// ones
+func runRestore(args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	list := fs.Bool("list", false, i18n.T("Lister les sauvegardes locales (.backups)"))
+	from := fs.String("from", "", i18n.T("Restaurer une sauvegarde locale, par nom ou chemin (\"latest\" pour la plus récente)"))
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), i18n.T("Usage: lazy-todo restore [options] [sauvegarde]  (la plus récente par défaut)"))
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *list || *from != "" {
+		cfg := loadConfig(*configPath)
+		setLang(*lang)
+		store := storage.NewStorage(resolveFilePath(*filePath))
+		store.SetRotation(localRotation(cfg))
+		if *list {
+			listSnapshots(store)
+		} else {
+			restoreSnapshot(store, *from)
+		}
+		return
+	}
+
+	backups := openBackups(*filePath, *configPath, *lang)
+	name, err := backups.Restore(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de restauration: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	fmt.Printf(i18n.T("%s restaurée, l'ancien fichier est gardé en .bak\n"), name)
+}
+
+// openBackups returns the backups of the tasks file, exiting when none is
+// configured
+func openBackups(filePath, configPath, lang string) *backup.Backups {
+	cfg := loadConfig(configPath)
+	setLang(lang)
+
+	target, err := backup.NewTarget(cfg.Backup)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de configuration: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	store := storage.NewStorage(resolveFilePath(filePath))
+	store.SetRotation(localRotation(cfg))
+	return backup.New(store, target, cfg.Backup.Keep)
+}
+
+// listSnapshots prints the local backups of the tasks file, newest first,
+// with their time and number of tasks
+func listSnapshots(store *storage.Storage) {
+	snapshots, err := store.Snapshots()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	if len(snapshots) == 0 {
+		fmt.Printf(i18n.T("Aucune sauvegarde locale dans %s\n"), store.BackupDir())
+		return
+	}
+	slices.Reverse(snapshots)
+	for _, sn := range snapshots {
+		count := "?"
+		if data, err := os.ReadFile(sn.Path); err == nil {
+			if tasks, err := storage.Validate(data); err == nil {
+				count = i18n.N(tasks, "%d tâche", "%d tâches")
+			}
+		}
+		fmt.Printf("%s  %s  %s\n", sn.Name, sn.At.Local().Format("2006-01-02 15:04:05"), count)
+	}
+}
+
+// restoreSnapshot replaces the tasks file with a local backup, the latest
+// for "latest"
+func restoreSnapshot(store *storage.Storage, name string) {
+	if name == "latest" {
+		name = ""
+	}
+	sn, err := store.FindSnapshot(name)
+	if err == nil {
+		err = store.RestoreSnapshot(sn)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de restauration: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	fmt.Printf(i18n.T("%s restaurée, l'ancien fichier est gardé en .bak\n"), sn.Name)
+}
+
+// localRotation returns the retention of the local backups of the
+// configuration, nil when they are disabled
+func localRotation(cfg *config.Config) *storage.Rotation {
+	local := cfg.Backup.Local
+	if local.Disabled {
+		return nil
+	}
+	if local.Keep == 0 && local.MaxAge == 0 {
+		local.Keep = config.DefaultLocalBackupKeep
+	}
+	return &storage.Rotation{Keep: local.Keep, MaxAge: local.MaxAge}
+}
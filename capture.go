@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/parse"
+	"lazy-todo/internal/storage"
+)
+
+// runCapture implements `lazy-todo capture --text "..."`: it adds a task
+// from a quick-add line and prints its short ID (T-42), for voice
+// assistants and scripts
+func runCapture(args []string) {
+	fs := flag.NewFlagSet("capture", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	text := fs.String("text", "", i18n.T("Texte de la tâche, avec #tag !priorité @date"))
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), i18n.T("Usage: lazy-todo capture [options] --text \"texte\" | texte..."))
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	line := *text
+	if line == "" {
+		line = strings.Join(fs.Args(), " ")
+	}
+	task, ok := parse.Capture(line, cfg.Capture.Tags, time.Now())
+	if !ok {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	store.SetRotation(localRotation(cfg))
+	tasks, err := store.AddTask(task)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de sauvegarde: %v\n"), err)
+		os.Exit(1)
+	}
+	// The short ID given by the save, the ID before
+	if i := model.FindTask(tasks, task.ID); i >= 0 && tasks[i].Number > 0 {
+		fmt.Println(tasks[i].ShortID())
+		return
+	}
+	fmt.Println(task.ID)
+}
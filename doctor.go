@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+)
+
+// runDoctor implements `lazy-todo doctor`: it lists what is worth tidying
+// up in the tasks file, like tag colors no task has, open tasks without
+// tags or high priority ones without due date, and saves the fixes that
+// need no input with --fix. It exits with 1 when findings are left.
+func runDoctor(args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	priority := fs.String("priority", "", i18n.T("Priorité à partir de laquelle une tâche sans échéance est signalée"))
+	fix := fs.Bool("fix", false, i18n.T("Enregistrer les corrections qui ne demandent rien"))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+	if *priority != "" {
+		cfg.Doctor.DuePriority = *priority
+	}
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	store.SetRotation(localRotation(cfg))
+	tasks, err := store.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de chargement: %v\n"), err)
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	findings := model.Hygiene(tasks, store.TagColors(), cfg.Doctor.Priority(), now)
+	left := len(findings)
+	if *fix {
+		left, err = fixFindings(store, tasks, findings, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Erreur d'enregistrement: %v\n"), err)
+			os.Exit(1)
+		}
+	}
+
+	for _, f := range findings {
+		label := "#" + f.Title
+		if i := model.FindTask(tasks, f.TaskID); f.TaskID != "" && i >= 0 {
+			label = tasks[i].ShortID() + " " + f.Title
+		}
+		mark := " "
+		if *fix && f.Automatic() {
+			mark = "✓"
+		}
+		fmt.Printf("%s %s: %s\n", mark, label, f)
+	}
+	switch {
+	case len(findings) == 0:
+		fmt.Println(i18n.T("Rien à ranger"))
+	case *fix:
+		fmt.Println(i18n.Nf(len(findings)-left, "%d point corrigé, %d à voir dans l'interface (M)", "%d points corrigés, %d à voir dans l'interface (M)", len(findings)-left, left))
+	default:
+		fmt.Println(i18n.N(len(findings), "%d point à ranger, avec --fix ou dans l'interface (M)", "%d points à ranger, avec --fix ou dans l'interface (M)"))
+	}
+	if left > 0 {
+		os.Exit(1)
+	}
+}
+
+// fixFindings saves the fixes of the findings that need no input and
+// returns the number of findings left
+func fixFindings(store *storage.Storage, tasks []model.Task, findings []model.Finding, now time.Time) (int, error) {
+	colors := map[string]string{}
+	var fixed []int
+	left := 0
+	for _, f := range findings {
+		switch {
+		case f.Kind == model.FindingUnusedTag:
+			colors[f.Title] = ""
+		case f.Automatic():
+			if i := model.FindTask(tasks, f.TaskID); i >= 0 && tasks[i].Fix(f, now) && !slices.Contains(fixed, i) {
+				fixed = append(fixed, i)
+			}
+		default:
+			left++
+		}
+	}
+	var changes storage.Changes
+	for _, i := range fixed {
+		changes.Updated = append(changes.Updated, tasks[i])
+	}
+	if len(colors) > 0 {
+		if _, err := store.SetTagColors(colors); err != nil {
+			return left, err
+		}
+	}
+	if len(changes.Updated) > 0 {
+		if _, err := store.Commit(changes); err != nil {
+			return left, err
+		}
+	}
+	return left, nil
+}
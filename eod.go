@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/parse"
+	"lazy-todo/internal/storage"
+)
+
+// runEOD implements `lazy-todo eod`, the end of day review: each task in
+// progress or of the today view is marked done, carried over to tomorrow
+// or snoozed, then a journal entry is written for the day and a summary of
+// it printed
+func runEOD(args []string) {
+	fs := flag.NewFlagSet("eod", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	note := fs.String("note", "", i18n.T("Entrée du journal, au lieu de la demander"))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	store.SetRotation(localRotation(cfg))
+	tasks, err := store.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de chargement: %v\n"), err)
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	in := bufio.NewScanner(os.Stdin)
+	ask := func(prompt string) string {
+		fmt.Print(prompt)
+		if !in.Scan() {
+			return ""
+		}
+		return strings.TrimSpace(in.Text())
+	}
+
+	// Review of the tasks of the day
+	var changes storage.Changes
+	var carried, snoozed []model.Task
+	review := eodTasks(tasks, now)
+	if len(review) == 0 {
+		fmt.Println(i18n.T("Aucune tâche en cours ni prévue aujourd'hui"))
+	}
+	for i, t := range review {
+		fmt.Printf("\n[%d/%d] %s %s (%s)\n", i+1, len(review), t.ShortID(), t.Title, t.Status.Label())
+		answer := ask(i18n.T("d: terminée, c: reportée à demain, s [jour]: en pause jusqu'à ce jour (une semaine par défaut), Entrée: inchangée > "))
+		action, day, _ := strings.Cut(answer, " ")
+		switch strings.ToLower(action) {
+		case "d":
+			t.Status = model.StatusOfKind(model.StatusDone)
+			changes.Updated = append(changes.Updated, t)
+		case "c":
+			t.CarryOver(now)
+			changes.Updated = append(changes.Updated, t)
+			carried = append(carried, t)
+		case "s":
+			until, ok := parse.ParseDate(strings.TrimSpace(day), now)
+			if !ok {
+				until, _ = parse.ParseDate("+1w", now)
+			}
+			// Not overdue while put off
+			if t.DueDate != nil && t.DueDate.Before(until) {
+				t.DueDate = &until
+			}
+			until = until.Add(model.ReminderHour * time.Hour)
+			t.Status = model.StatusOfKind(model.StatusTodo)
+			t.Today = nil
+			t.SnoozeUntil(until)
+			changes.Updated = append(changes.Updated, t)
+			snoozed = append(snoozed, t)
+		}
+	}
+	if len(changes.Updated) > 0 {
+		if tasks, err = store.Commit(changes); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Erreur d'enregistrement: %v\n"), err)
+			os.Exit(1)
+		}
+	}
+
+	// Journal entry of the day
+	text := *note
+	if text == "" {
+		fmt.Println()
+		text = ask(i18n.T("Journal du jour (Entrée pour passer) > "))
+	}
+	if text != "" {
+		if _, err := store.AddJournalEntry(model.NewJournalEntry(text, now)); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Erreur d'enregistrement: %v\n"), err)
+			os.Exit(1)
+		}
+	}
+
+	printEODSummary(tasks, carried, snoozed, text, now)
+}
+
+// eodTasks returns the tasks the end of day review goes through: those in
+// progress, then the other open ones of the today view
+func eodTasks(tasks []model.Task, now time.Time) []model.Task {
+	var inProgress, today []model.Task
+	for _, t := range tasks {
+		switch {
+		case t.Status.IsDone():
+		case t.Status.Kind() == model.StatusInProgress:
+			inProgress = append(inProgress, t)
+		case t.InToday(now):
+			today = append(today, t)
+		}
+	}
+	return append(inProgress, today...)
+}
+
+// printEODSummary prints the tasks done during the day, the ones carried
+// over or snoozed by the review, and the journal entry
+func printEODSummary(tasks, carried, snoozed []model.Task, note string, now time.Time) {
+	y, m, d := now.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	var done []model.Task
+	for _, t := range tasks {
+		if t.Status.IsDone() && t.DoneSince(start) {
+			done = append(done, t)
+		}
+	}
+
+	fmt.Printf("\n%s\n", i18n.Tf("Fin de journée du %s", model.JournalDay(now)))
+	list := func(title string, tasks []model.Task) {
+		if len(tasks) == 0 {
+			return
+		}
+		fmt.Println(title)
+		for _, t := range tasks {
+			fmt.Printf("  %s %s\n", t.ShortID(), t.Title)
+		}
+	}
+	list(i18n.N(len(done), "%d tâche terminée aujourd'hui", "%d tâches terminées aujourd'hui"), done)
+	list(i18n.N(len(carried), "%d tâche reportée à demain", "%d tâches reportées à demain"), carried)
+	list(i18n.N(len(snoozed), "%d tâche en pause", "%d tâches en pause"), snoozed)
+	if len(done)+len(carried)+len(snoozed) == 0 {
+		fmt.Println(i18n.T("Aucune tâche terminée, reportée ni mise en pause"))
+	}
+	if note != "" {
+		fmt.Println(i18n.T("Journal:"), note)
+	}
+}
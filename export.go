@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"lazy-todo/internal/export"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+)
+
+// runExport implements `lazy-todo export`
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	format := fs.String("format", "md", i18n.T("Format d'export: md, json, csv ou ics (tâches avec une échéance)"))
+	filter := fs.String("filter", "", i18n.T("N'exporter que les tâches contenant ce texte"))
+	output := fs.String("output", "", i18n.T("Fichier de sortie (défaut: sortie standard)"))
+	activity := fs.Bool("activity", false, i18n.T("Exporter les changements d'état et de priorité, avec le journal, au lieu des tâches"))
+	worked := fs.Bool("time", false, i18n.T("Exporter le temps suivi par tâche et par jour au lieu des tâches"))
+	days := fs.Int("days", 7, i18n.T("Avec --activity ou --time, nombre de jours couverts"))
+	fs.Parse(args)
+
+	f, err := export.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), err)
+		os.Exit(2)
+	}
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	tasks, err := store.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de chargement: %v\n"), err)
+		os.Exit(1)
+	}
+
+	var selected []model.Task
+	for _, t := range tasks {
+		if t.Matches(*filter) {
+			selected = append(selected, t)
+		}
+	}
+
+	var data []byte
+	switch {
+	case *activity:
+		since := time.Now().AddDate(0, 0, -*days)
+		data, err = export.RenderActivity(f, export.ActivityLog(selected, since), export.JournalSince(store.Journal(), since))
+	case *worked:
+		now := time.Now()
+		y, m, d := now.Date()
+		since := time.Date(y, m, d-*days+1, 0, 0, 0, 0, now.Location())
+		data, err = export.RenderWorkLog(f, model.WorkLog(selected, since, now))
+	default:
+		data, err = export.Render(f, selected, cfg.ICS)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur d'export: %v\n"), err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur d'écriture: %v\n"), err)
+		os.Exit(1)
+	}
+}
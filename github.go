@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/integrations/github"
+	"lazy-todo/internal/storage"
+)
+
+// runGitHub implements `lazy-todo github`: it imports the issues assigned
+// to the user and closes the issues of the done tasks
+func runGitHub(args []string) {
+	fs := flag.NewFlagSet("github", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	client, err := github.New(cfg.GitHub)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de configuration: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	store.SetRotation(localRotation(cfg))
+	result, err := client.Sync(store)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	fmt.Println(i18n.N(result.Imported, "%d issue importée", "%d issues importées") + ", " +
+		i18n.N(result.Completed, "%d tâche terminée", "%d tâches terminées") + ", " +
+		i18n.N(result.Closed, "%d issue fermée", "%d issues fermées"))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/importer"
+	"lazy-todo/internal/storage"
+)
+
+// runImport implements `lazy-todo import --from todotxt|taskwarrior|apple-reminders|mstodo path`
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	from := fs.String("from", "", i18n.T("Format source: todotxt, taskwarrior, apple-reminders ou mstodo"))
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), i18n.T("Usage: lazy-todo import --from todotxt|taskwarrior|apple-reminders|mstodo [options] <fichier|->"))
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	src, err := importer.ParseSource(*from)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), err)
+		os.Exit(2)
+	}
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	var in io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	tasks, err := importer.Import(src, in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur d'import: %v\n"), err)
+		os.Exit(1)
+	}
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	store.SetRotation(localRotation(cfg))
+	_, added, err := store.AddTasks(tasks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de sauvegarde: %v\n"), err)
+		os.Exit(1)
+	}
+
+	fmt.Println(i18n.N(added, "%d tâche importée", "%d tâches importées") + ", " +
+		i18n.N(len(tasks)-added, "%d déjà présente", "%d déjà présentes"))
+}
@@ -0,0 +1,179 @@
+// Package backup copies the tasks file to remote storage, an S3-compatible
+// bucket or rclone-style commands, keeping a limited number of copies.
+package backup
+
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/storage"
+)
+
+// Errors returned by the backups
+var (
+	ErrNotConfigured = errors.New("aucune destination de sauvegarde configurée")
+	ErrNoBackup      = errors.New("aucune sauvegarde trouvée")
+)
+
+// timeFormat is the UTC timestamp in backup names; it sorts chronologically
+const timeFormat = "20060102T150405Z"
+
+// Target is a remote storage holding backups, by name
+type Target interface {
+	Put(name string, data []byte) error
+	Get(name string) ([]byte, error)
+	List() ([]string, error)
+	Delete(name string) error
+}
+
+// NewTarget returns the target configured in cfg, the S3 bucket first
+func NewTarget(cfg config.BackupConfig) (Target, error) {
+	switch {
+	case cfg.S3.Bucket != "":
+		return newS3(cfg.S3), nil
+	case cfg.Command.Upload != "":
+		return commandTarget{cfg.Command}, nil
+	}
+	return nil, ErrNotConfigured
+}
+
+// Backups backs up a tasks file to a target
+type Backups struct {
+	store  *storage.Storage
+	target Target
+	keep   int // number of backups kept, all when zero
+}
+
+// New returns the backups of a tasks file
+func New(store *storage.Storage, target Target, keep int) *Backups {
+	return &Backups{store: store, target: target, keep: keep}
+}
+
+// prefix starts the names of the backups of the tasks file: its base name
+// without extension, so several files can share a target
+func (b *Backups) prefix() string {
+	base := filepath.Base(b.store.GetFilePath())
+	return strings.TrimSuffix(base, filepath.Ext(base)) + "-"
+}
+
+// name returns the name of a backup taken at t
+func (b *Backups) name(t time.Time) string {
+	return b.prefix() + t.UTC().Format(timeFormat) + ".yaml"
+}
+
+// List returns the names of the backups of the tasks file, oldest first
+func (b *Backups) List() ([]string, error) {
+	all, err := b.target.List()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range all {
+		if _, ok := b.takenAt(name); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// takenAt returns when a backup of the tasks file was taken, false if name
+// isn't one
+func (b *Backups) takenAt(name string) (time.Time, bool) {
+	stamp, ok := strings.CutPrefix(name, b.prefix())
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(timeFormat, strings.TrimSuffix(stamp, ".yaml"))
+	return t, err == nil
+}
+
+// Due returns true if the last backup is older than interval
+func (b *Backups) Due(interval time.Duration, now time.Time) (bool, error) {
+	names, err := b.List()
+	if err != nil || len(names) == 0 {
+		return err == nil, err
+	}
+	last, _ := b.takenAt(names[len(names)-1])
+	return now.Sub(last) >= interval, nil
+}
+
+// Run uploads the tasks file, then deletes the oldest backups beyond the
+// number kept. It returns the name of the new backup. A file that can't
+// be loaded isn't uploaded, so that it can't replace the good backups.
+func (b *Backups) Run(now time.Time) (string, error) {
+	data, err := b.store.ReadRaw()
+	if err != nil {
+		return "", err
+	}
+	if err := b.store.Verify(data); err != nil {
+		return "", err
+	}
+	name := b.name(now)
+	if err := b.target.Put(name, data); err != nil {
+		return "", err
+	}
+	return name, b.prune()
+}
+
+// prune deletes the oldest backups beyond the number kept
+func (b *Backups) prune() error {
+	if b.keep <= 0 {
+		return nil
+	}
+	names, err := b.List()
+	if err != nil {
+		return err
+	}
+	for len(names) > b.keep {
+		if err := b.target.Delete(names[0]); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+// LatestValid returns the most recent backup holding tasks, when it was
+// taken, and its content
+func (b *Backups) LatestValid() (string, time.Time, []byte, error) {
+	names, err := b.List()
+	if err != nil {
+		return "", time.Time{}, nil, err
+	}
+	for i := len(names) - 1; i >= 0; i-- {
+		data, err := b.target.Get(names[i])
+		if err != nil {
+			return "", time.Time{}, nil, err
+		}
+		if n, err := storage.Validate(data); err == nil && n > 0 {
+			at, _ := b.takenAt(names[i])
+			return names[i], at, data, nil
+		}
+	}
+	return "", time.Time{}, nil, ErrNoBackup
+}
+
+// Restore replaces the tasks file with a backup, the latest when name is
+// empty. It returns the name of the restored backup.
+func (b *Backups) Restore(name string) (string, error) {
+	if name == "" {
+		names, err := b.List()
+		if err != nil {
+			return "", err
+		}
+		if len(names) == 0 {
+			return "", ErrNoBackup
+		}
+		name = names[len(names)-1]
+	}
+	data, err := b.target.Get(name)
+	if err != nil {
+		return "", err
+	}
+	return name, b.store.WriteRaw(data)
+}
@@ -0,0 +1,109 @@
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"lazy-todo/internal/config"
+)
+
+// commandTarget runs shell commands, for tools like rclone. {name} is
+// replaced by the backup name and {file} by a local file holding it.
+type commandTarget struct {
+	cfg config.BackupCommandConfig
+}
+
+// Put uploads a backup with the upload command
+func (c commandTarget) Put(name string, data []byte) error {
+	return withTempFile(func(path string) error {
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return err
+		}
+		_, err := run(c.cfg.Upload, name, path)
+		return err
+	})
+}
+
+// Get downloads a backup with the download command
+func (c commandTarget) Get(name string) ([]byte, error) {
+	var data []byte
+	err := withTempFile(func(path string) error {
+		if _, err := run(c.cfg.Download, name, path); err != nil {
+			return err
+		}
+		var err error
+		data, err = os.ReadFile(path)
+		return err
+	})
+	return data, err
+}
+
+// List returns the names printed by the list command, one per line
+func (c commandTarget) List() ([]string, error) {
+	out, err := run(c.cfg.List, "", "")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// Delete deletes a backup with the delete command, when configured
+func (c commandTarget) Delete(name string) error {
+	if c.cfg.Delete == "" {
+		return nil
+	}
+	_, err := run(c.cfg.Delete, name, "")
+	return err
+}
+
+// withTempFile runs fn with the path of a temporary file, removed after
+func withTempFile(fn func(path string) error) error {
+	f, err := os.CreateTemp("", "lazy-todo-backup-*.yaml")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+	return fn(f.Name())
+}
+
+// run runs a command template with the shell and returns its output
+func run(template, name, file string) (string, error) {
+	if template == "" {
+		return "", ErrNotConfigured
+	}
+	line := strings.NewReplacer("{name}", shellQuote(name), "{file}", shellQuote(file)).Replace(template)
+
+	cmd := exec.Command("sh", "-c", line)
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", line)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", err, msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// shellQuote quotes s for the shell
+func shellQuote(s string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + s + `"`
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
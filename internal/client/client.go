@@ -0,0 +1,310 @@
+// Package client calls the HTTP API of `lazy-todo serve`, as described by
+// its OpenAPI document (internal/server/openapi.yaml).
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+// Client calls a lazy-todo server
+type Client struct {
+	// BaseURL of the server, like http://127.0.0.1:8765
+	BaseURL string
+	// Token sent as a bearer token; none when empty
+	Token string
+	// HTTP is the client making the requests, http.DefaultClient when nil
+	HTTP *http.Client
+}
+
+// New returns a client of the server at baseURL
+func New(baseURL, token string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), Token: token}
+}
+
+// Error is an error returned by the server
+type Error struct {
+	Status  int
+	Message string `json:"error"`
+	// RetryAfter is the time to wait before the next request, for 429 Too
+	// Many Requests
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Status, http.StatusText(e.Status), e.Message)
+}
+
+// Task is a task returned by the server, with its short ID (T-42). Only
+// the fields selected by ListOptions.Fields are set.
+type Task struct {
+	model.Task
+	ShortID string `json:"short_id,omitempty"`
+}
+
+// ListOptions are the parameters of GET /tasks
+type ListOptions struct {
+	// Query in the language of the search, like "tag:work is:actionable"
+	Query string
+	// Limit is the number of tasks per page, the server's default when 0
+	Limit  int
+	Offset int
+	// Fields of the tasks to return, all of them when empty
+	Fields []string
+	// ETag of a previous page: the page is returned with NotModified set
+	// while it's the same
+	ETag string
+}
+
+// TasksPage is a page of tasks
+type TasksPage struct {
+	Tasks []Task `json:"tasks"`
+	// Total is the number of tasks matching the query
+	Total int `json:"total"`
+	// NextOffset is the offset of the next page, 0 on the last one
+	NextOffset int `json:"next_offset"`
+	// ETag of the page, for ListOptions.ETag
+	ETag string `json:"-"`
+	// NotModified is set when the page is the one of ListOptions.ETag,
+	// Tasks is empty then
+	NotModified bool `json:"-"`
+}
+
+// CaptureResult is the task added by Capture
+type CaptureResult struct {
+	ID      string `json:"id"`
+	ShortID string `json:"short_id"`
+	Title   string `json:"title"`
+}
+
+// Tasks returns a page of the tasks matching opts.Query
+func (c *Client) Tasks(ctx context.Context, opts ListOptions) (*TasksPage, error) {
+	params := url.Values{}
+	if opts.Query != "" {
+		params.Set("q", opts.Query)
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		params.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	if len(opts.Fields) > 0 {
+		params.Set("fields", strings.Join(opts.Fields, ","))
+	}
+	req, err := c.request(ctx, http.MethodGet, "/tasks?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ETag != "" {
+		req.Header.Set("If-None-Match", opts.ETag)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	page := &TasksPage{ETag: resp.Header.Get("ETag")}
+	if resp.StatusCode == http.StatusNotModified {
+		page.NotModified = true
+		return page, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// AllTasks returns every task matching a query, going through the pages
+func (c *Client) AllTasks(ctx context.Context, query string) ([]Task, error) {
+	var tasks []Task
+	opts := ListOptions{Query: query, Limit: 500}
+	for {
+		page, err := c.Tasks(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, page.Tasks...)
+		if page.NextOffset == 0 {
+			return tasks, nil
+		}
+		opts.Offset = page.NextOffset
+	}
+}
+
+// Capture adds a task from a quick-add line (#tag !priority @date)
+func (c *Client) Capture(ctx context.Context, text string) (CaptureResult, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return CaptureResult{}, err
+	}
+	req, err := c.request(ctx, http.MethodPost, "/capture", bytes.NewReader(body))
+	if err != nil {
+		return CaptureResult{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return CaptureResult{}, err
+	}
+	defer resp.Body.Close()
+	var result CaptureResult
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
+}
+
+// SetStatus moves a task, given by ID, short ID or number, to a status of
+// the workflow and returns it
+func (c *Client) SetStatus(ctx context.Context, ref string, status model.Status) (Task, error) {
+	body, err := json.Marshal(map[string]model.Status{"status": status})
+	if err != nil {
+		return Task{}, err
+	}
+	req, err := c.request(ctx, http.MethodPatch, "/tasks/"+url.PathEscape(ref), bytes.NewReader(body))
+	if err != nil {
+		return Task{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return Task{}, err
+	}
+	defer resp.Body.Close()
+	var task Task
+	err = json.NewDecoder(resp.Body).Decode(&task)
+	return task, err
+}
+
+// Calendar returns the iCalendar feed of the tasks with a due date
+func (c *Client) Calendar(ctx context.Context) ([]byte, error) {
+	req, err := c.request(ctx, http.MethodGet, "/calendar.ics", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// GraphQLError is an error of a GraphQL result
+type GraphQLError struct {
+	Messages []string
+}
+
+func (e *GraphQLError) Error() string {
+	return strings.Join(e.Messages, "; ")
+}
+
+// graphQLResult is the result of a GraphQL operation
+type graphQLResult struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// err returns the errors of the result as a *GraphQLError, nil without
+// errors
+func (r *graphQLResult) err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	gqlErr := &GraphQLError{}
+	for _, e := range r.Errors {
+		gqlErr.Messages = append(gqlErr.Messages, e.Message)
+	}
+	return gqlErr
+}
+
+// GraphQL runs a GraphQL query on a server with server.graphql set, and
+// decodes the data of its result into data. Errors of the result are
+// returned as a *GraphQLError, after decoding what data there is.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]any, data any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := c.request(ctx, http.MethodPost, "/graphql", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result graphQLResult
+	resp, err := c.do(req)
+	if err != nil {
+		// A query that doesn't match the schema is a 400 with a result
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.Status == http.StatusBadRequest &&
+			json.Unmarshal([]byte(apiErr.Message), &result) == nil && result.err() != nil {
+			return result.err()
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if data != nil && len(result.Data) > 0 {
+		if err := json.Unmarshal(result.Data, data); err != nil {
+			return err
+		}
+	}
+	return result.err()
+}
+
+// request returns an authenticated request to a path of the server
+func (c *Client) request(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return req, nil
+}
+
+// do sends a request, turning error statuses into an *Error
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 400 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	apiErr := &Error{Status: resp.StatusCode}
+	if data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10)); err == nil {
+		if json.Unmarshal(data, apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(data))
+		}
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return nil, apiErr
+}
@@ -0,0 +1,34 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/muesli/termenv"
+)
+
+// Copy writes text to the system clipboard. When no native clipboard is
+// available (SSH sessions, headless machines), it falls back to the OSC52
+// escape sequence so the terminal emulator can handle the copy itself.
+func Copy(text string) error {
+	if err := clipboard.WriteAll(text); err == nil {
+		return nil
+	}
+
+	termenv.NewOutput(os.Stdout).Copy(text)
+	return nil
+}
+
+// ErrNoClipboard is returned when the system clipboard can't be read, as
+// the terminal can't be asked for its content
+var ErrNoClipboard = errors.New("presse-papiers système non disponible")
+
+// Paste reads text from the system clipboard
+func Paste() (string, error) {
+	text, err := clipboard.ReadAll()
+	if err != nil {
+		return "", ErrNoClipboard
+	}
+	return text, nil
+}
@@ -0,0 +1,726 @@
+package config
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the user preferences loaded from the config file
+type Config struct {
+	// Path is the file the config was loaded from, where the settings
+	// changed in the app are written
+	Path string `yaml:"-"`
+
+	// Language selects the interface language (fr, en); the environment
+	// (LANG) is used when empty
+	Language string `yaml:"language,omitempty"`
+
+	Labels          LabelsConfig          `yaml:"labels,omitempty"`
+	Priorities      []PriorityLevelConfig `yaml:"priorities,omitempty"`
+	DefaultPriority string                `yaml:"default_priority,omitempty"`
+
+	// Workflow replaces the four built-in statuses, one kanban column each
+	Workflow []StatusConfig `yaml:"workflow,omitempty"`
+
+	// AutoAdvanceStatus moves a task to in progress when its first subtask
+	// is checked, and offers to mark it done when the last one is
+	AutoAdvanceStatus bool `yaml:"auto_advance_status,omitempty"`
+
+	StaleInProgress StaleConfig `yaml:"stale_in_progress,omitempty"`
+
+	Autosave AutosaveConfig `yaml:"autosave,omitempty"`
+
+	Timer TimerConfig `yaml:"timer,omitempty"`
+
+	Doctor DoctorConfig `yaml:"doctor,omitempty"`
+
+	Kanban KanbanConfig `yaml:"kanban,omitempty"`
+
+	List ListConfig `yaml:"list,omitempty"`
+
+	Display DisplayConfig `yaml:"display,omitempty"`
+
+	Git GitConfig `yaml:"git,omitempty"`
+
+	// Boards lists the tasks files opened as tabs when none is given on
+	// the command line
+	Boards []BoardConfig `yaml:"boards,omitempty"`
+
+	Capture CaptureConfig `yaml:"capture,omitempty"`
+
+	Server ServerConfig `yaml:"server,omitempty"`
+
+	ICS ICSConfig `yaml:"ics,omitempty"`
+
+	Backup BackupConfig `yaml:"backup,omitempty"`
+
+	GitHub GitHubConfig `yaml:"github,omitempty"`
+
+	Jira JiraConfig `yaml:"jira,omitempty"`
+
+	CalDAV CalDAVConfig `yaml:"caldav,omitempty"`
+
+	Reminders RemindersConfig `yaml:"reminders,omitempty"`
+
+	Share ShareConfig `yaml:"share,omitempty"`
+
+	// Webhooks are called on task events, for automation tools
+	Webhooks []WebhookConfig `yaml:"webhooks,omitempty"`
+
+	Urgency UrgencyConfig `yaml:"urgency,omitempty"`
+
+	// Sprints tasks can be assigned to
+	Sprints []SprintConfig `yaml:"sprints,omitempty"`
+
+	// Keys rebinds actions, by name (sort_by: o), to one key or a list
+	Keys map[string]KeyList `yaml:"keys,omitempty"`
+
+	// Chords binds sequences of keys typed after the leader key ("s p") to
+	// actions, besides the default ones; "none" removes a chord
+	Chords map[string]string `yaml:"chords,omitempty"`
+}
+
+// KeyList is the keys of an action, written as one key or a list
+type KeyList []string
+
+// UnmarshalYAML reads a single key as a list of one
+func (k *KeyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = KeyList{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*k = list
+	return nil
+}
+
+// KeyOverrides returns the rebound actions with their keys
+func (c *Config) KeyOverrides() map[string][]string {
+	overrides := make(map[string][]string, len(c.Keys))
+	for name, keys := range c.Keys {
+		overrides[name] = keys
+	}
+	return overrides
+}
+
+// SprintConfig is a sprint, from the day of Start to the day of End
+// included (2026-10-05)
+type SprintConfig struct {
+	Name  string `yaml:"name"`
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// UrgencyConfig sorts the tasks by urgency score on startup and makes the
+// overdue tasks at a high priority stand out. The score is also available
+// as a sort option without it.
+type UrgencyConfig struct {
+	Enabled bool           `yaml:"enabled,omitempty"`
+	Weights UrgencyWeights `yaml:"weights,omitempty"`
+}
+
+// UrgencyWeights overrides the weights of the terms of the urgency score;
+// model.DefaultUrgencyWeights for the ones left out
+type UrgencyWeights struct {
+	Priority *float64 `yaml:"priority,omitempty"`
+	Due      *float64 `yaml:"due,omitempty"`
+	Age      *float64 `yaml:"age,omitempty"`
+}
+
+// ShareConfig uploads tasks to a paste service, which returns the link to
+// share them
+type ShareConfig struct {
+	// URL the tasks are posted to as a multipart file, like
+	// https://0x0.st; sharing is disabled when empty
+	URL string `yaml:"url,omitempty"`
+	// Field of the form holding the file; "file" when empty
+	Field string `yaml:"field,omitempty"`
+	// Expires asks the service to delete the paste after this duration,
+	// sent in hours as the "expires" field; the service's default when zero
+	Expires time.Duration `yaml:"expires,omitempty"`
+	// Token is sent as a bearer token, for private services
+	Token string `yaml:"token,omitempty"`
+	// Description includes the descriptions of the tasks, left out by
+	// default as they may hold private details
+	Description bool `yaml:"description,omitempty"`
+}
+
+// RemindersConfig applies to `lazy-todo remind`, which shows the reminders
+// of the tasks as desktop notifications, and to the reminders shown in the
+// footer of the TUI
+type RemindersConfig struct {
+	// Interval between two checks; a minute when zero
+	Interval time.Duration `yaml:"interval,omitempty"`
+	// Snooze postpones a reminder snoozed from its notification; 10
+	// minutes when zero
+	Snooze time.Duration `yaml:"snooze,omitempty"`
+	// Bell rings the terminal bell when a reminder goes off in the TUI
+	Bell bool `yaml:"bell,omitempty"`
+}
+
+// JiraConfig imports the issues of a JQL query, with `lazy-todo jira`, and
+// moves them through the Jira workflow when their task changes status
+type JiraConfig struct {
+	// URL of the Jira site (https://example.atlassian.net)
+	URL string `yaml:"url,omitempty"`
+	// Email of the Jira Cloud account; the token is sent as a bearer
+	// personal access token (Jira Data Center) when empty
+	Email string `yaml:"email,omitempty"`
+	// Token is an API token; JIRA_API_TOKEN when empty
+	Token string `yaml:"token,omitempty"`
+	// JQL selects the imported issues; those assigned to the user and not
+	// done when empty
+	JQL string `yaml:"jql,omitempty"`
+	// Tag added to the imported tasks; "jira" when empty
+	Tag string `yaml:"tag,omitempty"`
+	// Transitions names the Jira transition to apply for a task status
+	// or its kind (todo, in_progress, done); the first one leading to a
+	// status of the same category is used otherwise
+	Transitions map[string]string `yaml:"transitions,omitempty"`
+}
+
+// CalDAVConfig syncs the tasks both ways with a task list of a CalDAV
+// server (Nextcloud Tasks, iCloud Reminders), with `lazy-todo sync`
+type CalDAVConfig struct {
+	// URL of the calendar collection holding the tasks
+	// (https://cloud.example.com/remote.php/dav/calendars/me/tasks/)
+	URL      string `yaml:"url,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	// Password of the account, or an app password; CALDAV_PASSWORD when
+	// empty
+	Password string `yaml:"password,omitempty"`
+	// Filter selects the tasks sent to the server the first time, as a
+	// search (tag:perso -status:done); all when empty. Tasks synced once
+	// stay synced.
+	Filter string `yaml:"filter,omitempty"`
+}
+
+// WebhookConfig posts task events to a URL, in the background: created,
+// updated, completed, reopened, status_changed and deleted
+type WebhookConfig struct {
+	URL string `yaml:"url"`
+	// Events sent to the webhook; all when empty
+	Events []string `yaml:"events,omitempty"`
+	// Method of the request; POST when empty
+	Method  string            `yaml:"method,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Template of the body, a Go template of the event ({{.Event}},
+	// {{.Task.Title}}, {{json .Task}}); the event as JSON when empty
+	Template string `yaml:"template,omitempty"`
+	// Retries after a failed request, with a doubling delay; 3 when zero,
+	// none when negative
+	Retries int `yaml:"retries,omitempty"`
+	// Timeout of a request; 10s when zero
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// GitHubConfig imports the issues of a repository assigned to the user,
+// with `lazy-todo github`
+type GitHubConfig struct {
+	// Repo is owner/name
+	Repo string `yaml:"repo,omitempty"`
+	// Token is a personal access token; GITHUB_TOKEN when empty
+	Token string `yaml:"token,omitempty"`
+	// APIURL of GitHub Enterprise; github.com when empty
+	APIURL string `yaml:"api_url,omitempty"`
+}
+
+// BackupConfig copies the tasks file to an S3-compatible bucket, or with
+// commands (rclone...), every Interval while the interface runs and with
+// `lazy-todo backup`
+type BackupConfig struct {
+	// Interval between automatic backups; none when zero
+	Interval time.Duration `yaml:"interval,omitempty"`
+	// Keep is the number of backups kept, all when zero
+	Keep    int                 `yaml:"keep,omitempty"`
+	S3      S3Config            `yaml:"s3,omitempty"`
+	Command BackupCommandConfig `yaml:"command,omitempty"`
+	Local   LocalBackupConfig   `yaml:"local,omitempty"`
+}
+
+// LocalBackupConfig is the retention of the copies of the tasks file kept
+// in a .backups directory next to it before each save: Keep copies, or
+// the ones younger than MaxAge (DefaultLocalBackupKeep when neither is
+// set)
+type LocalBackupConfig struct {
+	Disabled bool          `yaml:"disabled,omitempty"`
+	Keep     int           `yaml:"keep,omitempty"`
+	MaxAge   time.Duration `yaml:"max_age,omitempty"`
+}
+
+// DefaultLocalBackupKeep is the number of local backups kept when no
+// retention is configured
+const DefaultLocalBackupKeep = 20
+
+// S3Config is an S3-compatible bucket (AWS, MinIO, R2...). The credentials
+// default to AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+type S3Config struct {
+	// Endpoint URL; AWS when empty
+	Endpoint  string `yaml:"endpoint,omitempty"`
+	Region    string `yaml:"region,omitempty"`
+	Bucket    string `yaml:"bucket,omitempty"`
+	Prefix    string `yaml:"prefix,omitempty"`
+	AccessKey string `yaml:"access_key,omitempty"`
+	SecretKey string `yaml:"secret_key,omitempty"`
+	// PathStyle puts the bucket in the path rather than the host name, as
+	// most self-hosted servers expect
+	PathStyle bool `yaml:"path_style,omitempty"`
+}
+
+// BackupCommandConfig lists shell commands handling the backups, where
+// {name} is the backup name and {file} a local copy of it
+type BackupCommandConfig struct {
+	Upload   string `yaml:"upload,omitempty"`   // rclone copyto {file} remote:tasks/{name}
+	Download string `yaml:"download,omitempty"` // rclone copyto remote:tasks/{name} {file}
+	List     string `yaml:"list,omitempty"`     // rclone lsf remote:tasks, a name per line
+	Delete   string `yaml:"delete,omitempty"`   // rclone deletefile remote:tasks/{name}
+}
+
+// CaptureConfig applies to the tasks captured from outside the interface,
+// with `lazy-todo capture` or the server's POST /capture
+type CaptureConfig struct {
+	// Tags added to every captured task, like "inbox"
+	Tags []string `yaml:"tags,omitempty"`
+}
+
+// ServerConfig configures `lazy-todo serve`
+type ServerConfig struct {
+	// Addr to listen on; DefaultServerAddr when empty
+	Addr string `yaml:"addr,omitempty"`
+	// Token required as "Authorization: Bearer <token>"; requests aren't
+	// authenticated when empty and Tokens is too
+	Token string `yaml:"token,omitempty"`
+	// Tokens are more tokens, each with a scope limiting what it sees
+	Tokens []ServerToken `yaml:"tokens,omitempty"`
+	// RateLimit is the number of requests a client can make per minute;
+	// DefaultRateLimit when 0, unlimited when negative
+	RateLimit int `yaml:"rate_limit,omitempty"`
+	// GraphQL exposes POST /graphql, with subscriptions streamed as
+	// server-sent events
+	GraphQL bool `yaml:"graphql,omitempty"`
+	// UI is the mode of the web page of the board served as /ui:
+	// ServerUIRead when empty, ServerUIWrite or ServerUIOff
+	UI string `yaml:"ui,omitempty"`
+	// Origins are the pages of other sites allowed to open GET /ws, like
+	// "http://localhost:3000" or "*" for any; only pages served by the
+	// server are otherwise
+	Origins []string `yaml:"origins,omitempty"`
+}
+
+// Modes of the web page of the server
+const (
+	ServerUIRead  = "read"  // shows the board
+	ServerUIWrite = "write" // also ticks tasks done, with a token that can write
+	ServerUIOff   = "off"
+)
+
+// ServerToken is a token of the server with its scope
+type ServerToken struct {
+	Token string     `yaml:"token"`
+	Scope TokenScope `yaml:"scope,omitempty"`
+}
+
+// TokenScope limits what a token of the server can do
+type TokenScope string
+
+const (
+	// ScopeFull sees and captures every task, like server.token
+	ScopeFull TokenScope = "full"
+	// ScopeTeam captures tasks but doesn't see the private ones, for a
+	// team dashboard
+	ScopeTeam TokenScope = "team"
+	// ScopeRead doesn't see the private tasks and can't capture
+	ScopeRead TokenScope = "read"
+)
+
+// SeesPrivate returns true if the scope sees the private tasks; an empty
+// scope is ScopeFull
+func (s TokenScope) SeesPrivate() bool {
+	return s == "" || s == ScopeFull
+}
+
+// CanWrite returns true if the scope can capture tasks. An unknown scope
+// is taken as ScopeRead, not to give more than meant.
+func (s TokenScope) CanWrite() bool {
+	return s.SeesPrivate() || s == ScopeTeam
+}
+
+// DefaultServerAddr only accepts local connections
+const DefaultServerAddr = "127.0.0.1:8765"
+
+// DefaultRateLimit is the number of requests per minute of a client of the
+// server, enough for a dashboard polling every few seconds
+const DefaultRateLimit = 120
+
+// ICSConfig shapes the iCalendar feed of the tasks with a due date, written
+// by `export --format ics` and served as /calendar.ics
+type ICSConfig struct {
+	// Name of the calendar; "lazy-todo" when empty
+	Name string `yaml:"name,omitempty"`
+	// Entries written for each task: event (the default, shown by every
+	// calendar app), todo, or both
+	Entries string `yaml:"entries,omitempty"`
+	// Alarms replaces the alarms of a priority (high: [24h, 1h]) by how
+	// long before the due date they ring; an empty list sets none
+	Alarms map[string][]time.Duration `yaml:"alarms,omitempty"`
+}
+
+// BoardConfig is a tasks file opened as a tab, named after the file when
+// Name is empty
+type BoardConfig struct {
+	Name string `yaml:"name,omitempty"`
+	File string `yaml:"file"`
+	// Colors of the board's columns, over the ones of the kanban settings
+	ColumnTheme `yaml:",inline"`
+}
+
+// GitConfig commits the tasks file to the git repository containing it
+// after each change, and syncs it with a remote
+type GitConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Remote to pull from and push to; "origin" when empty
+	Remote      string `yaml:"remote,omitempty"`
+	PullOnStart bool   `yaml:"pull_on_start,omitempty"`
+	PushOnExit  bool   `yaml:"push_on_exit,omitempty"`
+}
+
+// DisplayConfig tunes rendering for slow links and motion sensitivity
+type DisplayConfig struct {
+	// ReducedMotion disables blinking cursors and other animations
+	ReducedMotion bool `yaml:"reduced_motion,omitempty"`
+	// HideHints leaves the keys to try out of empty views
+	HideHints bool `yaml:"hide_hints,omitempty"`
+	// FPS caps the render frequency; Bubble Tea's default (60) when zero
+	FPS int `yaml:"fps,omitempty"`
+	// StartFilter is the search query applied on startup, like
+	// "is:actionable" to land on the tasks that can be started now
+	StartFilter string `yaml:"start_filter,omitempty"`
+	// TagColors sets the background of tags by name, a color of the tag
+	// palette ("teal") or "#rrggbb"; the colors set on the tags screen win
+	TagColors map[string]string `yaml:"tag_colors,omitempty"`
+	// LongDescription is the number of words past which the task form
+	// warns that a description is long; 300 when zero, never when negative
+	LongDescription int `yaml:"long_description,omitempty"`
+	// HideImages draws the images linked in a description by name only,
+	// even on a terminal that can show them (kitty, WezTerm, iTerm2)
+	HideImages bool `yaml:"hide_images,omitempty"`
+}
+
+// KanbanConfig holds the kanban board preferences
+type KanbanConfig struct {
+	// HideDone leaves the Done column out of the board
+	HideDone bool `yaml:"hide_done,omitempty"`
+	// WIPLimits caps the number of tasks of a column, keyed by status;
+	// moving a task into a full column asks for confirmation
+	WIPLimits map[string]int `yaml:"wip_limits,omitempty"`
+	// Swimlanes starts the board in swimlanes across the columns, by
+	// priority or tag, toggled with w
+	Swimlanes string `yaml:"swimlanes,omitempty"`
+
+	ColumnTheme `yaml:",inline"`
+}
+
+// ListConfig holds the list view preferences
+type ListConfig struct {
+	// Columns lists the columns of the list in order, among id, priority,
+	// severity, status, title, tags, due, age and spent; the default ones
+	// when empty
+	Columns []ListColumn `yaml:"columns,omitempty"`
+	// Density is compact (a line per task, default) or detailed (with a
+	// snippet of the description and a line of dates), toggled with D
+	Density string `yaml:"density,omitempty"`
+}
+
+// List densities
+const (
+	ListDensityCompact  = "compact"
+	ListDensityDetailed = "detailed"
+)
+
+// Swimlanes of the kanban board
+const (
+	SwimlanesPriority = "priority"
+	SwimlanesTag      = "tag"
+)
+
+// ListColumn is a column of the list, written as its field or as
+// {field: due, width: 10}. Width is in cells, fitted to the values when
+// zero; priority, severity and status show their icon in 1 or 2 cells.
+type ListColumn struct {
+	Field string `yaml:"field"`
+	Width int    `yaml:"width,omitempty"`
+}
+
+// UnmarshalYAML reads a column written as its field alone
+func (c *ListColumn) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*c = ListColumn{Field: node.Value}
+		return nil
+	}
+	type plain ListColumn
+	return node.Decode((*plain)(c))
+}
+
+// ColumnTheme colors the kanban columns: ColumnColors tints the title and
+// border of a column, keyed by status, with a color of the tag palette
+// ("red") or "#rrggbb", and ColumnBackground fills the columns with a
+// subtle shade of their color
+type ColumnTheme struct {
+	ColumnColors     map[string]string `yaml:"column_colors,omitempty"`
+	ColumnBackground *bool             `yaml:"column_background,omitempty"`
+}
+
+// Merge returns the theme with the settings of other over its own
+func (t ColumnTheme) Merge(other ColumnTheme) ColumnTheme {
+	merged := ColumnTheme{ColumnColors: maps.Clone(t.ColumnColors), ColumnBackground: t.ColumnBackground}
+	if merged.ColumnColors == nil {
+		merged.ColumnColors = map[string]string{}
+	}
+	maps.Copy(merged.ColumnColors, other.ColumnColors)
+	if other.ColumnBackground != nil {
+		merged.ColumnBackground = other.ColumnBackground
+	}
+	return merged
+}
+
+// AutosaveConfig controls when changes are written: immediately (default),
+// Delay after the last change ("debounced"), or only on ctrl+s and on quit
+// ("manual")
+type AutosaveConfig struct {
+	Mode  string        `yaml:"mode,omitempty"`
+	Delay time.Duration `yaml:"delay,omitempty"`
+}
+
+// Autosave modes
+const (
+	AutosaveImmediate = "immediate"
+	AutosaveDebounced = "debounced"
+	AutosaveManual    = "manual"
+)
+
+// DefaultAutosaveDelay is the debounce delay used when none is configured
+const DefaultAutosaveDelay = 2 * time.Second
+
+// StaleConfig flags in progress tasks without updates for Days days, or
+// moves them back to todo when Action is "move"
+type StaleConfig struct {
+	Days   int    `yaml:"days,omitempty"`
+	Action string `yaml:"action,omitempty"`
+}
+
+// Stale actions
+const (
+	StaleActionFlag = "flag"
+	StaleActionMove = "move"
+)
+
+// TimerConfig controls the work session timer (f): after IdleAfter without
+// a key pressed, the time idle can be kept or left out of the session
+// (DefaultIdleAfter when zero, never when negative)
+type TimerConfig struct {
+	IdleAfter time.Duration `yaml:"idle_after,omitempty"`
+}
+
+// DefaultIdleAfter is the time without a key pressed after which a running
+// timer asks what to do with it, when none is configured
+const DefaultIdleAfter = 15 * time.Minute
+
+// DoctorConfig tunes the maintenance report (lazy-todo doctor, M): open
+// tasks from DuePriority up without due date are reported, from the second
+// highest priority when empty
+type DoctorConfig struct {
+	DuePriority string `yaml:"due_priority,omitempty"`
+}
+
+// Priority returns the priority from which open tasks without due date are
+// reported
+func (c DoctorConfig) Priority() model.Priority {
+	if c.DuePriority == "" {
+		return model.DefaultDuePriority()
+	}
+	return model.MigratePriority(model.Priority(c.DuePriority))
+}
+
+// LabelsConfig overrides the displayed labels and icons of statuses and
+// priorities, keyed by their stored value (todo, in_progress, high...)
+type LabelsConfig struct {
+	Status   map[string]LabelConfig `yaml:"status,omitempty"`
+	Priority map[string]LabelConfig `yaml:"priority,omitempty"`
+}
+
+// LabelConfig is a display override for a single value
+type LabelConfig struct {
+	Label string `yaml:"label,omitempty"`
+	Icon  string `yaml:"icon,omitempty"`
+}
+
+// PriorityLevelConfig defines a custom priority level. Levels are listed
+// lowest first; Aliases lists legacy values migrated to this level.
+type PriorityLevelConfig struct {
+	Value   string   `yaml:"value"`
+	Label   string   `yaml:"label,omitempty"`
+	Icon    string   `yaml:"icon,omitempty"`
+	Color   string   `yaml:"color,omitempty"`
+	Weight  *int     `yaml:"weight,omitempty"`
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// StatusConfig defines a workflow status. Statuses are listed in board
+// order; Kind is the built-in status it behaves as (todo, in_progress,
+// blocked, done), the status itself for a built-in value and todo
+// otherwise. Aliases lists legacy values migrated to this status.
+type StatusConfig struct {
+	Value   string   `yaml:"value"`
+	Label   string   `yaml:"label,omitempty"`
+	Icon    string   `yaml:"icon,omitempty"`
+	Color   string   `yaml:"color,omitempty"`
+	Kind    string   `yaml:"kind,omitempty"`
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// Default returns the default configuration
+func Default() *Config {
+	return &Config{}
+}
+
+// DefaultConfigPath returns the default path for the config file
+func DefaultConfigPath() string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "config.yaml"
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configDir, "lazy-todo", "config.yaml")
+}
+
+// Load reads the config file, falling back to defaults if it doesn't exist
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	cfg.Path = path
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Apply registers the model-level settings: language, display labels,
+// priority levels and workflow. Presentation settings (icons, colors) are applied by the UI.
+func (c *Config) Apply() {
+	if l, ok := i18n.ParseLocale(c.Language); ok {
+		i18n.SetLocale(l)
+	}
+
+	for value, l := range c.Labels.Status {
+		if l.Label != "" {
+			model.SetStatusLabel(model.Status(value), l.Label)
+		}
+	}
+	for value, l := range c.Labels.Priority {
+		if l.Label != "" {
+			model.SetPriorityLabel(model.Priority(value), l.Label)
+		}
+	}
+
+	c.applyWorkflow()
+	c.applyUrgency()
+	c.applySprints()
+
+	if len(c.Priorities) == 0 {
+		return
+	}
+
+	levels := make([]model.Priority, 0, len(c.Priorities))
+	for _, level := range c.Priorities {
+		p := model.Priority(level.Value)
+		levels = append(levels, p)
+		if level.Label != "" {
+			model.SetPriorityLabel(p, level.Label)
+		}
+		if level.Weight != nil {
+			model.SetPriorityWeight(p, *level.Weight)
+		}
+		for _, alias := range level.Aliases {
+			model.SetPriorityAlias(model.Priority(alias), p)
+		}
+	}
+	model.SetPriorities(levels, model.Priority(c.DefaultPriority))
+}
+
+// applyWorkflow registers the workflow statuses
+func (c *Config) applyWorkflow() {
+	if len(c.Workflow) == 0 {
+		return
+	}
+	statuses := make([]model.Status, 0, len(c.Workflow))
+	for _, st := range c.Workflow {
+		s := model.Status(st.Value)
+		statuses = append(statuses, s)
+		if st.Label != "" {
+			model.SetStatusLabel(s, st.Label)
+		}
+		if kind := model.Status(st.Kind); kind.IsKind() {
+			model.SetStatusKind(s, kind)
+		}
+		for _, alias := range st.Aliases {
+			model.SetStatusAlias(model.Status(alias), s)
+		}
+	}
+	model.SetWorkflow(statuses)
+}
+
+// applyUrgency sets the weights of the urgency score
+func (c *Config) applyUrgency() {
+	w := model.DefaultUrgencyWeights
+	if p := c.Urgency.Weights.Priority; p != nil {
+		w.Priority = *p
+	}
+	if d := c.Urgency.Weights.Due; d != nil {
+		w.Due = *d
+	}
+	if a := c.Urgency.Weights.Age; a != nil {
+		w.Age = *a
+	}
+	model.SetUrgencyWeights(w)
+}
+
+// applySprints registers the sprints, leaving out the ones without name or
+// with invalid dates
+func (c *Config) applySprints() {
+	var sprints []model.Sprint
+	for _, sc := range c.Sprints {
+		start, err := time.ParseInLocation("2006-01-02", sc.Start, time.Local)
+		if err != nil {
+			continue
+		}
+		end, err := time.ParseInLocation("2006-01-02", sc.End, time.Local)
+		if err != nil || sc.Name == "" || end.Before(start) {
+			continue
+		}
+		sprints = append(sprints, model.Sprint{Name: sc.Name, Start: start, End: end})
+	}
+	model.SetSprints(sprints)
+}
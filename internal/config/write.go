@@ -0,0 +1,74 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotMapping is returned when a setting can't be written because the
+// config file, or the section holding it, isn't a mapping
+var ErrNotMapping = errors.New("le fichier de configuration n'est pas un dictionnaire YAML")
+
+// Set writes a setting to the config file, like Set("detailed", "list",
+// "density"), keeping the rest of the file and its comments. The file is
+// created when missing; nothing is written for a config that wasn't
+// loaded from a file.
+func (c *Config) Set(value string, keys ...string) error {
+	if c.Path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	node := doc.Content[0]
+	for _, key := range keys {
+		if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+			// A section left empty
+			*node = yaml.Node{Kind: yaml.MappingNode}
+		}
+		if node.Kind != yaml.MappingNode {
+			return ErrNotMapping
+		}
+		node = mappingValue(node, key)
+	}
+	// Replaces an empty section as well as a value
+	*node = yaml.Node{Kind: yaml.ScalarNode, Value: value, HeadComment: node.HeadComment, LineComment: node.LineComment}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, buf.Bytes(), 0o644)
+}
+
+// mappingValue returns the value of a key of a mapping node, added as an
+// empty mapping when missing
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
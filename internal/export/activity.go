@@ -0,0 +1,129 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+)
+
+// Activity is a status or priority change of a task, a line of the
+// activity log
+type Activity struct {
+	model.Change
+	TaskID string `json:"task_id"`
+	Title  string `json:"title"`
+}
+
+// ActivityLog returns the changes made to tasks since a time, oldest first
+func ActivityLog(tasks []model.Task, since time.Time) []Activity {
+	var log []Activity
+	for _, t := range tasks {
+		for _, c := range t.Since(since) {
+			log = append(log, Activity{Change: c, TaskID: t.ID, Title: t.Title})
+		}
+	}
+	sort.SliceStable(log, func(i, j int) bool {
+		return log[i].At.Before(log[j].At)
+	})
+	return log
+}
+
+// RenderActivity renders an activity log in the given format, the
+// Markdown one with the journal of the days next to their changes
+func RenderActivity(f Format, log []Activity, journal []model.JournalEntry) ([]byte, error) {
+	switch f {
+	case FormatMarkdown:
+		return []byte(ActivityMarkdown(log, journal)), nil
+	case FormatJSON:
+		if log == nil {
+			log = []Activity{}
+		}
+		data, err := json.MarshalIndent(log, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatCSV:
+		return activityCSV(log)
+	}
+	return nil, errors.New(i18n.Tf("format inconnu: %q", f))
+}
+
+// ActivityMarkdown renders an activity log as a list grouped by day, for
+// weekly reports. The journal of a day is quoted before its changes, days
+// with only a journal included.
+func ActivityMarkdown(log []Activity, journal []model.JournalEntry) string {
+	var b strings.Builder
+	b.WriteString("# " + i18n.T("Activité") + "\n")
+
+	byDay := make(map[string][]Activity)
+	days := make(map[string]bool)
+	for _, a := range log {
+		d := a.At.Local().Format(time.DateOnly)
+		byDay[d] = append(byDay[d], a)
+		days[d] = true
+	}
+	for _, e := range journal {
+		days[e.Day] = true
+	}
+
+	for _, day := range slices.Sorted(maps.Keys(days)) {
+		fmt.Fprintf(&b, "\n## %s\n\n", day)
+		entries := model.DayJournal(journal, day)
+		for i, e := range entries {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString("> " + strings.ReplaceAll(strings.TrimSpace(e.Text), "\n", "\n> ") + "\n")
+		}
+		if len(entries) > 0 && len(byDay[day]) > 0 {
+			b.WriteString("\n")
+		}
+		for _, a := range byDay[day] {
+			fmt.Fprintf(&b, "- %s **%s** — %s\n", a.At.Local().Format("15:04"), a.Title, a.Describe())
+		}
+	}
+	return b.String()
+}
+
+// JournalSince returns the entries of the journal about the days since a
+// time
+func JournalSince(journal []model.JournalEntry, since time.Time) []model.JournalEntry {
+	first := model.JournalDay(since.Local())
+	var entries []model.JournalEntry
+	for _, e := range journal {
+		if e.Day >= first {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// activityCSV renders an activity log as CSV with one row per change
+func activityCSV(log []Activity) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"at", "task_id", "title", "field", "from", "to"}); err != nil {
+		return nil, err
+	}
+	for _, a := range log {
+		record := []string{a.At.Format(time.RFC3339), a.TaskID, a.Title, a.Field, a.From, a.To}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,181 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+)
+
+// Format represents an export format
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+	// FormatICS is an iCalendar feed of the tasks with a due date, see ICS
+	FormatICS Format = "ics"
+)
+
+// AllFormats returns all supported export formats
+func AllFormats() []Format {
+	return []Format{FormatMarkdown, FormatJSON, FormatCSV, FormatICS}
+}
+
+// ParseFormat converts a format name to a Format
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	case "ics", "ical", "icalendar":
+		return FormatICS, nil
+	}
+	return "", errors.New(i18n.Tf("format inconnu: %q (md, json, csv ou ics)", name))
+}
+
+// Extension returns the file extension for the format
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Render renders tasks in the given format; the iCalendar feed is shaped
+// by the ics settings
+func Render(f Format, tasks []model.Task, ics config.ICSConfig) ([]byte, error) {
+	switch f {
+	case FormatMarkdown:
+		return []byte(Markdown(tasks)), nil
+	case FormatJSON:
+		return JSON(tasks)
+	case FormatCSV:
+		return CSV(tasks)
+	case FormatICS:
+		return ICS(tasks, ics, time.Now())
+	}
+	return nil, errors.New(i18n.Tf("format inconnu: %q", f))
+}
+
+// Markdown renders tasks as a checklist grouped by status
+func Markdown(tasks []model.Task) string {
+	var b strings.Builder
+	b.WriteString("# " + i18n.T("Tâches") + "\n")
+
+	for _, status := range model.AllStatuses() {
+		var group []model.Task
+		for _, t := range tasks {
+			if t.Status == status {
+				group = append(group, t)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n## %s\n\n", status.Label())
+		for _, t := range group {
+			writeMarkdownTask(&b, t)
+		}
+	}
+
+	return b.String()
+}
+
+// writeMarkdownTask writes a single checklist item with its details
+func writeMarkdownTask(b *strings.Builder, t model.Task) {
+	box := " "
+	if t.Status.IsDone() {
+		box = "x"
+	}
+
+	meta := []string{t.Priority.Label()}
+	if t.Severity != model.SeverityNone {
+		meta = append(meta, t.Severity.Label())
+	}
+	if t.DueDate != nil {
+		meta = append(meta, i18n.T("échéance ")+t.DueDate.Format("2006-01-02"))
+	}
+	for _, tag := range t.Tags {
+		meta = append(meta, "#"+tag)
+	}
+
+	fmt.Fprintf(b, "- [%s] **%s** — %s\n", box, t.Title, strings.Join(meta, " · "))
+
+	if desc := strings.TrimSpace(t.Description); desc != "" {
+		for _, line := range strings.Split(desc, "\n") {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	for _, st := range t.Subtasks {
+		box := " "
+		if st.Done {
+			box = "x"
+		}
+		fmt.Fprintf(b, "  - [%s] %s\n", box, st.Title)
+	}
+}
+
+// JSON renders tasks as an indented JSON array
+func JSON(tasks []model.Task) ([]byte, error) {
+	if tasks == nil {
+		tasks = []model.Task{}
+	}
+	data, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// CSV renders tasks as CSV with one row per task
+func CSV(tasks []model.Task) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{
+		"id", "title", "description", "priority", "status", "severity",
+		"tags", "subtasks_done", "subtasks_total", "due_date", "created_at", "updated_at",
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, t := range tasks {
+		done, total := t.SubtaskProgress()
+		due := ""
+		if t.DueDate != nil {
+			due = t.DueDate.Format("2006-01-02")
+		}
+		record := []string{
+			t.ID,
+			t.Title,
+			t.Description,
+			string(t.Priority),
+			string(t.Status),
+			string(t.Severity),
+			strings.Join(t.Tags, ";"),
+			strconv.Itoa(done),
+			strconv.Itoa(total),
+			due,
+			t.CreatedAt.Format(time.RFC3339),
+			t.UpdatedAt.Format(time.RFC3339),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,275 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+)
+
+// Entries of the iCalendar feed for each task
+const (
+	ICSEvent = "event"
+	ICSTodo  = "todo"
+	ICSBoth  = "both"
+)
+
+const (
+	icsTimeFormat = "20060102T150405Z"
+	icsDateFormat = "20060102"
+	// allDayAlarmHour is the time of day the alarms of a task due on a
+	// day, without a time, count back from
+	allDayAlarmHour = 9
+)
+
+// ICS renders the tasks with a due date as an iCalendar feed, an event or a
+// to-do for each (or both, per cfg.Entries), ringing alarms before the due
+// date of the open tasks by their priority
+func ICS(tasks []model.Task, cfg config.ICSConfig, now time.Time) ([]byte, error) {
+	entries := cfg.Entries
+	if entries == "" {
+		entries = ICSEvent
+	}
+	if entries != ICSEvent && entries != ICSTodo && entries != ICSBoth {
+		return nil, errors.New(i18n.Tf("entrées ICS inconnues: %q (event, todo ou both)", cfg.Entries))
+	}
+	name := cfg.Name
+	if name == "" {
+		name = "lazy-todo"
+	}
+
+	w := &icsWriter{cfg: cfg, now: now}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//lazy-todo//lazy-todo//EN")
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + icsText(name))
+	for _, t := range tasks {
+		if t.DueDate == nil {
+			continue
+		}
+		if entries != ICSTodo {
+			w.event(t)
+		}
+		if entries != ICSEvent {
+			w.todo(t, TodoUID(t.ID))
+		}
+	}
+	w.line("END:VCALENDAR")
+	return w.b.Bytes(), nil
+}
+
+// icsWriter writes the lines of an iCalendar feed
+type icsWriter struct {
+	b   bytes.Buffer
+	cfg config.ICSConfig
+	now time.Time
+}
+
+// line writes a content line, folded at 75 bytes without splitting a
+// character, with the CRLF ending of the format
+func (w *icsWriter) line(s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.b.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		limit = 74 // after the leading space
+	}
+	w.b.WriteString(s + "\r\n")
+}
+
+// event writes a task as an event on its due date, all day when it has no
+// time; done tasks are checked in the summary
+func (w *icsWriter) event(t model.Task) {
+	summary := t.Title
+	if t.Status.IsDone() {
+		summary = "✓ " + summary
+	}
+	w.line("BEGIN:VEVENT")
+	w.common(t, "event-"+t.ID+"@lazy-todo", summary)
+	if allDay(*t.DueDate) {
+		day := t.DueDate.In(time.Local)
+		w.line("DTSTART;VALUE=DATE:" + day.Format(icsDateFormat))
+		w.line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format(icsDateFormat))
+	} else {
+		w.line("DTSTART:" + t.DueDate.UTC().Format(icsTimeFormat))
+	}
+	w.line("TRANSP:TRANSPARENT")
+	w.alarms(t)
+	w.line("END:VEVENT")
+}
+
+// TodoUID returns the UID of the to-do of a task
+func TodoUID(id string) string {
+	return "todo-" + id + "@lazy-todo"
+}
+
+// TodoCalendar renders a task as a calendar holding its to-do alone, of
+// the given UID, as stored by CalDAV servers
+func TodoCalendar(t model.Task, uid string, cfg config.ICSConfig, now time.Time) []byte {
+	w := &icsWriter{cfg: cfg, now: now}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//lazy-todo//lazy-todo//EN")
+	w.todo(t, uid)
+	w.line("END:VCALENDAR")
+	return w.b.Bytes()
+}
+
+// todo writes a task as a to-do, due on its due date if it has one. The
+// status is also written as is, for the statuses iCalendar doesn't have.
+func (w *icsWriter) todo(t model.Task, uid string) {
+	w.line("BEGIN:VTODO")
+	w.common(t, uid, t.Title)
+	switch {
+	case t.DueDate == nil:
+	case allDay(*t.DueDate):
+		w.line("DUE;VALUE=DATE:" + t.DueDate.In(time.Local).Format(icsDateFormat))
+	default:
+		w.line("DUE:" + t.DueDate.UTC().Format(icsTimeFormat))
+	}
+	w.line("STATUS:" + ICSStatus(t.Status))
+	if t.Status.IsDone() {
+		w.line("PERCENT-COMPLETE:100")
+	}
+	w.line("X-LAZY-TODO-STATUS:" + icsText(string(t.Status)))
+	if t.DueDate != nil {
+		w.alarms(t)
+	}
+	w.line("END:VTODO")
+}
+
+// ICSStatus returns the to-do status of a task status, from its kind;
+// blocked tasks still need action
+func ICSStatus(s model.Status) string {
+	switch {
+	case s.IsDone():
+		return "COMPLETED"
+	case s.Kind() == model.StatusInProgress:
+		return "IN-PROCESS"
+	}
+	return "NEEDS-ACTION"
+}
+
+// common writes the properties of both entries of a task
+func (w *icsWriter) common(t model.Task, uid, summary string) {
+	w.line("UID:" + icsText(uid))
+	w.line("DTSTAMP:" + w.now.UTC().Format(icsTimeFormat))
+	if !t.CreatedAt.IsZero() {
+		w.line("CREATED:" + t.CreatedAt.UTC().Format(icsTimeFormat))
+	}
+	if !t.UpdatedAt.IsZero() {
+		w.line("LAST-MODIFIED:" + t.UpdatedAt.UTC().Format(icsTimeFormat))
+	}
+	w.line("SUMMARY:" + icsText(summary))
+	if desc := strings.TrimSpace(t.Description); desc != "" {
+		w.line("DESCRIPTION:" + icsText(desc))
+	}
+	if len(t.Tags) > 0 {
+		tags := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			tags[i] = icsText(tag)
+		}
+		w.line("CATEGORIES:" + strings.Join(tags, ","))
+	}
+	if p := ICSPriority(t.Priority); p > 0 {
+		w.line("PRIORITY:" + strconv.Itoa(p))
+	}
+}
+
+// alarms writes the alarms of an open task, at fixed times so they ring
+// the same in every app: before the due time, or before allDayAlarmHour
+// of the due day
+func (w *icsWriter) alarms(t model.Task) {
+	if t.Status.IsDone() {
+		return
+	}
+	due := *t.DueDate
+	if allDay(due) {
+		y, m, d := due.In(time.Local).Date()
+		due = time.Date(y, m, d, allDayAlarmHour, 0, 0, 0, time.Local)
+	}
+	for _, lead := range alarmLeads(t.Priority, w.cfg) {
+		w.line("BEGIN:VALARM")
+		w.line("ACTION:DISPLAY")
+		w.line("DESCRIPTION:" + icsText(t.Title))
+		w.line("TRIGGER;VALUE=DATE-TIME:" + due.Add(-lead).UTC().Format(icsTimeFormat))
+		w.line("END:VALARM")
+	}
+}
+
+// alarmLeads returns how long before the due date the alarms of a priority
+// ring: the ones of ics.alarms, else a day and an hour before for the
+// highest level, a day before for the next one, none below
+func alarmLeads(p model.Priority, cfg config.ICSConfig) []time.Duration {
+	p = model.MigratePriority(p)
+	if leads, ok := cfg.Alarms[string(p)]; ok {
+		return leads
+	}
+	levels := model.AllPriorities()
+	switch i := p.Index(); {
+	case i < 0:
+		return nil
+	case i == len(levels)-1:
+		return []time.Duration{24 * time.Hour, time.Hour}
+	case i == len(levels)-2:
+		return []time.Duration{24 * time.Hour}
+	}
+	return nil
+}
+
+// ICSPriority maps a priority to the iCalendar scale, from 1 (highest) to
+// 9 (lowest); 0 (undefined) for an unknown level
+func ICSPriority(p model.Priority) int {
+	levels := model.AllPriorities()
+	i := model.MigratePriority(p).Index()
+	switch {
+	case i < 0:
+		return 0
+	case len(levels) == 1:
+		return 5
+	}
+	return 9 - 8*i/(len(levels)-1)
+}
+
+// PriorityOfICS returns the level nearest to an iCalendar priority, the
+// middle one on a tie (5 is medium of the built-in levels); false for 0
+// (undefined)
+func PriorityOfICS(p int) (model.Priority, bool) {
+	if p < 1 || p > 9 {
+		return "", false
+	}
+	levels := model.AllPriorities()
+	i := int(math.Ceil(float64((9-p)*(len(levels)-1))/8 - 0.5))
+	return levels[i], true
+}
+
+// allDay returns true if a due date has no time of day: midnight in the
+// local time zone
+func allDay(due time.Time) bool {
+	local := due.In(time.Local)
+	h, m, s := local.Clock()
+	return h == 0 && m == 0 && s == 0 && local.Nanosecond() == 0
+}
+
+// icsText escapes a text value
+var icsText = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", "",
+).Replace
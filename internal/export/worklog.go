@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+)
+
+// workEntry is a line of the work log in JSON
+type workEntry struct {
+	Day       string   `json:"day"`
+	TaskID    string   `json:"task_id"`
+	Title     string   `json:"title"`
+	Tags      []string `json:"tags,omitempty"`
+	Minutes   int      `json:"minutes"`
+	Pomodoros int      `json:"pomodoros"`
+}
+
+// RenderWorkLog renders the time worked on tasks per day in the given
+// format, for invoices
+func RenderWorkLog(f Format, log []model.WorkEntry) ([]byte, error) {
+	switch f {
+	case FormatMarkdown:
+		return []byte(WorkLogMarkdown(log)), nil
+	case FormatJSON:
+		entries := []workEntry{}
+		for _, e := range log {
+			entries = append(entries, workEntry{e.Day, e.TaskID, e.Title, e.Tags, minutes(e.Duration), e.Pomodoros})
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatCSV:
+		return workLogCSV(log)
+	}
+	return nil, errors.New(i18n.Tf("format inconnu: %q", f))
+}
+
+// WorkLogMarkdown renders the time worked as a list per day, then the
+// totals per tag
+func WorkLogMarkdown(log []model.WorkEntry) string {
+	var b strings.Builder
+	b.WriteString("# " + i18n.T("Temps suivi") + "\n")
+
+	for _, day := range model.WorkByDay(log) {
+		fmt.Fprintf(&b, "\n## %s — %s\n\n", day.Key, formatMinutes(day.Duration))
+		for _, e := range log {
+			if e.Day == day.Key {
+				fmt.Fprintf(&b, "- %s **%s**\n", formatMinutes(e.Duration), e.Title)
+			}
+		}
+	}
+
+	if totals := model.WorkByTag(log); len(totals) > 0 {
+		b.WriteString("\n## " + i18n.T("Par tag") + "\n\n")
+		for _, t := range totals {
+			tag := t.Key
+			if tag == "" {
+				tag = i18n.T("Sans tag")
+			}
+			fmt.Fprintf(&b, "- %s: %s\n", tag, formatMinutes(t.Duration))
+		}
+	}
+	return b.String()
+}
+
+// workLogCSV renders the time worked as CSV with one row per task and day,
+// in minutes
+func workLogCSV(log []model.WorkEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"day", "task_id", "title", "tags", "minutes", "pomodoros"}); err != nil {
+		return nil, err
+	}
+	for _, e := range log {
+		record := []string{e.Day, e.TaskID, e.Title, strings.Join(e.Tags, ";"),
+			strconv.Itoa(minutes(e.Duration)), strconv.Itoa(e.Pomodoros)}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// minutes returns a duration in whole minutes, rounded
+func minutes(d time.Duration) int {
+	return int(d.Round(time.Minute).Minutes())
+}
+
+// formatMinutes formats a duration in hours and minutes, like "2:05"
+func formatMinutes(d time.Duration) string {
+	m := minutes(d)
+	return fmt.Sprintf("%d:%02d", m/60, m%60)
+}
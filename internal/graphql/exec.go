@@ -0,0 +1,347 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"lazy-todo/internal/i18n"
+)
+
+// Resolver returns the value of a root field for its arguments, shaped
+// like decoded JSON: map[string]any, []any and scalars (see JSON)
+type Resolver func(ctx context.Context, args map[string]any) (any, error)
+
+// RootField is a field of the query or subscription type
+type RootField struct {
+	Type    string            // like "Stats" or "[Task]"
+	Args    map[string]string // types of the arguments accepted, by name
+	Resolve Resolver
+}
+
+// Schema describes the fields of the types and resolves the root fields
+type Schema struct {
+	Query map[string]RootField
+	// Subscription fields are resolved again by the caller on each change
+	Subscription map[string]RootField
+	// Types lists the fields of the object types with their types: an
+	// object type, [Type] for a list of them, or a scalar (String, Int,
+	// Float, Boolean)
+	Types map[string]map[string]string
+}
+
+// Error is an error of a result
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// Result is the response to an operation
+type Result struct {
+	Data   *Object `json:"data"`
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Object is a result object, its fields in the order they were selected
+type Object struct {
+	keys   []string
+	values map[string]any
+}
+
+// set sets a field of the object
+func (o *Object) set(key string, value any) {
+	if o.values == nil {
+		o.values = map[string]any{}
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON writes the fields in their order
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// JSON converts a value to the shape resolvers return, through its JSON
+func JSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	err = json.Unmarshal(data, &out)
+	return out, err
+}
+
+// Execute runs an operation with the values of its variables. Selections
+// that don't match the schema fail the whole operation; a root field that
+// fails to resolve is null, with its error.
+func (s *Schema) Execute(ctx context.Context, op *Operation, vars map[string]any) Result {
+	roots, err := s.roots(op)
+	if err != nil {
+		return Result{Errors: []Error{{Message: err.Error()}}}
+	}
+	for _, f := range op.Selections {
+		if err := s.validate(roots, f, "", nil); err != nil {
+			return Result{Errors: []Error{{Message: err.Error()}}}
+		}
+	}
+
+	var result Result
+	data := &Object{}
+	for _, f := range op.Selections {
+		if f.Name == "__typename" {
+			data.set(f.Alias, typeName(op.Type))
+			continue
+		}
+		root := roots[f.Name]
+		args, err := arguments(f.Args, vars, op.Defaults)
+		var value any
+		if err == nil {
+			value, err = root.Resolve(ctx, args)
+		}
+		if err != nil {
+			data.set(f.Alias, nil)
+			result.Errors = append(result.Errors, Error{Message: i18n.T(err.Error()), Path: []any{f.Alias}})
+			continue
+		}
+		data.set(f.Alias, s.complete(value, root.Type, f.Selections))
+	}
+	result.Data = data
+	return result
+}
+
+// roots returns the root fields of the type of an operation
+func (s *Schema) roots(op *Operation) (map[string]RootField, error) {
+	if op.Type != "subscription" {
+		return s.Query, nil
+	}
+	if len(op.Selections) != 1 {
+		return nil, errors.New(i18n.T("Un abonnement sélectionne un seul champ"))
+	}
+	return s.Subscription, nil
+}
+
+// typeName returns the name of the root type of an operation type
+func typeName(opType string) string {
+	if opType == "subscription" {
+		return "Subscription"
+	}
+	return "Query"
+}
+
+// validate checks a selected field against the schema: a root field when
+// parent is empty, a field of parent otherwise
+func (s *Schema) validate(roots map[string]RootField, f *Field, parent string, path []string) error {
+	if f.Name == "__typename" {
+		return nil
+	}
+	path = append(path, f.Name)
+	var typ string
+	if parent == "" {
+		root, ok := roots[f.Name]
+		if !ok {
+			return errors.New(i18n.Tf("Champ inconnu: %s", f.Name))
+		}
+		for arg := range f.Args {
+			if _, ok := root.Args[arg]; !ok {
+				return errors.New(i18n.Tf("Argument inconnu: %s(%s)", f.Name, arg))
+			}
+		}
+		typ = root.Type
+	} else {
+		var ok bool
+		if typ, ok = s.Types[parent][f.Name]; !ok {
+			return errors.New(i18n.Tf("Champ inconnu: %s", strings.Join(path, ".")))
+		}
+		if len(f.Args) > 0 {
+			return errors.New(i18n.Tf("Arguments non pris en charge: %s", strings.Join(path, ".")))
+		}
+	}
+
+	elem := strings.Trim(typ, "[]")
+	_, object := s.Types[elem]
+	switch {
+	case object && len(f.Selections) == 0:
+		return errors.New(i18n.Tf("Sélection requise: %s", strings.Join(path, ".")))
+	case !object && len(f.Selections) > 0:
+		return errors.New(i18n.Tf("Pas de sélection sur une valeur simple: %s", strings.Join(path, ".")))
+	}
+	for _, sub := range f.Selections {
+		if err := s.validate(nil, sub, elem, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// arguments returns the values of the arguments of a field, with the
+// variables replaced
+func arguments(args, vars, defaults map[string]any) (map[string]any, error) {
+	values := make(map[string]any, len(args))
+	for name, v := range args {
+		value, err := resolveVariables(v, vars, defaults)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// resolveVariables replaces the variables of an argument value
+func resolveVariables(v any, vars, defaults map[string]any) (any, error) {
+	switch v := v.(type) {
+	case Variable:
+		if value, ok := vars[string(v)]; ok {
+			return value, nil
+		}
+		if value, ok := defaults[string(v)]; ok {
+			return value, nil
+		}
+		return nil, nil
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			value, err := resolveVariables(item, vars, defaults)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case map[string]any:
+		obj := make(map[string]any, len(v))
+		for k, item := range v {
+			value, err := resolveVariables(item, vars, defaults)
+			if err != nil {
+				return nil, err
+			}
+			obj[k] = value
+		}
+		return obj, nil
+	}
+	return v, nil
+}
+
+// complete shapes a value of a type after the selections
+func (s *Schema) complete(value any, typ string, selections []*Field) any {
+	if value == nil {
+		return nil
+	}
+	if elem, ok := strings.CutPrefix(typ, "["); ok {
+		elem = strings.TrimSuffix(elem, "]")
+		list, _ := value.([]any)
+		out := make([]any, len(list))
+		for i, item := range list {
+			out[i] = s.complete(item, elem, selections)
+		}
+		return out
+	}
+	fields, object := s.Types[typ]
+	if !object {
+		return value
+	}
+	m, _ := value.(map[string]any)
+	obj := &Object{}
+	for _, f := range selections {
+		if f.Name == "__typename" {
+			obj.set(f.Alias, typ)
+			continue
+		}
+		obj.set(f.Alias, s.complete(m[f.Name], fields[f.Name], f.Selections))
+	}
+	return obj
+}
+
+// SDL describes the schema in the GraphQL schema language
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	writeRoots := func(name string, roots map[string]RootField) {
+		if len(roots) == 0 {
+			return
+		}
+		b.WriteString("type " + name + " {\n")
+		for _, field := range sortedKeys(roots) {
+			root := roots[field]
+			b.WriteString("  " + field)
+			if len(root.Args) > 0 {
+				var args []string
+				for _, arg := range sortedKeys(root.Args) {
+					args = append(args, arg+": "+root.Args[arg])
+				}
+				b.WriteString("(" + strings.Join(args, ", ") + ")")
+			}
+			b.WriteString(": " + root.Type + "\n")
+		}
+		b.WriteString("}\n\n")
+	}
+	writeRoots("Query", s.Query)
+	writeRoots("Subscription", s.Subscription)
+	for _, name := range sortedKeys(s.Types) {
+		b.WriteString("type " + name + " {\n")
+		for _, field := range sortedKeys(s.Types[name]) {
+			b.WriteString("  " + field + ": " + s.Types[name][field] + "\n")
+		}
+		b.WriteString("}\n\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Int reads an Int argument, def when it's missing or null
+func Int(args map[string]any, name string, def int) (int, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return def, nil
+	case int:
+		return v, nil
+	case float64:
+		// From the JSON of the variables
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	}
+	return 0, errors.New(i18n.Tf("Argument %s: nombre entier attendu", name))
+}
+
+// String reads a String argument, empty when it's missing or null
+func String(args map[string]any, name string) (string, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	return "", errors.New(i18n.Tf("Argument %s: texte attendu", name))
+}
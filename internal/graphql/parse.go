@@ -0,0 +1,464 @@
+// Package graphql runs GraphQL queries over values shaped like JSON. It
+// covers what dashboards send: queries and subscriptions, aliases,
+// arguments and variables; fragments, directives and mutations are
+// refused.
+package graphql
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"lazy-todo/internal/i18n"
+)
+
+// maxDepth is the deepest nesting of selections accepted
+const maxDepth = 16
+
+// Document is a parsed GraphQL request
+type Document struct {
+	Operations []*Operation
+}
+
+// Operation is a query or a subscription of a document
+type Operation struct {
+	Type       string // "query" or "subscription"
+	Name       string
+	Defaults   map[string]any // default values of the variables
+	Selections []*Field
+}
+
+// Field is a selected field, with its arguments and the fields selected
+// in its value
+type Field struct {
+	Alias      string // the field name when not aliased
+	Name       string
+	Args       map[string]any // Variable for $name
+	Selections []*Field
+}
+
+// Variable is an argument given as $name
+type Variable string
+
+// Parse parses a GraphQL document
+func Parse(src string) (*Document, error) {
+	p := &parser{src: src}
+	p.next()
+	doc := &Document{}
+	for p.tok.kind != tokEOF {
+		op, err := p.operation()
+		if err != nil {
+			return nil, err
+		}
+		doc.Operations = append(doc.Operations, op)
+	}
+	if len(doc.Operations) == 0 {
+		return nil, errors.New(i18n.T("Requête GraphQL vide"))
+	}
+	return doc, nil
+}
+
+// Operation returns the operation of a name, the only one when name is
+// empty
+func (d *Document) Operation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) > 1 {
+			return nil, errors.New(i18n.T("Plusieurs opérations: operationName est requis"))
+		}
+		return d.Operations[0], nil
+	}
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, errors.New(i18n.Tf("Opération inconnue: %s", name))
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+	tokError
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+}
+
+// parser is a recursive descent parser reading one token ahead
+type parser struct {
+	src   string
+	pos   int
+	line  int
+	tok   token
+	depth int
+}
+
+// errorf returns a syntax error at the current token
+func (p *parser) errorf(format string, args ...any) error {
+	return errors.New(i18n.Tf("Ligne %d: ", p.tok.line+1) + i18n.Tf(format, args...))
+}
+
+// next reads the next token, skipping spaces, commas and comments
+func (p *parser) next() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\n':
+			p.line++
+			p.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			p.tok = p.scan()
+			return
+		}
+	}
+	p.tok = token{kind: tokEOF, line: p.line}
+}
+
+// scan reads the token at the current position
+func (p *parser) scan() token {
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		return token{kind: tokPunct, value: "...", line: p.line}
+	case strings.ContainsRune("!$():=@[]{}|&", rune(c)):
+		p.pos++
+		return token{kind: tokPunct, value: string(c), line: p.line}
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		return token{kind: tokName, value: p.src[start:p.pos], line: p.line}
+	case c == '-' || isDigit(c):
+		p.pos++
+		kind := tokInt
+		for p.pos < len(p.src) {
+			d := p.src[p.pos]
+			if d == '.' || d == 'e' || d == 'E' || ((d == '+' || d == '-') && kind == tokFloat) {
+				kind = tokFloat
+			} else if !isDigit(d) {
+				break
+			}
+			p.pos++
+		}
+		return token{kind: kind, value: p.src[start:p.pos], line: p.line}
+	case c == '"':
+		return p.scanString()
+	}
+	_, size := utf8.DecodeRuneInString(p.src[p.pos:])
+	p.pos += size
+	return token{kind: tokError, value: p.src[start:p.pos], line: p.line}
+}
+
+// scanString reads a string, block strings ("""...""") included
+func (p *parser) scanString() token {
+	line := p.line
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		end := strings.Index(p.src[p.pos+3:], `"""`)
+		if end < 0 {
+			p.pos = len(p.src)
+			return token{kind: tokError, value: `"""`, line: line}
+		}
+		value := p.src[p.pos+3 : p.pos+3+end]
+		p.line += strings.Count(value, "\n")
+		p.pos += end + 6
+		return token{kind: tokString, value: strings.TrimSpace(value), line: line}
+	}
+
+	end := p.pos + 1
+	for end < len(p.src) && p.src[end] != '"' && p.src[end] != '\n' {
+		if p.src[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	if end >= len(p.src) || p.src[end] != '"' {
+		p.pos = end
+		return token{kind: tokError, value: `"`, line: line}
+	}
+	raw := p.src[p.pos : end+1]
+	p.pos = end + 1
+	value, err := strconv.Unquote(raw)
+	if err != nil {
+		return token{kind: tokError, value: raw, line: line}
+	}
+	return token{kind: tokString, value: value, line: line}
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+// is returns true if the current token is a punctuator
+func (p *parser) is(punct string) bool {
+	return p.tok.kind == tokPunct && p.tok.value == punct
+}
+
+// expect skips a punctuator, failing if it's another token
+func (p *parser) expect(punct string) error {
+	if !p.is(punct) {
+		return p.errorf("« %s » attendu, « %s » trouvé", punct, p.tok.value)
+	}
+	p.next()
+	return nil
+}
+
+// name reads a name
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.errorf("nom attendu, « %s » trouvé", p.tok.value)
+	}
+	name := p.tok.value
+	p.next()
+	return name, nil
+}
+
+// operation reads an operation, or a query without its keyword
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{Type: "query"}
+	if p.tok.kind == tokName {
+		switch p.tok.value {
+		case "query", "subscription":
+			op.Type = p.tok.value
+		case "mutation":
+			return nil, p.errorf("mutations non prises en charge")
+		case "fragment":
+			return nil, p.errorf("fragments non pris en charge")
+		default:
+			return nil, p.errorf("opération inconnue « %s »", p.tok.value)
+		}
+		p.next()
+		if p.tok.kind == tokName {
+			op.Name = p.tok.value
+			p.next()
+		}
+		if p.is("(") {
+			defaults, err := p.variables()
+			if err != nil {
+				return nil, err
+			}
+			op.Defaults = defaults
+		}
+	}
+	if p.is("@") {
+		return nil, p.errorf("directives non prises en charge")
+	}
+	sel, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.Selections = sel
+	return op, nil
+}
+
+// variables reads the definitions of the variables, keeping their
+// default values; their types aren't checked
+func (p *parser) variables() (map[string]any, error) {
+	defaults := map[string]any{}
+	p.next()
+	for !p.is(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if err := p.typeRef(); err != nil {
+			return nil, err
+		}
+		if p.is("=") {
+			p.next()
+			value, err := p.value(true)
+			if err != nil {
+				return nil, err
+			}
+			defaults[name] = value
+		}
+	}
+	p.next()
+	return defaults, nil
+}
+
+// typeRef skips a type, like [String!]!
+func (p *parser) typeRef() error {
+	if p.is("[") {
+		p.next()
+		if err := p.typeRef(); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.name(); err != nil {
+		return err
+	}
+	if p.is("!") {
+		p.next()
+	}
+	return nil
+}
+
+// selectionSet reads the fields between braces
+func (p *parser) selectionSet() ([]*Field, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	p.depth++
+	if p.depth > maxDepth {
+		return nil, p.errorf("sélections trop imbriquées")
+	}
+	var fields []*Field
+	for !p.is("}") {
+		if p.tok.kind == tokEOF {
+			return nil, p.errorf("« } » attendu, fin de la requête trouvée")
+		}
+		if p.is("...") {
+			return nil, p.errorf("fragments non pris en charge")
+		}
+		f, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	p.next()
+	p.depth--
+	if len(fields) == 0 {
+		return nil, p.errorf("sélection vide")
+	}
+	return fields, nil
+}
+
+// field reads a field with its alias, arguments and selections
+func (p *parser) field() (*Field, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	f := &Field{Alias: name, Name: name}
+	if p.is(":") {
+		p.next()
+		if f.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("(") {
+		p.next()
+		f.Args = map[string]any{}
+		for !p.is(")") {
+			arg, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if f.Args[arg], err = p.value(false); err != nil {
+				return nil, err
+			}
+		}
+		p.next()
+	}
+	if p.is("@") {
+		return nil, p.errorf("directives non prises en charge")
+	}
+	if p.is("{") {
+		if f.Selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// value reads an argument value; constant ones can't hold variables
+func (p *parser) value(constant bool) (any, error) {
+	tok := p.tok
+	switch {
+	case p.is("$") && !constant:
+		p.next()
+		name, err := p.name()
+		return Variable(name), err
+	case p.is("["):
+		p.next()
+		list := []any{}
+		for !p.is("]") {
+			if p.tok.kind == tokEOF {
+				return nil, p.errorf("« ] » attendu, fin de la requête trouvée")
+			}
+			v, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		p.next()
+		return list, nil
+	case p.is("{"):
+		p.next()
+		obj := map[string]any{}
+		for !p.is("}") {
+			key, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if obj[key], err = p.value(constant); err != nil {
+				return nil, err
+			}
+		}
+		p.next()
+		return obj, nil
+	case tok.kind == tokInt:
+		p.next()
+		n, err := strconv.Atoi(tok.value)
+		if err != nil {
+			return nil, p.errorf("nombre invalide « %s »", tok.value)
+		}
+		return n, nil
+	case tok.kind == tokFloat:
+		p.next()
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, p.errorf("nombre invalide « %s »", tok.value)
+		}
+		return f, nil
+	case tok.kind == tokString:
+		p.next()
+		return tok.value, nil
+	case tok.kind == tokName:
+		p.next()
+		switch tok.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		// An enum value
+		return tok.value, nil
+	}
+	return nil, p.errorf("valeur attendue, « %s » trouvé", tok.value)
+}
@@ -0,0 +1,864 @@
+package i18n
+
+// english holds the English translations, keyed by French text
+var english = map[string]string{
+	// Statuses, priorities and severities
+	"À faire":    "To do",
+	"En cours":   "In progress",
+	"Bloqué":     "Blocked",
+	"Terminé":    "Done",
+	"Basse":      "Low",
+	"Moyenne":    "Medium",
+	"Haute":      "High",
+	"Critique":   "Critical",
+	"Aucune":     "None",
+	"Cosmétique": "Cosmetic",
+	"Mineure":    "Minor",
+	"Majeure":    "Major",
+
+	// Grouping and sorting
+	"Aucun":        "None",
+	"État":         "Status",
+	"Priorité":     "Priority",
+	"Tag":          "Tag",
+	"Sans tag":     "Untagged",
+	"Manuel":       "Manual",
+	"Création":     "Created",
+	"Modification": "Updated",
+	"Échéance":     "Due date",
+	"Titre":        "Title",
+	"Urgence":      "Urgency",
+
+	// Header and views
+	"Chargement...":              "Loading...",
+	"Liste":                      "List",
+	"%d tâche":                   "%d task",
+	"%d tâches":                  "%d tasks",
+	"Aucune tâche":               "No tasks",
+	"Aucun résultat pour \"%s\"": "No results for \"%s\"",
+	"Rechercher...":              "Search...",
+
+	// Messages
+	"Erreur: ":                      "Error: ",
+	"Tâches sauvegardées":           "Tasks saved",
+	"Synchronisé avec le dépôt git": "Synced with the git repository",
+	"Synchronisation git...":        "Syncing with git...",
+	"Synchronisation git désactivée (git.enabled dans la configuration)": "Git sync disabled (git.enabled in the config)",
+	"Git désactivé: ": "Git disabled: ",
+	"Erreur git: ":    "Git error: ",
+	"Erreur lors de l'ouverture de l'éditeur":               "Could not open the editor",
+	"Fichier modifié sur le disque, rechargé":               "File changed on disk, reloaded",
+	"Aucune modification en attente":                        "No pending changes",
+	"%d tâche créée":                                        "%d task created",
+	"%d tâches créées":                                      "%d tasks created",
+	"Créer %d tâches, une par ligne collée? (y/n)":          "Create %d tasks, one per pasted line? (y/n)",
+	"Titre #tag !priorité @date...":                         "Title #tag !priority @date...",
+	"Nouvelle note...":                                      "New note...",
+	"q à nouveau pour quitter sans enregistrer":             "q again to quit without saving",
+	"Copié dans le presse-papiers":                          "Copied to clipboard",
+	"partager":                                              "share",
+	"Partager par lien (service de partage)":                "Share as a link (paste service)",
+	"Partager par lien":                                     "Share as a link",
+	"aucun service de partage configuré (share.url)":        "no paste service configured (share.url)",
+	"le service de partage n'a pas renvoyé de lien":         "the paste service did not return a link",
+	"format de partage non pris en charge: %q (md ou json)": "unsupported share format: %q (md or json)",
+	"Envoi...":                           "Uploading...",
+	"Lien copié dans le presse-papiers:": "Link copied to clipboard:",
+	"Liste filtrée (%d):  (m)arkdown  (j)son\nTâche sélectionnée:  (M)arkdown  (J)son": "Filtered list (%d):  (m)arkdown  (j)son\nSelected task:       (M)arkdown  (J)son",
+	"Sans identifiants, notes, rappels ni descriptions":                                "Without IDs, notes, reminders or descriptions",
+	"Esc: fermer":         "Esc: close",
+	"copier en une ligne": "copy as one line",
+	"coller":              "paste",
+	"Copier la tâche en YAML / en une ligne":                          "Copy the task as YAML / as one line",
+	"Coller une tâche en YAML ou du texte comme nouvelle(s) tâche(s)": "Paste a YAML task or text as new task(s)",
+	"%d tâche collée":                                              "%d task pasted",
+	"%d tâches collées":                                            "%d tasks pasted",
+	"Presse-papiers vide":                                          "Clipboard is empty",
+	"presse-papiers système non disponible":                        "system clipboard not available",
+	"le texte n'est pas une tâche en YAML":                         "the text is not a YAML task",
+	"Erreur d'export: ":                                            "Export error: ",
+	"Exporté vers ":                                                "Exported to ",
+	"Grouper par: ":                                                "Group by: ",
+	"Trier par: ":                                                  "Sort by: ",
+	"Tâche passée à « %s »":                                        "Task moved to \"%s\"",
+	"Déplacement disponible en tri manuel uniquement (s)":          "Moving is only available in manual sort (s)",
+	"Remise à faire: aucune activité depuis %d jour":               "Moved back to todo: no activity for %d day",
+	"Remise à faire: aucune activité depuis %d jours":              "Moved back to todo: no activity for %d days",
+	"%d tâche inactive remise à faire":                             "%d idle task moved back to todo",
+	"%d tâches inactives remises à faire":                          "%d idle tasks moved back to todo",
+	"fichier verrouillé par une autre instance":                    "file locked by another instance",
+	"git introuvable":                                              "git not found",
+	"le fichier n'est pas dans un dépôt git":                       "the file is not in a git repository",
+	"aucun dépôt distant configuré":                                "no remote configured",
+	"aucune branche courante":                                      "no current branch",
+	"la récupération a échoué, modifications distantes en conflit": "pull failed, remote changes conflict",
+	"la tâche a été modifiée par ailleurs, rechargement":           "the task was modified elsewhere, reloading",
+
+	// Dialogs
+	"Fichier modifié sur le disque": "File changed on disk",
+	"Le fichier de tâches a été modifié par un autre programme.\nVos modifications en cours n'ont pas été enregistrées.": "The tasks file was modified by another program.\nYour pending changes have not been saved.",
+	"(R)echarger":                         "(R)eload",
+	"(C)ontinuer l'édition":               "(C)ontinue editing",
+	"Checklist terminée":                  "Checklist complete",
+	"Limite de travail en cours atteinte": "Work in progress limit reached",
+	"« %s » a déjà %d tâche pour une limite de %d.\nDéplacer « %s » quand même?":  "“%s” already has %d task for a limit of %d.\nMove “%s” anyway?",
+	"« %s » a déjà %d tâches pour une limite de %d.\nDéplacer « %s » quand même?": "“%s” already has %d tasks for a limit of %d.\nMove “%s” anyway?",
+	"Marquer « %s » comme %s?": "Mark \"%s\" as %s?",
+	"Exporter %d tâche":        "Export %d task",
+	"Exporter %d tâches":       "Export %d tasks",
+	"Fichier:         (m)arkdown  (j)son  (c)sv  (i)calendar\nPresse-papiers:  (M)arkdown  (J)son  (C)sv  (I)calendar": "File:       (m)arkdown  (j)son  (c)sv  (i)calendar\nClipboard:  (M)arkdown  (J)son  (C)sv  (I)calendar",
+	"Esc: annuler":                            "Esc: cancel",
+	"Supprimer la tâche?":                     "Delete task?",
+	"Ajouter/Retirer un tag":                  "Add/Remove a tag",
+	"Tags actuels: ":                          "Current tags: ",
+	"Aucun tag":                               "No tags",
+	"Ajouter une note":                        "Add a note",
+	"Aucune note":                             "No notes",
+	"Enter: ajouter, Esc: annuler":            "Enter: add, Esc: cancel",
+	"Note ajoutée":                            "Note added",
+	"Notes:":                                  "Notes:",
+	"… %d note plus ancienne":                 "… %d older note",
+	"… %d notes plus anciennes":               "… %d older notes",
+	"Ajouter une note au journal de la tâche": "Add a note to the task log",
+	"… %d de plus":                            "… %d more",
+	"↑ %d de plus":                            "↑ %d more",
+	"↓ %d de plus":                            "↓ %d more",
+	"Nouveau tag...":                          "New tag...",
+	"Enter: ajouter/retirer, Tab: compléter, Esc: annuler": "Enter: add/remove, Tab: complete, Esc: cancel",
+	"j/k: défiler, y: copier, Esc: fermer":                 "j/k: scroll, y: copy, Esc: close",
+
+	// Task form
+	"Nouvelle tâche":                      "New task",
+	"Modifier la tâche":                   "Edit task",
+	"Titre de la tâche":                   "Task title",
+	"Description en Markdown (optionnel)": "Markdown description (optional)",
+	"Aucune description":                  "No description",
+	"Tags séparés par des virgules":       "Comma-separated tags",
+	"Titre:":                              "Title:",
+	"Description:":                        "Description:",
+	"Tags:":                               "Tags:",
+	"Rappels:":                            "Reminders:",
+	"1d, 2h avant l'échéance, ou 2026-10-20 14:30": "1d, 2h before the due date, or 2026-10-20 14:30",
+	"Rappel non reconnu, ignoré: %s":               "Unrecognized reminder, ignored: %s",
+	"Rappels non reconnus, ignorés: %s":            "Unrecognized reminders, ignored: %s",
+	"Priorité:":                                    "Priority:",
+	"Sévérité:":                                    "Severity:",
+	"État:":                                        "Status:",
+	"Valider":                                      "Submit",
+	"Valider (nouvelle ligne dans la description)": "Submit (new line in the description)",
+	"Annuler":                      "Cancel",
+	"ctrl+o: éditeur, ctrl+f: zen": "ctrl+o: editor, ctrl+f: zen",
+	"+%d ligne":                    "+%d line",
+	"+%d lignes":                   "+%d lines",
+	"Mettre la ligne suivante dans la description? (y/n)":       "Put the next line in the description? (y/n)",
+	"Mettre les %d lignes suivantes dans la description? (y/n)": "Put the next %d lines in the description? (y/n)",
+
+	// Description editor
+	"Éditer la description": "Edit description",
+	"ctrl+s: enregistrer, ctrl+k: couper ligne, ctrl+u: coller, ctrl+f: zen, Esc: annuler": "ctrl+s: save, ctrl+k: cut line, ctrl+u: paste, ctrl+f: zen, Esc: cancel",
+	"%d mot":  "%d word",
+	"%d mots": "%d words",
+	" · ctrl+s: enregistrer, ctrl+f: quitter le mode zen, Esc: annuler": " · ctrl+s: save, ctrl+f: leave zen mode, Esc: cancel",
+
+	// Checklist
+	"Nouvel élément...": "New item...",
+	"Checklist: ":       "Checklist: ",
+	"%d/%d terminé":     "%d/%d done",
+	"%d/%d terminés":    "%d/%d done",
+	"Aucun élément":     "No items",
+	"Espace: cocher, a: ajouter, d: supprimer, Esc: fermer": "Space: check, a: add, d: delete, Esc: close",
+
+	// Help panel
+	"Raccourcis Clavier": "Keyboard Shortcuts",
+	"Navigation":         "Navigation",
+	"Descendre":          "Down",
+	"Monter":             "Up",
+	"Gauche (kanban)":    "Left (kanban)",
+	"Droite (kanban)":    "Right (kanban)",
+	"Actions":            "Actions",
+	"Ajouter une tâche":  "Add a task",
+	"Ajout rapide: #tag !priorité @date ~points (coller plusieurs lignes crée une tâche par ligne)": "Quick add: #tag !priority @date ~points (pasting several lines creates one task per line)",
+	"Éditer la tâche":                         "Edit the task",
+	"Supprimer la tâche":                      "Delete the task",
+	"Changer la priorité":                     "Change priority",
+	"Changer la sévérité":                     "Change severity",
+	"Gérer les tags":                          "Manage tags",
+	"Checklist (sous-tâches)":                 "Checklist (subtasks)",
+	"Voir/Éditer détails":                     "View/Edit details",
+	"États rapides":                           "Quick status",
+	"Kanban":                                  "Kanban",
+	"Calendrier":                              "Calendar",
+	"Jour précédent / suivant":                "Previous / next day",
+	"Semaine précédente / suivante":           "Previous / next week",
+	"Mois précédent / suivant":                "Previous / next month",
+	"Tâche précédente / suivante de l'agenda": "Previous / next task of the agenda",
+	"Aucune tâche pour ce jour":               "No tasks for this day",
+	"%s %d %s %d":                             "%s, %[3]s %[2]d, %[4]d",
+	"jour":                                    "day",
+	"mois":                                    "month",
+	"agenda":                                  "agenda",
+	"janvier":                                 "January",
+	"février":                                 "February",
+	"mars":                                    "March",
+	"avril":                                   "April",
+	"mai":                                     "May",
+	"juin":                                    "June",
+	"juillet":                                 "July",
+	"août":                                    "August",
+	"septembre":                               "September",
+	"octobre":                                 "October",
+	"novembre":                                "November",
+	"décembre":                                "December",
+	"lundi":                                   "Monday",
+	"mardi":                                   "Tuesday",
+	"mercredi":                                "Wednesday",
+	"jeudi":                                   "Thursday",
+	"vendredi":                                "Friday",
+	"samedi":                                  "Saturday",
+	"dimanche":                                "Sunday",
+	"Déplacer tâche à gauche":                 "Move task left",
+	"Déplacer tâche à droite":                 "Move task right",
+	"Élargir / rétrécir la colonne":           "Widen / narrow the column",
+	"Tri":                                     "Sorting",
+	"Changer le tri":                          "Change sorting",
+	"Monter la tâche (tri manuel)":            "Move task up (manual sort)",
+	"Descendre la tâche (tri manuel)":         "Move task down (manual sort)",
+	"Général":                                 "General",
+	"Changer de vue":                          "Switch view",
+	"Tableau suivant / précédent (plusieurs fichiers)": "Next / previous board (several files)",
+	"Changer le groupage":                              "Change grouping",
+	"Rechercher":                                       "Search",
+	"Filtrer par tag et ses sous-tags (#travail/)":     "Filter by tag and its subtags (#work/)",
+	"Filtres: tag:, status:, priority:, severity:, sprint:current, is:actionable (a,b = l'un ou l'autre, -x = exclure)": "Filters: tag:, status:, priority:, severity:, sprint:current, is:actionable (a,b = either, -x = exclude)",
+	"Ouvrir le fichier YAML":                          "Open the YAML file",
+	"Voir le YAML de la tâche":                        "View the task YAML",
+	"Voir le fichier YAML":                            "View the YAML file",
+	"Exporter (Markdown, JSON, CSV, iCalendar)":       "Export (Markdown, JSON, CSV, iCalendar)",
+	"Rafraîchir":                                      "Refresh",
+	"Enregistrer maintenant":                          "Save now",
+	"Synchroniser avec le dépôt git (pull puis push)": "Sync with the git repository (pull then push)",
+	"Afficher/Masquer l'aide":                         "Show/Hide help",
+	"Quitter":                                         "Quit",
+	"Visionneuse YAML":                                "YAML viewer",
+	"Défiler":                                         "Scroll",
+	"Copier dans le presse-papiers":                   "Copy to clipboard",
+	"Fermer":                                          "Close",
+	"Formulaire":                                      "Form",
+	"Champ suivant":                                   "Next field",
+	"Champ précédent":                                 "Previous field",
+	"Éditer la description ($EDITOR)":                 "Edit the description ($EDITOR)",
+	"Éditer la description en plein écran (zen)":      "Edit the description full screen (zen)",
+
+	// Footer and key bindings
+	"nav":                                  "nav",
+	"ouvrir":                               "open",
+	"rechercher partout":                   "search everywhere",
+	"Aucune tâche ne correspond":           "No matching task",
+	"Rechercher dans tous les tableaux...": "Search all boards...",
+	"Recherche dans tous les tableaux: %d résultat":  "Search across all boards: %d result",
+	"Recherche dans tous les tableaux: %d résultats": "Search across all boards: %d results",
+	"Rechercher dans tous les tableaux ouverts":      "Search all open boards",
+	"tableaux":                           "boards",
+	"en retard":                          "overdue",
+	"aujourd'hui":                        "today",
+	"Urgent":                             "Urgent",
+	"Vue d'ensemble: %d tâche urgente":   "Overview: %d urgent task",
+	"Vue d'ensemble: %d tâches urgentes": "Overview: %d urgent tasks",
+	"Rien d'urgent: aucune tâche en retard, pour aujourd'hui ou de priorité maximale": "Nothing urgent: no task overdue, due today or at the highest priority",
+	"colonnes":           "columns",
+	"déplacer":           "move",
+	"ajouter":            "add",
+	"ajout rapide":       "quick add",
+	"supprimer":          "delete",
+	"état":               "status",
+	"grouper":            "group",
+	"trier":              "sort",
+	"vue":                "view",
+	"aide":               "help",
+	"quitter":            "quit",
+	"monter":             "up",
+	"descendre":          "down",
+	"gauche":             "left",
+	"droite":             "right",
+	"éditer":             "edit",
+	"sélectionner":       "select",
+	"priorité":           "priority",
+	"sévérité":           "severity",
+	"tag":                "tag",
+	"checklist":          "checklist",
+	"sprint":             "sprint",
+	"élargir colonne":    "widen column",
+	"rétrécir colonne":   "narrow column",
+	"note":               "note",
+	"déplacer ←":         "move ←",
+	"déplacer →":         "move →",
+	"déplacer ↑":         "move ↑",
+	"déplacer ↓":         "move ↓",
+	"à faire":            "todo",
+	"en cours":           "in progress",
+	"bloqué":             "blocked",
+	"terminé":            "done",
+	"changer vue":        "switch view",
+	"rechercher":         "search",
+	"ouvrir fichier":     "open file",
+	"voir YAML":          "view YAML",
+	"voir fichier":       "view file",
+	"copier":             "copy",
+	"exporter":           "export",
+	"enregistrer":        "save",
+	"rafraîchir":         "refresh",
+	"synchroniser (git)": "sync (git)",
+	"valider":            "submit",
+	"annuler":            "cancel",
+	"suivant":            "next",
+	"précédent":          "previous",
+
+	// Export
+	"Tâches":    "Tasks",
+	"échéance ": "due ",
+	"format inconnu: %q (md, json, csv ou ics)":                             "unknown format: %q (md, json, csv or ics)",
+	"format inconnu: %q":                                                    "unknown format: %q",
+	"source inconnue: %q (todotxt, taskwarrior, apple-reminders ou mstodo)": "unknown source: %q (todotxt, taskwarrior, apple-reminders or mstodo)",
+	"source inconnue: %q":                                                   "unknown source: %q",
+
+	// Command line
+	"Chemin vers le fichier de tâches (défaut: ~/.local/share/lazy-todo/tasks.yaml)":                  "Path to the tasks file (default: ~/.local/share/lazy-todo/tasks.yaml)",
+	"Chemin vers le fichier de configuration (défaut: ~/.config/lazy-todo/config.yaml)":               "Path to the config file (default: ~/.config/lazy-todo/config.yaml)",
+	"Chemin vers le fichier de tâches":                                                                "Path to the tasks file",
+	"Chemin vers le fichier de configuration":                                                         "Path to the config file",
+	"Langue de l'interface (fr, en)":                                                                  "Interface language (fr, en)",
+	"Afficher la version":                                                                             "Show the version",
+	"Erreur: %v\n":                                                                                    "Error: %v\n",
+	"Erreur de configuration: %v\n":                                                                   "Configuration error: %v\n",
+	"Langue inconnue: %q\n":                                                                           "Unknown language: %q\n",
+	"Format d'export: md, json, csv ou ics (tâches avec une échéance)":                                "Export format: md, json, csv or ics (tasks with a due date)",
+	"N'exporter que les tâches contenant ce texte":                                                    "Only export tasks containing this text",
+	"N'afficher que les tâches correspondant à cette recherche":                                       "Only show the tasks matching this search",
+	"Afficher aussi les tâches terminées":                                                             "Also show done tasks",
+	"Réafficher la liste à chaque modification du fichier":                                            "Render the list again whenever the file changes",
+	"Fichier de sortie (défaut: sortie standard)":                                                     "Output file (default: standard output)",
+	"Erreur de chargement: %v\n":                                                                      "Load error: %v\n",
+	"Erreur d'export: %v\n":                                                                           "Export error: %v\n",
+	"Erreur d'écriture: %v\n":                                                                         "Write error: %v\n",
+	"Format source: todotxt, taskwarrior, apple-reminders ou mstodo":                                  "Source format: todotxt, taskwarrior, apple-reminders or mstodo",
+	"Usage: lazy-todo import --from todotxt|taskwarrior|apple-reminders|mstodo [options] <fichier|->": "Usage: lazy-todo import --from todotxt|taskwarrior|apple-reminders|mstodo [options] <file|->",
+	"Erreur d'import: %v\n":                                                                           "Import error: %v\n",
+	"Erreur de sauvegarde: %v\n":                                                                      "Save error: %v\n",
+	"%d tâche importée":                                                                               "%d task imported",
+	"%d tâches importées":                                                                             "%d tasks imported",
+	"%d déjà présente":                                                                                "%d already present",
+	"%d déjà présentes":                                                                               "%d already present",
+	"Texte de la tâche, avec #tag !priorité @date":                                                    "Task text, with #tag !priority @date",
+	"Usage: lazy-todo capture [options] --text \"texte\" | texte...":                                  "Usage: lazy-todo capture [options] --text \"text\" | text...",
+	"Adresse d'écoute (défaut: %s)":                                                                   "Address to listen on (default: %s)",
+	"Attention: aucun jeton configuré (server.token), les requêtes ne sont pas authentifiées": "Warning: no token configured (server.token), requests aren't authenticated",
+	"Écoute sur http://%s\n":                        "Listening on http://%s\n",
+	"Jeton d'accès manquant ou invalide":            "Missing or invalid access token",
+	"Le texte de la tâche est vide":                 "The task text is empty",
+	"Requête invalide":                              "Invalid request",
+	"Lister les sauvegardes au lieu d'en créer une": "List the backups instead of taking one",
+	"Usage: lazy-todo restore [options] [sauvegarde]  (la plus récente par défaut)": "Usage: lazy-todo restore [options] [backup]  (the latest by default)",
+	"Erreur de restauration: %v\n":                                    "Restore error: %v\n",
+	"%s restaurée, l'ancien fichier est gardé en .bak\n":              "%s restored, the previous file is kept as .bak\n",
+	"Erreur de sauvegarde distante: %v\n":                             "Backup error: %v\n",
+	"aucune destination de sauvegarde configurée":                     "no backup destination configured",
+	"aucune sauvegarde trouvée":                                       "no backup found",
+	"Sauvegardes désactivées: ":                                       "Backups disabled: ",
+	"Erreur de sauvegarde distante: ":                                 "Backup error: ",
+	"Sauvegardé: %s":                                                  "Backed up: %s",
+	"%d issue importée":                                               "%d issue imported",
+	"%d issues importées":                                             "%d issues imported",
+	"%d tâche terminée":                                               "%d task completed",
+	"%d tâches terminées":                                             "%d tasks completed",
+	"%d issue fermée":                                                 "%d issue closed",
+	"%d issues fermées":                                               "%d issues closed",
+	"aucun site Jira configuré (jira.url)":                            "no Jira site configured (jira.url)",
+	"aucun jeton Jira configuré (jira.token ou JIRA_API_TOKEN)":       "no Jira token configured (jira.token or JIRA_API_TOKEN)",
+	"aucune transition Jira ne correspond à ce statut":                "no Jira transition matches this status",
+	"Requête JQL, à la place de celle de la configuration":            "JQL query, instead of the configured one",
+	"%d ticket importé":                                               "%d issue imported",
+	"%d tickets importés":                                             "%d issues imported",
+	"%d tâche mise à jour":                                            "%d task updated",
+	"%d tâches mises à jour":                                          "%d tasks updated",
+	"Jira désactivé: ":                                                "Jira disabled: ",
+	"notifications non disponibles (notify-send ou osascript requis)": "notifications unavailable (notify-send or osascript required)",
+	"Afficher les rappels dus puis quitter":                           "Show the reminders due, then exit",
+	"Rappel":                                                          "Reminder",
+	"Échéance: %s":                                                    "Due: %s",
+	"Rappeler dans %s":                                                "Remind me in %s",
+	"rappels":                                                         "reminders",
+	"Rappels échus: acquitter ou reporter":                            "Reminders that went off: acknowledge or snooze",
+	"Aucun rappel échu":                                               "No reminder went off",
+	"Rappel: %s":                                                      "Reminder: %s",
+	"!: rappels":                                                      "!: reminders",
+	"Rappels (%d)":                                                    "Reminders (%d)",
+	"Entrée: acquitter │ a: tout acquitter │ s: reporter de %s │ Esc: fermer": "Enter: acknowledge │ a: acknowledge all │ s: snooze %s │ Esc: close",
+	"Transition Jira de %s impossible: %s":                                    "Could not transition Jira issue %s: %s",
+	"aucun dépôt GitHub configuré (github.repo: propriétaire/nom)":            "no GitHub repository configured (github.repo: owner/name)",
+	"aucun jeton GitHub configuré (github.token ou GITHUB_TOKEN)":             "no GitHub token configured (github.token or GITHUB_TOKEN)",
+	"le fichier de tâches semble corrompu":                                    "the tasks file seems corrupted",
+	"Fichier de tâches corrompu":                                              "Corrupted tasks file",
+	"Le fichier n'a pas pu être lu et n'a pas été modifié. Les modifications sont refusées tant qu'il n'est pas réparé ou restauré.": "The file couldn't be read and was left untouched. Changes are refused until it is fixed or restored.",
+	"Recherche d'une sauvegarde...":       "Looking for a backup...",
+	"Aucune sauvegarde valide trouvée.":   "No valid backup found.",
+	"Sauvegarde la plus récente: %s (%s)": "Most recent backup: %s (%s)",
+	"(R)estaurer":                         "(R)estore",
+	"(E)diter le fichier":                 "(E)dit the file",
+	"(I)gnorer":                           "(I)gnore",
+
+	// Sprints
+	"%s dans %d j":      "%s in %d d",
+	"%s · %d j · %d/%d": "%s · %d d · %d/%d",
+	"Aucun sprint en cours ou à venir (sprints dans la configuration)": "No current or upcoming sprint (sprints in the configuration)",
+	"Fin du sprint « %s »":                                   "End of sprint “%s”",
+	"%d/%d tâche terminée":                                   "%d/%d task done",
+	"%d/%d tâches terminées":                                 "%d/%d tasks done",
+	"%d non terminée:":                                       "%d unfinished:",
+	"%d non terminées:":                                      "%d unfinished:",
+	"  … et %d autre":                                        "  … and %d more",
+	"  … et %d autres":                                       "  … and %d more",
+	"Les remettre sans sprint?":                              "Take them out of the sprint?",
+	"Les reporter au sprint « %s »?":                         "Roll them over to sprint “%s”?",
+	"Planifier dans le sprint en cours, le suivant ou aucun": "Plan in the current sprint, the next one or none",
+
+	// History
+	"déplacée vers %s":              "moved to %s",
+	"priorité %s → %s":              "priority %s → %s",
+	"… %d changement plus ancien":   "… %d older change",
+	"… %d changements plus anciens": "… %d older changes",
+	"à l'instant":                   "just now",
+	"il y a %d min":                 "%d min ago",
+	"il y a %d h":                   "%d h ago",
+	"il y a %d j":                   "%d days ago",
+	"Historique:":                   "History:",
+	"Activité":                      "Activity",
+	"Exporter les changements d'état et de priorité, avec le journal, au lieu des tâches": "Export status and priority changes, with the journal, instead of tasks",
+	"Avec --activity ou --time, nombre de jours couverts":                                 "With --activity or --time, number of days covered",
+
+	// Velocity
+	"%d pt":               "%d pt",
+	"%d pts":              "%d pts",
+	"%s pt":               "%s pt",
+	"%s pts":              "%s pts",
+	"%s tâche":            "%s task",
+	"%s tâches":           "%s tasks",
+	"%d/%d tâche":         "%d/%d task",
+	"%d/%d tâches":        "%d/%d tasks",
+	"%d/%d pt":            "%d/%d pt",
+	"%d/%d pts":           "%d/%d pts",
+	"(en cours)":          "(current)",
+	"Vélocité par sprint": "Velocity per sprint",
+	"Aucun sprint commencé (sprints dans la configuration)": "No sprint started (sprints in the configuration)",
+	"Aucun sprint terminé pour estimer la vélocité":         "No finished sprint to estimate the velocity",
+	"Moyenne du dernier sprint: %[2]s par sprint":           "Average of the last sprint: %[2]s per sprint",
+	"Moyenne des %d derniers sprints: %s par sprint":        "Average of the last %d sprints: %s per sprint",
+	"À engager pour « %s »: environ %s":                     "To commit for “%s”: about %s",
+	"estimation":                                            "estimate",
+	"vélocité":                                              "velocity",
+	"Changer l'estimation (points: 1, 2, 3, 5, 8, 13)":      "Change the estimate (points: 1, 2, 3, 5, 8, 13)",
+	"Vélocité des sprints et engagement suggéré":            "Sprint velocity and suggested commitment",
+
+	// Keybinding conflicts
+	"Raccourcis en conflit":           "Conflicting keybindings",
+	"Actions inconnues, ignorées: %s": "Unknown actions, ignored: %s",
+	"Entrée: garder la touche pour cette action │ d: touches par défaut │ Esc: la première action l'emporte": "Enter: keep the key for this action │ d: default keys │ Esc: the first action wins",
+	"Corrigez la section keys de la configuration pour ne plus voir cet écran":                               "Fix the keys section of the configuration to stop seeing this screen",
+
+	// Chords
+	"espace":                        "space",
+	"accord":                        "chord",
+	"Accords (espace puis touches)": "Chords (space then keys)",
+	"Trier: manuel, création, modification, priorité, échéance, titre, urgence": "Sort: manual, created, updated, priority, due date, title, urgency",
+	"Grouper: aucun, état, priorité, tag":                                       "Group: none, status, priority, tag",
+	"Vue: liste, kanban, calendrier, aujourd'hui":                               "View: list, kanban, calendar, today",
+
+	// Tags screen
+	"tags": "tags",
+	"Tags": "Tags",
+	"Tags: renommer, fusionner, supprimer, couleur": "Tags: rename, merge, delete, color",
+	"Renommer %s en:":                       "Rename %s to:",
+	"Fusionner %s dans:":                    "Merge %s into:",
+	"Retirer le tag %s de %d tâche? (y/n)":  "Remove the tag %s from %d task? (y/n)",
+	"Retirer le tag %s de %d tâches? (y/n)": "Remove the tag %s from %d tasks? (y/n)",
+	"Tag %s fusionné dans %s (%d tâche)":    "Tag %s merged into %s (%d task)",
+	"Tag %s fusionné dans %s (%d tâches)":   "Tag %s merged into %s (%d tasks)",
+	"Tag %s renommé en %s (%d tâche)":       "Tag %s renamed to %s (%d task)",
+	"Tag %s renommé en %s (%d tâches)":      "Tag %s renamed to %s (%d tasks)",
+	"Tag %s supprimé de %d tâche":           "Tag %s removed from %d task",
+	"Tag %s supprimé de %d tâches":          "Tag %s removed from %d tasks",
+	"Tag inconnu: %s":                       "Unknown tag: %s",
+	"r: renommer │ m: fusionner │ d: supprimer │ c: couleur │ Esc: fermer": "r: rename │ m: merge │ d: delete │ c: color │ Esc: close",
+	"Entrée: valider │ Esc: annuler":                                       "Enter: confirm │ Esc: cancel",
+
+	// Chord popup
+	"trier: manuel":       "sort: manual",
+	"trier: création":     "sort: created",
+	"trier: modification": "sort: updated",
+	"trier: priorité":     "sort: priority",
+	"trier: échéance":     "sort: due date",
+	"trier: titre":        "sort: title",
+	"trier: urgence":      "sort: urgency",
+	"grouper: aucun":      "group: none",
+	"grouper: état":       "group: status",
+	"grouper: priorité":   "group: priority",
+	"grouper: tag":        "group: tag",
+	"vue: liste":          "view: list",
+	"vue: kanban":         "view: kanban",
+	"vue: calendrier":     "view: calendar",
+	"vue: aujourd'hui":    "view: today",
+	"+%d accord":          "+%d chord",
+	"+%d accords":         "+%d chords",
+
+	// Due date field and picker
+	"demain, vendredi, +2w, 2026-10-20":               "tomorrow, friday, +2w, 2026-10-20",
+	"Échéance:":                                       "Due date:",
+	"Date non reconnue, échéance inchangée":           "Unrecognized date, due date unchanged",
+	"Entrée: calendrier":                              "Enter: calendar",
+	"hjkl: jour/semaine │ H/L: mois │ t: aujourd'hui": "hjkl: day/week │ H/L: month │ t: today",
+	"Entrée: choisir │ x: aucune │ Esc: fermer":       "Enter: pick │ x: none │ Esc: close",
+
+	// Tasks file formats
+	"Format du fichier de tâches: yaml, json ou markdown (défaut: selon l'extension)": "Format of the tasks file: yaml, json or markdown (default: from the extension)",
+	"format de fichier inconnu (yaml, json, markdown)":                                "unknown file format (yaml, json, markdown)",
+
+	// Local backups
+	"sauvegarde locale introuvable":                                                        "local backup not found",
+	"Lister les sauvegardes locales (.backups)":                                            "List the local backups (.backups)",
+	"Restaurer une sauvegarde locale, par nom ou chemin (\"latest\" pour la plus récente)": "Restore a local backup, by name or path (\"latest\" for the most recent)",
+	"Aucune sauvegarde locale dans %s\n":                                                   "No local backup in %s\n",
+
+	// Webhooks
+	"webhook sans URL (webhooks.url)":                                            "webhook without a URL (webhooks.url)",
+	"webhook en échec, gardé pour un nouvel envoi (lazy-todo webhooks --replay)": "webhook failed, kept to be sent again (lazy-todo webhooks --replay)",
+	"événement de webhook inconnu: %s":                                           "unknown webhook event: %s",
+	"Webhooks désactivés: ":                                                      "Webhooks disabled: ",
+	"Erreur de webhook: ":                                                        "Webhook error: ",
+	"Renvoyer les appels en échec":                                               "Send the failed calls again",
+	"%d appel renvoyé":                                                           "%d call sent again",
+	"%d appels renvoyés":                                                         "%d calls sent again",
+	"%d toujours en échec":                                                       "%d still failing",
+	"Aucun appel de webhook en échec":                                            "No failed webhook call",
+
+	// Zero-state hints
+	"Aucun résultat":        "No results",
+	"%s: ajouter une tâche": "%s: add a task",
+	"%s: ajout rapide, par exemple « Appeler le dentiste #perso !haute @vendredi »": "%s: quick add, for example “Call the dentist #personal !high @friday”",
+	"%s: toutes les touches":                                               "%s: all keys",
+	"%s: modifier la recherche, Esc pour l'effacer":                        "%s: edit the search, Esc to clear it",
+	"Filtres: tag:travail status:todo,blocked -priority:low is:actionable": "Filters: tag:work status:todo,blocked -priority:low is:actionable",
+	"%s: créer la tâche cherchée":                                          "%s: create the task looked for",
+	"%s/%s: déplacer une tâche ici":                                        "%s/%s: move a task here",
+
+	// iCalendar feed
+	"entrées ICS inconnues: %q (event, todo ou both)": "unknown ICS entries: %q (event, todo or both)",
+
+	// CalDAV sync
+	"%d tâche supprimée":   "%d task deleted",
+	"%d tâches supprimées": "%d tasks deleted",
+	"%d tâche envoyée":     "%d task sent",
+	"%d tâches envoyées":   "%d tasks sent",
+	"Local: ":              "Local: ",
+	"Serveur: ":            "Server: ",
+	"aucune liste CalDAV configurée (caldav.url)":                               "no CalDAV task list configured (caldav.url)",
+	"aucun mot de passe CalDAV configuré (caldav.password ou CALDAV_PASSWORD)":  "no CalDAV password configured (caldav.password or CALDAV_PASSWORD)",
+	"une tâche a changé sur le serveur pendant la synchronisation, relancez-la": "a task changed on the server during the sync, run it again",
+
+	// Read-only mode
+	"Ouvrir le fichier en lecture seule (réunions, montage partagé)": "Open the file read-only (meetings, shared mount)",
+	"fichier ouvert en lecture seule":                                "file opened read-only",
+	"Lecture seule: « %s » désactivé":                                "Read-only: “%s” disabled",
+	"LECTURE SEULE":                                                  "READ-ONLY",
+
+	// Render once
+	"Afficher une seule image de l'interface et quitter":               "Print a single frame of the interface and exit",
+	"Largeur de l'image de --render-once":                              "Width of the --render-once frame",
+	"Hauteur de l'image de --render-once":                              "Height of the --render-once frame",
+	"Vue de l'image de --render-once: list, kanban, calendar ou today": "View of the --render-once frame: list, kanban, calendar or today",
+	"Utilisation de %s:\n":                                             "Usage of %s:\n",
+	"Vue inconnue: %q (list, kanban, calendar ou today)\n":             "Unknown view: %q (list, kanban, calendar or today)\n",
+	"--width et --height doivent être positifs":                        "--width and --height must be positive",
+
+	// Short IDs
+	"aller à la tâche":      "go to task",
+	"Aucune tâche %s":       "No task %s",
+	"%s n'est pas affichée": "%s isn't shown",
+
+	// Input limits
+	"fichier de tâches trop volumineux":                         "tasks file too large",
+	"contenu refusé: imbrication, alias ou valeur hors limites": "content refused: nesting, aliases or value past the limits",
+
+	// Bulk edit
+	"modifier la sélection":                        "edit selection",
+	"Modifications refusées (%s), gardées dans %s": "Edits refused (%s), kept in %s",
+	"Aucune modification":                          "No change",
+	"%d tâche modifiée":                            "%d task changed",
+	"%d tâches modifiées":                          "%d tasks changed",
+	"une tâche modifiée n'a pas de titre":          "an edited task has no title",
+	"deux tâches modifiées ont le même ID":         "two edited tasks have the same ID",
+	"état inconnu":                                 "unknown status",
+
+	// Private tasks
+	"privée": "private",
+	"Ce jeton ne permet pas d'ajouter des tâches": "This token can't add tasks",
+
+	// Task listing API
+	"Champ inconnu dans fields":             "Unknown field in fields",
+	"Trop de requêtes, réessayez plus tard": "Too many requests, try again later",
+
+	// Problems of the tasks file
+	"sans ID, un ID lui est donné":              "no ID, given one",
+	"ID %s déjà pris, un nouveau lui est donné": "ID %s already taken, given a new one",
+	"sans titre":                         "no title",
+	"Sans titre":                         "Untitled",
+	"état inconnu « %s », remis à faire": "unknown status \"%s\", reset to to do",
+	"priorité inconnue « %s », gardée telle quelle":                             "unknown priority \"%s\", kept as is",
+	"date %s illisible « %s », ignorée":                                         "unreadable %s date \"%s\", ignored",
+	"sans date de création, celle de modification ou du fichier lui est donnée": "no creation date, given the update date or the file's",
+	"sans date de modification, celle de création lui est donnée":               "no update date, given the creation date",
+	"modifiée avant d'être créée, date de modification corrigée":                "updated before being created, update date fixed",
+	"Problèmes du fichier de tâches":                                            "Problems of the tasks file",
+	"… %d problème de plus":                                                     "… %d more problem",
+	"… %d problèmes de plus":                                                    "… %d more problems",
+	"Tâche %d: %s":                                                              "Task %d: %s",
+	"Les réparations seront enregistrées à la prochaine modification":           "The repairs will be saved with the next change",
+	"Esc: continuer": "Esc: continue",
+	"Enregistrer les réparations dans le fichier": "Save the repairs to the file",
+	"tâche %d (%s): %s\n":                         "task %d (%s): %s\n",
+	"Aucun problème":                              "No problems",
+	"%d problème réparé":                          "%d problem repaired",
+	"%d problèmes réparés":                        "%d problems repaired",
+	"%d problème, à réparer avec --fix":           "%d problem, repair it with --fix",
+	"%d priorité inconnue gardée, à ajouter aux priorités de la configuration ou à changer":    "%d unknown priority kept, add it to the priorities of the config or change it",
+	"%d priorités inconnues gardées, à ajouter aux priorités de la configuration ou à changer": "%d unknown priorities kept, add them to the priorities of the config or change them",
+	"%d problèmes, à réparer avec --fix":                                                       "%d problems, repair them with --fix",
+
+	// Corrupt file recovery
+	"Erreur à la ligne %d":                                "Error at line %d",
+	"(E)diter à la ligne %d":                              "(E)dit at line %d",
+	"(G)arder la version affichée":                        "(K)eep the version shown",
+	"La dernière version lue (%d tâche) reste affichée.":  "The last version read (%d task) stays shown.",
+	"La dernière version lue (%d tâches) reste affichée.": "The last version read (%d tasks) stays shown.",
+
+	// GraphQL
+	"Ligne %d: ":                                                     "Line %d: ",
+	"« %s » attendu, « %s » trouvé":                                  "expected “%s”, found “%s”",
+	"nom attendu, « %s » trouvé":                                     "expected a name, found “%s”",
+	"mutations non prises en charge":                                 "mutations aren't supported",
+	"fragments non pris en charge":                                   "fragments aren't supported",
+	"directives non prises en charge":                                "directives aren't supported",
+	"opération inconnue « %s »":                                      "unknown operation “%s”",
+	"sélections trop imbriquées":                                     "selections nested too deep",
+	"« } » attendu, fin de la requête trouvée":                       "expected “}”, found the end of the query",
+	"« ] » attendu, fin de la requête trouvée":                       "expected “]”, found the end of the query",
+	"sélection vide":                                                 "empty selection",
+	"nombre invalide « %s »":                                         "invalid number “%s”",
+	"valeur attendue, « %s » trouvé":                                 "expected a value, found “%s”",
+	"Requête GraphQL vide":                                           "Empty GraphQL query",
+	"Plusieurs opérations: operationName est requis":                 "Several operations: operationName is required",
+	"Opération inconnue: %s":                                         "Unknown operation: %s",
+	"Un abonnement sélectionne un seul champ":                        "A subscription selects a single field",
+	"Champ inconnu: %s":                                              "Unknown field: %s",
+	"Argument inconnu: %s(%s)":                                       "Unknown argument: %s(%s)",
+	"Arguments non pris en charge: %s":                               "Arguments aren't supported: %s",
+	"Sélection requise: %s":                                          "Selection required: %s",
+	"Pas de sélection sur une valeur simple: %s":                     "No selection on a plain value: %s",
+	"Argument %s: nombre entier attendu":                             "Argument %s: expected an integer",
+	"Argument %s: texte attendu":                                     "Argument %s: expected a string",
+	"Les abonnements ne sont pas pris en charge par cette connexion": "Subscriptions aren't supported by this connection",
+
+	// WebSocket
+	"requête de connexion WebSocket invalide":         "invalid WebSocket connection request",
+	"origine de la connexion WebSocket non autorisée": "WebSocket connection origin not allowed",
+	"connexion WebSocket fermée":                      "WebSocket connection closed",
+	"message WebSocket trop grand":                    "WebSocket message too large",
+	"trame WebSocket invalide":                        "invalid WebSocket frame",
+
+	// Web page of the server
+	"Tableau":                   "Board",
+	"Chargement…":               "Loading…",
+	"Jeton d'accès":             "Access token",
+	"Hors ligne, nouvel essai…": "Offline, retrying…",
+	"et %d de plus":             "and %d more",
+	"Tâche introuvable":         "Task not found",
+	"Statut inconnu":            "Unknown status",
+	"Ce jeton ne permet pas de modifier des tâches": "This token can't change tasks",
+
+	// Undo of deletions
+	"annuler la suppression": "undo delete",
+	"Tâche supprimée: %s":    "Task deleted: %s",
+	"%s pour annuler":        "%s to undo",
+
+	// List columns
+	"colonnes de la liste": "list columns",
+	"Colonnes de la liste": "List columns",
+	"Identifiant":          "ID",
+	"Sévérité":             "Severity",
+	"Statut":               "Status",
+	"Âge":                  "Age",
+	"Temps passé":          "Time spent",
+	"auto":                 "auto",
+	"icône":                "icon",
+	"reste de la ligne":    "rest of the line",
+	"%d min":               "%d min",
+	"%d h":                 "%d h",
+	"%d j":                 "%d d",
+	"Espace: afficher │ K/J: déplacer │ >/<: largeur │ Esc: fermer": "Space: show │ K/J: move │ >/<: width │ Esc: close",
+
+	// List density
+	"liste compacte/détaillée": "compact/detailed list",
+	"créée":                    "created",
+	"modifiée":                 "updated",
+	"%d note":                  "%d note",
+	"%d notes":                 "%d notes",
+	"le fichier de configuration n'est pas un dictionnaire YAML": "the config file isn't a YAML mapping",
+
+	// Progress
+	"progression par priorité": "progress by priority",
+
+	// Word count
+	"%d min de lecture": "%d min read",
+	"Description longue (%d mot): un lien vers un document serait plus lisible":  "Long description (%d word): a link to a document would read better",
+	"Description longue (%d mots): un lien vers un document serait plus lisible": "Long description (%d words): a link to a document would read better",
+
+	// Today view
+	"Aujourd'hui":           "Today",
+	"ajouter à aujourd'hui": "add to today",
+	"Ajoutée à aujourd'hui": "Added to today",
+	"Retirée d'aujourd'hui": "Removed from today",
+	"Rien pour aujourd'hui: ni échéance, ni retard, ni tâche ajoutée":  "Nothing for today: no task due, overdue or added",
+	"%s: ajouter la tâche sélectionnée à aujourd'hui, depuis la liste": "%s: add the selected task to today, from the list",
+
+	// Week planning
+	"planifier la semaine":                           "plan the week",
+	"Aucune tâche ouverte sans échéance à planifier": "No open task without a due date to plan",
+	"%d tâche planifiée":                             "%d task planned",
+	"%d tâches planifiées":                           "%d tasks planned",
+	"Planifier la semaine (%d/%d)":                   "Plan the week (%d/%d)",
+	"lettre ou chiffre: jour · espace: passer · backspace: précédente · Esc: annuler": "letter or digit: day · space: skip · backspace: previous · Esc: cancel",
+	"Charge de la semaine": "Load of the week",
+	"Entrée: enregistrer · backspace: revenir · Esc: annuler": "Enter: save · backspace: go back · Esc: cancel",
+	"%d tâche laissée sans échéance":                          "%d task left without a due date",
+	"%d tâches laissées sans échéance":                        "%d tasks left without a due date",
+
+	// End of day review
+	"Entrée du journal, au lieu de la demander":   "Journal entry, instead of asking for it",
+	"Aucune tâche en cours ni prévue aujourd'hui": "No task in progress or planned for today",
+	"d: terminée, c: reportée à demain, s [jour]: en pause jusqu'à ce jour (une semaine par défaut), Entrée: inchangée > ": "d: done, c: carry over to tomorrow, s [day]: snooze until that day (a week by default), Enter: unchanged > ",
+	"Journal du jour (Entrée pour passer) > ":          "Journal of the day (Enter to skip) > ",
+	"Fin de journée du %s":                             "End of day %s",
+	"%d tâche terminée aujourd'hui":                    "%d task done today",
+	"%d tâches terminées aujourd'hui":                  "%d tasks done today",
+	"%d tâche reportée à demain":                       "%d task carried over to tomorrow",
+	"%d tâches reportées à demain":                     "%d tasks carried over to tomorrow",
+	"%d tâche en pause":                                "%d task snoozed",
+	"%d tâches en pause":                               "%d tasks snoozed",
+	"Aucune tâche terminée, reportée ni mise en pause": "No task done, carried over or snoozed",
+	"Journal:":                      "Journal:",
+	"Erreur d'enregistrement: %v\n": "Save error: %v\n",
+
+	// Pinned tasks
+	"épingler en haut": "pin to the top",
+
+	// Journal
+	"journal du jour":                  "journal of the day",
+	"journal":                          "journal",
+	"Journal":                          "Journal",
+	"Journal du %s":                    "Journal of %s",
+	"%s %d %s":                         "%s, %[3]s %[2]d",
+	"Ce qui s'est passé ce jour-là...": "What happened that day...",
+	"Journal du jour sélectionné (aujourd'hui dans les autres vues)": "Journal of the selected day (today in the other views)",
+
+	// Swimlanes
+	"couloirs":            "swimlanes",
+	"couloir précédent":   "previous swimlane",
+	"couloir suivant":     "next swimlane",
+	"Couloirs désactivés": "Swimlanes off",
+	"Couloirs par %s":     "Swimlanes by %s",
+	"Groupes en couloirs à travers les colonnes (par priorité ou tag)": "Groups as swimlanes across the columns (by priority or tag)",
+	"Couloir précédent / suivant":                                      "Previous / next swimlane",
+
+	// Time tracking
+	"Exporter le temps suivi par tâche et par jour au lieu des tâches": "Export the time tracked per task and day instead of tasks",
+	"démarrer/arrêter le chrono":                                       "start/stop the timer",
+	"temps suivi":                                                      "tracked time",
+	"Chrono arrêté: %s sur « %s »":                                     "Timer stopped: %s on “%s”",
+	"Chrono démarré sur « %s »":                                        "Timer started on “%s”",
+	"%d h %02d":                                                        "%d h %02d",
+	"Temps suivi, dernier jour":                                        "Tracked time, last day",
+	"Temps suivi, %d derniers jours":                                   "Tracked time, last %d days",
+	"Tab: 7 ou 30 jours · Esc: fermer":                                 "Tab: 7 or 30 days · Esc: close",
+	"CSV: lazy-todo export --time --format csv":                        "CSV: lazy-todo export --time --format csv",
+	"Aucun temps suivi sur la période":                                 "No time tracked over the period",
+	"Par jour":                                                         "Per day",
+	"Par tag":                                                          "Per tag",
+	"Total: %s":                                                        "Total: %s",
+	"Temps suivi":                                                      "Tracked time",
+	"Démarrer/arrêter le chrono de la tâche (un seul à la fois)": "Start/stop the timer of the task (one at a time)",
+	"Temps suivi par jour et par tag, avec les pomodoros":        "Time tracked per day and per tag, with the pomodoros",
+
+	// Hide done
+	"Tâches terminées affichées":                         "Done tasks shown",
+	"%d tâche terminée masquée":                          "%d done task hidden",
+	"%d tâches terminées masquées":                       "%d done tasks hidden",
+	"Aucune tâche ouverte, %d tâche terminée masquée":    "No open task, %d done task hidden",
+	"Aucune tâche ouverte, %d tâches terminées masquées": "No open task, %d done tasks hidden",
+	"(%d masquée)":          "(%d hidden)",
+	"(%d masquées)":         "(%d hidden)",
+	"masquer les terminées": "hide done",
+	"Masquer/afficher les tâches terminées et la colonne Terminé du kanban": "Hide/show the done tasks and the Done column of the kanban",
+
+	// Idle timer
+	"Temps inactif gardé":                      "Idle time kept",
+	"Temps inactif retiré: %s":                 "Idle time removed: %s",
+	"Chrono arrêté à %s, temps inactif retiré": "Timer stopped at %s, idle time removed",
+	"Inactivité pendant le chrono":             "Idle while timing",
+	"Aucune touche de %s à %s (%s)":            "No key pressed from %s to %s (%s)",
+	"Chrono de « %s »":                         "Timer of “%s”",
+	"garder ce temps":                          "keep this time",
+	"le retirer, le chrono continue":           "remove it, the timer goes on",
+	"le retirer et arrêter le chrono":          "remove it and stop the timer",
+	"Esc: garder":                              "Esc: keep",
+
+	// Blocked reasons
+	"Sans raison":               "No reason",
+	"En attente de revue":       "Waiting for review",
+	"En attente de déploiement": "Waiting for deploy",
+	"Dépendance externe":        "External dependency",
+	"raison du blocage retirée": "blocked reason removed",
+	"bloquée: %s":               "blocked: %s",
+	"Raison du blocage retirée": "Blocked reason removed",
+	"Bloquée: %s":               "Blocked: %s",
+	"raison du blocage":         "blocked reason",
+	"Bloquer la tâche, puis changer la raison: revue, déploiement, externe, aucune": "Block the task, then change the reason: review, deploy, external, none",
+	"Temps bloqué, par raison": "Time blocked, per reason",
+
+	// Maintenance
+	"Priorité à partir de laquelle une tâche sans échéance est signalée": "Priority from which a task without a due date is reported",
+	"Enregistrer les corrections qui ne demandent rien":                  "Save the fixes that need no input",
+	"Rien à ranger": "Nothing to tidy up",
+	"%d point corrigé, %d à voir dans l'interface (M)":       "%d finding fixed, %d to look at in the interface (M)",
+	"%d points corrigés, %d à voir dans l'interface (M)":     "%d findings fixed, %d to look at in the interface (M)",
+	"%d point à ranger, avec --fix ou dans l'interface (M)":  "%d finding to tidy up, with --fix or in the interface (M)",
+	"%d points à ranger, avec --fix ou dans l'interface (M)": "%d findings to tidy up, with --fix or in the interface (M)",
+	"couleur d'un tag qu'aucune tâche n'a":                   "color of a tag no task has",
+	"sans tag":                                               "untagged",
+	"sans échéance pour sa priorité":                         "no due date for its priority",
+	"checklist terminée, tâche ouverte":                      "checklist done, task open",
+	"terminée, chrono en cours":                              "done, timer running",
+	"terminée, encore épinglée":                              "done, still pinned",
+	"retirer la couleur":                                     "remove the color",
+	"ajouter des tags":                                       "add tags",
+	"éditer la tâche":                                        "edit the task",
+	"marquer terminée":                                       "mark done",
+	"arrêter le chrono":                                      "stop the timer",
+	"désépingler":                                            "unpin",
+	"Maintenance":                                            "Maintenance",
+	"maintenance":                                            "maintenance",
+	"j/k: naviguer │ Entrée: corriger │ Esc: fermer":         "j/k: navigate │ Enter: fix │ Esc: close",
+	"Maintenance: tags inutilisés, tâches sans tag ou sans échéance...": "Maintenance: unused tags, tasks without tags or due dates...",
+	// Images of the description
+	"Images:":            "Images:",
+	"%s (introuvable)":   "%s (not found)",
+	"… %d autre image":   "… %d other image",
+	"… %d autres images": "… %d other images",
+}
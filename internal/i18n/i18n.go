@@ -0,0 +1,124 @@
+// Package i18n translates the user interface. French is the source
+// language: messages are looked up by their French text, and bundles map
+// it to other languages. Missing translations fall back to French.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Locale represents a supported interface language
+type Locale string
+
+const (
+	French  Locale = "fr"
+	English Locale = "en"
+)
+
+// bundles maps each locale to its translations, keyed by French text
+var bundles = map[Locale]map[string]string{
+	French:  nil,
+	English: english,
+}
+
+var current = French
+
+// AllLocales returns all supported locales
+func AllLocales() []Locale {
+	return []Locale{French, English}
+}
+
+// ParseLocale extracts a supported locale from a name such as "en",
+// "en_US.UTF-8" or "fr-CA"
+func ParseLocale(name string) (Locale, bool) {
+	lang := strings.ToLower(name)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	l := Locale(lang)
+	if _, ok := bundles[l]; ok {
+		return l, true
+	}
+	return "", false
+}
+
+// FromEnv returns the locale selected by LC_ALL, LC_MESSAGES or LANG.
+// Unsupported languages get English; French is used when none is set.
+func FromEnv() Locale {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if value == "C" || value == "POSIX" || strings.HasPrefix(value, "C.") {
+			return French
+		}
+		if l, ok := ParseLocale(value); ok {
+			return l
+		}
+		return English
+	}
+	return French
+}
+
+// SetLocale sets the interface language
+func SetLocale(l Locale) {
+	if _, ok := bundles[l]; ok {
+		current = l
+	}
+}
+
+// Current returns the interface language
+func Current() Locale {
+	return current
+}
+
+// T translates a French message to the current locale
+func T(msg string) string {
+	if translated, ok := bundles[current][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Tf translates a French format string and formats it with args
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Count is a number that messages agree with
+type Count interface {
+	~int | ~int64 | ~float64
+}
+
+// isOne returns true when a count takes the singular in a language: 0 and
+// 1 in French ("0 tâche", "1,5 tâche"), only 1 in English
+func isOne[N Count](l Locale, n N) bool {
+	if l == French {
+		return n > -2 && n < 2
+	}
+	return n == 1 || n == -1
+}
+
+// N translates the singular or plural French format of a count, as the
+// plural rule of the current language picks it, and formats it with the
+// count: N(n, "%d tâche", "%d tâches")
+func N[C Count](n C, one, other string) string {
+	return Nf(n, one, other, n)
+}
+
+// Nf is N with other arguments than the count. A form without verbs, like
+// "la ligne suivante" for 1, is returned as is.
+func Nf[C Count](n C, one, other string, args ...any) string {
+	form := other
+	if isOne(current, n) {
+		form = one
+	}
+	form = T(form)
+	if !strings.Contains(form, "%") {
+		return form
+	}
+	return fmt.Sprintf(form, args...)
+}
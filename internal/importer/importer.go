@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// Source represents a supported import format
+type Source string
+
+const (
+	SourceTodoTxt     Source = "todotxt"
+	SourceTaskwarrior Source = "taskwarrior"
+	// Apple Reminders, exported with a shortcut
+	SourceAppleReminders Source = "apple-reminders"
+	SourceMicrosoftToDo  Source = "mstodo"
+)
+
+// importNamespace seeds the deterministic IDs of imported tasks, so that
+// importing the same file twice doesn't create duplicates
+var importNamespace = uuid.MustParse("6f0b6a52-3c1e-4d7a-9a51-2b8f3f1d8c40")
+
+// ParseSource converts a source name to a Source
+func ParseSource(name string) (Source, error) {
+	switch strings.ToLower(name) {
+	case "todotxt", "todo.txt":
+		return SourceTodoTxt, nil
+	case "taskwarrior", "tw":
+		return SourceTaskwarrior, nil
+	case "apple-reminders", "apple", "reminders", "rappels":
+		return SourceAppleReminders, nil
+	case "mstodo", "microsoft-todo", "ms-todo", "todo":
+		return SourceMicrosoftToDo, nil
+	}
+	return "", errors.New(i18n.Tf("source inconnue: %q (todotxt, taskwarrior, apple-reminders ou mstodo)", name))
+}
+
+// Import reads tasks from r in the given format
+func Import(src Source, r io.Reader) ([]model.Task, error) {
+	switch src {
+	case SourceTodoTxt:
+		return TodoTxt(r)
+	case SourceTaskwarrior:
+		return Taskwarrior(r)
+	case SourceAppleReminders:
+		return AppleReminders(r)
+	case SourceMicrosoftToDo:
+		return MicrosoftToDo(r)
+	}
+	return nil, errors.New(i18n.Tf("source inconnue: %q", src))
+}
+
+// importID returns a stable task ID derived from the source and a key
+func importID(src Source, key string) string {
+	return uuid.NewSHA1(importNamespace, []byte(string(src)+":"+key)).String()
+}
+
+// mapPriority returns the configured level for a built-in priority,
+// following aliases, or the default priority if it isn't a known level
+func mapPriority(p model.Priority) model.Priority {
+	p = model.MigratePriority(p)
+	if p.Index() < 0 {
+		return model.DefaultPriority()
+	}
+	return p
+}
+
+// addTag appends a tag if it isn't already present
+func addTag(tags []string, tag string) []string {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return tags
+	}
+	for _, t := range tags {
+		if t == tag {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
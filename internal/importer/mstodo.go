@@ -0,0 +1,248 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+// graphDateTime is a date and time with its time zone, as in Microsoft
+// Graph
+type graphDateTime struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+// time returns the date and time in its time zone; Windows time zone
+// names, which Go doesn't know, are read as local time
+func (d *graphDateTime) time() (time.Time, bool) {
+	if d == nil {
+		return time.Time{}, false
+	}
+	t, ok := parseExportDate(d.DateTime)
+	if !ok {
+		return time.Time{}, false
+	}
+	if loc, err := time.LoadLocation(d.TimeZone); err == nil && d.TimeZone != "" {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+	}
+	return t, true
+}
+
+// toDoTask is a task of Microsoft To Do, as returned by Microsoft Graph
+type toDoTask struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	Importance string `json:"importance"`
+	Body       struct {
+		Content     string `json:"content"`
+		ContentType string `json:"contentType"`
+	} `json:"body"`
+	Due            *graphDateTime `json:"dueDateTime"`
+	Reminder       *graphDateTime `json:"reminderDateTime"`
+	IsReminderOn   bool           `json:"isReminderOn"`
+	Created        string         `json:"createdDateTime"`
+	Modified       string         `json:"lastModifiedDateTime"`
+	Categories     []string       `json:"categories"`
+	ChecklistItems []struct {
+		DisplayName string `json:"displayName"`
+		IsChecked   bool   `json:"isChecked"`
+	} `json:"checklistItems"`
+}
+
+// toDoList is a list of Microsoft To Do with its tasks
+type toDoList struct {
+	DisplayName string     `json:"displayName"`
+	Tasks       []toDoTask `json:"tasks"`
+}
+
+// MicrosoftToDo parses Microsoft To Do tasks: the JSON of Microsoft Graph
+// (lists with their tasks, as an array or under "value" or "lists", or the
+// tasks of a list) or a CSV export of Outlook tasks. Lists and categories
+// become tags, important tasks get a high priority.
+func MicrosoftToDo(r io.Reader) ([]model.Task, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("\xef\xbb\xbf"))
+	if len(data) == 0 || (data[0] != '[' && data[0] != '{') {
+		return outlookTasks(data)
+	}
+
+	var envelope struct {
+		Value []json.RawMessage `json:"value"`
+		Lists []json.RawMessage `json:"lists"`
+	}
+	items := []json.RawMessage{data}
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+	} else if err := json.Unmarshal(data, &envelope); err == nil && len(envelope.Value)+len(envelope.Lists) > 0 {
+		items = append(envelope.Value, envelope.Lists...)
+	}
+
+	var tasks []model.Task
+	for _, item := range items {
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(item, &probe); err != nil {
+			return nil, err
+		}
+		if _, ok := probe["tasks"]; ok {
+			var list toDoList
+			if err := json.Unmarshal(item, &list); err != nil {
+				return nil, err
+			}
+			for _, t := range list.Tasks {
+				tasks = append(tasks, t.toTask(list.DisplayName))
+			}
+			continue
+		}
+		var t toDoTask
+		if err := json.Unmarshal(item, &t); err != nil {
+			return nil, err
+		}
+		if t.Title != "" {
+			tasks = append(tasks, t.toTask(""))
+		}
+	}
+	return tasks, nil
+}
+
+// toTask converts a To Do task of a list to a lazy-todo task
+func (t toDoTask) toTask(list string) model.Task {
+	task := model.NewTask(t.Title)
+	key := t.ID
+	if key == "" {
+		key = list + "\n" + t.Title + "\n" + t.Created
+	}
+	task.ID = importID(SourceMicrosoftToDo, key)
+	task.Status = toDoStatus(t.Status)
+	task.Priority = toDoPriority(t.Importance)
+
+	task.Description = strings.TrimSpace(t.Body.Content)
+	if strings.EqualFold(t.Body.ContentType, "html") {
+		task.Description = stripHTML(task.Description)
+	}
+
+	task.Tags = addTag(task.Tags, listTag(list))
+	for _, c := range t.Categories {
+		task.Tags = addTag(task.Tags, listTag(c))
+	}
+	for _, item := range t.ChecklistItems {
+		task.Subtasks = append(task.Subtasks, model.Subtask{Title: item.DisplayName, Done: item.IsChecked})
+	}
+
+	if created, ok := parseExportDate(t.Created); ok {
+		task.CreatedAt = created
+		task.UpdatedAt = created
+	}
+	if modified, ok := parseExportDate(t.Modified); ok {
+		task.UpdatedAt = modified
+	}
+	// Due dates are days, at midnight in the zone they were set in
+	if due, ok := parseExportDate(dateOf(t.Due)); ok {
+		task.DueDate = &due
+	}
+	if at, ok := t.Reminder.time(); ok && t.IsReminderOn && !task.Status.IsDone() {
+		task.Reminders = append(task.Reminders, model.Reminder{At: &at})
+	}
+	return task
+}
+
+// dateOf returns the day of a Graph date and time
+func dateOf(d *graphDateTime) string {
+	if d == nil {
+		return ""
+	}
+	day, _, _ := strings.Cut(d.DateTime, "T")
+	return day
+}
+
+// toDoStatus maps the status of a To Do or Outlook task
+func toDoStatus(s string) model.Status {
+	switch normalizeKey(s) {
+	case "completed", "complete":
+		return model.StatusOfKind(model.StatusDone)
+	case "inprogress":
+		return model.StatusOfKind(model.StatusInProgress)
+	case "waitingonothers", "waitingonsomeoneelse":
+		return model.StatusOfKind(model.StatusBlocked)
+	}
+	return model.StatusOfKind(model.StatusTodo)
+}
+
+// toDoPriority maps the importance of a To Do or Outlook task, high for
+// the tasks marked important
+func toDoPriority(importance string) model.Priority {
+	switch strings.ToLower(importance) {
+	case "high":
+		return mapPriority(model.PriorityHigh)
+	case "low":
+		return mapPriority(model.PriorityLow)
+	}
+	return model.DefaultPriority()
+}
+
+// outlookTasks parses the CSV export of Outlook tasks, which To Do syncs
+// with: Subject, Due Date, Status, Priority, Categories, Notes, Date
+// Completed, Reminder Date and Time; a List column becomes a tag
+func outlookTasks(data []byte) ([]model.Task, error) {
+	records, err := readRecords(data)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []model.Task
+	for _, rec := range records {
+		title := rec.str("subject", "title")
+		if title == "" {
+			continue
+		}
+		task := model.NewTask(title)
+		list := rec.str("list", "folder")
+		task.ID = importID(SourceMicrosoftToDo, list+"\n"+title+"\n"+rec.str("start date", "created"))
+		task.Status = toDoStatus(rec.str("status"))
+		if rec.str("date completed", "completed") != "" {
+			task.Status = model.StatusOfKind(model.StatusDone)
+		}
+		task.Priority = toDoPriority(rec.str("priority", "importance"))
+		task.Description = rec.str("notes", "body")
+		task.Tags = addTag(task.Tags, listTag(list))
+		for _, c := range rec.list("categories") {
+			task.Tags = addTag(task.Tags, listTag(c))
+		}
+		if due, ok := rec.time("due date", "due"); ok {
+			setDue(&task, due)
+		}
+		if rec.boolean("reminder on/off", "reminder") && !task.Status.IsDone() {
+			if at, ok := parseExportDate(rec.str("reminder date") + " " + rec.str("reminder time")); ok {
+				task.Reminders = append(task.Reminders, model.Reminder{At: &at})
+			}
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// stripHTML returns the text of an HTML body
+func stripHTML(s string) string {
+	var b strings.Builder
+	inTag := false
+	for _, r := range s {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>':
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(strings.NewReplacer("&nbsp;", " ", "&amp;", "&", "&lt;", "<", "&gt;", ">").Replace(b.String()))
+}
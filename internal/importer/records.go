@@ -0,0 +1,183 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// record is an exported item whose fields are named freely, as in the
+// dictionaries built with Shortcuts or the columns of a CSV export: keys
+// are normalized by normalizeKey
+type record map[string]any
+
+// normalizeKey makes "Due Date", "dueDate" and "due_date" the same key
+func normalizeKey(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// newRecord normalizes the keys of a JSON object
+func newRecord(m map[string]any) record {
+	r := make(record, len(m))
+	for k, v := range m {
+		r[normalizeKey(k)] = v
+	}
+	return r
+}
+
+// str returns the first non-empty field among keys, as text
+func (r record) str(keys ...string) string {
+	for _, k := range keys {
+		switch v := r[normalizeKey(k)].(type) {
+		case nil:
+		case string:
+			if v = strings.TrimSpace(v); v != "" {
+				return v
+			}
+		case map[string]any, []any:
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// boolean returns whether the first present field among keys is true, as
+// a JSON boolean or as text (true, yes, 1, oui)
+func (r record) boolean(keys ...string) bool {
+	for _, k := range keys {
+		switch v := r[normalizeKey(k)].(type) {
+		case bool:
+			return v
+		case float64:
+			return v != 0
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "true", "yes", "1", "oui", "vrai":
+				return true
+			case "":
+				continue
+			}
+			return false
+		}
+	}
+	return false
+}
+
+// list returns the first present field among keys as a list: a JSON array,
+// or text split on commas, semicolons and newlines
+func (r record) list(keys ...string) []string {
+	for _, k := range keys {
+		var items []string
+		switch v := r[normalizeKey(k)].(type) {
+		case []any:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					items = append(items, s)
+				}
+			}
+		case string:
+			items = strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ';' || r == '\n' })
+		}
+		if len(items) > 0 {
+			return items
+		}
+	}
+	return nil
+}
+
+// time returns the first field among keys that parses as a date
+func (r record) time(keys ...string) (time.Time, bool) {
+	for _, k := range keys {
+		if t, ok := parseExportDate(r.str(k)); ok {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// exportDates are the date formats of the exports: ISO 8601, Microsoft
+// Graph without time zone, Shortcuts' default format and Outlook's CSV
+var exportDates = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05.9999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"January 2, 2006 at 3:04 PM",
+	"Jan 2, 2006 at 3:04 PM",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006 at 15:04",
+	"2 January 2006",
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006",
+}
+
+// parseExportDate parses a date in one of exportDates, in local time when
+// it has no time zone
+func parseExportDate(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	// Shortcuts may use a narrow no-break space before AM/PM
+	s = strings.NewReplacer("\u202f", " ", "\u00a0", " ").Replace(s)
+	for _, layout := range exportDates {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// readRecords reads a JSON array of objects, or a CSV file with a header
+// row
+func readRecords(data []byte) ([]record, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("\xef\xbb\xbf"))
+	if len(data) > 0 && data[0] == '[' {
+		var items []map[string]any
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		records := make([]record, 0, len(items))
+		for _, item := range items {
+			records = append(records, newRecord(item))
+		}
+		return records, nil
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	header := rows[0]
+	records := make([]record, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		r := make(record, len(header))
+		for i, col := range header {
+			if i < len(row) {
+				r[normalizeKey(col)] = row[i]
+			}
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// listTag turns the name of a list into a tag, without spaces
+func listTag(name string) string {
+	return strings.Join(strings.Fields(name), "-")
+}
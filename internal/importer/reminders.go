@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+// AppleReminders parses reminders exported with a shortcut, as a JSON array
+// of dictionaries or a CSV file, with the fields of Shortcuts' Reminder
+// (Title, Notes, List, Due Date, Priority, Is Flagged, Is Completed, Tags,
+// Creation Date, URL; case and spaces don't matter). The list becomes a
+// tag, flagged reminders get a high priority and a due time a reminder.
+func AppleReminders(r io.Reader) ([]model.Task, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	records, err := readRecords(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []model.Task
+	for _, rec := range records {
+		if task, ok := appleReminder(rec); ok {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// appleReminder converts an exported reminder to a task, false without
+// title
+func appleReminder(rec record) (model.Task, bool) {
+	title := rec.str("title", "name")
+	if title == "" {
+		return model.Task{}, false
+	}
+	task := model.NewTask(title)
+	list := rec.str("list", "list name", "calendar")
+
+	key := rec.str("identifier", "id", "uuid")
+	if key == "" {
+		key = list + "\n" + title + "\n" + rec.str("creation date", "created")
+	}
+	task.ID = importID(SourceAppleReminders, key)
+
+	task.Description = rec.str("notes", "body")
+	if url := rec.str("url"); url != "" {
+		task.Description = strings.TrimSpace(task.Description + "\n\n" + url)
+	}
+
+	if rec.boolean("is completed", "completed") {
+		task.Status = model.StatusOfKind(model.StatusDone)
+	}
+
+	// Apple's priorities are 0 (none), 1 (high), 5 (medium) and 9 (low),
+	// shown as None, High, Medium, Low
+	switch p := strings.ToLower(rec.str("priority")); p {
+	case "high", "haute", "!!!":
+		task.Priority = mapPriority(model.PriorityHigh)
+	case "medium", "moyenne", "!!":
+		task.Priority = mapPriority(model.PriorityMedium)
+	case "low", "basse", "!":
+		task.Priority = mapPriority(model.PriorityLow)
+	default:
+		if n, err := strconv.Atoi(p); err == nil && n > 0 {
+			switch {
+			case n <= 4:
+				task.Priority = mapPriority(model.PriorityHigh)
+			case n == 5:
+				task.Priority = mapPriority(model.PriorityMedium)
+			default:
+				task.Priority = mapPriority(model.PriorityLow)
+			}
+		}
+	}
+	if rec.boolean("is flagged", "flagged") && task.Priority.Weight() < mapPriority(model.PriorityHigh).Weight() {
+		task.Priority = mapPriority(model.PriorityHigh)
+	}
+
+	task.Tags = addTag(task.Tags, listTag(list))
+	for _, tag := range rec.list("tags", "hashtags") {
+		task.Tags = addTag(task.Tags, listTag(strings.TrimPrefix(strings.TrimSpace(tag), "#")))
+	}
+
+	if created, ok := rec.time("creation date", "created", "creation"); ok {
+		task.CreatedAt = created
+		task.UpdatedAt = created
+	}
+	if modified, ok := rec.time("modification date", "modified", "last modified"); ok {
+		task.UpdatedAt = modified
+	}
+	if due, ok := rec.time("due date", "due", "date"); ok {
+		setDue(&task, due)
+	}
+	return task, true
+}
+
+// setDue sets the due day of a task; a due time other than midnight
+// becomes a reminder at that time, as due dates don't have one
+func setDue(task *model.Task, due time.Time) {
+	y, m, d := due.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, due.Location())
+	task.DueDate = &day
+	if !due.Equal(day) && !task.Status.IsDone() {
+		task.Reminders = append(task.Reminders, model.Reminder{At: &due})
+	}
+}
@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+const taskwarriorDate = "20060102T150405Z"
+
+// taskwarriorTask is a task as written by `task export`
+type taskwarriorTask struct {
+	UUID        string   `json:"uuid"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Priority    string   `json:"priority"`
+	Project     string   `json:"project"`
+	Tags        []string `json:"tags"`
+	Entry       string   `json:"entry"`
+	Modified    string   `json:"modified"`
+	Due         string   `json:"due"`
+	Start       string   `json:"start"`
+	Annotations []struct {
+		Description string `json:"description"`
+	} `json:"annotations"`
+}
+
+// Taskwarrior parses the JSON output of `task export`, either as an array
+// or one object per line (older versions). Deleted tasks are skipped, the
+// project becomes a tag and annotations become the description.
+func Taskwarrior(r io.Reader) ([]model.Task, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []taskwarriorTask
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, err
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := bytes.TrimRight(bytes.TrimSpace(scanner.Bytes()), ",")
+			if len(line) == 0 {
+				continue
+			}
+			var item taskwarriorTask
+			if err := json.Unmarshal(line, &item); err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var tasks []model.Task
+	for _, item := range items {
+		if item.Status == "deleted" {
+			continue
+		}
+		tasks = append(tasks, item.toTask())
+	}
+	return tasks, nil
+}
+
+// toTask converts a Taskwarrior task to a lazy-todo task
+func (tw taskwarriorTask) toTask() model.Task {
+	task := model.NewTask(tw.Description)
+	if tw.UUID != "" {
+		task.ID = importID(SourceTaskwarrior, tw.UUID)
+	}
+
+	switch {
+	case tw.Status == "completed":
+		task.Status = model.StatusOfKind(model.StatusDone)
+	case tw.Start != "":
+		task.Status = model.StatusOfKind(model.StatusInProgress)
+	}
+
+	switch tw.Priority {
+	case "H":
+		task.Priority = mapPriority(model.PriorityHigh)
+	case "M":
+		task.Priority = mapPriority(model.PriorityMedium)
+	case "L":
+		task.Priority = mapPriority(model.PriorityLow)
+	}
+
+	task.Tags = addTag(task.Tags, tw.Project)
+	for _, tag := range tw.Tags {
+		task.Tags = addTag(task.Tags, tag)
+	}
+
+	var notes []string
+	for _, a := range tw.Annotations {
+		notes = append(notes, a.Description)
+	}
+	task.Description = strings.Join(notes, "\n")
+
+	if d, ok := parseTaskwarriorDate(tw.Entry); ok {
+		task.CreatedAt = d
+		task.UpdatedAt = d
+	}
+	if d, ok := parseTaskwarriorDate(tw.Modified); ok {
+		task.UpdatedAt = d
+	}
+	if d, ok := parseTaskwarriorDate(tw.Due); ok {
+		task.DueDate = &d
+	}
+
+	return task
+}
+
+// parseTaskwarriorDate parses a Taskwarrior timestamp
+func parseTaskwarriorDate(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	d, err := time.Parse(taskwarriorDate, s)
+	return d, err == nil
+}
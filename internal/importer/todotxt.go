@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+const todoTxtDate = "2006-01-02"
+
+// TodoTxt parses a todo.txt file. Priorities (A) to (C) map to critical,
+// high and medium, lower letters to low. Projects (+x) and contexts (@x)
+// become tags, and completed lines (x ...) become done tasks.
+func TodoTxt(r io.Reader) ([]model.Task, error) {
+	var tasks []model.Task
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		tasks = append(tasks, parseTodoTxtLine(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// parseTodoTxtLine converts a single todo.txt line to a task
+func parseTodoTxtLine(line string) model.Task {
+	task := model.NewTask("")
+	task.ID = importID(SourceTodoTxt, line)
+
+	fields := strings.Fields(line)
+
+	// Completion marker and completion date
+	if len(fields) > 0 && fields[0] == "x" {
+		task.Status = model.StatusOfKind(model.StatusDone)
+		fields = fields[1:]
+		if len(fields) > 0 {
+			if d, err := time.Parse(todoTxtDate, fields[0]); err == nil {
+				task.UpdatedAt = d
+				fields = fields[1:]
+			}
+		}
+	}
+
+	// Priority
+	if len(fields) > 0 && len(fields[0]) == 3 && fields[0][0] == '(' && fields[0][2] == ')' {
+		if c := fields[0][1]; c >= 'A' && c <= 'Z' {
+			task.Priority = todoTxtPriority(c)
+			fields = fields[1:]
+		}
+	}
+
+	// Creation date
+	if len(fields) > 0 {
+		if d, err := time.Parse(todoTxtDate, fields[0]); err == nil {
+			task.CreatedAt = d
+			if !task.Status.IsDone() {
+				task.UpdatedAt = d
+			}
+			fields = fields[1:]
+		}
+	}
+
+	var words []string
+	for _, f := range fields {
+		switch {
+		case len(f) > 1 && (f[0] == '+' || f[0] == '@'):
+			task.Tags = addTag(task.Tags, f[1:])
+		case strings.HasPrefix(f, "due:"):
+			if d, err := time.Parse(todoTxtDate, strings.TrimPrefix(f, "due:")); err == nil {
+				task.DueDate = &d
+				continue
+			}
+			words = append(words, f)
+		case strings.HasPrefix(f, "pri:"):
+			// Completed tasks keep their priority as a pri: tag
+			if p := strings.TrimPrefix(f, "pri:"); len(p) == 1 && p[0] >= 'A' && p[0] <= 'Z' {
+				task.Priority = todoTxtPriority(p[0])
+				continue
+			}
+			words = append(words, f)
+		default:
+			words = append(words, f)
+		}
+	}
+
+	task.Title = strings.Join(words, " ")
+	return task
+}
+
+// todoTxtPriority maps a todo.txt priority letter to a priority
+func todoTxtPriority(c byte) model.Priority {
+	switch c {
+	case 'A':
+		return mapPriority(model.PriorityCritical)
+	case 'B':
+		return mapPriority(model.PriorityHigh)
+	case 'C':
+		return mapPriority(model.PriorityMedium)
+	default:
+		return mapPriority(model.PriorityLow)
+	}
+}
@@ -0,0 +1,388 @@
+// Package caldav syncs the tasks both ways with a task list of a CalDAV
+// server (Nextcloud Tasks, iCloud Reminders), as VTODOs. A task changed on
+// both sides since the last sync keeps the most recent version, by its
+// update time and the to-do's LAST-MODIFIED.
+package caldav
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/export"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when the server can't be used
+var (
+	ErrNoURL      = errors.New("aucune liste CalDAV configurée (caldav.url)")
+	ErrNoPassword = errors.New("aucun mot de passe CalDAV configuré (caldav.password ou CALDAV_PASSWORD)")
+	ErrChanged    = errors.New("une tâche a changé sur le serveur pendant la synchronisation, relancez-la")
+)
+
+// namespace seeds the IDs of the tasks created from to-dos of the server,
+// so that a to-do is imported once
+var namespace = uuid.MustParse("0d8c4b27-5e1f-4a93-b6d2-7f3e9a1c5b48")
+
+// prefix starts the references of the tasks imported from the server
+const prefix = "caldav:"
+
+// calendarQuery asks for the to-dos of the calendar with their content
+const calendarQuery = `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO"/></c:comp-filter></c:filter>
+</c:calendar-query>`
+
+// multistatus is the response of a REPORT
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"DAV: href"`
+		Propstat []struct {
+			Prop struct {
+				ETag string `xml:"DAV: getetag"`
+				Data string `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+			} `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// Client syncs with a calendar collection
+type Client struct {
+	cfg    config.CalDAVConfig
+	ics    config.ICSConfig
+	base   *url.URL
+	client *http.Client
+}
+
+// New returns a client for the configured calendar, with the password of
+// the config or of CALDAV_PASSWORD; the ics settings shape the to-dos sent
+func New(cfg config.CalDAVConfig, ics config.ICSConfig) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, ErrNoURL
+	}
+	if !strings.HasSuffix(cfg.URL, "/") {
+		cfg.URL += "/"
+	}
+	base, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Password == "" {
+		cfg.Password = os.Getenv("CALDAV_PASSWORD")
+	}
+	if cfg.Username != "" && cfg.Password == "" {
+		return nil, ErrNoPassword
+	}
+	return &Client{cfg: cfg, ics: ics, base: base, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// UID returns the UID of the to-do of a task: the one it was imported
+// from, or else one made from its ID
+func UID(t model.Task) string {
+	if uid, ok := strings.CutPrefix(t.External, prefix); ok && uid != "" {
+		return uid
+	}
+	return export.TodoUID(t.ID)
+}
+
+// Task converts a to-do of the server to a new task
+func Task(it Item) model.Task {
+	task := model.NewTask(it.Summary)
+	task.External = prefix + it.UID
+	task.ID = uuid.NewSHA1(namespace, []byte(task.External)).String()
+	return it.Apply(task)
+}
+
+// Items returns the to-dos of the calendar
+func (c *Client) Items() ([]Item, error) {
+	req, err := c.request("REPORT", c.base.String(), strings.NewReader(calendarQuery))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	data, _, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ms multistatus
+	if err := xml.Unmarshal(data, &ms); err != nil {
+		return nil, err
+	}
+	var items []Item
+	for _, r := range ms.Responses {
+		for _, ps := range r.Propstat {
+			if ps.Prop.Data == "" {
+				continue
+			}
+			it, ok := parseItem(ps.Prop.Data)
+			if !ok {
+				continue
+			}
+			it.Href = r.Href
+			it.ETag = ps.Prop.ETag
+			items = append(items, it)
+		}
+	}
+	return items, nil
+}
+
+// Put sends the to-do of a task: over the to-do of href when not empty,
+// as long as it still has the etag, else as a new one
+func (c *Client) Put(t model.Task, href, etag string) error {
+	target := c.base.JoinPath(url.PathEscape(t.ID) + ".ics").String()
+	if href != "" {
+		target = c.resolve(href)
+	}
+	body := export.TodoCalendar(t, UID(t), c.ics, time.Now())
+	req, err := c.request(http.MethodPut, target, strings.NewReader(string(body)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	if href == "" {
+		req.Header.Set("If-None-Match", "*")
+	} else if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+	_, _, err = c.do(req)
+	return err
+}
+
+// Delete deletes a to-do, as long as it still has the etag
+func (c *Client) Delete(it Item) error {
+	req, err := c.request(http.MethodDelete, c.resolve(it.Href), nil)
+	if err != nil {
+		return err
+	}
+	if it.ETag != "" {
+		req.Header.Set("If-Match", it.ETag)
+	}
+	_, code, err := c.do(req)
+	if code == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// Result counts the changes made by a sync, on each side
+type Result struct {
+	Imported int // to-dos imported as tasks
+	Updated  int // tasks updated from their to-do
+	Deleted  int // tasks whose to-do was deleted
+	Sent     int // tasks sent as new to-dos
+	Pushed   int // to-dos updated from their task
+	Removed  int // to-dos whose task was deleted
+}
+
+// state is what the last sync of a tasks file with the calendar left in
+// sync, to tell a deletion on a side from an addition on the other
+type state struct {
+	Time time.Time `json:"time"`
+	UIDs []string  `json:"uids"`
+}
+
+// Sync syncs the tasks file with the calendar. A task and its to-do
+// that differ keep the most recent version. A to-do or a task synced
+// before and gone from the other side is deleted, unless it changed since
+// the last sync; then it's sent or imported again.
+func (c *Client) Sync(store *storage.Storage) (Result, error) {
+	var result Result
+	items, err := c.Items()
+	if err != nil {
+		return result, err
+	}
+	tasks, err := store.Load()
+	if err != nil {
+		return result, err
+	}
+	statePath := c.statePath(store.FilePath)
+	last, err := loadState(statePath)
+	if err != nil {
+		return result, err
+	}
+
+	local := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		local[UID(t)] = i
+	}
+	query := model.ParseQuery(c.cfg.Filter).WithTasks(tasks)
+	wasSynced := make(map[string]bool, len(last.UIDs))
+	for _, uid := range last.UIDs {
+		wasSynced[uid] = true
+	}
+
+	var changes storage.Changes
+	var synced []string
+	seen := make(map[string]bool, len(items))
+	for _, it := range items {
+		seen[it.UID] = true
+		i, ok := local[it.UID]
+		switch {
+		case ok:
+			t := tasks[i]
+			if updated := it.Apply(t); !sameFields(t, updated) {
+				if it.Modified.After(t.UpdatedAt) {
+					changes.Updated = append(changes.Updated, updated)
+					result.Updated++
+				} else {
+					if err := c.Put(t, it.Href, it.ETag); err != nil {
+						return result, err
+					}
+					result.Pushed++
+				}
+			}
+			synced = append(synced, it.UID)
+		case wasSynced[it.UID] && !it.Modified.After(last.Time):
+			if err := c.Delete(it); err != nil {
+				return result, err
+			}
+			result.Removed++
+		default:
+			changes.Added = append(changes.Added, Task(it))
+			synced = append(synced, it.UID)
+			result.Imported++
+		}
+	}
+
+	for _, t := range tasks {
+		uid := UID(t)
+		if seen[uid] {
+			continue
+		}
+		switch {
+		case wasSynced[uid] && !t.UpdatedAt.After(last.Time):
+			changes.Deleted = append(changes.Deleted, t.ID)
+			result.Deleted++
+			continue
+		case !wasSynced[uid] && !strings.HasPrefix(t.External, prefix) && !query.Matches(t):
+			continue
+		}
+		if err := c.Put(t, "", ""); err != nil {
+			return result, err
+		}
+		synced = append(synced, uid)
+		result.Sent++
+	}
+
+	if !changes.IsEmpty() {
+		if _, err := store.Commit(changes); err != nil {
+			return result, err
+		}
+	}
+	// After the commit, which sets the update time of the tasks imported
+	return result, saveState(statePath, state{Time: time.Now(), UIDs: synced})
+}
+
+// statePath returns the file of the state of the sync of a tasks file with
+// the calendar, in the data directory
+func (c *Client) statePath(tasksFile string) string {
+	if abs, err := filepath.Abs(tasksFile); err == nil {
+		tasksFile = abs
+	}
+	sum := sha1.Sum([]byte(tasksFile + "\n" + c.cfg.URL))
+	name := hex.EncodeToString(sum[:8]) + ".json"
+
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(".caldav", name)
+		}
+		dataDir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataDir, "lazy-todo", "caldav", name)
+}
+
+// loadState reads the state of the last sync, empty before the first one
+func loadState(path string) (state, error) {
+	var s state
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	return s, json.Unmarshal(data, &s)
+}
+
+// saveState writes the state of a sync
+func saveState(path string, s state) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// resolve returns the URL of an href of the server, usually a path
+func (c *Client) resolve(href string) string {
+	ref, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	return c.base.ResolveReference(ref).String()
+}
+
+// request returns an authenticated request
+func (c *Client) request(method, target string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.cfg.Username != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	req.Header.Set("User-Agent", "lazy-todo")
+	return req, nil
+}
+
+// statusError is an unsuccessful response of the server
+type statusError struct {
+	status string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("CalDAV: %s", e.status)
+}
+
+// do sends a request and returns the body of the response. A failed
+// precondition means the to-do changed since it was listed: ErrChanged.
+func (c *Client) do(req *http.Request) ([]byte, int, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return nil, resp.StatusCode, ErrChanged
+	case resp.StatusCode >= 300:
+		return nil, resp.StatusCode, &statusError{status: resp.Status}
+	}
+	return data, resp.StatusCode, nil
+}
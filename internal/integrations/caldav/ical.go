@@ -0,0 +1,244 @@
+package caldav
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/export"
+	"lazy-todo/internal/model"
+)
+
+// property is a content line of an iCalendar object
+type property struct {
+	params map[string]string
+	value  string
+}
+
+// Item is a to-do of the server, with the fields synced with a task
+type Item struct {
+	Href string
+	ETag string
+
+	UID         string
+	Summary     string
+	Description string
+	Status      string // NEEDS-ACTION, IN-PROCESS, COMPLETED or CANCELLED
+	Priority    int    // 1 (highest) to 9, 0 when undefined
+	Due         *time.Time
+	Categories  []string
+	Modified    time.Time
+	// TaskStatus is the status of the task the to-do was sent from
+	TaskStatus model.Status
+}
+
+// parseItem reads the first to-do of a calendar object; false when it has
+// none
+func parseItem(data string) (Item, bool) {
+	props, ok := parseVTODO(data)
+	if !ok {
+		return Item{}, false
+	}
+	text := func(name string) string {
+		if p := props[name]; len(p) > 0 {
+			return unescape(p[0].value)
+		}
+		return ""
+	}
+
+	it := Item{
+		UID:         text("UID"),
+		Summary:     text("SUMMARY"),
+		Description: text("DESCRIPTION"),
+		Status:      strings.ToUpper(text("STATUS")),
+		TaskStatus:  model.Status(text("X-LAZY-TODO-STATUS")),
+	}
+	if it.Status == "" {
+		it.Status = "NEEDS-ACTION"
+	}
+	it.Priority, _ = strconv.Atoi(text("PRIORITY"))
+	if p := props["DUE"]; len(p) > 0 {
+		if due, ok := parseTime(p[0]); ok {
+			it.Due = &due
+		}
+	}
+	for _, name := range []string{"LAST-MODIFIED", "DTSTAMP"} {
+		if p := props[name]; len(p) > 0 {
+			if at, ok := parseTime(p[0]); ok {
+				it.Modified = at
+				break
+			}
+		}
+	}
+	for _, p := range props["CATEGORIES"] {
+		for _, tag := range splitList(p.value) {
+			if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(it.Categories, tag) {
+				it.Categories = append(it.Categories, tag)
+			}
+		}
+	}
+	return it, it.UID != ""
+}
+
+// parseVTODO returns the properties of the first VTODO of a calendar
+// object, by name, leaving out those of its alarms
+func parseVTODO(data string) (map[string][]property, bool) {
+	// Unfold the lines continued on the next one
+	data = strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "").Replace(data)
+
+	var props map[string][]property
+	depth := 0 // of the components nested in the to-do
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		name, p, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case props == nil:
+			if name == "BEGIN" && strings.EqualFold(p.value, "VTODO") {
+				props = map[string][]property{}
+			}
+		case name == "BEGIN":
+			depth++
+		case name == "END" && depth > 0:
+			depth--
+		case name == "END":
+			return props, true
+		case depth == 0:
+			props[name] = append(props[name], p)
+		}
+	}
+	return nil, false
+}
+
+// parseLine splits a content line, NAME;PARAM=VALUE:value, ignoring the
+// colons and semicolons of quoted parameter values
+func parseLine(line string) (string, property, bool) {
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", property{}, false
+	}
+
+	p := property{params: map[string]string{}, value: line[colon+1:]}
+	parts := splitOutsideQuotes(line[:colon], ';')
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		p.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return strings.ToUpper(parts[0]), p, true
+}
+
+// splitOutsideQuotes splits s on sep, except inside double quotes
+func splitOutsideQuotes(s string, sep rune) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseTime reads a date (all day, at midnight local time), a UTC time, a
+// time in the zone of its TZID or a floating time, taken as local
+func parseTime(p property) (time.Time, bool) {
+	if p.params["VALUE"] == "DATE" || len(p.value) == 8 {
+		t, err := time.ParseInLocation("20060102", p.value, time.Local)
+		return t, err == nil
+	}
+	if strings.HasSuffix(p.value, "Z") {
+		t, err := time.Parse("20060102T150405Z", p.value)
+		return t, err == nil
+	}
+	loc := time.Local
+	if tzid := p.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", p.value, loc)
+	return t, err == nil
+}
+
+// unescape reads a text value
+func unescape(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
+
+// splitList splits a list of text values on the commas that aren't
+// escaped
+func splitList(s string) []string {
+	var values []string
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			b.WriteRune('\\')
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			values = append(values, unescape(b.String()))
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return append(values, unescape(b.String()))
+}
+
+// Apply returns a task with the fields of the to-do. A task keeps its
+// status while the to-do's matches it (blocked needs action), and the
+// status it was sent with when the to-do's is unchanged.
+func (it Item) Apply(t model.Task) model.Task {
+	t.Title = it.Summary
+	t.Description = it.Description
+	switch {
+	case export.ICSStatus(t.Status) == it.Status:
+	case it.TaskStatus != "" && export.ICSStatus(it.TaskStatus) == it.Status:
+		t.Status = it.TaskStatus
+	case it.Status == "COMPLETED", it.Status == "CANCELLED":
+		t.Status = model.StatusOfKind(model.StatusDone)
+	case it.Status == "IN-PROCESS":
+		t.Status = model.StatusOfKind(model.StatusInProgress)
+	default:
+		t.Status = model.StatusOfKind(model.StatusTodo)
+	}
+	if p, ok := export.PriorityOfICS(it.Priority); ok {
+		t.Priority = p
+	}
+	t.DueDate = it.Due
+	t.Tags = append([]string{}, it.Categories...)
+	return t
+}
+
+// sameFields returns true if two tasks have the same synced fields
+func sameFields(a, b model.Task) bool {
+	sameDue := a.DueDate == nil && b.DueDate == nil ||
+		a.DueDate != nil && b.DueDate != nil && a.DueDate.Equal(*b.DueDate)
+	return a.Title == b.Title &&
+		strings.TrimSpace(a.Description) == strings.TrimSpace(b.Description) &&
+		a.Status == b.Status &&
+		a.Priority == b.Priority &&
+		sameDue &&
+		slices.Equal(a.Tags, b.Tags)
+}
@@ -0,0 +1,287 @@
+// Package github imports the GitHub issues assigned to the user as tasks,
+// and closes the issues of the tasks marked done.
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+// DefaultAPIURL is the API of github.com
+const DefaultAPIURL = "https://api.github.com"
+
+// Errors returned when the integration can't be used
+var (
+	ErrNoRepo  = errors.New("aucun dépôt GitHub configuré (github.repo: propriétaire/nom)")
+	ErrNoToken = errors.New("aucun jeton GitHub configuré (github.token ou GITHUB_TOKEN)")
+)
+
+// namespace seeds the IDs of the imported tasks, so that an issue is
+// imported once
+var namespace = uuid.MustParse("0d5c4f7e-8a3b-4f1e-b6c2-7e9a1d3f5b80")
+
+// Issue is the part of a GitHub issue we use
+type Issue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"` // open or closed
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	// Set when the issue is a pull request, which aren't imported
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// Client calls the GitHub API for a repository
+type Client struct {
+	repo   string // owner/name
+	token  string
+	api    string
+	client *http.Client
+}
+
+// New returns a client for the configured repository, with the token of
+// the config or of GITHUB_TOKEN
+func New(cfg config.GitHubConfig) (*Client, error) {
+	if strings.Count(cfg.Repo, "/") != 1 {
+		return nil, ErrNoRepo
+	}
+	token := cfg.Token
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		return nil, ErrNoToken
+	}
+	api := strings.TrimSuffix(cfg.APIURL, "/")
+	if api == "" {
+		api = DefaultAPIURL
+	}
+	return &Client{
+		repo:   cfg.Repo,
+		token:  token,
+		api:    api,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// External returns the reference stored in the task imported from an issue
+func (c *Client) External(number int) string {
+	return fmt.Sprintf("github:%s#%d", c.repo, number)
+}
+
+// externalRe matches the references of the imported tasks
+var externalRe = regexp.MustCompile(`^github:([^#]+)#(\d+)$`)
+
+// issueNumber returns the number of the issue a task was imported from in
+// this repository, false for other tasks
+func (c *Client) issueNumber(t model.Task) (int, bool) {
+	m := externalRe.FindStringSubmatch(t.External)
+	if m == nil || !strings.EqualFold(m[1], c.repo) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[2])
+	return n, err == nil
+}
+
+// Task converts an issue to a task: labels become tags, and a closed issue
+// is done
+func (c *Client) Task(issue Issue) model.Task {
+	task := model.NewTask(issue.Title)
+	task.External = c.External(issue.Number)
+	task.ID = uuid.NewSHA1(namespace, []byte(task.External)).String()
+	task.Description = strings.ReplaceAll(issue.Body, "\r\n", "\n")
+	for _, l := range issue.Labels {
+		task.Tags = append(task.Tags, l.Name)
+	}
+	if issue.State == "closed" {
+		task.Status = model.StatusOfKind(model.StatusDone)
+	}
+	return task
+}
+
+// AssignedIssues returns the open issues of the repository assigned to the
+// owner of the token
+func (c *Client) AssignedIssues() ([]Issue, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if _, err := c.do(http.MethodGet, c.api+"/user", nil, &user); err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	next := fmt.Sprintf("%s/repos/%s/issues?assignee=%s&state=open&per_page=100", c.api, c.repo, url.QueryEscape(user.Login))
+	for next != "" {
+		var page []Issue
+		resp, err := c.do(http.MethodGet, next, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range page {
+			if issue.PullRequest == nil {
+				issues = append(issues, issue)
+			}
+		}
+		next = nextPage(resp.Header.Get("Link"))
+	}
+	return issues, nil
+}
+
+// Issue returns an issue of the repository
+func (c *Client) Issue(number int) (Issue, error) {
+	var issue Issue
+	_, err := c.do(http.MethodGet, fmt.Sprintf("%s/repos/%s/issues/%d", c.api, c.repo, number), nil, &issue)
+	return issue, err
+}
+
+// Close closes an issue as completed
+func (c *Client) Close(number int) error {
+	body := map[string]string{"state": "closed", "state_reason": "completed"}
+	_, err := c.do(http.MethodPatch, fmt.Sprintf("%s/repos/%s/issues/%d", c.api, c.repo, number), body, nil)
+	return err
+}
+
+// Result counts the changes made by a sync
+type Result struct {
+	Imported  int // new issues imported as tasks
+	Completed int // tasks marked done as their issue was closed
+	Closed    int // issues closed as their task is done
+}
+
+// Sync closes the open issues whose task is done, marks done the tasks
+// whose issue was closed, and imports the newly assigned issues
+func (c *Client) Sync(store *storage.Storage) (Result, error) {
+	var result Result
+	issues, err := c.AssignedIssues()
+	if err != nil {
+		return result, err
+	}
+	open := make(map[int]Issue, len(issues))
+	for _, issue := range issues {
+		open[issue.Number] = issue
+	}
+
+	tasks, err := store.Load()
+	if err != nil {
+		return result, err
+	}
+
+	var changes storage.Changes
+	imported := make(map[int]bool)
+	for _, t := range tasks {
+		number, ok := c.issueNumber(t)
+		if !ok {
+			continue
+		}
+		imported[number] = true
+		_, isOpen := open[number]
+		switch {
+		case t.Status.IsDone() && isOpen:
+			if err := c.Close(number); err != nil {
+				return result, err
+			}
+			result.Closed++
+		case !t.Status.IsDone() && !isOpen:
+			// No longer listed: closed, assigned to someone else, or
+			// deleted, which leaves the task alone
+			issue, err := c.Issue(number)
+			if err == nil && issue.State == "closed" {
+				t.Status = model.StatusOfKind(model.StatusDone)
+				changes.Updated = append(changes.Updated, t)
+				result.Completed++
+			}
+		}
+	}
+
+	for _, issue := range issues {
+		if !imported[issue.Number] {
+			changes.Added = append(changes.Added, c.Task(issue))
+			result.Imported++
+		}
+	}
+
+	if changes.IsEmpty() {
+		return result, nil
+	}
+	_, err = store.Commit(changes)
+	return result, err
+}
+
+// do sends a request to the API and decodes the JSON response into out,
+// when not nil
+func (c *Client) do(method, endpoint string, in, out any) (*http.Response, error) {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		var e struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &e) == nil && e.Message != "" {
+			return nil, fmt.Errorf("GitHub: %s: %s", resp.Status, e.Message)
+		}
+		return nil, fmt.Errorf("GitHub: %s", resp.Status)
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// linkRe matches the next page in a Link header
+var linkRe = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// nextPage returns the URL of the next page from a Link header, empty on
+// the last page
+func nextPage(link string) string {
+	if m := linkRe.FindStringSubmatch(link); m != nil {
+		return m[1]
+	}
+	return ""
+}
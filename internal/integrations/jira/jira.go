@@ -0,0 +1,362 @@
+// Package jira imports the Jira issues of a JQL query as tasks, and moves
+// the issues through their workflow when their task changes status.
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+// DefaultJQL selects the open issues assigned to the user
+const DefaultJQL = "assignee = currentUser() AND statusCategory != Done ORDER BY updated DESC"
+
+// DefaultTag is added to the imported tasks
+const DefaultTag = "jira"
+
+// Errors returned when the integration can't be used
+var (
+	ErrNoURL        = errors.New("aucun site Jira configuré (jira.url)")
+	ErrNoToken      = errors.New("aucun jeton Jira configuré (jira.token ou JIRA_API_TOKEN)")
+	ErrNoTransition = errors.New("aucune transition Jira ne correspond à ce statut")
+)
+
+// namespace seeds the IDs of the imported tasks, so that an issue is
+// imported once
+var namespace = uuid.MustParse("6a1e3b9d-2c47-4f85-9e0a-b3d8c5f7a214")
+
+// prefix starts the references of the imported tasks
+const prefix = "jira:"
+
+// Issue is the part of a Jira issue we use
+type Issue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string   `json:"summary"`
+		Labels  []string `json:"labels"`
+		Status  Status   `json:"status"`
+	} `json:"fields"`
+}
+
+// Status is a Jira status, grouped in a category: new, indeterminate or
+// done
+type Status struct {
+	Name     string `json:"name"`
+	Category struct {
+		Key string `json:"key"`
+	} `json:"statusCategory"`
+}
+
+// Client calls the Jira REST API of a site
+type Client struct {
+	cfg    config.JiraConfig
+	client *http.Client
+}
+
+// New returns a client for the configured site, with the token of the
+// config or of JIRA_API_TOKEN
+func New(cfg config.JiraConfig) (*Client, error) {
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	if cfg.URL == "" {
+		return nil, ErrNoURL
+	}
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("JIRA_API_TOKEN")
+	}
+	if cfg.Token == "" {
+		return nil, ErrNoToken
+	}
+	if cfg.JQL == "" {
+		cfg.JQL = DefaultJQL
+	}
+	if cfg.Tag == "" {
+		cfg.Tag = DefaultTag
+	}
+	return &Client{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// IssueKey returns the key of the issue a task was imported from, false
+// for other tasks
+func IssueKey(t model.Task) (string, bool) {
+	key, ok := strings.CutPrefix(t.External, prefix)
+	return key, ok && key != ""
+}
+
+// statusOf returns the task status of a Jira status category: the first
+// workflow status of the matching kind
+func statusOf(s Status) model.Status {
+	switch s.Category.Key {
+	case "indeterminate":
+		return model.StatusOfKind(model.StatusInProgress)
+	case "done":
+		return model.StatusOfKind(model.StatusDone)
+	}
+	return model.StatusOfKind(model.StatusTodo)
+}
+
+// categoryOf returns the Jira status category of a task status, from its
+// kind; empty for blocked which has none
+func categoryOf(s model.Status) string {
+	switch s.Kind() {
+	case model.StatusTodo:
+		return "new"
+	case model.StatusInProgress:
+		return "indeterminate"
+	case model.StatusDone:
+		return "done"
+	}
+	return ""
+}
+
+// Task converts an issue to a task, tagged with the configured tag and the
+// issue labels
+func (c *Client) Task(issue Issue) model.Task {
+	task := model.NewTask(issue.Fields.Summary)
+	task.External = prefix + issue.Key
+	task.ID = uuid.NewSHA1(namespace, []byte(task.External)).String()
+	task.Tags = append([]string{c.cfg.Tag}, issue.Fields.Labels...)
+	task.Status = statusOf(issue.Fields.Status)
+	return task
+}
+
+// Search returns the issues of the configured JQL query
+func (c *Client) Search() ([]Issue, error) {
+	issues, err := c.searchJQL()
+	var status *statusError
+	if errors.As(err, &status) && (status.code == http.StatusNotFound || status.code == http.StatusGone) {
+		// Jira Data Center doesn't have the new search endpoint
+		return c.searchV2()
+	}
+	return issues, err
+}
+
+// searchJQL pages through /rest/api/3/search/jql, on Jira Cloud
+func (c *Client) searchJQL() ([]Issue, error) {
+	var issues []Issue
+	token := ""
+	for {
+		query := url.Values{
+			"jql":        {c.cfg.JQL},
+			"fields":     {"summary,status,labels"},
+			"maxResults": {"100"},
+		}
+		if token != "" {
+			query.Set("nextPageToken", token)
+		}
+		var page struct {
+			Issues        []Issue `json:"issues"`
+			NextPageToken string  `json:"nextPageToken"`
+			IsLast        bool    `json:"isLast"`
+		}
+		if err := c.do(http.MethodGet, "/rest/api/3/search/jql?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		issues = append(issues, page.Issues...)
+		if page.IsLast || page.NextPageToken == "" {
+			return issues, nil
+		}
+		token = page.NextPageToken
+	}
+}
+
+// searchV2 pages through /rest/api/2/search
+func (c *Client) searchV2() ([]Issue, error) {
+	var issues []Issue
+	for {
+		query := url.Values{
+			"jql":        {c.cfg.JQL},
+			"fields":     {"summary,status,labels"},
+			"maxResults": {"100"},
+			"startAt":    {strconv.Itoa(len(issues))},
+		}
+		var page struct {
+			Issues []Issue `json:"issues"`
+			Total  int     `json:"total"`
+		}
+		if err := c.do(http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		issues = append(issues, page.Issues...)
+		if len(page.Issues) == 0 || len(issues) >= page.Total {
+			return issues, nil
+		}
+	}
+}
+
+// Transition moves an issue to the status matching a task status: the
+// transition configured for it, or else the first one leading to a status
+// of the same category. Without a configured transition, nothing is done
+// when the issue is already in that category, or for blocked tasks.
+func (c *Client) Transition(key string, status model.Status) error {
+	name, ok := c.cfg.Transitions[string(status)]
+	if !ok {
+		name = c.cfg.Transitions[string(status.Kind())]
+	}
+	category := categoryOf(status)
+	issuePath := "/rest/api/2/issue/" + url.PathEscape(key)
+	if name == "" {
+		if category == "" {
+			return nil
+		}
+		var issue Issue
+		if err := c.do(http.MethodGet, issuePath+"?fields=status", nil, &issue); err != nil {
+			return err
+		}
+		if issue.Fields.Status.Category.Key == category {
+			return nil
+		}
+	}
+
+	var result struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   Status `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := c.do(http.MethodGet, issuePath+"/transitions", nil, &result); err != nil {
+		return err
+	}
+	for _, t := range result.Transitions {
+		if (name != "" && strings.EqualFold(t.Name, name)) || (name == "" && t.To.Category.Key == category) {
+			body := map[string]any{"transition": map[string]string{"id": t.ID}}
+			return c.do(http.MethodPost, issuePath+"/transitions", body, nil)
+		}
+	}
+	return ErrNoTransition
+}
+
+// Result counts the changes made by a sync
+type Result struct {
+	Imported int // new issues imported as tasks
+	Updated  int // tasks whose title or status changed in Jira
+}
+
+// Sync imports the new issues of the query, and updates the title and
+// status of the tasks already imported from the issues it returns
+func (c *Client) Sync(store *storage.Storage) (Result, error) {
+	var result Result
+	issues, err := c.Search()
+	if err != nil {
+		return result, err
+	}
+	tasks, err := store.Load()
+	if err != nil {
+		return result, err
+	}
+	imported := make(map[string]model.Task)
+	for _, t := range tasks {
+		if key, ok := IssueKey(t); ok {
+			imported[key] = t
+		}
+	}
+
+	var changes storage.Changes
+	for _, issue := range issues {
+		t, ok := imported[issue.Key]
+		if !ok {
+			changes.Added = append(changes.Added, c.Task(issue))
+			result.Imported++
+			continue
+		}
+		status := statusOf(issue.Fields.Status)
+		// A task keeps its column while the issue stays in the same
+		// category, and stays blocked while the issue is in progress
+		if t.Status.Kind() == status.Kind() ||
+			(t.Status.Kind() == model.StatusBlocked && status.Kind() == model.StatusInProgress) {
+			status = t.Status
+		}
+		if t.Title == issue.Fields.Summary && t.Status == status {
+			continue
+		}
+		t.Title = issue.Fields.Summary
+		t.Status = status
+		changes.Updated = append(changes.Updated, t)
+		result.Updated++
+	}
+
+	if changes.IsEmpty() {
+		return result, nil
+	}
+	_, err = store.Commit(changes)
+	return result, err
+}
+
+// statusError is an unsuccessful response of the API
+type statusError struct {
+	code    int
+	status  string
+	message string
+}
+
+func (e *statusError) Error() string {
+	if e.message != "" {
+		return fmt.Sprintf("Jira: %s: %s", e.status, e.message)
+	}
+	return "Jira: " + e.status
+}
+
+// do sends a request to the API and decodes the JSON response into out,
+// when not nil
+func (c *Client) do(method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.cfg.URL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.Email != "" {
+		req.SetBasicAuth(c.cfg.Email, c.cfg.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		e := &statusError{code: resp.StatusCode, status: resp.Status}
+		var msg struct {
+			ErrorMessages []string `json:"errorMessages"`
+		}
+		if json.Unmarshal(data, &msg) == nil {
+			e.message = strings.Join(msg.ErrorMessages, ", ")
+		}
+		return e
+	}
+	if out != nil && len(data) > 0 {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
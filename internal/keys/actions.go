@@ -0,0 +1,199 @@
+package keys
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// Action is a binding of the key map with the name the keys section of the
+// configuration gives it
+type Action struct {
+	Name    string
+	Binding *key.Binding
+	// Form is set for the bindings of the task form, which don't collide
+	// with the ones of the task views
+	Form bool
+	// Writes is set for the actions that change the tasks file, disabled
+	// when it's opened read-only
+	Writes bool
+}
+
+// Actions returns the bindings of the key map by name, in the order they
+// are matched
+func (k *KeyMap) Actions() []Action {
+	return []Action{
+		{Name: "up", Binding: &k.Up},
+		{Name: "down", Binding: &k.Down},
+		{Name: "left", Binding: &k.Left},
+		{Name: "right", Binding: &k.Right},
+		{Name: "add", Binding: &k.Add, Writes: true},
+		{Name: "quick_add", Binding: &k.QuickAdd, Writes: true},
+		{Name: "edit", Binding: &k.Edit, Writes: true},
+		{Name: "delete", Binding: &k.Delete, Writes: true},
+		{Name: "undo", Binding: &k.Undo, Writes: true},
+		{Name: "enter", Binding: &k.Enter},
+		{Name: "priority", Binding: &k.Priority, Writes: true},
+		{Name: "severity", Binding: &k.Severity, Writes: true},
+		{Name: "tag", Binding: &k.Tag, Writes: true},
+		{Name: "checklist", Binding: &k.Checklist, Writes: true},
+		{Name: "sprint", Binding: &k.Sprint, Writes: true},
+		{Name: "estimate", Binding: &k.Estimate, Writes: true},
+		{Name: "block", Binding: &k.Block, Writes: true},
+		{Name: "note", Binding: &k.Note, Writes: true},
+		{Name: "private", Binding: &k.Private, Writes: true},
+		{Name: "pin_today", Binding: &k.PinToday, Writes: true},
+		{Name: "pin", Binding: &k.Pin, Writes: true},
+		{Name: "timer", Binding: &k.Timer, Writes: true},
+		{Name: "move_left", Binding: &k.MoveLeft, Writes: true},
+		{Name: "move_right", Binding: &k.MoveRight, Writes: true},
+		{Name: "move_up", Binding: &k.MoveUp, Writes: true},
+		{Name: "move_down", Binding: &k.MoveDown, Writes: true},
+		{Name: "widen", Binding: &k.Widen},
+		{Name: "narrow", Binding: &k.Narrow},
+		{Name: "lanes", Binding: &k.Lanes},
+		{Name: "prev_lane", Binding: &k.PrevLane},
+		{Name: "next_lane", Binding: &k.NextLane},
+		{Name: "status_todo", Binding: &k.StatusTodo, Writes: true},
+		{Name: "status_in_progress", Binding: &k.StatusInProgress, Writes: true},
+		{Name: "status_blocked", Binding: &k.StatusBlocked, Writes: true},
+		{Name: "status_done", Binding: &k.StatusDone, Writes: true},
+		{Name: "toggle_view", Binding: &k.ToggleView},
+		{Name: "group_by", Binding: &k.GroupBy},
+		{Name: "sort_by", Binding: &k.SortBy},
+		{Name: "search", Binding: &k.Search},
+		{Name: "search_all", Binding: &k.SearchAll},
+		{Name: "go_to", Binding: &k.GoTo},
+		{Name: "open_editor", Binding: &k.OpenEditor, Writes: true},
+		{Name: "bulk_edit", Binding: &k.BulkEdit, Writes: true},
+		{Name: "view_yaml", Binding: &k.ViewYAML},
+		{Name: "view_file", Binding: &k.ViewFile},
+		{Name: "yank", Binding: &k.Yank},
+		{Name: "yank_line", Binding: &k.YankLine},
+		{Name: "paste", Binding: &k.Paste, Writes: true},
+		{Name: "export", Binding: &k.Export},
+		{Name: "share", Binding: &k.Share},
+		{Name: "save", Binding: &k.Save, Writes: true},
+		{Name: "help", Binding: &k.Help},
+		{Name: "refresh", Binding: &k.Refresh},
+		{Name: "sync", Binding: &k.Sync, Writes: true},
+		{Name: "alerts", Binding: &k.Alerts},
+		{Name: "velocity", Binding: &k.Velocity},
+		{Name: "time_stats", Binding: &k.TimeStats},
+		{Name: "doctor", Binding: &k.Doctor, Writes: true},
+		{Name: "plan_week", Binding: &k.PlanWeek, Writes: true},
+		{Name: "journal", Binding: &k.Journal, Writes: true},
+		{Name: "tags", Binding: &k.Tags},
+		{Name: "columns", Binding: &k.Columns},
+		{Name: "density", Binding: &k.Density},
+		{Name: "progress", Binding: &k.Progress},
+		{Name: "hide_done", Binding: &k.HideDone},
+		{Name: "submit", Binding: &k.Submit, Form: true},
+		{Name: "cancel", Binding: &k.Cancel, Form: true},
+		{Name: "next", Binding: &k.Next, Form: true},
+		{Name: "prev", Binding: &k.Prev, Form: true},
+		{Name: "quit", Binding: &k.Quit},
+		{Name: "leader", Binding: &k.Leader},
+	}
+}
+
+// Writing returns the action changing the tasks file a key is bound to;
+// false for the other keys
+func (k *KeyMap) Writing(msg fmt.Stringer) (Action, bool) {
+	for _, act := range k.Actions() {
+		if act.Writes && key.Matches(msg, *act.Binding) {
+			return act, true
+		}
+	}
+	return Action{}, false
+}
+
+// Override rebinds the actions named in overrides to their keys, the first
+// one shown in the help. It returns the names that aren't actions, sorted.
+func (k *KeyMap) Override(overrides map[string][]string) []string {
+	actions := make(map[string]*key.Binding)
+	for _, a := range k.Actions() {
+		actions[a.Name] = a.Binding
+	}
+
+	var unknown []string
+	for name, keys := range overrides {
+		b, ok := actions[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		b.SetKeys(keys...)
+		if len(keys) > 0 {
+			b.SetHelp(keys[0], b.Help().Desc)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Conflict is a key bound to several actions of the same state
+type Conflict struct {
+	Key     string
+	Actions []string
+}
+
+// Conflicts returns the keys bound to several actions of the task views, or
+// of the task form
+func (k *KeyMap) Conflicts() []Conflict {
+	var conflicts []Conflict
+	for _, form := range []bool{false, true} {
+		var order []string
+		bound := map[string][]string{}
+		for _, a := range k.Actions() {
+			if a.Form != form {
+				continue
+			}
+			for _, key := range a.Binding.Keys() {
+				if len(bound[key]) == 0 {
+					order = append(order, key)
+				}
+				if !slices.Contains(bound[key], a.Name) {
+					bound[key] = append(bound[key], a.Name)
+				}
+			}
+		}
+		for _, key := range order {
+			if len(bound[key]) > 1 {
+				conflicts = append(conflicts, Conflict{Key: key, Actions: bound[key]})
+			}
+		}
+	}
+	return conflicts
+}
+
+// Unbind removes a key from an action
+func (k *KeyMap) Unbind(name, unbound string) {
+	for _, a := range k.Actions() {
+		if a.Name != name {
+			continue
+		}
+		var keys []string
+		for _, key := range a.Binding.Keys() {
+			if key != unbound {
+				keys = append(keys, key)
+			}
+		}
+		a.Binding.SetKeys(keys...)
+		if len(keys) > 0 && a.Binding.Help().Key == unbound {
+			a.Binding.SetHelp(keys[0], a.Binding.Help().Desc)
+		}
+	}
+}
+
+// Reset restores the default keys of an action
+func (k *KeyMap) Reset(name string) {
+	defaults := DefaultKeyMap()
+	for i, a := range defaults.Actions() {
+		if a.Name == name {
+			*k.Actions()[i].Binding = *a.Binding
+		}
+	}
+}
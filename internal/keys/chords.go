@@ -0,0 +1,145 @@
+package keys
+
+import (
+	"slices"
+	"strings"
+)
+
+// Chord is a sequence of keys typed after the leader key, bound to an
+// action: one of the key map's (see Actions) or a chord action
+type Chord struct {
+	Keys   []string
+	Action string
+}
+
+// Chord actions, for the settings that single keys only cycle through
+const (
+	ChordSortManual    = "sort_manual"
+	ChordSortCreated   = "sort_created"
+	ChordSortUpdated   = "sort_updated"
+	ChordSortPriority  = "sort_priority"
+	ChordSortDue       = "sort_due"
+	ChordSortTitle     = "sort_title"
+	ChordSortUrgency   = "sort_urgency"
+	ChordGroupNone     = "group_none"
+	ChordGroupStatus   = "group_status"
+	ChordGroupPriority = "group_priority"
+	ChordGroupTag      = "group_tag"
+	ChordViewList      = "view_list"
+	ChordViewKanban    = "view_kanban"
+	ChordViewCalendar  = "view_calendar"
+	ChordViewToday     = "view_today"
+)
+
+// chordActions lists the chord actions
+var chordActions = []string{
+	ChordSortManual, ChordSortCreated, ChordSortUpdated, ChordSortPriority,
+	ChordSortDue, ChordSortTitle, ChordSortUrgency, ChordGroupNone,
+	ChordGroupStatus, ChordGroupPriority, ChordGroupTag, ChordViewList,
+	ChordViewKanban, ChordViewCalendar, ChordViewToday,
+}
+
+// DefaultChords returns the default chords: s to sort, g to group and v to
+// switch views
+func DefaultChords() []Chord {
+	return []Chord{
+		{Keys: []string{"s", "o"}, Action: ChordSortManual},
+		{Keys: []string{"s", "c"}, Action: ChordSortCreated},
+		{Keys: []string{"s", "m"}, Action: ChordSortUpdated},
+		{Keys: []string{"s", "p"}, Action: ChordSortPriority},
+		{Keys: []string{"s", "d"}, Action: ChordSortDue},
+		{Keys: []string{"s", "t"}, Action: ChordSortTitle},
+		{Keys: []string{"s", "u"}, Action: ChordSortUrgency},
+		{Keys: []string{"g", "n"}, Action: ChordGroupNone},
+		{Keys: []string{"g", "s"}, Action: ChordGroupStatus},
+		{Keys: []string{"g", "p"}, Action: ChordGroupPriority},
+		{Keys: []string{"g", "t"}, Action: ChordGroupTag},
+		{Keys: []string{"v", "l"}, Action: ChordViewList},
+		{Keys: []string{"v", "k"}, Action: ChordViewKanban},
+		{Keys: []string{"v", "c"}, Action: ChordViewCalendar},
+		{Keys: []string{"v", "t"}, Action: ChordViewToday},
+	}
+}
+
+// OverrideChords binds the sequences of keys separated by spaces ("s p")
+// to actions, replacing the chords of the same sequence; an empty action
+// or "none" removes the chord
+func (k *KeyMap) OverrideChords(overrides map[string]string) {
+	for seq, action := range overrides {
+		keys := strings.Fields(seq)
+		if len(keys) == 0 {
+			continue
+		}
+		k.Chords = slices.DeleteFunc(k.Chords, func(c Chord) bool {
+			return slices.Equal(c.Keys, keys)
+		})
+		if action != "" && action != "none" {
+			k.Chords = append(k.Chords, Chord{Keys: keys, Action: action})
+		}
+	}
+	slices.SortStableFunc(k.Chords, func(a, b Chord) int {
+		return strings.Compare(strings.Join(a.Keys, " "), strings.Join(b.Keys, " "))
+	})
+}
+
+// ChordAction returns the action of the chord typed after the leader, or
+// pending when longer chords start with it. A chord completes as soon as
+// it is typed, even if a longer one starts with it.
+func (k KeyMap) ChordAction(typed []string) (action string, pending bool) {
+	for _, c := range k.Chords {
+		if slices.Equal(c.Keys, typed) {
+			return c.Action, false
+		}
+		if len(c.Keys) > len(typed) && slices.Equal(c.Keys[:len(typed)], typed) {
+			pending = true
+		}
+	}
+	return "", pending
+}
+
+// Continuation is a key continuing the chord being typed: it completes the
+// chord of Action, or leads to Count longer chords
+type Continuation struct {
+	Key    string
+	Action string
+	Count  int
+}
+
+// Continuations returns the keys continuing the chord typed after the
+// leader, in the order of the chords
+func (k KeyMap) Continuations(typed []string) []Continuation {
+	var next []Continuation
+	for _, c := range k.Chords {
+		if len(c.Keys) <= len(typed) || !slices.Equal(c.Keys[:len(typed)], typed) {
+			continue
+		}
+		key := c.Keys[len(typed)]
+		i := slices.IndexFunc(next, func(n Continuation) bool { return n.Key == key })
+		if i < 0 {
+			next = append(next, Continuation{Key: key})
+			i = len(next) - 1
+		}
+		if len(c.Keys) == len(typed)+1 {
+			next[i].Action = c.Action
+		} else {
+			next[i].Count++
+		}
+	}
+	return next
+}
+
+// UnknownChordActions returns the actions of chords that are neither chord
+// actions nor actions of the key map
+func (k *KeyMap) UnknownChordActions() []string {
+	var unknown []string
+	for _, c := range k.Chords {
+		known := slices.Contains(chordActions, c.Action)
+		for _, a := range k.Actions() {
+			known = known || (a.Name == c.Action && !a.Form)
+		}
+		if !known && !slices.Contains(unknown, c.Action) {
+			unknown = append(unknown, c.Action)
+		}
+	}
+	return unknown
+}
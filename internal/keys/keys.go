@@ -1,6 +1,10 @@
 package keys
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"lazy-todo/internal/i18n"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap contains all keybindings for the application
 type KeyMap struct {
@@ -12,13 +16,32 @@ type KeyMap struct {
 
 	// Actions
 	Add       key.Binding
+	QuickAdd  key.Binding
 	Edit      key.Binding
 	Delete    key.Binding
+	Undo      key.Binding
 	Enter     key.Binding
 	Priority  key.Binding
+	Severity  key.Binding
 	Tag       key.Binding
+	Checklist key.Binding
+	Sprint    key.Binding
+	Estimate  key.Binding
+	Block     key.Binding
+	Note      key.Binding
+	Private   key.Binding
+	PinToday  key.Binding
+	Pin       key.Binding
+	Timer     key.Binding
 	MoveLeft  key.Binding
 	MoveRight key.Binding
+	MoveUp    key.Binding
+	MoveDown  key.Binding
+	Widen     key.Binding
+	Narrow    key.Binding
+	Lanes     key.Binding
+	PrevLane  key.Binding
+	NextLane  key.Binding
 
 	// Quick status change
 	StatusTodo       key.Binding
@@ -29,10 +52,34 @@ type KeyMap struct {
 	// Views
 	ToggleView key.Binding
 	GroupBy    key.Binding
+	SortBy     key.Binding
 	Search     key.Binding
+	SearchAll  key.Binding
+	GoTo       key.Binding
 	OpenEditor key.Binding
+	BulkEdit   key.Binding
+	ViewYAML   key.Binding
+	ViewFile   key.Binding
+	Yank       key.Binding
+	YankLine   key.Binding
+	Paste      key.Binding
+	Export     key.Binding
+	Share      key.Binding
+	Save       key.Binding
 	Help       key.Binding
 	Refresh    key.Binding
+	Sync       key.Binding
+	Alerts     key.Binding
+	Velocity   key.Binding
+	TimeStats  key.Binding
+	Doctor     key.Binding
+	PlanWeek   key.Binding
+	Journal    key.Binding
+	Tags       key.Binding
+	Columns    key.Binding
+	Density    key.Binding
+	Progress   key.Binding
+	HideDone   key.Binding
 
 	// Form
 	Submit key.Binding
@@ -42,6 +89,10 @@ type KeyMap struct {
 
 	// Global
 	Quit key.Binding
+
+	// Leader starts a chord, a sequence of keys bound to an action
+	Leader key.Binding
+	Chords []Chord
 }
 
 // DefaultKeyMap returns the default keybindings
@@ -50,121 +101,298 @@ func DefaultKeyMap() KeyMap {
 		// Navigation
 		Up: key.NewBinding(
 			key.WithKeys("k", "up"),
-			key.WithHelp("k/↑", "monter"),
+			key.WithHelp("k/↑", i18n.T("monter")),
 		),
 		Down: key.NewBinding(
 			key.WithKeys("j", "down"),
-			key.WithHelp("j/↓", "descendre"),
+			key.WithHelp("j/↓", i18n.T("descendre")),
 		),
 		Left: key.NewBinding(
 			key.WithKeys("h", "left"),
-			key.WithHelp("h/←", "gauche"),
+			key.WithHelp("h/←", i18n.T("gauche")),
 		),
 		Right: key.NewBinding(
 			key.WithKeys("l", "right"),
-			key.WithHelp("l/→", "droite"),
+			key.WithHelp("l/→", i18n.T("droite")),
 		),
 
 		// Actions
 		Add: key.NewBinding(
 			key.WithKeys("a"),
-			key.WithHelp("a", "ajouter"),
+			key.WithHelp("a", i18n.T("ajouter")),
+		),
+		QuickAdd: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", i18n.T("ajout rapide")),
 		),
 		Edit: key.NewBinding(
 			key.WithKeys("e"),
-			key.WithHelp("e", "éditer"),
+			key.WithHelp("e", i18n.T("éditer")),
 		),
 		Delete: key.NewBinding(
 			key.WithKeys("d", "delete"),
-			key.WithHelp("d", "supprimer"),
+			key.WithHelp("d", i18n.T("supprimer")),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", i18n.T("annuler la suppression")),
 		),
 		Enter: key.NewBinding(
 			key.WithKeys("enter"),
-			key.WithHelp("enter", "sélectionner"),
+			key.WithHelp("enter", i18n.T("sélectionner")),
 		),
 		Priority: key.NewBinding(
 			key.WithKeys("p"),
-			key.WithHelp("p", "priorité"),
+			key.WithHelp("p", i18n.T("priorité")),
+		),
+		Severity: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", i18n.T("sévérité")),
 		),
 		Tag: key.NewBinding(
 			key.WithKeys("t"),
-			key.WithHelp("t", "tag"),
+			key.WithHelp("t", i18n.T("tag")),
+		),
+		Checklist: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", i18n.T("checklist")),
+		),
+		Sprint: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", i18n.T("sprint")),
+		),
+		Estimate: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", i18n.T("estimation")),
+		),
+		Block: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", i18n.T("raison du blocage")),
+		),
+		Note: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", i18n.T("note")),
+		),
+		Private: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", i18n.T("privée")),
+		),
+		PinToday: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", i18n.T("ajouter à aujourd'hui")),
+		),
+		Pin: key.NewBinding(
+			key.WithKeys("*"),
+			key.WithHelp("*", i18n.T("épingler en haut")),
+		),
+		Timer: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", i18n.T("démarrer/arrêter le chrono")),
 		),
 		MoveLeft: key.NewBinding(
 			key.WithKeys("H", "shift+left"),
-			key.WithHelp("H", "déplacer ←"),
+			key.WithHelp("H", i18n.T("déplacer ←")),
 		),
 		MoveRight: key.NewBinding(
 			key.WithKeys("L", "shift+right"),
-			key.WithHelp("L", "déplacer →"),
+			key.WithHelp("L", i18n.T("déplacer →")),
+		),
+		MoveUp: key.NewBinding(
+			key.WithKeys("K", "shift+up"),
+			key.WithHelp("K", i18n.T("déplacer ↑")),
+		),
+		MoveDown: key.NewBinding(
+			key.WithKeys("J", "shift+down"),
+			key.WithHelp("J", i18n.T("déplacer ↓")),
+		),
+		Widen: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", i18n.T("élargir colonne")),
+		),
+		Narrow: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", i18n.T("rétrécir colonne")),
+		),
+		Lanes: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", i18n.T("couloirs")),
+		),
+		PrevLane: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", i18n.T("couloir précédent")),
+		),
+		NextLane: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", i18n.T("couloir suivant")),
 		),
 
 		// Quick status
 		StatusTodo: key.NewBinding(
 			key.WithKeys("1"),
-			key.WithHelp("1", "à faire"),
+			key.WithHelp("1", i18n.T("à faire")),
 		),
 		StatusInProgress: key.NewBinding(
 			key.WithKeys("2"),
-			key.WithHelp("2", "en cours"),
+			key.WithHelp("2", i18n.T("en cours")),
 		),
 		StatusBlocked: key.NewBinding(
 			key.WithKeys("3"),
-			key.WithHelp("3", "bloqué"),
+			key.WithHelp("3", i18n.T("bloqué")),
 		),
 		StatusDone: key.NewBinding(
 			key.WithKeys("4"),
-			key.WithHelp("4", "terminé"),
+			key.WithHelp("4", i18n.T("terminé")),
 		),
 
 		// Views
 		ToggleView: key.NewBinding(
 			key.WithKeys("tab"),
-			key.WithHelp("tab", "changer vue"),
+			key.WithHelp("tab", i18n.T("changer vue")),
 		),
 		GroupBy: key.NewBinding(
 			key.WithKeys("g"),
-			key.WithHelp("g", "grouper"),
+			key.WithHelp("g", i18n.T("grouper")),
+		),
+		SortBy: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", i18n.T("trier")),
 		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
-			key.WithHelp("/", "rechercher"),
+			key.WithHelp("/", i18n.T("rechercher")),
+		),
+		SearchAll: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", i18n.T("rechercher partout")),
+		),
+		GoTo: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", i18n.T("aller à la tâche")),
 		),
 		OpenEditor: key.NewBinding(
 			key.WithKeys("o"),
-			key.WithHelp("o", "ouvrir fichier"),
+			key.WithHelp("o", i18n.T("ouvrir fichier")),
+		),
+		BulkEdit: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", i18n.T("modifier la sélection")),
+		),
+		ViewYAML: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", i18n.T("voir YAML")),
+		),
+		ViewFile: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", i18n.T("voir fichier")),
+		),
+		Yank: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", i18n.T("copier")),
+		),
+		YankLine: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", i18n.T("copier en une ligne")),
+		),
+		Paste: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", i18n.T("coller")),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", i18n.T("exporter")),
+		),
+		Share: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", i18n.T("partager")),
+		),
+		Save: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", i18n.T("enregistrer")),
 		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
-			key.WithHelp("?", "aide"),
+			key.WithHelp("?", i18n.T("aide")),
 		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
-			key.WithHelp("r", "rafraîchir"),
+			key.WithHelp("r", i18n.T("rafraîchir")),
+		),
+		Sync: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("G", i18n.T("synchroniser (git)")),
+		),
+		Alerts: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", i18n.T("rappels")),
+		),
+		Velocity: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", i18n.T("vélocité")),
+		),
+		TimeStats: key.NewBinding(
+			key.WithKeys("$"),
+			key.WithHelp("$", i18n.T("temps suivi")),
+		),
+		Doctor: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", i18n.T("maintenance")),
+		),
+		PlanWeek: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", i18n.T("planifier la semaine")),
+		),
+		Journal: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", i18n.T("journal du jour")),
+		),
+		Tags: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", i18n.T("tags")),
+		),
+		Columns: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", i18n.T("colonnes de la liste")),
+		),
+		Density: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", i18n.T("liste compacte/détaillée")),
+		),
+		Progress: key.NewBinding(
+			key.WithKeys("%"),
+			key.WithHelp("%", i18n.T("progression par priorité")),
+		),
+		HideDone: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", i18n.T("masquer les terminées")),
 		),
 
 		// Form
 		Submit: key.NewBinding(
 			key.WithKeys("enter"),
-			key.WithHelp("enter", "valider"),
+			key.WithHelp("enter", i18n.T("valider")),
 		),
 		Cancel: key.NewBinding(
 			key.WithKeys("esc"),
-			key.WithHelp("esc", "annuler"),
+			key.WithHelp("esc", i18n.T("annuler")),
 		),
 		Next: key.NewBinding(
 			key.WithKeys("tab"),
-			key.WithHelp("tab", "suivant"),
+			key.WithHelp("tab", i18n.T("suivant")),
 		),
 		Prev: key.NewBinding(
 			key.WithKeys("shift+tab"),
-			key.WithHelp("shift+tab", "précédent"),
+			key.WithHelp("shift+tab", i18n.T("précédent")),
 		),
 
 		// Global
+		Leader: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp(i18n.T("espace"), i18n.T("accord")),
+		),
+		Chords: DefaultChords(),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
-			key.WithHelp("q", "quitter"),
+			key.WithHelp("q", i18n.T("quitter")),
 		),
 	}
 }
@@ -180,9 +408,11 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
-		{k.Add, k.Edit, k.Delete, k.Priority},
+		{k.Add, k.QuickAdd, k.Edit, k.Delete, k.Undo, k.Priority, k.Severity, k.Checklist, k.Sprint, k.Estimate, k.Block, k.Note, k.Private, k.PinToday, k.Pin, k.Timer},
 		{k.StatusTodo, k.StatusInProgress, k.StatusBlocked, k.StatusDone},
-		{k.ToggleView, k.GroupBy, k.Search, k.OpenEditor},
-		{k.MoveLeft, k.MoveRight, k.Refresh, k.Help, k.Quit},
+		{k.ToggleView, k.GroupBy, k.SortBy, k.Leader, k.Search, k.SearchAll, k.GoTo, k.OpenEditor, k.BulkEdit},
+		{k.ViewYAML, k.ViewFile, k.Yank, k.YankLine, k.Paste, k.Export, k.Share},
+		{k.MoveLeft, k.MoveRight, k.MoveUp, k.MoveDown, k.Widen, k.Narrow, k.Lanes, k.PrevLane, k.NextLane},
+		{k.Save, k.Refresh, k.Sync, k.Alerts, k.Velocity, k.TimeStats, k.Doctor, k.PlanWeek, k.Journal, k.Tags, k.Columns, k.Density, k.Progress, k.HideDone, k.Help, k.Quit},
 	}
 }
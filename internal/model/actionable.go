@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// OpenIDs returns the IDs of the tasks not done
+func OpenIDs(tasks []Task) map[string]bool {
+	open := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		if !t.Status.IsDone() {
+			open[t.ID] = true
+		}
+	}
+	return open
+}
+
+// WaitingOn returns the IDs of the tasks the task depends on that are
+// still open. Tasks that no longer exist don't hold it back.
+func (t Task) WaitingOn(open map[string]bool) []string {
+	var ids []string
+	for _, id := range t.DependsOn {
+		if open[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// IsSnoozed returns true if a reminder of the task was snoozed past now,
+// putting the task off until then
+func (t Task) IsSnoozed(now time.Time) bool {
+	for _, r := range t.Reminders {
+		if r.Snoozed != nil && r.Snoozed.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// SnoozeUntil puts the task off until a time, with a reminder snoozed to
+// it
+func (t *Task) SnoozeUntil(until time.Time) {
+	t.Reminders = append(t.Reminders, Reminder{At: &until, Snoozed: &until})
+}
+
+// IsActionable returns true if the task can be started now: not done, not
+// blocked or waiting (a status of the blocked kind), not snoozed, and no
+// open task it depends on
+func (t Task) IsActionable(now time.Time, open map[string]bool) bool {
+	if t.Status.IsDone() || t.Status.Kind() == StatusBlocked {
+		return false
+	}
+	return !t.IsSnoozed(now) && len(t.WaitingOn(open)) == 0
+}
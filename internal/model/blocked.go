@@ -0,0 +1,112 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+)
+
+// Reasons a task is blocked for, to account for the time lost waiting
+const (
+	BlockWaitingReview = "waiting-review"
+	BlockWaitingDeploy = "waiting-deploy"
+	BlockExternal      = "external"
+)
+
+// BlockReasons returns the reasons a task can be blocked for, in order
+func BlockReasons() []string {
+	return []string{BlockWaitingReview, BlockWaitingDeploy, BlockExternal}
+}
+
+// NextBlockReason cycles to the next reason, none after the last one
+func NextBlockReason(reason string) string {
+	reasons := BlockReasons()
+	i := slices.Index(reasons, reason)
+	if i+1 < len(reasons) {
+		return reasons[i+1]
+	}
+	return ""
+}
+
+// BlockReasonLabel returns the label of a reason a task is blocked for;
+// the reasons that aren't known ones are shown as they are
+func BlockReasonLabel(reason string) string {
+	switch reason {
+	case "":
+		return i18n.T("Sans raison")
+	case BlockWaitingReview:
+		return i18n.T("En attente de revue")
+	case BlockWaitingDeploy:
+		return i18n.T("En attente de déploiement")
+	case BlockExternal:
+		return i18n.T("Dépendance externe")
+	}
+	return reason
+}
+
+// BlockedTime returns how long the task was blocked since a time, per
+// reason, from the status and reason changes of its history; a task
+// created blocked counts from its creation
+func (t Task) BlockedTime(since, now time.Time) map[string]time.Duration {
+	// Status and reason the task was created with
+	status, reason := t.Status, t.BlockedReason
+	statusFound, reasonFound := false, false
+	for _, c := range t.History {
+		switch {
+		case c.Field == FieldChangeStatus && !statusFound:
+			status, statusFound = Status(c.From), true
+		case c.Field == FieldChangeBlockedReason && !reasonFound:
+			reason, reasonFound = c.From, true
+		}
+	}
+
+	totals := make(map[string]time.Duration)
+	from := t.CreatedAt
+	add := func(to time.Time) {
+		start := from
+		if start.Before(since) {
+			start = since
+		}
+		if status.Kind() == StatusBlocked && !from.IsZero() && to.After(start) {
+			totals[reason] += to.Sub(start)
+		}
+		from = to
+	}
+	for _, c := range t.History {
+		switch c.Field {
+		case FieldChangeStatus:
+			add(c.At)
+			status = Status(c.To)
+		case FieldChangeBlockedReason:
+			add(c.At)
+			reason = c.To
+		}
+	}
+	add(now)
+	return totals
+}
+
+// BlockedByReason sums the time the tasks were blocked since a time per
+// reason, longest first
+func BlockedByReason(tasks []Task, since, now time.Time) []WorkTotal {
+	index := make(map[string]int)
+	var totals []WorkTotal
+	for _, t := range tasks {
+		for reason, d := range t.BlockedTime(since, now) {
+			i, ok := index[reason]
+			if !ok {
+				i = len(totals)
+				index[reason] = i
+				totals = append(totals, WorkTotal{Key: reason})
+			}
+			totals[i].Duration += d
+		}
+	}
+	slices.SortStableFunc(totals, func(a, b WorkTotal) int {
+		return cmp.Or(cmp.Compare(b.Duration, a.Duration), strings.Compare(a.Key, b.Key))
+	})
+	return totals
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// startOfDay returns midnight of the day of t, in t's location
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// IsOverdue returns true if the task is not done and its due date is
+// before the day of now
+func (t Task) IsOverdue(now time.Time) bool {
+	if t.DueDate == nil || t.Status.IsDone() {
+		return false
+	}
+	return startOfDay(t.DueDate.In(now.Location())).Before(startOfDay(now))
+}
+
+// IsDueOn returns true if the task is due on the day of day
+func (t Task) IsDueOn(day time.Time) bool {
+	if t.DueDate == nil {
+		return false
+	}
+	return startOfDay(t.DueDate.In(day.Location())).Equal(startOfDay(day))
+}
+
+// IsUrgent returns true if the task is open and overdue, due today, or at
+// the highest priority level
+func (t Task) IsUrgent(now time.Time) bool {
+	if t.Status.IsDone() {
+		return false
+	}
+	levels := AllPriorities()
+	return t.IsOverdue(now) || t.IsDueOn(now) || MigratePriority(t.Priority) == levels[len(levels)-1]
+}
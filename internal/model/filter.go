@@ -0,0 +1,9 @@
+package model
+
+// Matches checks if the task matches a search query (see ParseQuery):
+// free text is looked up in the title, description and tags
+// (case-insensitive), and #tag matches the tag and its descendants (#work
+// matches work/clientA). An empty query matches every task.
+func (t Task) Matches(query string) bool {
+	return ParseQuery(query).Matches(t)
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"lazy-todo/internal/i18n"
+)
+
+// Fields whose changes are kept in a task's history
+const (
+	FieldChangeStatus        = "status"
+	FieldChangePriority      = "priority"
+	FieldChangeBlockedReason = "blocked_reason"
+)
+
+// Change is an entry of a task's history: a field that went from one value
+// to another
+type Change struct {
+	At    time.Time `yaml:"at" json:"at"`
+	Field string    `yaml:"field" json:"field"`
+	From  string    `yaml:"from" json:"from"`
+	To    string    `yaml:"to" json:"to"`
+}
+
+// RecordChanges appends to the task's history its status, priority and
+// blocked reason changes since before. Like notes, the history is never
+// edited. The reason is dropped once the task isn't blocked anymore.
+func (t *Task) RecordChanges(before Task, now time.Time) {
+	if t.Status.Kind() != StatusBlocked {
+		t.BlockedReason = ""
+	}
+	if t.Status != before.Status {
+		t.History = append(t.History, Change{
+			At:    now,
+			Field: FieldChangeStatus,
+			From:  string(before.Status),
+			To:    string(t.Status),
+		})
+	}
+	if t.Priority != before.Priority {
+		t.History = append(t.History, Change{
+			At:    now,
+			Field: FieldChangePriority,
+			From:  string(before.Priority),
+			To:    string(t.Priority),
+		})
+	}
+	if t.BlockedReason != before.BlockedReason {
+		t.History = append(t.History, Change{
+			At:    now,
+			Field: FieldChangeBlockedReason,
+			From:  before.BlockedReason,
+			To:    t.BlockedReason,
+		})
+	}
+}
+
+// Describe returns the change in words, like "déplacée vers En cours"
+func (c Change) Describe() string {
+	switch c.Field {
+	case FieldChangeStatus:
+		return i18n.Tf("déplacée vers %s", Status(c.To).Label())
+	case FieldChangePriority:
+		return i18n.Tf("priorité %s → %s", Priority(c.From).Label(), Priority(c.To).Label())
+	case FieldChangeBlockedReason:
+		if c.To == "" {
+			return i18n.T("raison du blocage retirée")
+		}
+		return i18n.Tf("bloquée: %s", BlockReasonLabel(c.To))
+	}
+	return c.Field + ": " + c.From + " → " + c.To
+}
+
+// Since returns the changes of the history made at or after a time
+func (t Task) Since(since time.Time) []Change {
+	var changes []Change
+	for _, c := range t.History {
+		if !c.At.Before(since) {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// TimeInProgress returns how long the task was in progress, from the status
+// changes of its history; a task created in progress counts from its
+// creation
+func (t Task) TimeInProgress(now time.Time) time.Duration {
+	// Status the task was created with
+	status := t.Status
+	for _, c := range t.History {
+		if c.Field == FieldChangeStatus {
+			status = Status(c.From)
+			break
+		}
+	}
+
+	var total time.Duration
+	since := t.CreatedAt
+	started := status.Kind() == StatusInProgress && !since.IsZero()
+	for _, c := range t.History {
+		if c.Field != FieldChangeStatus {
+			continue
+		}
+		inProgress := Status(c.To).Kind() == StatusInProgress
+		switch {
+		case started && !inProgress:
+			total += c.At.Sub(since)
+			started = false
+		case !started && inProgress:
+			since, started = c.At, true
+		}
+	}
+	if started {
+		total += now.Sub(since)
+	}
+	return total
+}
+
+// DoneSince returns true if the task was last moved to a done status at or
+// after a time, according to its history
+func (t Task) DoneSince(since time.Time) bool {
+	for _, c := range slices.Backward(t.History) {
+		if c.Field == FieldChangeStatus {
+			return Status(c.To).IsDone() && !c.At.Before(since)
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+)
+
+// FindingKind is a kind of finding of the maintenance report
+type FindingKind int
+
+const (
+	FindingUnusedTag     FindingKind = iota // a tag color no task uses
+	FindingUntagged                         // an open task without tags
+	FindingNoDueDate                        // an open task of a high priority without due date
+	FindingChecklistDone                    // an open task with all its checklist checked
+	FindingDoneTimer                        // a done task still timed
+	FindingDonePinned                       // a done task still pinned, or to the today view
+)
+
+// Finding is something of the tasks file worth tidying up, found by
+// Hygiene
+type Finding struct {
+	Kind FindingKind
+	// Task it is about, empty for a tag
+	TaskID string
+	// Title of the task, or the tag
+	Title string
+}
+
+// String describes the finding
+func (f Finding) String() string {
+	switch f.Kind {
+	case FindingUnusedTag:
+		return i18n.T("couleur d'un tag qu'aucune tâche n'a")
+	case FindingUntagged:
+		return i18n.T("sans tag")
+	case FindingNoDueDate:
+		return i18n.T("sans échéance pour sa priorité")
+	case FindingChecklistDone:
+		return i18n.T("checklist terminée, tâche ouverte")
+	case FindingDoneTimer:
+		return i18n.T("terminée, chrono en cours")
+	case FindingDonePinned:
+		return i18n.T("terminée, encore épinglée")
+	}
+	return ""
+}
+
+// Automatic returns true if the finding is fixed without asking anything,
+// see Task.Fix
+func (f Finding) Automatic() bool {
+	switch f.Kind {
+	case FindingUnusedTag, FindingDoneTimer, FindingDonePinned:
+		return true
+	}
+	return false
+}
+
+// DefaultDuePriority returns the priority from which open tasks without
+// due date are findings, when none is configured: the second highest
+func DefaultDuePriority() Priority {
+	levels := AllPriorities()
+	return levels[max(len(levels)-2, 0)]
+}
+
+// Hygiene returns what is worth tidying up in the tasks: the colors of
+// tags no task has, then for each task in order, the open ones without
+// tags, at or above a priority without due date, or with all their
+// checklist checked, and the done ones still timed or pinned
+func Hygiene(tasks []Task, tagColors map[string]string, duePriority Priority, now time.Time) []Finding {
+	var findings []Finding
+
+	var unused []string
+	for tag := range tagColors {
+		// Colors may have been keyed by hand in another case
+		key := strings.ToLower(tag)
+		used := slices.ContainsFunc(tasks, func(t Task) bool {
+			return slices.ContainsFunc(t.Tags, func(name string) bool {
+				name = strings.ToLower(name)
+				return name == key || strings.HasPrefix(name, key+"/")
+			})
+		})
+		if !used {
+			unused = append(unused, tag)
+		}
+	}
+	slices.Sort(unused)
+	for _, tag := range unused {
+		findings = append(findings, Finding{Kind: FindingUnusedTag, Title: tag})
+	}
+
+	for _, t := range tasks {
+		add := func(kind FindingKind) {
+			findings = append(findings, Finding{Kind: kind, TaskID: t.ID, Title: t.Title})
+		}
+		if t.Status.IsDone() {
+			if t.RunningSession() != nil {
+				add(FindingDoneTimer)
+			}
+			if t.Pinned || t.Today != nil {
+				add(FindingDonePinned)
+			}
+			continue
+		}
+		if len(t.Tags) == 0 {
+			add(FindingUntagged)
+		}
+		if t.DueDate == nil && t.Priority.Weight() >= duePriority.Weight() {
+			add(FindingNoDueDate)
+		}
+		if len(t.Subtasks) > 0 && !slices.ContainsFunc(t.Subtasks, func(s Subtask) bool { return !s.Done }) {
+			add(FindingChecklistDone)
+		}
+	}
+	return findings
+}
+
+// Fix fixes a finding about the task that needs no input: stops its timer,
+// or unpins it. It returns false for the other findings.
+func (t *Task) Fix(f Finding, now time.Time) bool {
+	switch f.Kind {
+	case FindingDoneTimer:
+		return t.StopSession(now) != nil
+	case FindingDonePinned:
+		t.Pinned = false
+		t.Today = nil
+		return true
+	}
+	return false
+}
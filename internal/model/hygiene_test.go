@@ -0,0 +1,22 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHygieneUnusedTags(t *testing.T) {
+	task := NewTask("Tâche")
+	task.Tags = []string{"work", "Home/Garden"}
+	colors := map[string]string{"Work": "red", "home": "green", "Old": "blue"}
+
+	var unused []string
+	for _, f := range Hygiene([]Task{task}, colors, DefaultDuePriority(), time.Now()) {
+		if f.Kind == FindingUnusedTag {
+			unused = append(unused, f.Title)
+		}
+	}
+	if len(unused) != 1 || unused[0] != "Old" {
+		t.Errorf("unused tag colors %v, want [Old]", unused)
+	}
+}
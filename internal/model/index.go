@@ -0,0 +1,144 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Index looks up the tasks of a slice without going through all of them,
+// for views that ask on every frame. It describes the slice as it was
+// built and must be built again when the tasks change.
+type Index struct {
+	tasks   []Task
+	ids     map[string]int
+	numbers map[int]int
+	open    map[string]bool
+	kinds   map[Status]int   // open tasks of each built-in status kind
+	columns []int            // tasks of each kanban column
+	tags    map[string][]int // positions of the tasks of each tag
+	due     []int            // positions of the open tasks with a due date, soonest first
+	pinned  []int            // positions of the open tasks pinned to a day
+}
+
+// NewIndex indexes tasks
+func NewIndex(tasks []Task) *Index {
+	x := &Index{
+		tasks:   tasks,
+		ids:     make(map[string]int, len(tasks)),
+		numbers: make(map[int]int, len(tasks)),
+		open:    make(map[string]bool, len(tasks)),
+		kinds:   map[Status]int{},
+		columns: make([]int, len(AllStatuses())),
+		tags:    map[string][]int{},
+	}
+	for i, t := range tasks {
+		if _, ok := x.ids[t.ID]; !ok {
+			x.ids[t.ID] = i
+		}
+		if _, ok := x.numbers[t.Number]; !ok && t.Number > 0 {
+			x.numbers[t.Number] = i
+		}
+		x.columns[t.Status.Column()]++
+		for _, tag := range t.Tags {
+			x.tags[tag] = append(x.tags[tag], i)
+		}
+		if t.Status.IsDone() {
+			continue
+		}
+		x.open[t.ID] = true
+		x.kinds[t.Status.Kind()]++
+		if t.DueDate != nil {
+			x.due = append(x.due, i)
+		}
+		if t.Today != nil {
+			x.pinned = append(x.pinned, i)
+		}
+	}
+	sort.SliceStable(x.due, func(i, j int) bool {
+		return tasks[x.due[i]].DueDate.Before(*tasks[x.due[j]].DueDate)
+	})
+	return x
+}
+
+// Tasks returns the tasks indexed
+func (x *Index) Tasks() []Task {
+	return x.tasks
+}
+
+// Find is FindTask without going through the tasks for a short ID or a
+// whole ID
+func (x *Index) Find(ref string) int {
+	if n, ok := ParseShortID(ref); ok {
+		if i, ok := x.numbers[n]; ok {
+			return i
+		}
+	}
+	if i, ok := x.ids[ref]; ok {
+		return i
+	}
+	return FindTask(x.tasks, ref)
+}
+
+// Open returns the IDs of the open tasks, like OpenIDs; not to be modified
+func (x *Index) Open() map[string]bool {
+	return x.open
+}
+
+// OpenOfKind returns the number of open tasks whose status behaves as a
+// built-in status
+func (x *Index) OpenOfKind(kind Status) int {
+	return x.kinds[kind]
+}
+
+// ColumnLoad returns the number of tasks in a kanban column
+func (x *Index) ColumnLoad(col int) int {
+	if col < 0 || col >= len(x.columns) {
+		return 0
+	}
+	return x.columns[col]
+}
+
+// Tagged returns the positions of the tasks with a tag, exactly; not to be
+// modified
+func (x *Index) Tagged(tag string) []int {
+	return x.tags[tag]
+}
+
+// DueLoad counts the open tasks due on each of the given number of days,
+// starting with the day of now, and the open tasks already overdue; only
+// the tasks due before the last day are gone through
+func (x *Index) DueLoad(now time.Time, days int) (load []int, overdue int) {
+	load = make([]int, days)
+	today := startOfDay(now)
+	// Tasks due before the start of today are overdue
+	first := sort.Search(len(x.due), func(i int) bool {
+		return !x.tasks[x.due[i]].DueDate.Before(today)
+	})
+	end := today.AddDate(0, 0, days)
+	for _, i := range x.due[first:] {
+		due := x.tasks[i].DueDate
+		if !due.Before(end) {
+			break
+		}
+		// Round to absorb DST shifts
+		day := int(startOfDay(due.In(now.Location())).Sub(today).Hours()/24 + 0.5)
+		if day < days {
+			load[day]++
+		}
+	}
+	return load, first
+}
+
+// OpenToday returns the number of open tasks of the today view of the day
+// of now, like counting those InToday
+func (x *Index) OpenToday(now time.Time) int {
+	load, overdue := x.DueLoad(now, 1)
+	count := load[0] + overdue
+	for _, i := range x.pinned {
+		t := x.tasks[i]
+		if t.IsPinnedToday(now) && !t.IsDueOn(now) && !t.IsOverdue(now) {
+			count++
+		}
+	}
+	return count
+}
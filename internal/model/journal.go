@@ -0,0 +1,66 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// JournalEntry is a dated note kept next to the tasks, about a day rather
+// than a task
+type JournalEntry struct {
+	Day       string    `yaml:"day" json:"day"` // 2026-10-17
+	Text      string    `yaml:"text" json:"text"`
+	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
+}
+
+// JournalDay returns the day of the journal a time falls on
+func JournalDay(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+// NewJournalEntry creates an entry of the journal for the day of now
+func NewJournalEntry(text string, now time.Time) JournalEntry {
+	return JournalEntry{Day: JournalDay(now), Text: text, CreatedAt: now}
+}
+
+// DayJournal returns the entries of a day of the journal, oldest first
+func DayJournal(journal []JournalEntry, day string) []JournalEntry {
+	var entries []JournalEntry
+	for _, e := range journal {
+		if e.Day == day {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// DayJournalText returns the text of the entries of a day, a paragraph each
+func DayJournalText(journal []JournalEntry, day string) string {
+	var texts []string
+	for _, e := range DayJournal(journal, day) {
+		texts = append(texts, e.Text)
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// SetDayJournal replaces the entries of a day by a single one with text,
+// created when the first of them was, or removes them when text is blank.
+// The journal stays sorted by day.
+func SetDayJournal(journal []JournalEntry, day, text string, now time.Time) []JournalEntry {
+	entry := JournalEntry{Day: day, Text: strings.TrimSpace(text), CreatedAt: now}
+	if previous := DayJournal(journal, day); len(previous) > 0 {
+		entry.CreatedAt = previous[0].CreatedAt
+	}
+	journal = slices.DeleteFunc(slices.Clone(journal), func(e JournalEntry) bool {
+		return e.Day == day
+	})
+	if entry.Text == "" {
+		return journal
+	}
+	journal = append(journal, entry)
+	slices.SortStableFunc(journal, func(a, b JournalEntry) int {
+		return strings.Compare(a.Day, b.Day)
+	})
+	return journal
+}
@@ -0,0 +1,19 @@
+package model
+
+// Display label overrides, keyed by stored value
+var (
+	statusLabels   = map[Status]string{}
+	priorityLabels = map[Priority]string{}
+)
+
+// SetStatusLabel overrides the displayed label of a status without
+// changing its stored value
+func SetStatusLabel(s Status, label string) {
+	statusLabels[s] = label
+}
+
+// SetPriorityLabel overrides the displayed label of a priority without
+// changing its stored value
+func SetPriorityLabel(p Priority, label string) {
+	priorityLabels[p] = label
+}
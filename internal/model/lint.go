@@ -0,0 +1,167 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"time"
+
+	"lazy-todo/internal/i18n"
+
+	"github.com/google/uuid"
+)
+
+// ProblemKind is a kind of problem of a task read from the tasks file
+type ProblemKind int
+
+const (
+	ProblemNoID ProblemKind = iota
+	ProblemDuplicateID
+	ProblemNoTitle
+	ProblemStatus
+	ProblemPriority
+	ProblemBadTime
+	ProblemNoCreatedAt
+	ProblemNoUpdatedAt
+	ProblemUpdatedBeforeCreated
+)
+
+// BadTime is a timestamp of a task of the tasks file that couldn't be
+// read, left out of the task when decoding
+type BadTime struct {
+	// Index of the task in the file, from 0
+	Index int
+	// Field of the task, like created_at
+	Field string
+	Value string
+}
+
+// Problem is a problem of a task read from the tasks file, and how
+// RepairTasks repaired it
+type Problem struct {
+	Kind ProblemKind
+	// Index of the task in the file, from 0
+	Index int
+	// Title of the task once repaired
+	Title string
+	// Value at fault, like an unknown status
+	Value string
+	// Field of the value at fault, for timestamps
+	Field string
+}
+
+// String describes the problem and its repair
+func (p Problem) String() string {
+	switch p.Kind {
+	case ProblemNoID:
+		return i18n.T("sans ID, un ID lui est donné")
+	case ProblemDuplicateID:
+		return i18n.Tf("ID %s déjà pris, un nouveau lui est donné", p.Value)
+	case ProblemNoTitle:
+		return i18n.T("sans titre")
+	case ProblemStatus:
+		return i18n.Tf("état inconnu « %s », remis à faire", p.Value)
+	case ProblemPriority:
+		return i18n.Tf("priorité inconnue « %s », gardée telle quelle", p.Value)
+	case ProblemBadTime:
+		return i18n.Tf("date %s illisible « %s », ignorée", p.Field, p.Value)
+	case ProblemNoCreatedAt:
+		return i18n.T("sans date de création, celle de modification ou du fichier lui est donnée")
+	case ProblemNoUpdatedAt:
+		return i18n.T("sans date de modification, celle de création lui est donnée")
+	case ProblemUpdatedBeforeCreated:
+		return i18n.T("modifiée avant d'être créée, date de modification corrigée")
+	}
+	return ""
+}
+
+// Repaired returns false for the problems only reported: an unknown
+// priority is kept, the levels being configurable
+func (p Problem) Repaired() bool {
+	return p.Kind != ProblemPriority
+}
+
+// RepairTasks repairs the tasks read from a file edited by hand or by
+// another tool, which the views can't handle as is, and returns what it
+// did. badTimes are the timestamps left out when decoding, repaired like
+// missing ones. Repairs only depend on the tasks and modified, the time
+// the file was written, so that reading the same file twice gives the
+// same IDs.
+func RepairTasks(tasks []Task, badTimes []BadTime, modified time.Time) []Problem {
+	var problems []Problem
+	report := func(i int, kind ProblemKind, value string) {
+		problems = append(problems, Problem{Kind: kind, Index: i, Value: value})
+	}
+	bad := make(map[int]map[string]bool)
+	for _, b := range badTimes {
+		if b.Index < 0 || b.Index >= len(tasks) {
+			continue
+		}
+		if bad[b.Index] == nil {
+			bad[b.Index] = make(map[string]bool)
+		}
+		bad[b.Index][b.Field] = true
+		problems = append(problems, Problem{Kind: ProblemBadTime, Index: b.Index, Value: b.Value, Field: b.Field})
+	}
+
+	seen := make(map[string]bool, len(tasks))
+	for i := range tasks {
+		t := &tasks[i]
+		switch {
+		case t.ID == "":
+			t.ID = repairedID(i, "")
+			report(i, ProblemNoID, "")
+		case seen[t.ID]:
+			report(i, ProblemDuplicateID, t.ID)
+			t.ID = repairedID(i, t.ID)
+		}
+		seen[t.ID] = true
+
+		if t.Title == "" {
+			t.Title = i18n.T("Sans titre")
+			report(i, ProblemNoTitle, "")
+		}
+		if t.Status.Index() < 0 {
+			report(i, ProblemStatus, string(t.Status))
+			t.Status = StatusOfKind(StatusTodo)
+		}
+		// Priority levels are configurable: a value unknown with this
+		// config may be known with another, it's only reported
+		if t.Priority.Index() < 0 {
+			report(i, ProblemPriority, string(t.Priority))
+		}
+		if t.CreatedAt.IsZero() {
+			t.CreatedAt = t.UpdatedAt
+			if t.CreatedAt.IsZero() {
+				t.CreatedAt = modified
+			}
+			if !bad[i]["created_at"] {
+				report(i, ProblemNoCreatedAt, "")
+			}
+		}
+		switch {
+		case t.UpdatedAt.IsZero():
+			t.UpdatedAt = t.CreatedAt
+			if !bad[i]["updated_at"] {
+				report(i, ProblemNoUpdatedAt, "")
+			}
+		case t.UpdatedAt.Before(t.CreatedAt):
+			t.UpdatedAt = t.CreatedAt
+			report(i, ProblemUpdatedBeforeCreated, "")
+		}
+	}
+
+	slices.SortStableFunc(problems, func(a, b Problem) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+	for i := range problems {
+		problems[i].Title = tasks[problems[i].Index].Title
+	}
+	return problems
+}
+
+// repairedID returns the ID given to the task at index i of the file,
+// without an ID or with the ID of a task before it
+func repairedID(i int, id string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte("lazy-todo:"+strconv.Itoa(i)+":"+id)).String()
+}
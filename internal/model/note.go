@@ -0,0 +1,24 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Note is a timestamped entry of a task's notes log
+type Note struct {
+	At   time.Time `yaml:"at" json:"at"`
+	Text string    `yaml:"text" json:"text"`
+}
+
+// AddNote appends a note to the task's log. Notes are never edited or
+// removed, so the log keeps the history of the task. Blank notes are
+// ignored and false is returned.
+func (t *Task) AddNote(text string, now time.Time) bool {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return false
+	}
+	t.Notes = append(t.Notes, Note{At: now, Text: text})
+	return true
+}
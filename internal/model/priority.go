@@ -0,0 +1,83 @@
+package model
+
+// Priority levels, lowest first. The four built-in levels can be replaced
+// by a user-defined list through SetPriorities.
+var (
+	priorityLevels  = []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical}
+	priorityWeights = map[Priority]int{}
+	priorityAliases = map[Priority]Priority{}
+	defaultPriority = PriorityMedium
+)
+
+// SetPriorities replaces the ordered list of priority levels (lowest first)
+// and the priority given to new tasks
+func SetPriorities(levels []Priority, def Priority) {
+	if len(levels) == 0 {
+		return
+	}
+	priorityLevels = levels
+	defaultPriority = def
+	if def.Index() < 0 {
+		defaultPriority = levels[len(levels)/2]
+	}
+}
+
+// SetPriorityWeight sets the weight of a priority, used when scoring tasks
+func SetPriorityWeight(p Priority, weight int) {
+	priorityWeights[p] = weight
+}
+
+// SetPriorityAlias maps a legacy priority value to an existing level, so
+// tasks stored with the old value are migrated on load
+func SetPriorityAlias(from, to Priority) {
+	priorityAliases[from] = to
+}
+
+// AllPriorities returns all available priorities, lowest first
+func AllPriorities() []Priority {
+	return append([]Priority(nil), priorityLevels...)
+}
+
+// DefaultPriority returns the priority given to new tasks
+func DefaultPriority() Priority {
+	return defaultPriority
+}
+
+// MigratePriority returns the level a stored priority value maps to.
+// Unknown values without an alias are kept as is.
+func MigratePriority(p Priority) Priority {
+	if to, ok := priorityAliases[p]; ok {
+		return to
+	}
+	return p
+}
+
+// Index returns the index of the priority, or -1 if it is not a known level
+func (p Priority) Index() int {
+	for i, level := range priorityLevels {
+		if level == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// Weight returns the weight of the priority (its index by default)
+func (p Priority) Weight() int {
+	if w, ok := priorityWeights[p]; ok {
+		return w
+	}
+	if i := p.Index(); i >= 0 {
+		return i
+	}
+	return defaultPriority.Index()
+}
+
+// Next cycles to the next priority
+func (p Priority) Next() Priority {
+	i := p.Index()
+	if i < 0 {
+		return defaultPriority
+	}
+	return priorityLevels[(i+1)%len(priorityLevels)]
+}
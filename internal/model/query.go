@@ -0,0 +1,284 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Query fields
+const (
+	FieldText     = ""
+	FieldTag      = "tag"
+	FieldStatus   = "status"
+	FieldPriority = "priority"
+	FieldSeverity = "severity"
+	FieldSprint   = "sprint"
+	FieldIs       = "is"
+	FieldID       = "id"
+)
+
+// queryFields lists the fields accepted before a colon, with their aliases
+var queryFields = map[string]string{
+	"tag":      FieldTag,
+	"tags":     FieldTag,
+	"status":   FieldStatus,
+	"state":    FieldStatus,
+	"priority": FieldPriority,
+	"prio":     FieldPriority,
+	"severity": FieldSeverity,
+	"sev":      FieldSeverity,
+	"sprint":   FieldSprint,
+	"is":       FieldIs,
+	"est":      FieldIs,
+	"id":       FieldID,
+}
+
+// Query is a parsed search query, like `tag:work status:todo,blocked
+// -priority:low report`. A task matches when it matches every term.
+type Query []QueryTerm
+
+// QueryTerm is a condition of a query: free text (Field is FieldText) or
+// a field matching any of Values, negated with a leading "-"
+type QueryTerm struct {
+	Field  string
+	Values []string
+	Negate bool
+
+	// IDs of the open tasks, for is:actionable (see WithTasks)
+	open map[string]bool
+}
+
+// ParseQuery parses a search query. Words are free text unless they are
+// `field:value` with a known field; values can be quoted ("en cours") and
+// separated by commas to match any of them. #tag is short for tag:tag.
+// Unknown fields are kept as free text.
+func ParseQuery(s string) Query {
+	var q Query
+	for _, word := range splitQuery(s) {
+		var term QueryTerm
+		if rest, ok := strings.CutPrefix(word, "-"); ok && rest != "" {
+			term.Negate = true
+			word = rest
+		}
+
+		if tag, ok := strings.CutPrefix(word, "#"); ok && tag != "" {
+			term.Field = FieldTag
+			word = tag
+		} else if name, value, ok := strings.Cut(word, ":"); ok {
+			if field, known := queryFields[strings.ToLower(name)]; known {
+				term.Field = field
+				word = value
+			}
+		}
+
+		if term.Field == FieldText {
+			term.Values = []string{strings.ToLower(unquote(word))}
+		} else {
+			for _, v := range strings.Split(word, ",") {
+				if v = strings.ToLower(unquote(v)); v != "" {
+					term.Values = append(term.Values, v)
+				}
+			}
+			// A field being typed (status:) doesn't filter yet
+			if len(term.Values) == 0 {
+				continue
+			}
+		}
+		q = append(q, term)
+	}
+	return q
+}
+
+// splitQuery splits a query on spaces outside of double quotes
+func splitQuery(s string) []string {
+	var words []string
+	var b strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			b.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if b.Len() > 0 {
+				words = append(words, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		words = append(words, b.String())
+	}
+	return words
+}
+
+// unquote removes the double quotes around a value
+func unquote(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
+}
+
+// WithTasks returns the query resolving the dependencies between tasks,
+// for is:actionable; without it, dependencies are taken as done
+func (q Query) WithTasks(tasks []Task) Query {
+	var open map[string]bool
+	bound := make(Query, len(q))
+	for i, term := range q {
+		if term.Field == FieldIs {
+			if open == nil {
+				open = OpenIDs(tasks)
+			}
+			term.open = open
+		}
+		bound[i] = term
+	}
+	return bound
+}
+
+// IsEmpty returns true if the query has no terms and matches every task
+func (q Query) IsEmpty() bool {
+	return len(q) == 0
+}
+
+// Narrows returns true if every task matching q matches prev too, so that
+// q can filter the tasks prev kept: q has the terms of prev and maybe more,
+// the last one possibly a word of free text being typed
+func (q Query) Narrows(prev Query) bool {
+	if len(q) < len(prev) {
+		return false
+	}
+	for i, term := range prev {
+		next := q[i]
+		if next.Field == term.Field && next.Negate == term.Negate && slices.Equal(next.Values, term.Values) {
+			continue
+		}
+		if i < len(prev)-1 || !next.extends(term) {
+			return false
+		}
+	}
+	return true
+}
+
+// extends returns true if the term is free text matching only tasks
+// matching prev, free text it contains. A short ID (t-12) matches the
+// task with that ID, which can lack the shorter text.
+func (term QueryTerm) extends(prev QueryTerm) bool {
+	if term.Field != FieldText || prev.Field != FieldText || term.Negate || prev.Negate {
+		return false
+	}
+	v := normalizeValue(term.Values[0])
+	if digits, ok := strings.CutPrefix(v, "t"); ok && digits != "" && strings.Trim(digits, "0123456789") == "" {
+		return false
+	}
+	return strings.Contains(term.Values[0], prev.Values[0])
+}
+
+// Matches returns true if the task matches every term of the query
+func (q Query) Matches(t Task) bool {
+	for _, term := range q {
+		if term.Matches(t) == term.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches returns true if the task matches the term, ignoring Negate
+func (term QueryTerm) Matches(t Task) bool {
+	for _, v := range term.Values {
+		if term.matchesValue(t, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesValue matches a single lower case value of the term
+func (term QueryTerm) matchesValue(t Task, v string) bool {
+	switch term.Field {
+	case FieldTag:
+		return t.HasTag(v)
+	case FieldStatus:
+		return sameValue(v, string(t.Status), t.Status.Label())
+	case FieldPriority:
+		return sameValue(v, string(t.Priority), t.Priority.Label())
+	case FieldSeverity:
+		if t.Severity == SeverityNone {
+			return sameValue(v, "none", t.Severity.Label())
+		}
+		return sameValue(v, string(t.Severity), t.Severity.Label())
+	case FieldSprint:
+		return t.matchesSprint(v)
+	case FieldIs:
+		switch v {
+		case "actionable", "actionnable", "ready", "prêt", "pret":
+			return t.IsActionable(time.Now(), term.open)
+		case "private", "privée", "privee":
+			return t.Private
+		case "today", "aujourdhui", "aujourd'hui":
+			return t.InToday(time.Now())
+		case "pinned", "épinglée", "epinglee":
+			return t.Pinned
+		}
+		return false
+	case FieldID:
+		if n, ok := ParseShortID(v); ok && n == t.Number {
+			return true
+		}
+		return strings.HasPrefix(t.ID, v)
+	}
+
+	if t.Number > 0 && sameValue(v, t.ShortID()) ||
+		strings.Contains(strings.ToLower(t.Title), v) ||
+		strings.Contains(strings.ToLower(t.Description), v) {
+		return true
+	}
+	for _, tag := range t.Tags {
+		if strings.Contains(strings.ToLower(tag), v) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesSprint matches a sprint by name, or current, next and none (in
+// English or French)
+func (t Task) matchesSprint(v string) bool {
+	now := time.Now()
+	switch v {
+	case "current", "courant", "actuel":
+		s, ok := CurrentSprint(now)
+		return ok && t.InSprint(s.Name)
+	case "next", "suivant", "prochain":
+		s, ok := NextSprint(now)
+		return ok && t.InSprint(s.Name)
+	case "none", "aucun":
+		return t.Sprint == ""
+	}
+	return t.Sprint != "" && sameValue(v, t.Sprint)
+}
+
+// sameValue returns true if v designates a stored value or its label,
+// ignoring case, spaces, dashes and underscores (inprogress, "en cours")
+func sameValue(v string, values ...string) bool {
+	v = normalizeValue(v)
+	for _, candidate := range values {
+		if v == normalizeValue(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeValue lower-cases s and drops spaces, dashes and underscores
+func normalizeValue(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '_' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReminderHour is the time of day a due date falls at for the reminders
+// relative to it, as due dates don't have a time
+const ReminderHour = 9
+
+// Reminder is a notification of a task, at a fixed time or some time
+// before its due date
+type Reminder struct {
+	At     *time.Time    `yaml:"at,omitempty" json:"at,omitempty"`
+	Before time.Duration `yaml:"before,omitempty" json:"before,omitempty"`
+	// Snoozed postpones the reminder to that time
+	Snoozed *time.Time `yaml:"snoozed,omitempty" json:"snoozed,omitempty"`
+	// Notified is when the reminder was last shown
+	Notified *time.Time `yaml:"notified,omitempty" json:"notified,omitempty"`
+}
+
+// Time returns when the reminder goes off for a task due at due, false for
+// a relative reminder of a task without due date
+func (r Reminder) Time(due *time.Time) (time.Time, bool) {
+	var at time.Time
+	switch {
+	case r.At != nil:
+		at = *r.At
+	case due != nil:
+		y, m, d := due.Date()
+		at = time.Date(y, m, d, ReminderHour, 0, 0, 0, due.Location()).Add(-r.Before)
+	default:
+		return time.Time{}, false
+	}
+	if r.Snoozed != nil && r.Snoozed.After(at) {
+		at = *r.Snoozed
+	}
+	return at, true
+}
+
+// String returns the reminder as typed in the task form: a duration before
+// the due date (1d, 2h30m) or a date and time (2026-10-20 09:00)
+func (r Reminder) String() string {
+	if r.At != nil {
+		return r.At.Local().Format("2006-01-02 15:04")
+	}
+	if r.Before == 0 {
+		return "0m"
+	}
+	var parts []string
+	rest := r.Before
+	for _, unit := range []struct {
+		suffix string
+		d      time.Duration
+	}{{"d", 24 * time.Hour}, {"h", time.Hour}, {"m", time.Minute}} {
+		if n := rest / unit.d; n > 0 {
+			parts = append(parts, strconv.Itoa(int(n))+unit.suffix)
+			rest -= n * unit.d
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// PendingReminders returns the indices of the reminders of an open task
+// that went off and weren't shown since
+func (t Task) PendingReminders(now time.Time) []int {
+	if t.Status.IsDone() {
+		return nil
+	}
+	var pending []int
+	for i, r := range t.Reminders {
+		at, ok := r.Time(t.DueDate)
+		if ok && !at.After(now) && (r.Notified == nil || r.Notified.Before(at)) {
+			pending = append(pending, i)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,196 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PomodoroLength is the length of a pomodoro: a session counts as many of
+// them as it lasted full ones
+const PomodoroLength = 25 * time.Minute
+
+// WorkSession is a span of time worked on a task, timed from the TUI
+type WorkSession struct {
+	Start time.Time  `yaml:"start" json:"start"`
+	End   *time.Time `yaml:"end,omitempty" json:"end,omitempty"` // nil while running
+}
+
+// Duration returns the length of the session, up to now while it runs
+func (s WorkSession) Duration(now time.Time) time.Duration {
+	if s.End != nil {
+		return s.End.Sub(s.Start)
+	}
+	return now.Sub(s.Start)
+}
+
+// Pomodoros returns the number of full pomodoros the session lasted
+func (s WorkSession) Pomodoros(now time.Time) int {
+	return int(s.Duration(now) / PomodoroLength)
+}
+
+// RunningSession returns the session of the task being timed, nil when
+// there is none
+func (t *Task) RunningSession() *WorkSession {
+	for i := range t.Sessions {
+		if t.Sessions[i].End == nil {
+			return &t.Sessions[i]
+		}
+	}
+	return nil
+}
+
+// StartSession starts timing the task. It returns false if it already is.
+func (t *Task) StartSession(now time.Time) bool {
+	if t.RunningSession() != nil {
+		return false
+	}
+	t.Sessions = append(t.Sessions, WorkSession{Start: now})
+	return true
+}
+
+// StopSession ends the running session of the task and returns it, nil
+// when there was none. The sessions are copied first, being shared with
+// the other copies of the task.
+func (t *Task) StopSession(now time.Time) *WorkSession {
+	t.Sessions = slices.Clone(t.Sessions)
+	s := t.RunningSession()
+	if s == nil {
+		return nil
+	}
+	s.End = &now
+	return s
+}
+
+// TrimSession ends the running session of the task at from, leaving out
+// the time since, and starts a new one at to when resume is true. A
+// session left empty is dropped. It returns false if there was no running
+// session.
+func (t *Task) TrimSession(from, to time.Time, resume bool) bool {
+	i := slices.IndexFunc(t.Sessions, func(s WorkSession) bool { return s.End == nil })
+	if i < 0 {
+		return false
+	}
+	t.Sessions = slices.Clone(t.Sessions)
+	if from.After(t.Sessions[i].Start) {
+		t.Sessions[i].End = &from
+	} else {
+		t.Sessions = slices.Delete(t.Sessions, i, i+1)
+	}
+	if resume {
+		t.Sessions = append(t.Sessions, WorkSession{Start: to})
+	}
+	return true
+}
+
+// WorkEntry is the time worked on a task during a day
+type WorkEntry struct {
+	Day       string // 2026-10-17
+	TaskID    string
+	Title     string
+	Tags      []string
+	Duration  time.Duration
+	Pomodoros int // counted on the day their session started
+}
+
+// WorkLog returns the time worked on each task each day since a time, the
+// sessions over midnight split between their days; oldest day first, then
+// in order of tasks
+func WorkLog(tasks []Task, since, now time.Time) []WorkEntry {
+	var log []WorkEntry
+	for _, t := range tasks {
+		byDay := make(map[string]*WorkEntry)
+		var days []string
+		add := func(day string, d time.Duration, pomodoros int) {
+			e, ok := byDay[day]
+			if !ok {
+				e = &WorkEntry{Day: day, TaskID: t.ID, Title: t.Title, Tags: t.Tags}
+				byDay[day] = e
+				days = append(days, day)
+			}
+			e.Duration += d
+			e.Pomodoros += pomodoros
+		}
+
+		for _, s := range t.Sessions {
+			start, end := s.Start.Local(), now.Local()
+			if s.End != nil {
+				end = s.End.Local()
+			}
+			if !start.Before(since) {
+				add(JournalDay(start), 0, s.Pomodoros(now))
+			}
+			if start.Before(since) {
+				start = since.Local()
+			}
+			for start.Before(end) {
+				y, m, d := start.Date()
+				stop := time.Date(y, m, d+1, 0, 0, 0, 0, start.Location())
+				if end.Before(stop) {
+					stop = end
+				}
+				add(JournalDay(start), stop.Sub(start), 0)
+				start = stop
+			}
+		}
+
+		for _, day := range days {
+			if e := byDay[day]; e.Duration > 0 {
+				log = append(log, *e)
+			}
+		}
+	}
+	slices.SortStableFunc(log, func(a, b WorkEntry) int {
+		return strings.Compare(a.Day, b.Day)
+	})
+	return log
+}
+
+// WorkTotal is the time worked on a tag or during a day
+type WorkTotal struct {
+	Key       string
+	Duration  time.Duration
+	Pomodoros int
+}
+
+// WorkByDay sums a work log per day, oldest first
+func WorkByDay(log []WorkEntry) []WorkTotal {
+	var totals []WorkTotal
+	for _, e := range log {
+		if n := len(totals); n > 0 && totals[n-1].Key == e.Day {
+			totals[n-1].Duration += e.Duration
+			totals[n-1].Pomodoros += e.Pomodoros
+			continue
+		}
+		totals = append(totals, WorkTotal{Key: e.Day, Duration: e.Duration, Pomodoros: e.Pomodoros})
+	}
+	return totals
+}
+
+// WorkByTag sums a work log per tag, longest first: a task counts in each
+// of its tags, the untagged ones under an empty tag
+func WorkByTag(log []WorkEntry) []WorkTotal {
+	index := make(map[string]int)
+	var totals []WorkTotal
+	for _, e := range log {
+		tags := e.Tags
+		if len(tags) == 0 {
+			tags = []string{""}
+		}
+		for _, tag := range tags {
+			i, ok := index[tag]
+			if !ok {
+				i = len(totals)
+				index[tag] = i
+				totals = append(totals, WorkTotal{Key: tag})
+			}
+			totals[i].Duration += e.Duration
+			totals[i].Pomodoros += e.Pomodoros
+		}
+	}
+	slices.SortStableFunc(totals, func(a, b WorkTotal) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	return totals
+}
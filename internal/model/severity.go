@@ -0,0 +1,54 @@
+package model
+
+import "lazy-todo/internal/i18n"
+
+// Severity represents the impact of a task (typically a bug), as opposed to
+// its priority which is the order of work. It is optional.
+type Severity string
+
+const (
+	SeverityNone     Severity = ""
+	SeverityCosmetic Severity = "cosmetic"
+	SeverityMinor    Severity = "minor"
+	SeverityMajor    Severity = "major"
+	SeverityCritical Severity = "critical"
+)
+
+// AllSeverities returns all severities, including none, lowest first
+func AllSeverities() []Severity {
+	return []Severity{SeverityNone, SeverityCosmetic, SeverityMinor, SeverityMajor, SeverityCritical}
+}
+
+// Label returns the French label for a severity
+func (s Severity) Label() string {
+	switch s {
+	case SeverityNone:
+		return i18n.T("Aucune")
+	case SeverityCosmetic:
+		return i18n.T("Cosmétique")
+	case SeverityMinor:
+		return i18n.T("Mineure")
+	case SeverityMajor:
+		return i18n.T("Majeure")
+	case SeverityCritical:
+		return i18n.T("Critique")
+	default:
+		return string(s)
+	}
+}
+
+// Index returns the index of the severity, 0 being none
+func (s Severity) Index() int {
+	for i, v := range AllSeverities() {
+		if v == s {
+			return i
+		}
+	}
+	return 0
+}
+
+// Next cycles to the next severity
+func (s Severity) Next() Severity {
+	all := AllSeverities()
+	return all[(s.Index()+1)%len(all)]
+}
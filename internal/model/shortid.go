@@ -0,0 +1,80 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ShortIDPrefix starts the short IDs of the tasks, like T-42
+const ShortIDPrefix = "T-"
+
+// ShortID returns the short ID of the task, like T-42; empty while it has
+// no number
+func (t Task) ShortID() string {
+	if t.Number <= 0 {
+		return ""
+	}
+	return ShortIDPrefix + strconv.Itoa(t.Number)
+}
+
+// ParseShortID reads the number of a short ID, typed as T-42, t42, #42 or
+// 42
+func ParseShortID(s string) (int, bool) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(strings.ToUpper(s), "T"); ok {
+		s = strings.TrimPrefix(rest, "-")
+	} else {
+		s = strings.TrimPrefix(s, "#")
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// NumberTasks gives the tasks without a number, or with the number of a
+// task before them, the next numbers from next, which is raised above
+// the numbers in use. It returns the number to give next, so that numbers
+// of deleted tasks aren't given again.
+func NumberTasks(tasks []Task, next int) int {
+	for _, t := range tasks {
+		next = max(next, t.Number+1)
+	}
+	next = max(next, 1)
+
+	taken := make(map[int]bool, len(tasks))
+	for i := range tasks {
+		if tasks[i].Number <= 0 || taken[tasks[i].Number] {
+			tasks[i].Number = next
+			next++
+		}
+		taken[tasks[i].Number] = true
+	}
+	return next
+}
+
+// FindTask returns the index of the task of a reference: its short ID, its
+// ID or the start of its ID; -1 when no single task has it
+func FindTask(tasks []Task, ref string) int {
+	if n, ok := ParseShortID(ref); ok {
+		for i, t := range tasks {
+			if t.Number == n {
+				return i
+			}
+		}
+	}
+	found := -1
+	for i, t := range tasks {
+		switch {
+		case t.ID == ref:
+			return i
+		case len(ref) >= 4 && strings.HasPrefix(t.ID, ref):
+			if found >= 0 {
+				return -1
+			}
+			found = i
+		}
+	}
+	return found
+}
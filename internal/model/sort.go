@@ -0,0 +1,131 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+)
+
+// SortBy represents the sorting criteria for tasks
+type SortBy int
+
+const (
+	SortByManual SortBy = iota
+	SortByCreated
+	SortByUpdated
+	SortByPriority
+	SortByDueDate
+	SortByTitle
+	SortByUrgency
+)
+
+// AllSortBy returns all available sorting options
+func AllSortBy() []SortBy {
+	return []SortBy{SortByManual, SortByCreated, SortByUpdated, SortByPriority, SortByDueDate, SortByTitle, SortByUrgency}
+}
+
+// Label returns the French label for a sorting option
+func (s SortBy) Label() string {
+	switch s {
+	case SortByManual:
+		return i18n.T("Manuel")
+	case SortByCreated:
+		return i18n.T("Création")
+	case SortByUpdated:
+		return i18n.T("Modification")
+	case SortByPriority:
+		return i18n.T("Priorité")
+	case SortByDueDate:
+		return i18n.T("Échéance")
+	case SortByTitle:
+		return i18n.T("Titre")
+	case SortByUrgency:
+		return i18n.T("Urgence")
+	default:
+		return i18n.T("Manuel")
+	}
+}
+
+// Next cycles to the next sorting option
+func (s SortBy) Next() SortBy {
+	all := AllSortBy()
+	for i, v := range all {
+		if v == s {
+			return all[(i+1)%len(all)]
+		}
+	}
+	return SortByManual
+}
+
+// SortIndices sorts indices into tasks according to the sorting option,
+// pinned tasks first. The sort is stable so tasks that compare equal keep
+// their file order.
+func SortIndices(tasks []Task, indices []int, by SortBy) {
+	sortIndices(tasks, indices, by)
+	sort.SliceStable(indices, func(i, j int) bool {
+		return tasks[indices[i]].Pinned && !tasks[indices[j]].Pinned
+	})
+}
+
+// sortIndices sorts indices into tasks according to the sorting option only
+func sortIndices(tasks []Task, indices []int, by SortBy) {
+	if by == SortByUrgency {
+		sortByUrgency(tasks, indices, time.Now())
+		return
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return Less(tasks[indices[i]], tasks[indices[j]], by)
+	})
+}
+
+// Less reports whether task a sorts before task b
+func Less(a, b Task, by SortBy) bool {
+	switch by {
+	case SortByCreated:
+		return a.CreatedAt.After(b.CreatedAt)
+	case SortByUpdated:
+		return a.UpdatedAt.After(b.UpdatedAt)
+	case SortByPriority:
+		// Severity breaks ties between tasks of the same priority
+		if a.Priority.Weight() == b.Priority.Weight() {
+			return a.Severity.Index() > b.Severity.Index()
+		}
+		return a.Priority.Weight() > b.Priority.Weight()
+	case SortByDueDate:
+		// Tasks without due date go last
+		if a.DueDate == nil || b.DueDate == nil {
+			return a.DueDate != nil && b.DueDate == nil
+		}
+		return a.DueDate.Before(*b.DueDate)
+	case SortByTitle:
+		return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+	case SortByUrgency:
+		now := time.Now()
+		return a.Urgency(now) > b.Urgency(now)
+	default:
+		// Tasks without explicit order go last, in file order
+		if a.Order == 0 || b.Order == 0 {
+			return a.Order != 0 && b.Order == 0
+		}
+		return a.Order < b.Order
+	}
+}
+
+// ManualOrder returns a normalized manual position (starting at 1) for
+// every task, following the current manual sort
+func ManualOrder(tasks []Task) map[string]int {
+	indices := make([]int, len(tasks))
+	for i := range indices {
+		indices[i] = i
+	}
+	// Pinned tasks keep their place in the manual order
+	sortIndices(tasks, indices, SortByManual)
+
+	order := make(map[string]int, len(tasks))
+	for pos, idx := range indices {
+		order[tasks[idx].ID] = pos + 1
+	}
+	return order
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sprint is a timebox tasks are planned in, from the day of Start to the
+// day of End included
+type Sprint struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+var sprints []Sprint
+
+// SetSprints sets the sprints tasks can be assigned to, sorted by start
+func SetSprints(list []Sprint) {
+	sprints = append([]Sprint(nil), list...)
+	sort.SliceStable(sprints, func(i, j int) bool { return sprints[i].Start.Before(sprints[j].Start) })
+}
+
+// AllSprints returns the sprints, by start
+func AllSprints() []Sprint {
+	return append([]Sprint(nil), sprints...)
+}
+
+// Contains returns true if the day of now is within the sprint
+func (s Sprint) Contains(now time.Time) bool {
+	return !s.IsUpcoming(now) && !s.IsOver(now)
+}
+
+// IsOver returns true once the last day of the sprint has passed
+func (s Sprint) IsOver(now time.Time) bool {
+	return daysBetween(now, s.End) < 0
+}
+
+// IsUpcoming returns true before the first day of the sprint
+func (s Sprint) IsUpcoming(now time.Time) bool {
+	return daysBetween(now, s.Start) > 0
+}
+
+// DaysLeft returns the number of days left in the sprint, today included
+func (s Sprint) DaysLeft(now time.Time) int {
+	return max(daysBetween(now, s.End)+1, 0)
+}
+
+// DaysUntil returns the number of days until the sprint starts
+func (s Sprint) DaysUntil(now time.Time) int {
+	return daysBetween(now, s.Start)
+}
+
+// CurrentSprint returns the sprint the day of now is in
+func CurrentSprint(now time.Time) (Sprint, bool) {
+	for _, s := range sprints {
+		if s.Contains(now) {
+			return s, true
+		}
+	}
+	return Sprint{}, false
+}
+
+// NextSprint returns the first sprint starting after the day of now
+func NextSprint(now time.Time) (Sprint, bool) {
+	for _, s := range sprints {
+		if s.IsUpcoming(now) {
+			return s, true
+		}
+	}
+	return Sprint{}, false
+}
+
+// SprintAfter returns the sprint following the given one
+func SprintAfter(name string) (Sprint, bool) {
+	for i, s := range sprints {
+		if s.Name == name && i+1 < len(sprints) {
+			return sprints[i+1], true
+		}
+	}
+	return Sprint{}, false
+}
+
+// LastEndedSprint returns the latest sprint that is over
+func LastEndedSprint(now time.Time) (Sprint, bool) {
+	for i := len(sprints) - 1; i >= 0; i-- {
+		if sprints[i].IsOver(now) {
+			return sprints[i], true
+		}
+	}
+	return Sprint{}, false
+}
+
+// InSprint returns true if the task is assigned to the named sprint,
+// ignoring case
+func (t Task) InSprint(name string) bool {
+	return t.Sprint != "" && strings.EqualFold(t.Sprint, name)
+}
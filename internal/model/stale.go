@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// IsStale returns true if the task is in progress and hasn't been updated
+// for at least the given number of days
+func (t Task) IsStale(days int, now time.Time) bool {
+	if days <= 0 || t.Status.Kind() != StatusInProgress {
+		return false
+	}
+	return now.Sub(t.UpdatedAt) >= time.Duration(days)*24*time.Hour
+}
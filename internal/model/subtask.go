@@ -0,0 +1,17 @@
+package model
+
+// Subtask is a checklist item of a task
+type Subtask struct {
+	Title string `yaml:"title" json:"title"`
+	Done  bool   `yaml:"done" json:"done"`
+}
+
+// SubtaskProgress returns the number of done subtasks and the total
+func (t Task) SubtaskProgress() (done, total int) {
+	for _, st := range t.Subtasks {
+		if st.Done {
+			done++
+		}
+	}
+	return done, len(t.Subtasks)
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// TagSeparator separates the levels of hierarchical tags (work/clientA/api)
+const TagSeparator = "/"
+
+// TagMatches reports whether tag is filter or one of its descendants,
+// ignoring case. A filter ending with the separator only matches
+// descendants.
+func TagMatches(tag, filter string) bool {
+	tag, filter = strings.ToLower(tag), strings.ToLower(filter)
+	if strings.HasSuffix(filter, TagSeparator) {
+		return strings.HasPrefix(tag, filter)
+	}
+	return tag == filter || strings.HasPrefix(tag, filter+TagSeparator)
+}
+
+// HasTag reports whether the task has the tag or one of its descendants
+func (t Task) HasTag(filter string) bool {
+	for _, tag := range t.Tags {
+		if TagMatches(tag, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllTags returns the distinct tags of the tasks, sorted
+func AllTags(tasks []Task) []string {
+	seen := map[string]bool{}
+	var tags []string
+	for _, t := range tasks {
+		for _, tag := range t.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// TagNode is a level of the tag hierarchy. Path is the full tag of the
+// node; intermediate levels exist even if no task uses them directly.
+type TagNode struct {
+	Name     string
+	Path     string
+	Children []*TagNode
+}
+
+// TagTree builds the hierarchy of the given tags, sorted by name
+func TagTree(tags []string) []*TagNode {
+	var roots []*TagNode
+	for _, tag := range tags {
+		level := &roots
+		var path []string
+		for _, name := range strings.Split(tag, TagSeparator) {
+			if name == "" {
+				continue
+			}
+			path = append(path, name)
+			node := findTagNode(*level, name)
+			if node == nil {
+				node = &TagNode{Name: name, Path: strings.Join(path, TagSeparator)}
+				*level = append(*level, node)
+			}
+			level = &node.Children
+		}
+	}
+	sortTagNodes(roots)
+	return roots
+}
+
+// findTagNode returns the node with the given name, or nil
+func findTagNode(nodes []*TagNode, name string) *TagNode {
+	for _, n := range nodes {
+		if n.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+// sortTagNodes sorts nodes and their children by name
+func sortTagNodes(nodes []*TagNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+	for _, n := range nodes {
+		sortTagNodes(n.Children)
+	}
+}
+
+// TagCounts returns the number of tasks having each tag
+func TagCounts(tasks []Task) map[string]int {
+	counts := map[string]int{}
+	for _, t := range tasks {
+		for _, tag := range t.Tags {
+			counts[tag]++
+		}
+	}
+	return counts
+}
+
+// RenameTag replaces a tag and its descendants by another in tags (work
+// to job renames work/api to job/api), keeping the tags distinct; false
+// when none is renamed
+func RenameTag(tags []string, from, to string) ([]string, bool) {
+	renamed := false
+	var result []string
+	for _, tag := range tags {
+		if TagMatches(tag, from) {
+			tag = to + tag[len(from):]
+			renamed = true
+		}
+		if !slices.ContainsFunc(result, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			result = append(result, tag)
+		}
+	}
+	if !renamed {
+		return tags, false
+	}
+	return result, true
+}
+
+// RemoveTag removes a tag from tags, but not its descendants; false when
+// absent
+func RemoveTag(tags []string, tag string) ([]string, bool) {
+	i := slices.Index(tags, tag)
+	if i < 0 {
+		return tags, false
+	}
+	return slices.Delete(slices.Clone(tags), i, i+1), true
+}
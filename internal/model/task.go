@@ -1,8 +1,12 @@
 package model
 
 import (
+	"slices"
+	"strings"
 	"time"
 
+	"lazy-todo/internal/i18n"
+
 	"github.com/google/uuid"
 )
 
@@ -45,15 +49,15 @@ func AllGroupBy() []GroupBy {
 func (g GroupBy) Label() string {
 	switch g {
 	case GroupByNone:
-		return "Aucun"
+		return i18n.T("Aucun")
 	case GroupByStatus:
-		return "État"
+		return i18n.T("État")
 	case GroupByPriority:
-		return "Priorité"
+		return i18n.T("Priorité")
 	case GroupByTag:
-		return "Tag"
+		return i18n.T("Tag")
 	default:
-		return "Aucun"
+		return i18n.T("Aucun")
 	}
 }
 
@@ -75,19 +79,66 @@ func (g GroupBy) Next() GroupBy {
 
 // Task represents a single todo item
 type Task struct {
-	ID          string    `yaml:"id"`
-	Title       string    `yaml:"title"`
-	Description string    `yaml:"description,omitempty"`
-	Priority    Priority  `yaml:"priority"`
-	Status      Status    `yaml:"status"`
-	Tags        []string  `yaml:"tags,omitempty"`
-	CreatedAt   time.Time `yaml:"created_at"`
-	UpdatedAt   time.Time `yaml:"updated_at"`
+	ID            string        `yaml:"id" json:"id"`
+	Number        int           `yaml:"number,omitempty" json:"number,omitempty"` // short ID, see NumberTasks
+	Title         string        `yaml:"title" json:"title"`
+	Description   string        `yaml:"description,omitempty" json:"description,omitempty"`
+	Priority      Priority      `yaml:"priority" json:"priority"`
+	Status        Status        `yaml:"status" json:"status"`
+	BlockedReason string        `yaml:"blocked_reason,omitempty" json:"blocked_reason,omitempty"` // see BlockReasons
+	Severity      Severity      `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Tags          []string      `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Subtasks      []Subtask     `yaml:"subtasks,omitempty" json:"subtasks,omitempty"`
+	Notes         []Note        `yaml:"notes,omitempty" json:"notes,omitempty"`
+	History       []Change      `yaml:"history,omitempty" json:"history,omitempty"`
+	Sessions      []WorkSession `yaml:"sessions,omitempty" json:"sessions,omitempty"` // timed work, see WorkLog
+	DueDate       *time.Time    `yaml:"due_date,omitempty" json:"due_date,omitempty"`
+	Reminders     []Reminder    `yaml:"reminders,omitempty" json:"reminders,omitempty"`
+	Order         int           `yaml:"order,omitempty" json:"order,omitempty"`
+	Sprint        string        `yaml:"sprint,omitempty" json:"sprint,omitempty"`         // by name
+	Estimate      int           `yaml:"estimate,omitempty" json:"estimate,omitempty"`     // story points
+	DependsOn     []string      `yaml:"depends_on,omitempty" json:"depends_on,omitempty"` // task IDs
+	Private       bool          `yaml:"private,omitempty" json:"private,omitempty"`       // hidden from restricted server tokens
+	Today         *time.Time    `yaml:"today,omitempty" json:"today,omitempty"`           // day pinned to the today view, see InToday
+	Pinned        bool          `yaml:"pinned,omitempty" json:"pinned,omitempty"`         // sorted first, see SortIndices
+	CreatedAt     time.Time     `yaml:"created_at" json:"created_at"`
+	UpdatedAt     time.Time     `yaml:"updated_at" json:"updated_at"`
+	// External identifies the item the task was imported from, like
+	// "github:owner/repo#12", to sync changes back
+	External string `yaml:"external,omitempty" json:"external,omitempty"`
+}
+
+// ExternalKey returns the short reference of the item the task was
+// imported from, shown on the task: the ticket key of a Jira issue
+// (ABC-12), the number of a GitHub issue (#12); empty for other tasks
+func (t Task) ExternalKey() string {
+	source, ref, ok := strings.Cut(t.External, ":")
+	if !ok {
+		return ""
+	}
+	switch source {
+	case "jira":
+		return ref
+	case "github":
+		if i := strings.LastIndex(ref, "#"); i >= 0 {
+			return ref[i:]
+		}
+	}
+	return ""
 }
 
 // TaskStore represents the root structure of the YAML file
 type TaskStore struct {
-	Tasks []Task `yaml:"tasks"`
+	Tasks []Task `yaml:"tasks" json:"tasks"`
+	// TagColors holds the color of tags, set on the tags screen
+	TagColors map[string]string `yaml:"tag_colors,omitempty" json:"tag_colors,omitempty"`
+	// NextNumber is the number of the next task created, see NumberTasks
+	NextNumber int `yaml:"next_number,omitempty" json:"next_number,omitempty"`
+	// Journal holds the notes about days, oldest first
+	Journal []JournalEntry `yaml:"journal,omitempty" json:"journal,omitempty"`
+	// BadTimes are the timestamps of tasks that couldn't be read, left out
+	// when decoding and repaired by RepairTasks
+	BadTimes []BadTime `yaml:"-" json:"-"`
 }
 
 // NewTask creates a new task with default values
@@ -96,7 +147,7 @@ func NewTask(title string) Task {
 	return Task{
 		ID:        uuid.New().String(),
 		Title:     title,
-		Priority:  PriorityMedium,
+		Priority:  DefaultPriority(),
 		Status:    StatusTodo,
 		Tags:      []string{},
 		CreatedAt: now,
@@ -104,27 +155,41 @@ func NewTask(title string) Task {
 	}
 }
 
-// AllPriorities returns all available priorities
-func AllPriorities() []Priority {
-	return []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical}
-}
-
-// AllStatuses returns all available statuses
-func AllStatuses() []Status {
-	return []Status{StatusTodo, StatusInProgress, StatusBlocked, StatusDone}
+// CloneTasks copies tasks along with their lists, so that the copies can
+// be modified without touching the originals; times pointed to are shared
+// since they are replaced, never modified
+func CloneTasks(tasks []Task) []Task {
+	if tasks == nil {
+		return nil
+	}
+	clones := make([]Task, len(tasks))
+	for i, t := range tasks {
+		t.Tags = slices.Clone(t.Tags)
+		t.Subtasks = slices.Clone(t.Subtasks)
+		t.Notes = slices.Clone(t.Notes)
+		t.History = slices.Clone(t.History)
+		t.Reminders = slices.Clone(t.Reminders)
+		t.DependsOn = slices.Clone(t.DependsOn)
+		t.Sessions = slices.Clone(t.Sessions)
+		clones[i] = t
+	}
+	return clones
 }
 
 // PriorityLabel returns the French label for a priority
 func (p Priority) Label() string {
+	if label, ok := priorityLabels[p]; ok {
+		return label
+	}
 	switch p {
 	case PriorityLow:
-		return "Basse"
+		return i18n.T("Basse")
 	case PriorityMedium:
-		return "Moyenne"
+		return i18n.T("Moyenne")
 	case PriorityHigh:
-		return "Haute"
+		return i18n.T("Haute")
 	case PriorityCritical:
-		return "Critique"
+		return i18n.T("Critique")
 	default:
 		return string(p)
 	}
@@ -132,73 +197,19 @@ func (p Priority) Label() string {
 
 // StatusLabel returns the French label for a status
 func (s Status) Label() string {
-	switch s {
-	case StatusTodo:
-		return "À faire"
-	case StatusInProgress:
-		return "En cours"
-	case StatusBlocked:
-		return "Bloqué"
-	case StatusDone:
-		return "Terminé"
-	default:
-		return string(s)
+	if label, ok := statusLabels[s]; ok {
+		return label
 	}
-}
-
-// StatusIndex returns the index of the status (for kanban columns)
-func (s Status) Index() int {
 	switch s {
 	case StatusTodo:
-		return 0
+		return i18n.T("À faire")
 	case StatusInProgress:
-		return 1
+		return i18n.T("En cours")
 	case StatusBlocked:
-		return 2
+		return i18n.T("Bloqué")
 	case StatusDone:
-		return 3
+		return i18n.T("Terminé")
 	default:
-		return 0
-	}
-}
-
-// StatusFromIndex returns the status for a given index
-func StatusFromIndex(i int) Status {
-	statuses := AllStatuses()
-	if i >= 0 && i < len(statuses) {
-		return statuses[i]
-	}
-	return StatusTodo
-}
-
-// NextPriority cycles to the next priority
-func (p Priority) Next() Priority {
-	switch p {
-	case PriorityLow:
-		return PriorityMedium
-	case PriorityMedium:
-		return PriorityHigh
-	case PriorityHigh:
-		return PriorityCritical
-	case PriorityCritical:
-		return PriorityLow
-	default:
-		return PriorityMedium
-	}
-}
-
-// Index returns the index of the priority
-func (p Priority) Index() int {
-	switch p {
-	case PriorityLow:
-		return 0
-	case PriorityMedium:
-		return 1
-	case PriorityHigh:
-		return 2
-	case PriorityCritical:
-		return 3
-	default:
-		return 1
+		return string(s)
 	}
 }
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCloneTasks(t *testing.T) {
+	start := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	task := NewTask("Tâche")
+	task.Tags = []string{"work"}
+	task.Subtasks = []Subtask{{Title: "Étape"}}
+	task.DependsOn = []string{"a"}
+	task.Sessions = make([]WorkSession, 1, 4)
+	task.Sessions[0] = WorkSession{Start: start}
+	tasks := []Task{task}
+
+	clones := CloneTasks(tasks)
+	clones[0].Tags[0] = "home"
+	clones[0].Subtasks[0].Done = true
+	clones[0].DependsOn[0] = "b"
+	clones[0].Sessions[0].Start = start.Add(time.Hour)
+	clones[0].StartSession(start.Add(2 * time.Hour))
+
+	orig := tasks[0]
+	if orig.Tags[0] != "work" || orig.Subtasks[0].Done || orig.DependsOn[0] != "a" {
+		t.Errorf("original changed through its clone: %+v", orig)
+	}
+	if !orig.Sessions[0].Start.Equal(start) {
+		t.Errorf("original session starts at %v, want %v", orig.Sessions[0].Start, start)
+	}
+	if full := orig.Sessions[:2]; full[1] != (WorkSession{}) {
+		t.Errorf("original sessions backing array written: %+v", full[1])
+	}
+}
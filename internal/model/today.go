@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// IsPinnedToday returns true if the task was pinned to the today view on
+// the day of now; a pin left from an earlier day no longer counts
+func (t Task) IsPinnedToday(now time.Time) bool {
+	return t.Today != nil && startOfDay(t.Today.In(now.Location())).Equal(startOfDay(now))
+}
+
+// InToday returns true if the task belongs to the working set of the day
+// of now: due that day, overdue, or pinned to it
+func (t Task) InToday(now time.Time) bool {
+	return t.IsDueOn(now) || t.IsOverdue(now) || t.IsPinnedToday(now)
+}
+
+// PinToday pins the task to the today view of the day of now, or unpins
+// it when it already is
+func (t *Task) PinToday(now time.Time) {
+	if t.IsPinnedToday(now) {
+		t.Today = nil
+		return
+	}
+	day := startOfDay(now)
+	t.Today = &day
+}
+
+// CarryOver moves the task to the day after now: it is due then, unless
+// due later, and no longer pinned to today
+func (t *Task) CarryOver(now time.Time) {
+	tomorrow := startOfDay(now).AddDate(0, 0, 1)
+	if t.DueDate == nil || t.DueDate.Before(tomorrow) {
+		t.DueDate = &tomorrow
+	}
+	t.Today = nil
+}
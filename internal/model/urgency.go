@@ -0,0 +1,111 @@
+package model
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// UrgencyWeights weighs the terms of the urgency score of a task
+type UrgencyWeights struct {
+	Priority float64
+	Due      float64
+	Age      float64
+}
+
+// DefaultUrgencyWeights makes the due date count most, then the priority,
+// and the age only break ties
+var DefaultUrgencyWeights = UrgencyWeights{Priority: 4, Due: 6, Age: 1}
+
+// Horizons over which the due date and age terms grow from 0 to 1
+const (
+	UrgencyDueDays = 14
+	UrgencyAgeDays = 30
+)
+
+var urgencyWeights = DefaultUrgencyWeights
+
+// SetUrgencyWeights sets the weights of the urgency score
+func SetUrgencyWeights(w UrgencyWeights) {
+	urgencyWeights = w
+}
+
+// Urgency returns the urgency score of a task, 0 when done. It adds:
+//
+//   - the priority, from 0 for the lowest level to 1 for the highest
+//   - the due date, from 0 UrgencyDueDays ahead to 1 on the day, up to 2
+//     when UrgencyDueDays overdue
+//   - the age, from 0 when created to 1 after UrgencyAgeDays
+//
+// each multiplied by its weight
+func (t Task) Urgency(now time.Time) float64 {
+	if t.Status.IsDone() {
+		return 0
+	}
+	w := urgencyWeights
+	return w.Priority*t.priorityTerm() + w.Due*t.dueTerm(now) + w.Age*t.ageTerm(now)
+}
+
+// priorityTerm places the task's priority weight between the lowest and
+// highest weights of the levels
+func (t Task) priorityTerm() float64 {
+	levels := AllPriorities()
+	lo, hi := levels[0].Weight(), levels[0].Weight()
+	for _, p := range levels {
+		lo, hi = min(lo, p.Weight()), max(hi, p.Weight())
+	}
+	if hi == lo {
+		return 0
+	}
+	return float64(MigratePriority(t.Priority).Weight()-lo) / float64(hi-lo)
+}
+
+// dueTerm grows as the due date gets closer, and keeps growing once passed
+func (t Task) dueTerm(now time.Time) float64 {
+	if t.DueDate == nil {
+		return 0
+	}
+	days := float64(daysBetween(now, *t.DueDate))
+	if days <= 0 {
+		return 1 + min(-days, UrgencyDueDays)/UrgencyDueDays
+	}
+	return max(0, 1-days/UrgencyDueDays)
+}
+
+// ageTerm grows with the time since the task was created
+func (t Task) ageTerm(now time.Time) float64 {
+	if t.CreatedAt.IsZero() {
+		return 0
+	}
+	days := now.Sub(t.CreatedAt).Hours() / 24
+	return min(max(days, 0), UrgencyAgeDays) / UrgencyAgeDays
+}
+
+// daysBetween returns the number of days from the day of from to the day
+// of to, in from's location, negative when to is before
+func daysBetween(from, to time.Time) int {
+	d := startOfDay(to.In(from.Location())).Sub(startOfDay(from))
+	return int(math.Round(d.Hours() / 24))
+}
+
+// IsEscalated returns true if the task is overdue at one of the two
+// highest priority levels
+func (t Task) IsEscalated(now time.Time) bool {
+	if !t.IsOverdue(now) {
+		return false
+	}
+	levels := AllPriorities()
+	return MigratePriority(t.Priority).Index() >= len(levels)-2
+}
+
+// sortByUrgency sorts indices into tasks by decreasing urgency, scoring
+// each task once
+func sortByUrgency(tasks []Task, indices []int, now time.Time) {
+	scores := make(map[int]float64, len(indices))
+	for _, i := range indices {
+		scores[i] = tasks[i].Urgency(now)
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return scores[indices[i]] > scores[indices[j]]
+	})
+}
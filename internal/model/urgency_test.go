@@ -0,0 +1,88 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// urgencyNow is the time the urgency tests are scored at
+var urgencyNow = time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+
+// urgencyTask returns an open task of a priority, created at urgencyNow
+// and due in dueDays days unless nil
+func urgencyTask(p Priority, dueDays *int) Task {
+	t := Task{Title: "Tâche", Priority: p, Status: StatusTodo, CreatedAt: urgencyNow}
+	if dueDays != nil {
+		due := time.Date(2026, 10, 17+*dueDays, 0, 0, 0, 0, time.Local)
+		t.DueDate = &due
+	}
+	return t
+}
+
+func days(n int) *int { return &n }
+
+func TestUrgency(t *testing.T) {
+	aged := func(t Task, days int) Task {
+		t.CreatedAt = urgencyNow.AddDate(0, 0, -days)
+		return t
+	}
+	tagged := urgencyTask(PriorityLow, nil)
+	tagged.Tags = []string{"work", "urgent"}
+	done := urgencyTask(PriorityCritical, days(-10))
+	done.Status = StatusDone
+
+	tests := []struct {
+		name    string
+		task    Task
+		weights *UrgencyWeights
+		want    float64
+	}{
+		{"zero value", Task{}, nil, 4.0 / 3}, // default priority, no dates
+		{"lowest priority", urgencyTask(PriorityLow, nil), nil, 0},
+		{"medium priority", urgencyTask(PriorityMedium, nil), nil, 4.0 / 3},
+		{"highest priority", urgencyTask(PriorityCritical, nil), nil, 4},
+		{"due past the horizon", urgencyTask(PriorityLow, days(UrgencyDueDays+5)), nil, 0},
+		{"due in a week", urgencyTask(PriorityLow, days(7)), nil, 3},
+		{"due today", urgencyTask(PriorityLow, days(0)), nil, 6},
+		{"a week overdue", urgencyTask(PriorityLow, days(-7)), nil, 9},
+		{"overdue past the horizon", urgencyTask(PriorityLow, days(-40)), nil, 12},
+		{"half the age horizon", aged(urgencyTask(PriorityLow, nil), 15), nil, 0.5},
+		{"past the age horizon", aged(urgencyTask(PriorityLow, nil), 90), nil, 1},
+		{"tags don't count", tagged, nil, 0},
+		{"done", done, nil, 0},
+		{"all terms", aged(urgencyTask(PriorityHigh, days(-7)), 15), nil, 4*2.0/3 + 6*1.5 + 0.5},
+		{"configured weights", aged(urgencyTask(PriorityHigh, days(-7)), 15), &UrgencyWeights{Priority: 3, Due: 2, Age: 0}, 3*2.0/3 + 2*1.5},
+		{"priority only", urgencyTask(PriorityCritical, days(0)), &UrgencyWeights{Priority: 1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.weights != nil {
+				SetUrgencyWeights(*tt.weights)
+				defer SetUrgencyWeights(DefaultUrgencyWeights)
+			}
+			if got := tt.task.Urgency(urgencyNow); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Urgency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsEscalated(t *testing.T) {
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{"critical overdue", urgencyTask(PriorityCritical, days(-1)), true},
+		{"high overdue", urgencyTask(PriorityHigh, days(-1)), true},
+		{"medium overdue", urgencyTask(PriorityMedium, days(-1)), false},
+		{"high due today", urgencyTask(PriorityHigh, days(0)), false},
+		{"high without due date", urgencyTask(PriorityHigh, nil), false},
+	}
+	for _, tt := range tests {
+		if got := tt.task.IsEscalated(urgencyNow); got != tt.want {
+			t.Errorf("%s: IsEscalated() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
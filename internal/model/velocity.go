@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// estimates are the story points a task can be estimated at
+var estimates = []int{1, 2, 3, 5, 8, 13}
+
+// NextEstimate cycles to the next estimate, none after the largest
+func NextEstimate(e int) int {
+	for _, next := range estimates {
+		if next > e {
+			return next
+		}
+	}
+	return 0
+}
+
+// Velocity is what was planned in a sprint and what got done, in tasks and
+// in points
+type Velocity struct {
+	Sprint        Sprint
+	Done          int
+	DonePoints    int
+	Planned       int
+	PlannedPoints int
+}
+
+// SprintVelocities returns the velocity of the sprints that have started
+// by now, by start. Tasks rolled over to a later sprint count in the
+// sprint they were finished in.
+func SprintVelocities(tasks []Task, now time.Time) []Velocity {
+	var velocities []Velocity
+	for _, s := range sprints {
+		if s.IsUpcoming(now) {
+			continue
+		}
+		v := Velocity{Sprint: s}
+		for _, t := range tasks {
+			if !t.InSprint(s.Name) {
+				continue
+			}
+			v.Planned++
+			v.PlannedPoints += t.Estimate
+			if t.Status.IsDone() {
+				v.Done++
+				v.DonePoints += t.Estimate
+			}
+		}
+		velocities = append(velocities, v)
+	}
+	return velocities
+}
+
+// AverageVelocity returns the average done tasks and points of the last n
+// sprints that are over, with the number of sprints averaged
+func AverageVelocity(velocities []Velocity, now time.Time, n int) (done, points float64, count int) {
+	for i := len(velocities) - 1; i >= 0 && count < n; i-- {
+		v := velocities[i]
+		if !v.Sprint.IsOver(now) {
+			continue
+		}
+		done += float64(v.Done)
+		points += float64(v.DonePoints)
+		count++
+	}
+	if count == 0 {
+		return 0, 0, 0
+	}
+	return done / float64(count), points / float64(count), count
+}
@@ -0,0 +1,113 @@
+package model
+
+// Workflow statuses, in board order. The four built-in statuses can be
+// replaced by a user-defined list through SetWorkflow; each custom status
+// behaves as one of the built-in ones, its kind, for due dates, staleness,
+// completion and the 1-4 keys.
+var (
+	workflow      = []Status{StatusTodo, StatusInProgress, StatusBlocked, StatusDone}
+	statusKinds   = map[Status]Status{}
+	statusAliases = map[Status]Status{}
+	builtinKinds  = []Status{StatusTodo, StatusInProgress, StatusBlocked, StatusDone}
+)
+
+// SetWorkflow replaces the ordered list of statuses, one kanban column
+// each
+func SetWorkflow(statuses []Status) {
+	if len(statuses) == 0 {
+		return
+	}
+	workflow = statuses
+}
+
+// SetStatusKind sets the built-in status a custom status behaves as
+func SetStatusKind(s, kind Status) {
+	statusKinds[s] = kind
+}
+
+// SetStatusAlias maps a legacy status value to a workflow status, so
+// tasks stored with the old value are migrated on load
+func SetStatusAlias(from, to Status) {
+	statusAliases[from] = to
+}
+
+// AllStatuses returns the workflow statuses, in board order
+func AllStatuses() []Status {
+	return append([]Status(nil), workflow...)
+}
+
+// IsKind returns true if s is one of the built-in statuses, which custom
+// statuses take as kind
+func (s Status) IsKind() bool {
+	for _, k := range builtinKinds {
+		if k == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Kind returns the built-in status s behaves as: the configured kind, s
+// itself for a built-in status, todo otherwise
+func (s Status) Kind() Status {
+	if k, ok := statusKinds[s]; ok {
+		return k
+	}
+	if s.IsKind() {
+		return s
+	}
+	return StatusTodo
+}
+
+// IsDone returns true if s closes the task
+func (s Status) IsDone() bool {
+	return s.Kind() == StatusDone
+}
+
+// Index returns the index of the status in the workflow, or -1 if it is
+// not a workflow status
+func (s Status) Index() int {
+	for i, w := range workflow {
+		if w == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// StatusOfKind returns the first workflow status of a kind, used when a
+// task is marked done, blocked... without choosing a column. The kind
+// itself is returned when no workflow status has it.
+func StatusOfKind(kind Status) Status {
+	for _, s := range workflow {
+		if s.Kind() == kind {
+			return s
+		}
+	}
+	return kind
+}
+
+// MigrateStatus returns the workflow status a stored status value maps
+// to: its alias, or the first status of its kind for a built-in status
+// left out of the workflow. Other unknown values are kept as is.
+func MigrateStatus(s Status) Status {
+	if to, ok := statusAliases[s]; ok {
+		return to
+	}
+	if s.Index() < 0 && s.IsKind() {
+		return StatusOfKind(s)
+	}
+	return s
+}
+
+// Column returns the index of the kanban column of a status: its own, or
+// for a value outside the workflow the first column of its kind, else of
+// the todo kind
+func (s Status) Column() int {
+	for _, kind := range []Status{s, s.Kind(), StatusTodo} {
+		if i := StatusOfKind(kind).Index(); i >= 0 {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+// Package notify shows the reminders of the tasks as desktop
+// notifications, with a snooze action where the platform supports it.
+package notify
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnsupported is returned when no notification tool is available
+var ErrUnsupported = errors.New("notifications non disponibles (notify-send ou osascript requis)")
+
+// Notification is a desktop notification
+type Notification struct {
+	Title string
+	Body  string
+	// Snooze labels the snooze action; none when empty
+	Snooze string
+}
+
+// Notifier shows notifications
+type Notifier interface {
+	// Notify shows a notification. With a snooze action it may wait for
+	// the notification to be closed, and returns true if it was snoozed.
+	Notify(n Notification) (bool, error)
+}
+
+// New returns the notifier of the platform: notify-send on Linux and BSD,
+// osascript on macOS
+func New() (Notifier, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		if path, err := exec.LookPath("osascript"); err == nil {
+			return osascript{path}, nil
+		}
+	case "windows":
+	default:
+		if path, err := exec.LookPath("notify-send"); err == nil {
+			return newNotifySend(path), nil
+		}
+	}
+	return nil, ErrUnsupported
+}
+
+// notifySend uses libnotify's notify-send, whose actions (0.7.9 and
+// later) make snoozing possible
+type notifySend struct {
+	path    string
+	actions bool
+}
+
+func newNotifySend(path string) notifySend {
+	help, _ := exec.Command(path, "--help").CombinedOutput()
+	return notifySend{path: path, actions: strings.Contains(string(help), "--action")}
+}
+
+func (s notifySend) Notify(n Notification) (bool, error) {
+	args := []string{"--app-name=lazy-todo"}
+	if n.Snooze != "" && s.actions {
+		// An action makes notify-send wait and print the chosen one
+		args = append(args, "--action=snooze="+n.Snooze)
+	}
+	out, err := exec.Command(s.path, append(args, "--", n.Title, n.Body)...).Output()
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) == "snooze", nil
+}
+
+// osascript shows notifications through AppleScript, without actions
+type osascript struct {
+	path string
+}
+
+func (o osascript) Notify(n Notification) (bool, error) {
+	script := "display notification " + appleString(n.Body) + " with title " + appleString(n.Title)
+	return false, exec.Command(o.path, "-e", script).Run()
+}
+
+// appleString quotes s as an AppleScript string
+func appleString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"sync"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+)
+
+// DefaultSnooze is how long a snoozed reminder is postponed
+const DefaultSnooze = 10 * time.Minute
+
+// Reminders shows the reminders of the tasks of a file as they go off
+type Reminders struct {
+	store    *storage.Storage
+	notifier Notifier
+	snooze   time.Duration
+	// Notifications waiting for an action
+	open sync.WaitGroup
+}
+
+// NewReminders returns the reminders of a tasks file, postponed by snooze
+// when snoozed (DefaultSnooze when zero)
+func NewReminders(store *storage.Storage, notifier Notifier, snooze time.Duration) *Reminders {
+	if snooze <= 0 {
+		snooze = DefaultSnooze
+	}
+	return &Reminders{store: store, notifier: notifier, snooze: snooze}
+}
+
+// Check shows the reminders that went off and marks them notified. It
+// returns the number of notifications shown.
+func (r *Reminders) Check(now time.Time) (int, error) {
+	tasks, err := r.store.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	var changes storage.Changes
+	var notifications []Notification
+	var refs []reminderRef
+	for _, t := range tasks {
+		pending := t.PendingReminders(now)
+		if len(pending) == 0 {
+			continue
+		}
+		t.Reminders = append([]model.Reminder(nil), t.Reminders...)
+		for _, i := range pending {
+			t.Reminders[i].Notified = &now
+			t.Reminders[i].Snoozed = nil
+		}
+		changes.Updated = append(changes.Updated, t)
+		// Reminders going off together make one notification
+		notifications = append(notifications, r.notification(t))
+		refs = append(refs, reminderRef{t.ID, pending})
+	}
+	if changes.IsEmpty() {
+		return 0, nil
+	}
+	// Marked before showing, so that a notification waiting for an
+	// action isn't shown again
+	if _, err := r.store.Commit(changes); err != nil {
+		return 0, err
+	}
+
+	for i, n := range notifications {
+		r.open.Add(1)
+		go func(n Notification, ref reminderRef) {
+			defer r.open.Done()
+			if snoozed, err := r.notifier.Notify(n); err == nil && snoozed {
+				r.snoozeReminders(ref)
+			}
+		}(n, refs[i])
+	}
+	return len(notifications), nil
+}
+
+// Wait waits for the notifications still open to be closed or snoozed
+func (r *Reminders) Wait() {
+	r.open.Wait()
+}
+
+// reminderRef identifies reminders of a task, by index
+type reminderRef struct {
+	taskID  string
+	indices []int
+}
+
+// snoozeReminders postpones snoozed reminders
+func (r *Reminders) snoozeReminders(ref reminderRef) {
+	tasks, err := r.store.Load()
+	if err != nil {
+		return
+	}
+	until := time.Now().Add(r.snooze)
+	for _, t := range tasks {
+		if t.ID != ref.taskID {
+			continue
+		}
+		t.Reminders = append([]model.Reminder(nil), t.Reminders...)
+		for _, i := range ref.indices {
+			// The reminders may have been edited meanwhile
+			if i < len(t.Reminders) {
+				t.Reminders[i].Snoozed = &until
+			}
+		}
+		r.store.Commit(storage.Changes{Updated: []model.Task{t}})
+		return
+	}
+}
+
+// notification returns the notification of a task's reminders
+func (r *Reminders) notification(t model.Task) Notification {
+	body := i18n.T("Rappel")
+	if t.DueDate != nil {
+		body = i18n.Tf("Échéance: %s", t.DueDate.Format("2006-01-02"))
+	}
+	return Notification{
+		Title:  t.Title,
+		Body:   body,
+		Snooze: i18n.Tf("Rappeler dans %s", model.Reminder{Before: r.snooze}),
+	}
+}
@@ -0,0 +1,223 @@
+// Package parse reads task metadata typed inline, for fast capture.
+package parse
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+// QuickAdd is a task line split into its title and inline metadata
+type QuickAdd struct {
+	Title    string
+	Tags     []string
+	Priority model.Priority // empty when not given
+	Due      *time.Time     // midnight of the due day, nil when not given
+	Estimate int            // story points, 0 when not given
+}
+
+// ParseQuickAdd splits a line like `Fix login bug #backend !high @friday`
+// into a title and metadata:
+//
+//	#tag       adds a tag
+//	!priority  sets the priority (value or label: !high, !haute)
+//	@date      sets the due date: today, tomorrow, a weekday (the next
+//	           one, today included), +3d / +2w, or 2026-10-20
+//	~points    sets the estimate in story points (~3)
+//
+// Words that don't parse as metadata, like an email address, stay in the
+// title; a leading backslash keeps a word as is (\#1).
+func ParseQuickAdd(line string, now time.Time) QuickAdd {
+	var q QuickAdd
+	var title []string
+	for _, word := range strings.Fields(line) {
+		if rest, ok := strings.CutPrefix(word, `\`); ok && rest != "" {
+			title = append(title, rest)
+			continue
+		}
+
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			q.Tags = appendTag(q.Tags, word[1:])
+			continue
+		case len(word) > 1 && word[0] == '!':
+			if p, ok := parsePriority(word[1:]); ok {
+				q.Priority = p
+				continue
+			}
+		case len(word) > 1 && word[0] == '@':
+			if due, ok := ParseDate(word[1:], now); ok {
+				q.Due = &due
+				continue
+			}
+		case len(word) > 1 && word[0] == '~':
+			if n, err := strconv.Atoi(word[1:]); err == nil && n > 0 {
+				q.Estimate = n
+				continue
+			}
+		}
+		title = append(title, word)
+	}
+	q.Title = strings.Join(title, " ")
+	return q
+}
+
+// Apply sets the parsed title and metadata on a task
+func (q QuickAdd) Apply(t *model.Task) {
+	t.Title = q.Title
+	for _, tag := range q.Tags {
+		t.Tags = appendTag(t.Tags, tag)
+	}
+	if q.Priority != "" {
+		t.Priority = q.Priority
+	}
+	if q.Due != nil {
+		t.DueDate = q.Due
+	}
+	if q.Estimate > 0 {
+		t.Estimate = q.Estimate
+	}
+}
+
+// Format returns a task as a quick-add line, which ParseQuickAdd reads
+// back: `Fix login bug #backend !high @2026-10-20`
+func Format(t model.Task) string {
+	var words []string
+	for _, word := range strings.Fields(t.Title) {
+		// Keep the title's words that would read as metadata
+		if strings.ContainsRune(`#!@~\`, rune(word[0])) && len(word) > 1 {
+			word = `\` + word
+		}
+		words = append(words, word)
+	}
+	for _, tag := range t.Tags {
+		words = append(words, "#"+tag)
+	}
+	if t.Priority != "" {
+		words = append(words, "!"+string(t.Priority))
+	}
+	if t.DueDate != nil {
+		words = append(words, "@"+t.DueDate.Format("2006-01-02"))
+	}
+	if t.Estimate > 0 {
+		words = append(words, "~"+strconv.Itoa(t.Estimate))
+	}
+	return strings.Join(words, " ")
+}
+
+// FromSearch returns the quick-add line of a search, to create the task
+// it didn't find: its words as typed, its tags as #tag and a single
+// priority as !priority. The other fields and the negated terms are left
+// out.
+func FromSearch(search string) string {
+	var words []string
+	for _, word := range strings.Fields(search) {
+		q := model.ParseQuery(word)
+		if len(q) != 1 || q[0].Negate {
+			continue
+		}
+		switch term := q[0]; term.Field {
+		case model.FieldText:
+			word = strings.Trim(word, `"`)
+			if word != "" && strings.ContainsRune(`!@~\`, rune(word[0])) && len(word) > 1 {
+				word = `\` + word
+			}
+			words = append(words, word)
+		case model.FieldTag:
+			for _, tag := range term.Values {
+				words = append(words, "#"+tag)
+			}
+		case model.FieldPriority:
+			if len(term.Values) == 1 {
+				words = append(words, "!"+term.Values[0])
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Capture creates a task from a quick-add line, with the given tags added
+// to the inline ones. It returns false if the line has no title.
+func Capture(line string, tags []string, now time.Time) (model.Task, bool) {
+	q := ParseQuickAdd(line, now)
+	if q.Title == "" {
+		return model.Task{}, false
+	}
+	q.Tags = append(q.Tags, tags...)
+	task := model.NewTask("")
+	q.Apply(&task)
+	return task, true
+}
+
+// appendTag adds a tag unless already present
+func appendTag(tags []string, tag string) []string {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
+
+// parsePriority returns the priority level designated by its value, a
+// legacy alias or its label, ignoring case
+func parsePriority(s string) (model.Priority, bool) {
+	p := model.MigratePriority(model.Priority(strings.ToLower(s)))
+	for _, level := range model.AllPriorities() {
+		if level == p || strings.EqualFold(level.Label(), s) {
+			return level, true
+		}
+	}
+	return "", false
+}
+
+// weekdays maps English and French weekday names and abbreviations
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday, "dimanche": time.Sunday, "dim": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday, "lundi": time.Monday, "lun": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "mardi": time.Tuesday, "mar": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "mercredi": time.Wednesday, "mer": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "jeudi": time.Thursday, "jeu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "vendredi": time.Friday, "ven": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "samedi": time.Saturday, "sam": time.Saturday,
+}
+
+// ParseDate parses a due date relative to now, in English or French:
+// today, tomorrow, a weekday, +Nd, +Nw or an ISO date (2026-10-20). It
+// returns midnight of the day, in now's location.
+func ParseDate(s string, now time.Time) (time.Time, bool) {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+
+	switch s = strings.ToLower(s); s {
+	case "today", "aujourd'hui", "aujourdhui", "auj":
+		return today, true
+	case "tomorrow", "tmr", "demain":
+		return today.AddDate(0, 0, 1), true
+	}
+
+	if day, ok := weekdays[s]; ok {
+		return today.AddDate(0, 0, (int(day)-int(today.Weekday())+7)%7), true
+	}
+
+	if rest, ok := strings.CutPrefix(s, "+"); ok && len(rest) > 1 {
+		n, err := strconv.Atoi(rest[:len(rest)-1])
+		if err != nil || n < 0 {
+			return time.Time{}, false
+		}
+		switch rest[len(rest)-1] {
+		case 'd', 'j':
+			return today.AddDate(0, 0, n), true
+		case 'w', 's':
+			return today.AddDate(0, 0, 7*n), true
+		}
+		return time.Time{}, false
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return date, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,75 @@
+package parse
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+// reminderUnits are the units of the durations before the due date, in
+// English and French
+var reminderUnits = map[byte]time.Duration{
+	'w': 7 * 24 * time.Hour, 's': 7 * 24 * time.Hour,
+	'd': 24 * time.Hour, 'j': 24 * time.Hour,
+	'h': time.Hour,
+	'm': time.Minute,
+}
+
+// ParseReminder parses a reminder typed in the task form: a duration
+// before the due date made of numbers and units (1d, 2h30m, 1w; j and s in
+// French), or a date as accepted by ParseDate followed by an optional
+// time (2026-10-20 14:30, tomorrow 8:00), at ReminderHour without time
+func ParseReminder(s string, now time.Time) (model.Reminder, bool) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if before, ok := parseBefore(strings.TrimPrefix(s, "-")); ok {
+		return model.Reminder{Before: before}, true
+	}
+
+	day, clock, _ := strings.Cut(s, " ")
+	date, ok := ParseDate(day, now)
+	if !ok {
+		return model.Reminder{}, false
+	}
+	hour, minute := model.ReminderHour, 0
+	if clock = strings.TrimSpace(clock); clock != "" {
+		t, err := time.Parse("15:04", clock)
+		if err != nil {
+			if t, err = time.Parse("15h04", clock); err != nil {
+				return model.Reminder{}, false
+			}
+		}
+		hour, minute = t.Hour(), t.Minute()
+	}
+	at := date.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	return model.Reminder{At: &at}, true
+}
+
+// parseBefore parses a duration like 1d12h
+func parseBefore(s string) (time.Duration, bool) {
+	if s == "" {
+		return 0, false
+	}
+	var total time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return 0, false
+		}
+		unit, ok := reminderUnits[s[i]]
+		if !ok {
+			return 0, false
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, false
+		}
+		total += time.Duration(n) * unit
+		s = s[i+1:]
+	}
+	return total, true
+}
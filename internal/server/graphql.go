@@ -0,0 +1,394 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"lazy-todo/internal/graphql"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+)
+
+// Timing of GraphQL subscriptions: the operation is run again every
+// subscriptionInterval, and a comment keeps idle streams open through
+// proxies
+const (
+	subscriptionInterval  = time.Second
+	subscriptionKeepAlive = 15 * time.Second
+)
+
+// ErrNoSubscriptionStream is returned when the connection can't stream
+var ErrNoSubscriptionStream = errors.New("Les abonnements ne sont pas pris en charge par cette connexion")
+
+// graphQLTypes are the object types of the schema; their fields are the
+// JSON fields of the REST API
+var graphQLTypes = map[string]map[string]string{
+	"TaskPage": {"tasks": "[Task]", "total": "Int", "next_offset": "Int"},
+	"Task": {
+		"id": "String", "short_id": "String", "number": "Int", "title": "String",
+		"description": "String", "priority": "String", "status": "String", "blocked_reason": "String",
+		"severity": "String", "tags": "[String]", "subtasks": "[Subtask]",
+		"notes": "[Note]", "history": "[Change]", "due_date": "String",
+		"reminders": "[Reminder]", "order": "Int", "sprint": "String",
+		"estimate": "Int", "depends_on": "[String]", "private": "Boolean",
+		"created_at": "String", "updated_at": "String", "external": "String",
+	},
+	"Subtask":  {"title": "String", "done": "Boolean"},
+	"Note":     {"at": "String", "text": "String"},
+	"Change":   {"at": "String", "field": "String", "from": "String", "to": "String"},
+	"Reminder": {"at": "String", "before": "Int", "snoozed": "String", "notified": "String"},
+	"Tag":      {"name": "String", "count": "Int", "open": "Int", "color": "String"},
+	"Stats": {
+		"total": "Int", "open": "Int", "done": "Int", "overdue": "Int",
+		"estimate_open": "Int", "by_status": "[Count]", "by_priority": "[Count]",
+	},
+	"Count": {"value": "String", "count": "Int"},
+	"HistoryEntry": {
+		"task_id": "String", "short_id": "String", "title": "String",
+		"at": "String", "field": "String", "from": "String", "to": "String",
+	},
+}
+
+// graphQLSchema returns the schema of the server. Subscriptions have the
+// same fields as queries: they are run again on each change.
+func (s *Server) graphQLSchema() *graphql.Schema {
+	roots := map[string]graphql.RootField{
+		"tasks": {
+			Type:    "TaskPage",
+			Args:    map[string]string{"q": "String", "limit": "Int", "offset": "Int"},
+			Resolve: s.resolveTasks,
+		},
+		"task": {
+			Type:    "Task",
+			Args:    map[string]string{"id": "String!"},
+			Resolve: s.resolveTask,
+		},
+		"tags":    {Type: "[Tag]", Resolve: s.resolveTags},
+		"stats":   {Type: "Stats", Args: map[string]string{"q": "String"}, Resolve: s.resolveStats},
+		"history": {Type: "[HistoryEntry]", Args: map[string]string{"limit": "Int"}, Resolve: s.resolveHistory},
+	}
+	return &graphql.Schema{Query: roots, Subscription: roots, Types: graphQLTypes}
+}
+
+// resolveTasks returns a page of the tasks matching q, like GET /tasks
+func (s *Server) resolveTasks(ctx context.Context, args map[string]any) (any, error) {
+	q, err := graphql.String(args, "q")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := graphql.Int(args, "limit", defaultPageSize)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := graphql.Int(args, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxPageSize || offset < 0 {
+		return nil, ErrBadRequest
+	}
+	matched, err := s.matchingTasks(contextScope(ctx), q)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []any{}
+	end := min(offset+limit, len(matched))
+	for _, t := range matched[min(offset, end):end] {
+		m, err := taskJSON(t, nil)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, m)
+	}
+	page := map[string]any{"tasks": tasks, "total": len(matched), "next_offset": nil}
+	if end < len(matched) {
+		page["next_offset"] = end
+	}
+	return page, nil
+}
+
+// resolveTask returns the task of an ID, short ID or number; null when
+// there's none the token sees
+func (s *Server) resolveTask(ctx context.Context, args map[string]any) (any, error) {
+	ref, err := graphql.String(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.store.Load(); err != nil {
+		return nil, err
+	}
+	index := s.store.Index()
+	i := index.Find(ref)
+	if i < 0 {
+		return nil, nil
+	}
+	task := index.Tasks()[i]
+	if task.Private && !contextScope(ctx).SeesPrivate() {
+		return nil, nil
+	}
+	return taskJSON(task, nil)
+}
+
+// resolveTags returns the tags of the tasks with their number and color,
+// most used first
+func (s *Server) resolveTags(ctx context.Context, args map[string]any) (any, error) {
+	tasks, err := s.matchingTasks(contextScope(ctx), "")
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	open := map[string]int{}
+	for _, t := range tasks {
+		for _, tag := range t.Tags {
+			counts[tag]++
+			if !t.Status.IsDone() {
+				open[tag]++
+			}
+		}
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	colors := s.store.TagColors()
+	tags := make([]any, 0, len(names))
+	for _, name := range names {
+		tag := map[string]any{"name": name, "count": counts[name], "open": open[name], "color": nil}
+		if color, ok := colors[name]; ok {
+			tag["color"] = color
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// resolveStats counts the tasks matching q by status and priority, in the
+// order of the workflow and of the priority levels
+func (s *Server) resolveStats(ctx context.Context, args map[string]any) (any, error) {
+	q, err := graphql.String(args, "q")
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.matchingTasks(contextScope(ctx), q)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	byStatus := map[model.Status]int{}
+	byPriority := map[model.Priority]int{}
+	done, overdue, estimate := 0, 0, 0
+	for _, t := range tasks {
+		byStatus[t.Status]++
+		byPriority[t.Priority]++
+		if t.Status.IsDone() {
+			done++
+			continue
+		}
+		estimate += t.Estimate
+		if t.IsOverdue(now) {
+			overdue++
+		}
+	}
+	statuses := []any{}
+	for _, st := range model.AllStatuses() {
+		statuses = append(statuses, map[string]any{"value": string(st), "count": byStatus[st]})
+	}
+	priorities := []any{}
+	for _, p := range slices.Backward(model.AllPriorities()) {
+		priorities = append(priorities, map[string]any{"value": string(p), "count": byPriority[p]})
+	}
+	return map[string]any{
+		"total":         len(tasks),
+		"open":          len(tasks) - done,
+		"done":          done,
+		"overdue":       overdue,
+		"estimate_open": estimate,
+		"by_status":     statuses,
+		"by_priority":   priorities,
+	}, nil
+}
+
+// resolveHistory returns the last changes of status and priority of the
+// tasks, most recent first
+func (s *Server) resolveHistory(ctx context.Context, args map[string]any) (any, error) {
+	limit, err := graphql.Int(args, "limit", defaultPageSize)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxPageSize {
+		return nil, ErrBadRequest
+	}
+	tasks, err := s.matchingTasks(contextScope(ctx), "")
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		task   model.Task
+		change model.Change
+	}
+	var entries []entry
+	for _, t := range tasks {
+		for _, c := range t.History {
+			entries = append(entries, entry{t, c})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].change.At.After(entries[j].change.At)
+	})
+
+	history := []any{}
+	for _, e := range entries[:min(limit, len(entries))] {
+		history = append(history, map[string]any{
+			"task_id":  e.task.ID,
+			"short_id": e.task.ShortID(),
+			"title":    e.task.Title,
+			"at":       e.change.At.Format(time.RFC3339),
+			"field":    e.change.Field,
+			"from":     e.change.From,
+			"to":       e.change.To,
+		})
+	}
+	return history, nil
+}
+
+// graphQLRequest is a GraphQL request, as the JSON body of POST /graphql
+// or the parameters of GET /graphql
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName"`
+}
+
+// handleGraphQL runs a GraphQL operation. Queries are answered with their
+// result; subscriptions stream a result as a server-sent event ("next")
+// each time it changes, until the client disconnects.
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	req, err := readGraphQLRequest(w, r)
+	if err != nil {
+		writeGraphQLError(w, http.StatusBadRequest, err)
+		return
+	}
+	doc, err := graphql.Parse(req.Query)
+	if err != nil {
+		writeGraphQLError(w, http.StatusBadRequest, err)
+		return
+	}
+	op, err := doc.Operation(req.OperationName)
+	if err != nil {
+		writeGraphQLError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result := s.schema.Execute(r.Context(), op, req.Variables)
+	if result.Data == nil {
+		writeJSON(w, http.StatusBadRequest, result)
+		return
+	}
+	if op.Type != "subscription" {
+		writeJSON(w, http.StatusOK, result)
+		return
+	}
+	s.subscribe(w, r, op, req.Variables, result)
+}
+
+// subscribe streams the results of a subscription as server-sent events,
+// starting with first
+func (s *Server) subscribe(w http.ResponseWriter, r *http.Request, op *graphql.Operation, vars map[string]any, first graphql.Result) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeGraphQLError(w, http.StatusInternalServerError, ErrNoSubscriptionStream)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(subscriptionInterval)
+	defer ticker.Stop()
+	var last []byte
+	sent := time.Now()
+	result := first
+	for {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return
+		}
+		switch {
+		case !bytes.Equal(data, last):
+			fmt.Fprintf(w, "event: next\ndata: %s\n\n", data)
+			last, sent = data, time.Now()
+			flusher.Flush()
+		case time.Since(sent) >= subscriptionKeepAlive:
+			io.WriteString(w, ":\n\n")
+			sent = time.Now()
+			flusher.Flush()
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+		result = s.schema.Execute(r.Context(), op, vars)
+	}
+}
+
+// readGraphQLRequest reads a GraphQL request from the JSON body of a POST
+// or the ?query=, ?variables= and ?operationName= of a GET
+func readGraphQLRequest(w http.ResponseWriter, r *http.Request) (graphQLRequest, error) {
+	var req graphQLRequest
+	if r.Method == http.MethodGet {
+		params := r.URL.Query()
+		req.Query = params.Get("query")
+		req.OperationName = params.Get("operationName")
+		if v := params.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				return req, ErrBadRequest
+			}
+		}
+		return req, nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		return req, err
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/graphql" {
+		req.Query = string(body)
+		return req, nil
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return req, ErrBadRequest
+	}
+	return req, nil
+}
+
+// writeGraphQLError writes an error as a GraphQL result without data
+func writeGraphQLError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, graphql.Result{Errors: []graphql.Error{{Message: i18n.T(err.Error())}}})
+}
+
+// handleGraphQLSchema serves the schema in the GraphQL schema language
+func (s *Server) handleGraphQLSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, s.schema.SDL())
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned to clients making too many requests
+var ErrRateLimited = errors.New("Trop de requêtes, réessayez plus tard")
+
+// maxClients is the number of clients tracked before the idle ones are
+// forgotten
+const maxClients = 1024
+
+// rateLimiter gives each client, by IP address, a bucket of perMinute
+// requests refilled over a minute
+type rateLimiter struct {
+	perMinute int
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+}
+
+// bucket is the requests left to a client
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a limiter of perMinute requests per client; nil,
+// which lets every request through, when perMinute isn't positive
+func newRateLimiter(perMinute int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{perMinute: perMinute, buckets: make(map[string]*bucket)}
+}
+
+// allow takes a request from the bucket of a client. When it's empty, it
+// returns the time until the next request is allowed.
+func (l *rateLimiter) allow(client string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rate := float64(l.perMinute) / float64(time.Minute)
+	b, ok := l.buckets[client]
+	if !ok {
+		if len(l.buckets) >= maxClients {
+			l.forgetIdle(now)
+		}
+		b = &bucket{tokens: float64(l.perMinute), last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = min(float64(l.perMinute), b.tokens+float64(now.Sub(b.last))*rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rate)
+	}
+	b.tokens--
+	return true, 0
+}
+
+// forgetIdle drops the buckets refilled since, as new ones would be
+func (l *rateLimiter) forgetIdle(now time.Time) {
+	for client, b := range l.buckets {
+		if now.Sub(b.last) >= time.Minute {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// limit rejects the requests of clients past the rate limit with 429 Too
+// Many Requests and the seconds to wait in Retry-After
+func (l *rateLimiter) limit(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			client = r.RemoteAddr
+		}
+		if ok, wait := l.allow(client, time.Now()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			writeError(w, http.StatusTooManyRequests, ErrRateLimited)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,264 @@
+// Package server exposes the tasks file over HTTP, for voice assistants
+// and other tools that can't run the command line.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/export"
+	"lazy-todo/internal/graphql"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/parse"
+	"lazy-todo/internal/storage"
+)
+
+// OpenAPI is the OpenAPI document of the server, served as /openapi.yaml;
+// keep it and internal/client in step with the handlers
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
+
+// maxBodySize limits the size of request bodies
+const maxBodySize = 64 << 10
+
+// Errors returned to the clients
+var (
+	ErrUnauthorized = errors.New("Jeton d'accès manquant ou invalide")
+	ErrEmptyText    = errors.New("Le texte de la tâche est vide")
+	ErrBadRequest   = errors.New("Requête invalide")
+	ErrForbidden    = errors.New("Ce jeton ne permet pas d'ajouter des tâches")
+)
+
+// Server serves the tasks of a storage
+type Server struct {
+	store   *storage.Storage
+	tokens  []config.ServerToken // server.token first, with the full scope
+	tags    []string             // added to captured tasks
+	ics     config.ICSConfig
+	limiter *rateLimiter
+	schema  *graphql.Schema // nil unless server.graphql is set
+	ui      string          // mode of the web page, see config.ServerUIRead
+	origins []string        // pages of other sites allowed on /ws
+}
+
+// New creates a server for the given storage
+func New(store *storage.Storage, cfg *config.Config) *Server {
+	var tokens []config.ServerToken
+	if cfg.Server.Token != "" {
+		tokens = append(tokens, config.ServerToken{Token: cfg.Server.Token, Scope: config.ScopeFull})
+	}
+	for _, t := range cfg.Server.Tokens {
+		if t.Token != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	rateLimit := cfg.Server.RateLimit
+	if rateLimit == 0 {
+		rateLimit = config.DefaultRateLimit
+	}
+	s := &Server{
+		store:   store,
+		tokens:  tokens,
+		tags:    cfg.Capture.Tags,
+		ics:     cfg.ICS,
+		limiter: newRateLimiter(rateLimit),
+		origins: cfg.Server.Origins,
+	}
+	if cfg.Server.GraphQL {
+		s.schema = s.graphQLSchema()
+	}
+	s.ui = cfg.Server.UI
+	if s.ui == "" {
+		s.ui = config.ServerUIRead
+	}
+	return s
+}
+
+// Handler returns the HTTP handler of the server. Clients are rate limited
+// before being authenticated, so that tokens can't be guessed quickly; the
+// OpenAPI document and the files of the web page are public.
+func (s *Server) Handler() http.Handler {
+	api := http.NewServeMux()
+	api.HandleFunc("POST /capture", s.handleCapture)
+	api.HandleFunc("GET /calendar.ics", s.handleCalendar)
+	api.HandleFunc("GET /tasks", s.handleTasks)
+	api.HandleFunc("PATCH /tasks/{id}", s.handleUpdateTask)
+	api.HandleFunc("GET /ws", s.handleWebSocket)
+	if s.schema != nil {
+		api.HandleFunc("GET /graphql", s.handleGraphQL)
+		api.HandleFunc("POST /graphql", s.handleGraphQL)
+		api.HandleFunc("GET /graphql/schema.graphql", s.handleGraphQLSchema)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /openapi.yaml", handleOpenAPI)
+	if s.ui != config.ServerUIOff {
+		mux.Handle("GET /ui/", s.webHandler())
+	}
+	mux.Handle("/", s.authenticate(api))
+	return s.limiter.limit(mux)
+}
+
+// handleOpenAPI serves the OpenAPI document of the server
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(OpenAPI)
+}
+
+// scopeKey is the context key of the scope of the request's token
+type scopeKey struct{}
+
+// authenticate rejects requests without one of the configured bearer
+// tokens, and passes the scope of the token on in the context. The token
+// can also be given as ?token=, for calendar apps that subscribe to a URL
+// without headers.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(s.tokens) > 0 {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				token = r.URL.Query().Get("token")
+				ok = token != ""
+			}
+			scope, known := s.scope(token)
+			if !ok || !known {
+				writeError(w, http.StatusUnauthorized, ErrUnauthorized)
+				return
+			}
+			r = r.WithContext(context.WithValue(r.Context(), scopeKey{}, scope))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// scope returns the scope of a token, comparing it with every configured
+// token in constant time
+func (s *Server) scope(token string) (config.TokenScope, bool) {
+	var scope config.TokenScope
+	known := false
+	for _, t := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t.Token)) == 1 && !known {
+			scope, known = t.Scope, true
+		}
+	}
+	return scope, known
+}
+
+// requestScope returns the scope of the token of a request; the full scope
+// when the server doesn't authenticate
+func requestScope(r *http.Request) config.TokenScope {
+	return contextScope(r.Context())
+}
+
+// contextScope returns the scope passed in a request context
+func contextScope(ctx context.Context) config.TokenScope {
+	scope, _ := ctx.Value(scopeKey{}).(config.TokenScope)
+	return scope
+}
+
+// visibleTasks drops the private tasks when the scope doesn't see them
+func visibleTasks(tasks []model.Task, scope config.TokenScope) []model.Task {
+	if scope.SeesPrivate() {
+		return tasks
+	}
+	var visible []model.Task
+	for _, t := range tasks {
+		if !t.Private {
+			visible = append(visible, t)
+		}
+	}
+	return visible
+}
+
+// captureRequest is the JSON body of POST /capture
+type captureRequest struct {
+	Text string `json:"text"`
+}
+
+// captureResponse is returned for a captured task
+type captureResponse struct {
+	ID      string `json:"id"`
+	ShortID string `json:"short_id,omitempty"`
+	Title   string `json:"title"`
+}
+
+// handleCapture creates a task from a quick-add line (#tag !priority
+// @date), sent as JSON ({"text": "..."}) or as plain text, and returns
+// its ID and short ID
+func (s *Server) handleCapture(w http.ResponseWriter, r *http.Request) {
+	if !requestScope(r).CanWrite() {
+		writeError(w, http.StatusForbidden, ErrForbidden)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+
+	text := string(body)
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+		var req captureRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, ErrBadRequest)
+			return
+		}
+		text = req.Text
+	}
+
+	task, ok := parse.Capture(text, s.tags, time.Now())
+	if !ok {
+		writeError(w, http.StatusBadRequest, ErrEmptyText)
+		return
+	}
+	tasks, err := s.store.AddTask(task)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if i := model.FindTask(tasks, task.ID); i >= 0 {
+		task = tasks[i]
+	}
+	writeJSON(w, http.StatusCreated, captureResponse{ID: task.ID, ShortID: task.ShortID(), Title: task.Title})
+}
+
+// handleCalendar serves the iCalendar feed of the tasks with a due date,
+// for calendar apps to subscribe to; without the private tasks for a
+// restricted token
+func (s *Server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.store.Load()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	data, err := export.ICS(visibleTasks(tasks, requestScope(r)), s.ics, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Write(data)
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error as {"error": "..."}, in the interface language
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": i18n.T(err.Error())})
+}
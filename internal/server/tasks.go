@@ -0,0 +1,265 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+)
+
+// Page sizes of GET /tasks
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// Errors of the task endpoints
+var (
+	ErrUnknownField  = errors.New("Champ inconnu dans fields")
+	ErrTaskNotFound  = errors.New("Tâche introuvable")
+	ErrUnknownStatus = errors.New("Statut inconnu")
+	ErrReadOnlyToken = errors.New("Ce jeton ne permet pas de modifier des tâches")
+)
+
+// shortIDField is the short ID of a task, added to its JSON fields
+const shortIDField = "short_id"
+
+// taskFields are the JSON fields of a task, for ?fields=
+var taskFields = jsonFields(reflect.TypeFor[model.Task](), shortIDField)
+
+// tasksResponse is a page of tasks returned by GET /tasks
+type tasksResponse struct {
+	Tasks []map[string]any `json:"tasks"`
+	// Total is the number of tasks matching the query, on every page
+	Total int `json:"total"`
+	// NextOffset is the offset of the next page; none on the last one
+	NextOffset int `json:"next_offset,omitempty"`
+}
+
+// handleTasks lists the tasks matching ?q= (the query language of the
+// search), a page at a time: ?limit= tasks (defaultPageSize, up to
+// maxPageSize) from ?offset=. ?fields=id,title,status keeps those fields
+// of the tasks. The response has an ETag, so that a dashboard polling with
+// If-None-Match gets 304 Not Modified until the page changes.
+func (s *Server) handleTasks(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	limit, offset, ok := pageParams(params)
+	if !ok {
+		writeError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+	fields, ok := selectedFields(params.Get("fields"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, ErrUnknownField)
+		return
+	}
+
+	matched, err := s.matchingTasks(requestScope(r), params.Get("q"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := tasksResponse{Total: len(matched), Tasks: []map[string]any{}}
+	end := min(offset+limit, len(matched))
+	for _, t := range matched[min(offset, end):end] {
+		m, err := taskJSON(t, fields)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		resp.Tasks = append(resp.Tasks, m)
+	}
+	if end < len(matched) {
+		resp.NextOffset = end
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// updateRequest is the JSON body of PATCH /tasks/{id}
+type updateRequest struct {
+	Status model.Status `json:"status"`
+}
+
+// handleUpdateTask changes the status of a task, given by ID, short ID or
+// number, and returns it; for the web page ticking tasks
+func (s *Server) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	scope := requestScope(r)
+	if !scope.CanWrite() {
+		writeError(w, http.StatusForbidden, ErrReadOnlyToken)
+		return
+	}
+	var req updateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+	if req.Status.Index() < 0 {
+		writeError(w, http.StatusBadRequest, ErrUnknownStatus)
+		return
+	}
+
+	tasks, err := s.store.Load()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	i := model.FindTask(tasks, r.PathValue("id"))
+	if i < 0 || (tasks[i].Private && !scope.SeesPrivate()) {
+		writeError(w, http.StatusNotFound, ErrTaskNotFound)
+		return
+	}
+	task := tasks[i]
+	task.Status = req.Status
+	tasks, err = s.store.UpdateTask(task)
+	switch {
+	case errors.Is(err, storage.ErrConflict):
+		writeError(w, http.StatusConflict, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if i := model.FindTask(tasks, task.ID); i >= 0 {
+		task = tasks[i]
+	}
+	m, err := taskJSON(task, nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
+
+// matchingTasks returns the tasks a scope sees matching a query of the
+// search
+func (s *Server) matchingTasks(scope config.TokenScope, q string) ([]model.Task, error) {
+	all, err := s.store.Load()
+	if err != nil {
+		return nil, err
+	}
+	query := model.ParseQuery(q).WithTasks(all)
+	var matched []model.Task
+	for _, t := range visibleTasks(all, scope) {
+		if query.Matches(t) {
+			matched = append(matched, t)
+		}
+	}
+	return matched, nil
+}
+
+// pageParams reads ?limit= and ?offset=, false when they aren't numbers
+// in range
+func pageParams(params url.Values) (limit, offset int, ok bool) {
+	limit, offset = defaultPageSize, 0
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			return 0, 0, false
+		}
+		limit = n
+	}
+	if v := params.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, false
+		}
+		offset = n
+	}
+	return limit, offset, true
+}
+
+// selectedFields reads ?fields=, a comma separated list of fields; nil for
+// every field, false for a field tasks don't have
+func selectedFields(list string) (map[string]bool, bool) {
+	if strings.TrimSpace(list) == "" {
+		return nil, true
+	}
+	fields := make(map[string]bool)
+	for _, f := range strings.Split(list, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if !taskFields[f] {
+			return nil, false
+		}
+		fields[f] = true
+	}
+	return fields, true
+}
+
+// taskJSON returns the JSON fields of a task with its short ID, only the
+// selected ones when fields isn't nil
+func taskJSON(t model.Task, fields map[string]bool) (map[string]any, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if id := t.ShortID(); id != "" {
+		m[shortIDField] = id
+	}
+	if fields != nil {
+		for f := range m {
+			if !fields[f] {
+				delete(m, f)
+			}
+		}
+	}
+	return m, nil
+}
+
+// jsonFields returns the names of the JSON fields of a struct type, with
+// extra ones
+func jsonFields(t reflect.Type, extra ...string) map[string]bool {
+	fields := make(map[string]bool)
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	for _, f := range extra {
+		fields[f] = true
+	}
+	return fields
+}
+
+// etagMatches returns true if an If-None-Match header lists the ETag
+func etagMatches(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			return true
+		}
+	}
+	return false
+}
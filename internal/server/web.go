@@ -0,0 +1,107 @@
+package server
+
+import (
+	"embed"
+	"html/template"
+	"io/fs"
+	"net/http"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+)
+
+// web holds the page of the board served as /ui: index.html is a template
+// given a webPage, the other files are served as they are
+//
+//go:embed web
+var web embed.FS
+
+// webTemplate is the template of the page
+var webTemplate = template.Must(template.ParseFS(web, "web/index.html"))
+
+// webPage is what the page is given: the labels of the workflow and its
+// strings in the interface language, since it has no access to them
+type webPage struct {
+	Lang   string
+	Title  string
+	Config webConfig
+}
+
+// webConfig is handed to the script of the page as JSON
+type webConfig struct {
+	// Write shows the boxes ticking tasks, which need a token that can write
+	Write      bool              `json:"write"`
+	Columns    []webColumn       `json:"columns"`
+	Priorities []webLabel        `json:"priorities"` // lowest first
+	Done       model.Status      `json:"done"`       // status of ticked tasks
+	Reopen     model.Status      `json:"reopen"`     // status of unticked tasks
+	Text       map[string]string `json:"text"`
+}
+
+// webColumn is a status of the workflow, a column of the board
+type webColumn struct {
+	Status model.Status `json:"status"`
+	Label  string       `json:"label"`
+	Done   bool         `json:"done"`
+}
+
+// webLabel is a value with its label
+type webLabel struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// webText are the strings of the page, translated when it's served
+var webText = []string{
+	"Chargement…",
+	"Aucune tâche",
+	"Jeton d'accès",
+	"Valider",
+	"Rechercher",
+	"Hors ligne, nouvel essai…",
+	"Erreur: ",
+	"Échéance",
+	"en retard",
+	"et %d de plus",
+}
+
+// webHandler serves the page and its files, without a token: the page
+// asks for one and sends it with its requests to the API
+func (s *Server) webHandler() http.Handler {
+	files, _ := fs.Sub(web, "web")
+	static := http.StripPrefix("/ui/", http.FileServerFS(files))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ui/" && r.URL.Path != "/ui/index.html" {
+			static.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		webTemplate.Execute(w, s.webPage())
+	})
+}
+
+// webPage returns what the page is given
+func (s *Server) webPage() webPage {
+	page := webPage{
+		Lang:  string(i18n.Current()),
+		Title: i18n.T("Tableau"),
+		Config: webConfig{
+			Write:  s.ui == config.ServerUIWrite,
+			Done:   model.StatusOfKind(model.StatusDone),
+			Reopen: model.StatusOfKind(model.StatusTodo),
+			Text:   map[string]string{},
+		},
+	}
+	for _, status := range model.AllStatuses() {
+		page.Config.Columns = append(page.Config.Columns, webColumn{Status: status, Label: status.Label(), Done: status.IsDone()})
+	}
+	for _, p := range model.AllPriorities() {
+		page.Config.Priorities = append(page.Config.Priorities, webLabel{Value: string(p), Label: p.Label()})
+	}
+	for _, text := range webText {
+		page.Config.Text[text] = i18n.T(text)
+	}
+	return page
+}
@@ -0,0 +1,309 @@
+// Board of lazy-todo, served by `lazy-todo serve` as /ui. The tasks come
+// from GET /tasks and are loaded again on each event of /ws; with
+// server.ui: write, ticking a task moves it to the first done status
+// (PATCH /tasks/{id}). The token is given once as ?token= and kept by the
+// browser.
+(function () {
+  "use strict";
+
+  const config = window.LAZY_TODO;
+  const fields = "id,short_id,title,status,priority,tags,due_date,updated_at,order";
+  // Most recent tasks shown in the done columns
+  const doneShown = 20;
+  const retryDelay = 5000;
+  const tokenKey = "lazy-todo-token";
+
+  const board = document.getElementById("board");
+  const statusLine = document.getElementById("status");
+  const search = document.getElementById("search");
+  const login = document.getElementById("login");
+
+  let token = "";
+  let tasks = [];
+  let reloadTimer = null;
+  let socket = null;
+
+  // t translates a string of the page, like i18n.T
+  function t(text) {
+    return config.text[text] || text;
+  }
+
+  // Unauthorized is thrown when the token is missing or refused
+  class Unauthorized extends Error {}
+
+  // readToken takes the token from ?token=, removed from the address bar,
+  // or else from the browser's storage
+  function readToken() {
+    const params = new URLSearchParams(location.search);
+    if (params.has("token")) {
+      token = params.get("token");
+      localStorage.setItem(tokenKey, token);
+      params.delete("token");
+      const query = params.toString();
+      history.replaceState(null, "", location.pathname + (query ? "?" + query : ""));
+      return;
+    }
+    token = localStorage.getItem(tokenKey) || "";
+  }
+
+  // api calls an endpoint of the server and returns its JSON response
+  async function api(method, path, body) {
+    const headers = {};
+    if (token) {
+      headers.Authorization = "Bearer " + token;
+    }
+    const options = {method: method, headers: headers};
+    if (body !== undefined) {
+      headers["Content-Type"] = "application/json";
+      options.body = JSON.stringify(body);
+    }
+    const resp = await fetch(path, options);
+    if (resp.status === 401) {
+      throw new Unauthorized();
+    }
+    const data = await resp.json();
+    if (!resp.ok) {
+      throw new Error(data.error || resp.statusText);
+    }
+    return data;
+  }
+
+  // load reads every task, a page at a time
+  async function load() {
+    const all = [];
+    let offset = 0;
+    do {
+      const page = await api("GET", "/tasks?limit=500&fields=" + fields + "&offset=" + offset);
+      all.push(...page.tasks);
+      offset = page.next_offset || 0;
+    } while (offset > 0);
+    tasks = all;
+    render();
+  }
+
+  // reload loads the tasks, once for a burst of events
+  function reload() {
+    clearTimeout(reloadTimer);
+    reloadTimer = setTimeout(function () {
+      load().then(function () { showStatus(""); }, fail);
+    }, 300);
+  }
+
+  // fail shows an error, or the token form when the token is refused
+  function fail(err) {
+    if (err instanceof Unauthorized) {
+      askToken();
+      return;
+    }
+    showStatus(t("Erreur: ") + err.message);
+  }
+
+  function showStatus(text) {
+    statusLine.textContent = text;
+  }
+
+  // priorityRank returns the rank of a priority, highest last
+  function priorityRank(priority) {
+    return config.priorities.findIndex(function (p) { return p.value === priority; });
+  }
+
+  function priorityLabel(priority) {
+    const p = config.priorities.find(function (p) { return p.value === priority; });
+    return p ? p.label : priority;
+  }
+
+  // compareOpen sorts open tasks like the manual order of the TUI, then by
+  // priority, due date and title
+  function compareOpen(a, b) {
+    if ((a.order || 0) !== (b.order || 0) && a.order && b.order) {
+      return a.order - b.order;
+    }
+    const byPriority = priorityRank(b.priority) - priorityRank(a.priority);
+    if (byPriority !== 0) {
+      return byPriority;
+    }
+    if ((a.due_date || "") !== (b.due_date || "")) {
+      if (!a.due_date) return 1;
+      if (!b.due_date) return -1;
+      return a.due_date < b.due_date ? -1 : 1;
+    }
+    return a.title.localeCompare(b.title);
+  }
+
+  // matches returns true if a task has the searched text in its title,
+  // short ID or tags
+  function matches(task, text) {
+    if (!text) {
+      return true;
+    }
+    const haystack = [task.title, task.short_id || ""].concat(task.tags || []).join(" ").toLowerCase();
+    return haystack.includes(text);
+  }
+
+  function element(tag, className, text) {
+    const el = document.createElement(tag);
+    if (className) {
+      el.className = className;
+    }
+    if (text !== undefined) {
+      el.textContent = text;
+    }
+    return el;
+  }
+
+  // render draws one column per status of the workflow
+  function render() {
+    const text = search.value.trim().toLowerCase();
+    const today = new Date();
+    today.setHours(0, 0, 0, 0);
+    board.replaceChildren();
+
+    let shown = 0;
+    for (const column of config.columns) {
+      let columnTasks = tasks.filter(function (task) {
+        return task.status === column.status && matches(task, text);
+      });
+      let hidden = 0;
+      if (column.done) {
+        columnTasks.sort(function (a, b) { return a.updated_at < b.updated_at ? 1 : -1; });
+        hidden = Math.max(0, columnTasks.length - doneShown);
+        columnTasks = columnTasks.slice(0, doneShown);
+      } else {
+        columnTasks.sort(compareOpen);
+      }
+      shown += columnTasks.length;
+
+      const section = element("section", column.done ? "column done" : "column");
+      const heading = element("h2", "", column.label + " ");
+      heading.appendChild(element("span", "count", String(columnTasks.length + hidden)));
+      section.appendChild(heading);
+      const list = element("ul");
+      for (const task of columnTasks) {
+        list.appendChild(renderTask(task, column, today));
+      }
+      section.appendChild(list);
+      if (hidden > 0) {
+        section.appendChild(element("p", "more", t("et %d de plus").replace("%d", hidden)));
+      }
+      board.appendChild(section);
+    }
+    if (shown === 0) {
+      board.replaceChildren(element("p", "empty", t("Aucune tâche")));
+    }
+  }
+
+  // renderTask draws a task, with a box to tick it when the page writes
+  function renderTask(task, column, today) {
+    const item = element("li", "task");
+    const label = element("label");
+    if (config.write) {
+      const box = document.createElement("input");
+      box.type = "checkbox";
+      box.checked = column.done;
+      box.addEventListener("change", function () {
+        tick(task, box.checked ? config.done : config.reopen);
+      });
+      label.appendChild(box);
+    }
+    if (task.short_id) {
+      label.appendChild(element("span", "id", task.short_id));
+    }
+    label.appendChild(element("span", "title", task.title));
+    item.appendChild(label);
+
+    const meta = element("div", "meta");
+    meta.appendChild(element("span", "priority", priorityLabel(task.priority)));
+    if (task.due_date) {
+      const due = new Date(task.due_date);
+      const late = !column.done && due < today;
+      const text = t("Échéance") + " " + due.toLocaleDateString(document.documentElement.lang);
+      meta.appendChild(element("span", late ? "due late" : "due", late ? text + " · " + t("en retard") : text));
+    }
+    for (const tag of task.tags || []) {
+      meta.appendChild(element("span", "tag", tag));
+    }
+    item.appendChild(meta);
+    return item;
+  }
+
+  // tick moves a task to a status, shown before the server answers
+  async function tick(task, status) {
+    const previous = task.status;
+    task.status = status;
+    render();
+    try {
+      const saved = await api("PATCH", "/tasks/" + encodeURIComponent(task.id), {status: status});
+      Object.assign(task, saved);
+      render();
+    } catch (err) {
+      task.status = previous;
+      render();
+      fail(err);
+    }
+  }
+
+  // listen follows the events of the tasks, and loads them again after a
+  // disconnection since events may have been missed
+  function listen() {
+    const scheme = location.protocol === "https:" ? "wss://" : "ws://";
+    const query = token ? "?token=" + encodeURIComponent(token) : "";
+    socket = new WebSocket(scheme + location.host + "/ws" + query);
+    socket.addEventListener("message", reload);
+    socket.addEventListener("open", function () {
+      showStatus("");
+      reload();
+    });
+    socket.addEventListener("close", function () {
+      socket = null;
+      if (!login.hidden) {
+        return;
+      }
+      showStatus(t("Hors ligne, nouvel essai…"));
+      setTimeout(listen, retryDelay);
+    });
+  }
+
+  // askToken shows the form asking for the token
+  function askToken() {
+    if (socket) {
+      socket.close();
+    }
+    board.replaceChildren();
+    showStatus("");
+    login.hidden = false;
+    login.querySelector("input").focus();
+  }
+
+  async function start() {
+    showStatus(t("Chargement…"));
+    try {
+      await load();
+      showStatus("");
+      listen();
+    } catch (err) {
+      fail(err);
+    }
+  }
+
+  login.querySelector("label").textContent = t("Jeton d'accès");
+  login.querySelector("button").textContent = t("Valider");
+  login.addEventListener("submit", function (event) {
+    event.preventDefault();
+    token = login.querySelector("input").value;
+    localStorage.setItem(tokenKey, token);
+    login.hidden = true;
+    start();
+  });
+  search.placeholder = t("Rechercher");
+  search.addEventListener("input", render);
+  // Phones suspend pages in the background
+  document.addEventListener("visibilitychange", function () {
+    if (!document.hidden && login.hidden) {
+      reload();
+    }
+  });
+
+  document.body.classList.toggle("write", config.write);
+  readToken();
+  start();
+})();
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/storage"
+	"lazy-todo/internal/webhook"
+	"lazy-todo/internal/websocket"
+)
+
+// pingInterval is how often idle WebSocket connections are pinged, to keep
+// them open through proxies
+const pingInterval = 30 * time.Second
+
+// handleWebSocket streams the events of the tasks (created, completed,
+// deleted...) as WebSocket text messages shaped like the webhook
+// payloads, for web pages and overlays that follow the board. The file is
+// checked for changes every subscriptionInterval, whichever program wrote
+// it; private tasks are left out for a restricted token.
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.store.Load(); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	last := s.store.Index()
+	conn, err := websocket.Upgrade(w, r, s.origins)
+	if errors.Is(err, websocket.ErrOrigin) {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	if err != nil {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	defer conn.Close()
+
+	// The client only sends pings and its close, read here
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, err := conn.Read(); err != nil {
+				return
+			}
+		}
+	}()
+
+	scope := requestScope(r)
+	ticker := time.NewTicker(subscriptionInterval)
+	defer ticker.Stop()
+	pinged := time.Now()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+		}
+		if time.Since(pinged) >= pingInterval {
+			if conn.Ping() != nil {
+				return
+			}
+			pinged = time.Now()
+		}
+
+		if _, err := s.store.Load(); err != nil {
+			// Being edited, or corrupted: sent once fixed
+			continue
+		}
+		index := s.store.Index()
+		if index == last {
+			continue
+		}
+		c := storage.Diff(last.Tasks(), index.Tasks())
+		events := webhook.Events(c, last.Tasks(), "", time.Now())
+		last = index
+		if err := sendEvents(conn, events, scope); err != nil {
+			return
+		}
+	}
+}
+
+// sendEvents sends events as JSON messages, without those of private tasks
+// unless the scope sees them
+func sendEvents(conn *websocket.Conn, events []webhook.Event, scope config.TokenScope) error {
+	for _, e := range events {
+		if e.Task.Private && !scope.SeesPrivate() {
+			continue
+		}
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if err := conn.WriteText(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/storage"
+)
+
+// upgradeStatus opens /ws of a server without a token from origin, "same"
+// for a page of the server, and returns the status of the answer
+func upgradeStatus(t *testing.T, cfg *config.Config, origin string) int {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	if err := os.WriteFile(path, []byte("tasks: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(New(storage.NewStorage(path), cfg).Handler())
+	defer ts.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(ts.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	request := "GET /ws HTTP/1.1\r\nHost: " + strings.TrimPrefix(ts.URL, "http://") + "\r\n" +
+		"Connection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Version: 13\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n"
+	if origin == "same" {
+		origin = ts.URL
+	}
+	if origin != "" {
+		request += "Origin: " + origin + "\r\n"
+	}
+	if _, err := conn.Write([]byte(request + "\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestWebSocketCrossOrigin(t *testing.T) {
+	cfg := &config.Config{}
+	if got := upgradeStatus(t, cfg, "http://evil.example"); got != http.StatusForbidden {
+		t.Errorf("page of another site: status %d, want %d", got, http.StatusForbidden)
+	}
+	if got := upgradeStatus(t, cfg, "same"); got != http.StatusSwitchingProtocols {
+		t.Errorf("page of the server: status %d, want %d", got, http.StatusSwitchingProtocols)
+	}
+	if got := upgradeStatus(t, cfg, ""); got != http.StatusSwitchingProtocols {
+		t.Errorf("no origin: status %d, want %d", got, http.StatusSwitchingProtocols)
+	}
+	cfg.Server.Origins = []string{"http://evil.example"}
+	if got := upgradeStatus(t, cfg, "http://evil.example"); got != http.StatusSwitchingProtocols {
+		t.Errorf("allowed origin: status %d, want %d", got, http.StatusSwitchingProtocols)
+	}
+}
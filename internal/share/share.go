@@ -0,0 +1,176 @@
+// Package share uploads a redacted rendering of tasks to a paste service
+// (0x0.st or compatible) and returns the link to share them with people who
+// don't use lazy-todo.
+package share
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/export"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+)
+
+// DefaultField is the form field holding the file, as 0x0.st expects it
+const DefaultField = "file"
+
+// Errors returned when tasks can't be shared
+var (
+	ErrNoURL  = errors.New("aucun service de partage configuré (share.url)")
+	ErrNoLink = errors.New("le service de partage n'a pas renvoyé de lien")
+)
+
+// sharedTask is what is shared of a task, without its ID, notes,
+// reminders or history
+type sharedTask struct {
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Status      string          `json:"status"`
+	Priority    string          `json:"priority"`
+	Severity    string          `json:"severity,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Subtasks    []model.Subtask `json:"subtasks,omitempty"`
+	DueDate     string          `json:"due_date,omitempty"`
+}
+
+// Redact keeps what others need to read tasks: title, status, priority,
+// severity, tags, checklist and due date, and the description if asked
+func Redact(tasks []model.Task, description bool) []model.Task {
+	redacted := make([]model.Task, 0, len(tasks))
+	for _, t := range tasks {
+		r := model.Task{
+			Title:    t.Title,
+			Status:   t.Status,
+			Priority: t.Priority,
+			Severity: t.Severity,
+			Tags:     t.Tags,
+			Subtasks: t.Subtasks,
+			DueDate:  t.DueDate,
+		}
+		if description {
+			r.Description = t.Description
+		}
+		redacted = append(redacted, r)
+	}
+	return redacted
+}
+
+// Render renders redacted tasks as Markdown or JSON
+func Render(f export.Format, tasks []model.Task, description bool) ([]byte, error) {
+	tasks = Redact(tasks, description)
+	switch f {
+	case export.FormatMarkdown:
+		return []byte(export.Markdown(tasks)), nil
+	case export.FormatJSON:
+		shared := make([]sharedTask, 0, len(tasks))
+		for _, t := range tasks {
+			s := sharedTask{
+				Title:       t.Title,
+				Description: t.Description,
+				Status:      t.Status.Label(),
+				Priority:    t.Priority.Label(),
+				Tags:        t.Tags,
+				Subtasks:    t.Subtasks,
+			}
+			if t.Severity != model.SeverityNone {
+				s.Severity = t.Severity.Label()
+			}
+			if t.DueDate != nil {
+				s.DueDate = t.DueDate.Format("2006-01-02")
+			}
+			shared = append(shared, s)
+		}
+		data, err := json.MarshalIndent(shared, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+	return nil, errors.New(i18n.Tf("format de partage non pris en charge: %q (md ou json)", f))
+}
+
+// Uploader posts files to a paste service
+type Uploader struct {
+	cfg    config.ShareConfig
+	client *http.Client
+}
+
+// New returns an uploader to the configured paste service
+func New(cfg config.ShareConfig) (*Uploader, error) {
+	if cfg.URL == "" {
+		return nil, ErrNoURL
+	}
+	if cfg.Field == "" {
+		cfg.Field = DefaultField
+	}
+	return &Uploader{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// Upload posts a file and returns the link the service answered with
+func (u *Uploader) Upload(name string, data []byte) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile(u.cfg.Field, name)
+	if err != nil {
+		return "", err
+	}
+	part.Write(data)
+	if u.cfg.Expires > 0 {
+		// In whole hours, rounded up
+		hours := int((u.cfg.Expires + time.Hour - 1) / time.Hour)
+		form.WriteField("expires", strconv.Itoa(hours))
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u.cfg.URL, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	// 0x0.st rejects requests without a user agent
+	req.Header.Set("User-Agent", "lazy-todo")
+	if u.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+u.cfg.Token)
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	answer, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return "", err
+	}
+	text := strings.TrimSpace(string(answer))
+	if resp.StatusCode >= 300 {
+		if text != "" && len(text) < 200 {
+			return "", fmt.Errorf("%s: %s", resp.Status, text)
+		}
+		return "", errors.New(resp.Status)
+	}
+
+	// Some services answer with a JSON object holding the link
+	var link struct {
+		URL string `json:"url"`
+	}
+	if json.Unmarshal(answer, &link) == nil && link.URL != "" {
+		text = link.URL
+	}
+	if !strings.HasPrefix(text, "http://") && !strings.HasPrefix(text, "https://") {
+		return "", ErrNoLink
+	}
+	return text, nil
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+// Saved is the result of saving the pending changes
+type Saved struct {
+	Tasks   []model.Task // as saved
+	Changes Changes      // saved, empty when nothing was pending
+	Err     error
+}
+
+// SetSaveDelay makes Queue save the pending changes in the background once
+// no change was queued for delay; with 0, only Flush saves them
+func (s *Storage) SetSaveDelay(delay time.Duration) {
+	s.mu.Lock()
+	s.saveDelay = delay
+	s.mu.Unlock()
+}
+
+// Queue adds a batch of changes to the pending ones instead of saving it,
+// so that a burst of changes is written once. It doesn't touch the file.
+func (s *Storage) Queue(c Changes) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending.Merge(c)
+	if s.saveDelay <= 0 {
+		return nil
+	}
+	if s.saveTimer != nil {
+		s.saveTimer.Stop()
+	}
+	s.saveTimer = time.AfterFunc(s.saveDelay, s.flushInBackground)
+	return nil
+}
+
+// Dirty returns true if changes are waiting to be saved
+func (s *Storage) Dirty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.pending.IsEmpty()
+}
+
+// Pending returns the changes waiting to be saved, to show them over the
+// tasks loaded
+func (s *Storage) Pending() Changes {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var c Changes
+	c.Merge(s.pending)
+	return c
+}
+
+// Flush saves the pending changes now, like Commit. When they can't be
+// saved they stay pending, except after ErrConflict: they were made over
+// tasks modified by another instance since.
+func (s *Storage) Flush() Saved {
+	s.flushing.Lock()
+	defer s.flushing.Unlock()
+
+	s.mu.Lock()
+	c := s.pending
+	s.pending = Changes{}
+	if s.saveTimer != nil {
+		s.saveTimer.Stop()
+		s.saveTimer = nil
+	}
+	s.mu.Unlock()
+	if c.IsEmpty() {
+		return Saved{}
+	}
+
+	tasks, err := s.Commit(c)
+	if err != nil && !errors.Is(err, ErrConflict) {
+		// Before the changes queued while saving
+		var kept Changes
+		kept.Merge(c)
+		s.mu.Lock()
+		kept.Merge(s.pending)
+		s.pending = kept
+		s.mu.Unlock()
+	}
+	return Saved{Tasks: tasks, Changes: c, Err: err}
+}
+
+// Discard drops the pending changes
+func (s *Storage) Discard() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = Changes{}
+	if s.saveTimer != nil {
+		s.saveTimer.Stop()
+		s.saveTimer = nil
+	}
+}
+
+// Saves returns a channel receiving the result of each background save
+func (s *Storage) Saves() <-chan Saved {
+	return s.saves
+}
+
+// flushInBackground saves the pending changes once the save delay is over
+func (s *Storage) flushInBackground() {
+	saved := s.Flush()
+	if saved.Changes.IsEmpty() {
+		// Saved by Flush in the meantime
+		return
+	}
+	s.saves <- saved
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"lazy-todo/internal/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// taskTimes are the timestamp fields of a task, read leniently: a value
+// that isn't a time is left out and reported rather than failing the load
+var taskTimes = []string{"created_at", "updated_at", "due_date", "today"}
+
+// dropBadTimesYAML removes the timestamps of the tasks of a YAML document
+// that can't be decoded as times, and returns them
+func dropBadTimesYAML(doc *yaml.Node) []model.BadTime {
+	tasks := yamlTasks(doc)
+	if tasks == nil {
+		return nil
+	}
+	var bad []model.BadTime
+	for i, task := range tasks.Content {
+		if task.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(task.Content); {
+			key, value := task.Content[j], task.Content[j+1]
+			var t time.Time
+			if !slices.Contains(taskTimes, key.Value) || value.Kind != yaml.ScalarNode || value.Decode(&t) == nil {
+				j += 2
+				continue
+			}
+			if value.Value != "" {
+				bad = append(bad, model.BadTime{Index: i, Field: key.Value, Value: value.Value})
+			}
+			task.Content = slices.Delete(task.Content, j, j+2)
+		}
+	}
+	return bad
+}
+
+// yamlTasks returns the sequence of tasks of a tasks file, nil when there
+// is none
+func yamlTasks(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	root := doc.Content[0]
+	for j := 0; j+1 < len(root.Content); j += 2 {
+		if root.Content[j].Value == "tasks" && root.Content[j+1].Kind == yaml.SequenceNode {
+			return root.Content[j+1]
+		}
+	}
+	return nil
+}
+
+// dropBadTimesJSON removes the timestamps of the tasks of a JSON tasks
+// file that can't be decoded as times. It returns the file without them,
+// as is when there were none, and them.
+func dropBadTimesJSON(data []byte) ([]byte, []model.BadTime) {
+	var root map[string]json.RawMessage
+	var tasks []map[string]json.RawMessage
+	if json.Unmarshal(data, &root) != nil || json.Unmarshal(root["tasks"], &tasks) != nil {
+		return data, nil
+	}
+	var bad []model.BadTime
+	for i, task := range tasks {
+		for _, field := range taskTimes {
+			value, ok := task[field]
+			var t *time.Time
+			if !ok || json.Unmarshal(value, &t) == nil {
+				continue
+			}
+			var text string
+			if json.Unmarshal(value, &text) != nil {
+				text = string(value)
+			}
+			if text != "" {
+				bad = append(bad, model.BadTime{Index: i, Field: field, Value: text})
+			}
+			delete(task, field)
+		}
+	}
+	if len(bad) == 0 {
+		return data, nil
+	}
+	var err error
+	if root["tasks"], err = json.Marshal(tasks); err != nil {
+		return data, nil
+	}
+	fixed, err := json.Marshal(root)
+	if err != nil {
+		return data, nil
+	}
+	return fixed, bad
+}
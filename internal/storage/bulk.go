@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+// Errors returned for edited tasks that can't be merged back
+var (
+	ErrEditNoTitle     = errors.New("une tâche modifiée n'a pas de titre")
+	ErrEditDuplicateID = errors.New("deux tâches modifiées ont le même ID")
+	ErrEditStatus      = errors.New("état inconnu")
+)
+
+// EncodeTasks writes tasks on their own in the format of the tasks file,
+// to be edited as a snippet, and returns the extension of the format
+func (s *Storage) EncodeTasks(tasks []model.Task) ([]byte, string, error) {
+	data, err := s.format.Marshal(&model.TaskStore{Tasks: tasks})
+	return data, formatExtension(s.format), err
+}
+
+// DecodeTasks reads tasks written by EncodeTasks, within the limits of the
+// tasks file
+func (s *Storage) DecodeTasks(data []byte) ([]model.Task, error) {
+	var store model.TaskStore
+	if err := decode(s.format, data, &store); err != nil {
+		return nil, err
+	}
+	migrate(store.Tasks)
+	return store.Tasks, nil
+}
+
+// formatExtension returns the file extension of a format
+func formatExtension(f Format) string {
+	switch f.(type) {
+	case jsonFormat:
+		return ".json"
+	case markdownFormat:
+		return ".md"
+	}
+	return ".yaml"
+}
+
+// EditChanges returns the changes made to tasks edited as a snippet: the
+// tasks whose ID is in the snippet are updated when they changed, the ones
+// left out are deleted, and the ones with a new or no ID are added. A task
+// keeps its ID, number and creation and update times, so that a change
+// made meanwhile by another instance is still detected by Commit. Every
+// task needs a title and a status of the workflow.
+func EditChanges(original, edited []model.Task, now time.Time) (Changes, error) {
+	var c Changes
+	before := make(map[string]model.Task, len(original))
+	for _, t := range original {
+		before[t.ID] = t
+	}
+
+	seen := make(map[string]bool, len(edited))
+	for _, t := range edited {
+		if t.Title == "" {
+			return Changes{}, ErrEditNoTitle
+		}
+		if t.Status.Index() < 0 {
+			return Changes{}, fmt.Errorf("%w: %s", ErrEditStatus, t.Status)
+		}
+		if t.Priority == "" {
+			t.Priority = model.DefaultPriority()
+		}
+		if t.ID != "" && seen[t.ID] {
+			return Changes{}, ErrEditDuplicateID
+		}
+		seen[t.ID] = true
+
+		old, ok := before[t.ID]
+		if !ok {
+			fresh := model.NewTask(t.Title)
+			t.ID = fresh.ID
+			t.Number = 0
+			t.History = nil
+			t.CreatedAt = now
+			t.UpdatedAt = now
+			c.Added = append(c.Added, t)
+			continue
+		}
+		t.Number = old.Number
+		t.CreatedAt = old.CreatedAt
+		t.UpdatedAt = old.UpdatedAt
+		t.History = old.History
+		if !sameTask(old, t) {
+			c.Updated = append(c.Updated, t)
+		}
+	}
+
+	for _, t := range original {
+		if !seen[t.ID] {
+			c.Deleted = append(c.Deleted, t.ID)
+		}
+	}
+	return c, nil
+}
+
+// sameTask returns true if two tasks are written the same way
+func sameTask(a, b model.Task) bool {
+	da, errA := MarshalTask(a)
+	db, errB := MarshalTask(b)
+	return errA == nil && errB == nil && bytes.Equal(da, db)
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"reflect"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+// Changes is a batch of task modifications saved in a single write
+type Changes struct {
+	Added   []model.Task
+	Updated []model.Task
+	Order   map[string]int
+	Deleted []string
+}
+
+// IsEmpty returns true if the batch contains no modification
+func (c Changes) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Order) == 0 && len(c.Deleted) == 0
+}
+
+// Merge adds a later batch to c. Updates and deletions of tasks added in
+// c are folded into the added tasks, since they aren't stored yet.
+func (c *Changes) Merge(o Changes) {
+	c.Added = append(c.Added, o.Added...)
+
+	for _, u := range o.Updated {
+		if i := indexOf(c.Added, u.ID); i >= 0 {
+			c.Added[i] = u
+		} else if i := indexOf(c.Updated, u.ID); i >= 0 {
+			// Keep the first copy's update time for conflict detection
+			u.UpdatedAt = c.Updated[i].UpdatedAt
+			c.Updated[i] = u
+		} else {
+			c.Updated = append(c.Updated, u)
+		}
+	}
+
+	for id, pos := range o.Order {
+		if c.Order == nil {
+			c.Order = map[string]int{}
+		}
+		c.Order[id] = pos
+	}
+
+	for _, id := range o.Deleted {
+		if i := indexOf(c.Added, id); i >= 0 {
+			c.Added = append(c.Added[:i], c.Added[i+1:]...)
+			continue
+		}
+		if i := indexOf(c.Updated, id); i >= 0 {
+			c.Updated = append(c.Updated[:i], c.Updated[i+1:]...)
+		}
+		c.Deleted = append(c.Deleted, id)
+	}
+}
+
+// Apply returns a copy of tasks with the batch applied, without conflict
+// detection. It is used to show pending changes before they are saved.
+func (c Changes) Apply(tasks []model.Task) []model.Task {
+	result, _ := c.apply(tasks, func(stored, task *model.Task) error {
+		*stored = *task
+		return nil
+	})
+	return result
+}
+
+// apply returns a copy of tasks with the batch applied, calling update to
+// replace each stored task by its updated copy
+func (c Changes) apply(tasks []model.Task, update func(stored, task *model.Task) error) ([]model.Task, error) {
+	deleted := make(map[string]bool, len(c.Deleted))
+	for _, id := range c.Deleted {
+		deleted[id] = true
+	}
+
+	result := make([]model.Task, 0, len(tasks)+len(c.Added))
+	for _, t := range tasks {
+		if !deleted[t.ID] {
+			result = append(result, t)
+		}
+	}
+
+	for i := range c.Updated {
+		if j := indexOf(result, c.Updated[i].ID); j >= 0 {
+			if err := update(&result[j], &c.Updated[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, t := range c.Added {
+		if indexOf(result, t.ID) < 0 {
+			result = append(result, t)
+		}
+	}
+
+	for i := range result {
+		if pos, ok := c.Order[result[i].ID]; ok {
+			result[i].Order = pos
+		}
+	}
+
+	return result, nil
+}
+
+// Commit saves a batch of changes, with the same conflict detection as
+// UpdateTask
+func (s *Storage) Commit(c Changes) ([]model.Task, error) {
+	return s.modify(func(tasks []model.Task) ([]model.Task, error) {
+		now := time.Now()
+		return c.apply(tasks, func(stored, task *model.Task) error {
+			if !stored.UpdatedAt.Equal(task.UpdatedAt) && !s.wroteVersion(*stored) {
+				return ErrConflict
+			}
+			updated := *task
+			updated.UpdatedAt = now
+			// The history is only written here, the stored one is the most
+			// recent
+			updated.History = stored.History
+			updated.RecordChanges(*stored, now)
+			*stored = updated
+			s.mu.Lock()
+			s.written[task.ID] = now
+			s.mu.Unlock()
+			return nil
+		})
+	})
+}
+
+// Diff returns the changes that turn the tasks before into the tasks
+// after, like the ones of another instance between two loads
+func Diff(before, after []model.Task) Changes {
+	var c Changes
+	old := make(map[string]model.Task, len(before))
+	for _, t := range before {
+		old[t.ID] = t
+	}
+	seen := make(map[string]bool, len(after))
+	for _, t := range after {
+		seen[t.ID] = true
+		prev, ok := old[t.ID]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, t)
+		case !reflect.DeepEqual(prev, t) && !sameTask(prev, t):
+			c.Updated = append(c.Updated, t)
+		}
+	}
+	for _, t := range before {
+		if !seen[t.ID] {
+			c.Deleted = append(c.Deleted, t.ID)
+		}
+	}
+	return c
+}
+
+// indexOf returns the index of the task with the given ID, or -1
+func indexOf(tasks []model.Task, id string) int {
+	for i, t := range tasks {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"lazy-todo/internal/model"
+)
+
+// ErrCorrupted is returned when the tasks file is truncated or corrupted,
+// so that it isn't taken for an empty list and overwritten
+var ErrCorrupted = errors.New("le fichier de tâches semble corrompu")
+
+// checksum is what was last written to the tasks file, stored next to it
+type checksum struct {
+	sum   string // hex SHA-256 of the content
+	tasks int
+}
+
+// checksumPath returns the path of the checksum file
+func (s *Storage) checksumPath() string {
+	return s.FilePath + ".sum"
+}
+
+// writeChecksum records the content just written to the tasks file
+func (s *Storage) writeChecksum(data []byte, tasks int) error {
+	sum := sha256.Sum256(data)
+	line := fmt.Sprintf("sha256:%s tasks:%d\n", hex.EncodeToString(sum[:]), tasks)
+	return writeAtomic(s.checksumPath(), []byte(line), 0644)
+}
+
+// readChecksum returns the recorded checksum, false when there is none
+func (s *Storage) readChecksum() (checksum, bool) {
+	data, err := os.ReadFile(s.checksumPath())
+	if err != nil {
+		return checksum{}, false
+	}
+	var c checksum
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(data)), "sha256:%s tasks:%d", &c.sum, &c.tasks); err != nil {
+		return checksum{}, false
+	}
+	return c, true
+}
+
+// verify checks content read from the tasks file. Content matching the
+// checksum is trusted; otherwise it was edited by another program, which
+// is fine unless it can't be parsed, holds NUL bytes, or was emptied
+// while the last save wrote tasks.
+func (s *Storage) verify(data []byte, parseErr error) error {
+	c, ok := s.readChecksum()
+	sum := sha256.Sum256(data)
+	if ok && c.sum == hex.EncodeToString(sum[:]) {
+		return parseErr
+	}
+
+	switch {
+	case parseErr != nil:
+		return fmt.Errorf("%w: %w", ErrCorrupted, parseErr)
+	case bytes.IndexByte(data, 0) >= 0:
+		return fmt.Errorf("%w: %s", ErrCorrupted, "octets nuls")
+	case ok && c.tasks > 0 && len(bytes.TrimSpace(data)) == 0:
+		return fmt.Errorf("%w: %s", ErrCorrupted, "fichier vide")
+	}
+	return nil
+}
+
+// Verify checks content read from the tasks file, like Load
+func (s *Storage) Verify(data []byte) error {
+	var store model.TaskStore
+	return s.verify(data, decode(s.format, data, &store))
+}
+
+// Validate returns the number of tasks of a tasks file content, YAML or
+// JSON, or an error if it isn't valid
+func Validate(data []byte) (int, error) {
+	if bytes.IndexByte(data, 0) >= 0 {
+		return 0, ErrCorrupted
+	}
+	var store model.TaskStore
+	if err := decode(formatOfContent(data), data, &store); err != nil {
+		return 0, err
+	}
+	return len(store.Tasks), nil
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"lazy-todo/internal/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownFormat is returned for a tasks file format other than yaml or
+// json
+var ErrUnknownFormat = errors.New("format de fichier inconnu (yaml, json, markdown)")
+
+// Formats of the tasks file
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// Format serializes the tasks file
+type Format interface {
+	Marshal(store *model.TaskStore) ([]byte, error)
+	Unmarshal(data []byte, store *model.TaskStore) error
+}
+
+// yamlFormat is the default format of the tasks file
+type yamlFormat struct{}
+
+func (yamlFormat) Marshal(store *model.TaskStore) ([]byte, error) {
+	return yaml.Marshal(store)
+}
+
+func (yamlFormat) Unmarshal(data []byte, store *model.TaskStore) error {
+	doc, err := parseYAML(data)
+	if err != nil || doc.Kind == 0 {
+		return err
+	}
+	bad := dropBadTimesYAML(doc)
+	if err := doc.Decode(store); err != nil {
+		return err
+	}
+	store.BadTimes = bad
+	return nil
+}
+
+// jsonFormat writes the tasks file as indented JSON, for tools that only
+// read JSON
+type jsonFormat struct{}
+
+func (jsonFormat) Marshal(store *model.TaskStore) ([]byte, error) {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (jsonFormat) Unmarshal(data []byte, store *model.TaskStore) error {
+	// An empty file is an empty list, as in YAML
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	data, bad := dropBadTimesJSON(data)
+	if err := json.Unmarshal(data, store); err != nil {
+		return err
+	}
+	store.BadTimes = bad
+	return nil
+}
+
+// NewFormat returns the format of a name
+func NewFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case FormatYAML, "yml":
+		return yamlFormat{}, nil
+	case FormatJSON:
+		return jsonFormat{}, nil
+	case FormatMarkdown, "md":
+		return markdownFormat{}, nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// FormatOf returns the format of a tasks file by its extension: JSON for
+// .json, Markdown for .md, YAML otherwise
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return jsonFormat{}
+	case ".md", ".markdown":
+		return markdownFormat{}
+	}
+	return yamlFormat{}
+}
+
+// formatOfContent returns the format of a tasks file content: JSON when it
+// starts with an object, Markdown with a heading or a checklist, YAML
+// otherwise
+func formatOfContent(data []byte) Format {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("{")):
+		return jsonFormat{}
+	case bytes.HasPrefix(data, []byte("## ")), bytes.HasPrefix(data, []byte("- [")):
+		return markdownFormat{}
+	}
+	return yamlFormat{}
+}
+
+// SetFormat sets the format of the tasks file, instead of the one of its
+// extension
+func (s *Storage) SetFormat(name string) error {
+	format, err := NewFormat(name)
+	if err != nil {
+		return err
+	}
+	s.format = format
+	return nil
+}
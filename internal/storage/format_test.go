@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"lazy-todo/internal/model"
+)
+
+// fullStore returns a store with every field of the tasks set, and tag
+// colors and a journal
+func fullStore() model.TaskStore {
+	at := func(day, hour int) time.Time { return time.Date(2026, 10, day, hour, 30, 15, 123456789, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	return model.TaskStore{
+		Tasks: []model.Task{
+			{
+				ID: "11111111-1111-1111-1111-111111111111", Number: 1,
+				Title: "Préparer la démo", Description: "Avec les\nnotes « spéciales »: <b>&</b>",
+				Priority: model.PriorityHigh, Status: model.StatusBlocked, BlockedReason: model.BlockWaitingReview,
+				Severity: model.SeverityCosmetic, Tags: []string{"work", "work/client"},
+				Subtasks:  []model.Subtask{{Title: "Slides", Done: true}, {Title: "Répétition"}},
+				Notes:     []model.Note{{At: at(2, 9), Text: "Vu avec l'équipe"}},
+				History:   []model.Change{{At: at(3, 10), Field: "status", From: "todo", To: "blocked"}},
+				Sessions:  []model.WorkSession{{Start: at(3, 8), End: ptr(at(3, 9))}, {Start: at(4, 8)}},
+				DueDate:   ptr(at(20, 0)),
+				Reminders: []model.Reminder{{At: ptr(at(19, 9)), Notified: ptr(at(19, 9))}, {Before: 2 * time.Hour, Snoozed: ptr(at(19, 18))}},
+				Order:     3, Sprint: "S42", Estimate: 5, DependsOn: []string{"22222222-2222-2222-2222-222222222222"},
+				Private: true, Today: ptr(at(17, 0)), Pinned: true,
+				CreatedAt: at(1, 8), UpdatedAt: at(4, 9), External: "github:owner/repo#12",
+			},
+			{
+				ID: "22222222-2222-2222-2222-222222222222", Number: 2, Title: "Sans options",
+				Priority: model.PriorityLow, Status: model.StatusDone, CreatedAt: at(1, 8), UpdatedAt: at(1, 8),
+			},
+		},
+		TagColors:  map[string]string{"work": "blue", "home": "#a6e3a1"},
+		NextNumber: 3,
+		Journal:    []model.JournalEntry{{Day: "2026-10-16", Text: "Longue réunion", CreatedAt: at(16, 18)}},
+	}
+}
+
+// saveAs saves the tasks loaded by a storage to another file, in the
+// format of its extension, and returns the storage of that file
+func saveAs(t *testing.T, s *Storage, tasks []model.Task, path string) *Storage {
+	t.Helper()
+	s.FilePath, s.format = path, FormatOf(path)
+	if err := s.Save(tasks); err != nil {
+		t.Fatalf("Save %s: %v", filepath.Base(path), err)
+	}
+	loaded := NewStorage(path)
+	if _, err := loaded.Load(); err != nil {
+		t.Fatalf("Load %s: %v", filepath.Base(path), err)
+	}
+	return loaded
+}
+
+// checkStore checks that a storage holds the tasks, tag colors and journal
+// of a store
+func checkStore(t *testing.T, name string, s *Storage, want model.TaskStore) {
+	t.Helper()
+	tasks, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tasks, want.Tasks) {
+		t.Errorf("%s: tasks\n%+v\nwant\n%+v", name, tasks, want.Tasks)
+	}
+	if !reflect.DeepEqual(s.TagColors(), want.TagColors) {
+		t.Errorf("%s: tag colors %v, want %v", name, s.TagColors(), want.TagColors)
+	}
+	if !reflect.DeepEqual(s.Journal(), want.Journal) {
+		t.Errorf("%s: journal %v, want %v", name, s.Journal(), want.Journal)
+	}
+	if problems := s.Problems(); len(problems) > 0 {
+		t.Errorf("%s: problems %v", name, problems)
+	}
+}
+
+// checkChecksum checks that the checksum file of a storage records the
+// content of its tasks file
+func checkChecksum(t *testing.T, s *Storage, tasks int) {
+	t.Helper()
+	data, err := os.ReadFile(s.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, ok := s.readChecksum()
+	sum := sha256.Sum256(data)
+	if !ok || c.sum != hex.EncodeToString(sum[:]) || c.tasks != tasks {
+		t.Errorf("%s: checksum %+v (%v), want the content's with %d tasks", filepath.Base(s.FilePath), c, ok, tasks)
+	}
+}
+
+func TestRoundTripYAMLJSON(t *testing.T) {
+	want := fullStore()
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "tasks.yaml")
+	data, err := yamlFormat{}.Marshal(&want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(yamlPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	yamlStore := NewStorage(yamlPath)
+	checkStore(t, "yaml", yamlStore, want)
+	tasks, _ := yamlStore.Load()
+	jsonStore := saveAs(t, yamlStore, tasks, filepath.Join(dir, "tasks.json"))
+	checkStore(t, "json", jsonStore, want)
+	checkChecksum(t, jsonStore, len(want.Tasks))
+
+	jsonData, _ := os.ReadFile(jsonStore.FilePath)
+	if !bytes.HasPrefix(jsonData, []byte("{\n  \"tasks\": [")) {
+		t.Errorf("JSON file not indented: %.40q", jsonData)
+	}
+
+	tasks, _ = jsonStore.Load()
+	backStore := saveAs(t, jsonStore, tasks, filepath.Join(dir, "back.yaml"))
+	checkStore(t, "back to yaml", backStore, want)
+	checkChecksum(t, backStore, len(want.Tasks))
+	if back, _ := os.ReadFile(backStore.FilePath); !bytes.Equal(back, data) {
+		t.Errorf("YAML after JSON differs:\n%s\nwant\n%s", back, data)
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := map[string]Format{
+		"tasks.yaml":       yamlFormat{},
+		"tasks.yml":        yamlFormat{},
+		"tasks":            yamlFormat{},
+		"tasks.json":       jsonFormat{},
+		"TASKS.JSON":       jsonFormat{},
+		"tasks.md":         markdownFormat{},
+		"tasks.markdown":   markdownFormat{},
+		"dir.json/tasks":   yamlFormat{},
+		"/sync/tasks.json": jsonFormat{},
+	}
+	for path, want := range tests {
+		if got := FormatOf(path); got != want {
+			t.Errorf("FormatOf(%q) = %T, want %T", path, got, want)
+		}
+	}
+}
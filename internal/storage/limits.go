@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+
+	"lazy-todo/internal/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Limits of the content read as tasks. The tasks file may come from a
+// synced or shared folder and pasted text from anywhere, so content past
+// them is refused instead of being decoded.
+const (
+	// MaxFileSize is the size of the largest tasks file read
+	MaxFileSize = 32 << 20
+	// maxDepth is the deepest nesting of YAML nodes; a tasks file needs
+	// about 6 (tasks, a task, its subtasks, a subtask, its fields)
+	maxDepth = 32
+	// maxAliases is the number of YAML aliases; lazy-todo writes none
+	maxAliases = 256
+	// maxValueSize is the size of the largest single value, like a
+	// description
+	maxValueSize = 1 << 20
+)
+
+// Errors returned for content past the limits
+var (
+	ErrTooLarge = errors.New("fichier de tâches trop volumineux")
+	ErrUnsafe   = errors.New("contenu refusé: imbrication, alias ou valeur hors limites")
+)
+
+// readLimited reads a file, failing with ErrTooLarge past MaxFileSize
+func readLimited(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxFileSize {
+		return nil, ErrTooLarge
+	}
+	return data, nil
+}
+
+// decode reads content with a format, within the limits. A panic of a
+// parser on malformed content is returned as ErrUnsafe.
+func decode(f Format, data []byte, store *model.TaskStore) (err error) {
+	if len(data) > MaxFileSize {
+		return ErrTooLarge
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrUnsafe, r)
+		}
+	}()
+	if err := f.Unmarshal(data, store); err != nil {
+		return parseError(data, err)
+	}
+	return nil
+}
+
+// parseYAML parses YAML to a tree of nodes, checked against the limits
+// before anything is decoded from it. An empty document has a zero Kind.
+func parseYAML(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	aliases := 0
+	if err := checkNode(&doc, 0, &aliases); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// checkNode checks the depth, aliases and value sizes of a node and its
+// children
+func checkNode(n *yaml.Node, depth int, aliases *int) error {
+	if depth > maxDepth || len(n.Value) > maxValueSize {
+		return ErrUnsafe
+	}
+	if n.Kind == yaml.AliasNode {
+		// The node it points to is checked where its anchor is
+		*aliases++
+		if *aliases > maxAliases {
+			return ErrUnsafe
+		}
+		return nil
+	}
+	for _, c := range n.Content {
+		if err := checkNode(c, depth+1, aliases); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseError is an error of a parser, with the line of the content it's
+// at when the parser tells
+type ParseError struct {
+	Line int // from 1; 0 when unknown
+	Err  error
+}
+
+func (e *ParseError) Error() string { return e.Err.Error() }
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// yamlLine finds the line in the errors of the YAML parser and decoder
+var yamlLine = regexp.MustCompile(`line (\d+)`)
+
+// parseError wraps an error of a parser in a ParseError, with the line of
+// data it's at
+func parseError(data []byte, err error) error {
+	if errors.Is(err, ErrUnsafe) || errors.Is(err, ErrTooLarge) {
+		return err
+	}
+	pe := &ParseError{Err: err}
+	var syntax *json.SyntaxError
+	var typ *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntax):
+		pe.Line = lineAt(data, syntax.Offset)
+	case errors.As(err, &typ):
+		pe.Line = lineAt(data, typ.Offset)
+	default:
+		if m := yamlLine.FindStringSubmatch(err.Error()); m != nil {
+			pe.Line, _ = strconv.Atoi(m[1])
+			if line := yamlSyntaxLine(data); line > 0 && line != pe.Line {
+				// Told at the right line
+				pe.Line = line
+				pe.Err = errors.New(yamlLine.ReplaceAllString(err.Error(), "line "+strconv.Itoa(line)))
+			}
+		}
+	}
+	return pe
+}
+
+// yamlSyntaxLine returns the first line the YAML of data doesn't parse
+// past; 0 when it parses. For a syntax error, the parser tells the line
+// the enclosing block starts at, which can be far above the mistake, so
+// the shortest failing run of lines is searched for instead.
+func yamlSyntaxLine(data []byte) int {
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) == nil {
+		return 0
+	}
+	// ends[i] is the end of line i+1
+	var ends []int
+	for i, b := range data {
+		if b == '\n' {
+			ends = append(ends, i+1)
+		}
+	}
+	if len(ends) == 0 || ends[len(ends)-1] != len(data) {
+		ends = append(ends, len(data))
+	}
+
+	lo, hi := 1, len(ends)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if yaml.Unmarshal(data[:ends[mid-1]], &doc) != nil {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return hi
+}
+
+// lineAt returns the line of a byte offset of data, from 1
+func lineAt(data []byte, offset int64) int {
+	offset = min(max(offset, 0), int64(len(data)))
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// ErrorLine returns the line of the tasks file an error of Load is at; 0
+// when it isn't a parse error or the parser didn't tell
+func ErrorLine(err error) int {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		return pe.Line
+	}
+	return 0
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockTimeout is how long to wait for another instance to release the lock
+const lockTimeout = 2 * time.Second
+
+// ErrLocked is returned when another instance holds the lock for too long
+var ErrLocked = errors.New("fichier verrouillé par une autre instance")
+
+// ErrConflict is returned when a task was modified by another instance
+// since it was loaded
+var ErrConflict = errors.New("la tâche a été modifiée par ailleurs, rechargement")
+
+// ErrReadOnly is returned when writing a tasks file opened read-only
+var ErrReadOnly = errors.New("fichier ouvert en lecture seule")
+
+// SetReadOnly makes every write fail with ErrReadOnly, without taking the
+// lock either, for a file on a shared mount or projected in a meeting
+func (s *Storage) SetReadOnly(readOnly bool) {
+	s.readOnly = readOnly
+}
+
+// ReadOnly returns true if the tasks file was opened read-only
+func (s *Storage) ReadOnly() bool {
+	return s.readOnly
+}
+
+// lockPath returns the path of the advisory lock file
+func (s *Storage) lockPath() string {
+	return s.FilePath + ".lock"
+}
+
+// withLock runs fn while holding the advisory lock on the tasks file, so
+// concurrent instances can't interleave their load/modify/save cycles
+func (s *Storage) withLock(fn func() error) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if err := os.MkdirAll(filepath.Dir(s.FilePath), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.lockPath(), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		ok, err := tryLock(f)
+		if err != nil {
+			return err
+		}
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			return ErrLocked
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	defer unlock(f)
+
+	return fn()
+}
+
+// writeAtomic writes data to a temporary file next to path then renames it
+// over path, so readers never see a partially written file
+func writeAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/parse"
+
+	"github.com/google/uuid"
+)
+
+// FormatMarkdown writes the tasks file as GitHub checklists
+const FormatMarkdown = "markdown"
+
+// markdownNamespace derives the IDs of the tasks added to the Markdown file
+// by hand, which have no metadata comment yet
+var markdownNamespace = uuid.MustParse("6f1c2a4e-3b7d-4c58-9a0e-2d5f8b1c7e93")
+
+var (
+	// ## À faire <!-- status:todo -->
+	mdHeading = regexp.MustCompile(`^##\s+(.*?)\s*(?:<!--\s*status:(\S+)\s*-->)?$`)
+	// - [ ] Fix login #backend !high <!-- {"id":"..."} -->
+	mdTask = regexp.MustCompile(`^([-*])\s+\[([ xX])\]\s+(.*?)\s*(?:<!--\s*(\{.*\})\s*-->)?$`)
+	// <!-- tag_colors: {"work":"teal"} -->
+	mdTagColors = regexp.MustCompile(`^<!--\s*tag_colors:\s*(\{.*\})\s*-->$`)
+	// <!-- next_number: 43 -->
+	mdNextNumber = regexp.MustCompile(`^<!--\s*next_number:\s*(\d+)\s*-->$`)
+	// <!-- journal: [{"day":"2026-10-17","text":"..."}] -->
+	mdJournal = regexp.MustCompile(`^<!--\s*journal:\s*(\[.*\])\s*-->$`)
+)
+
+// markdownFormat writes the tasks as checklists under a heading per
+// status, readable on GitHub. The title, tags, priority, due date and
+// estimate are written as quick-add tokens (parse.Format) and the
+// subtasks as a nested checklist; the other fields are kept as JSON in a
+// comment at the end of the line, which GitHub doesn't show.
+type markdownFormat struct{}
+
+func (markdownFormat) Marshal(store *model.TaskStore) ([]byte, error) {
+	// Statuses of the workflow first, then the others in order of tasks
+	statuses := model.AllStatuses()
+	for _, t := range store.Tasks {
+		if !slices.Contains(statuses, t.Status) {
+			statuses = append(statuses, t.Status)
+		}
+	}
+
+	var b bytes.Buffer
+	for _, status := range statuses {
+		b.WriteString("## " + status.Label() + " <!-- status:" + string(status) + " -->\n")
+		for i, t := range store.Tasks {
+			if t.Status != status {
+				continue
+			}
+			if i == slices.IndexFunc(store.Tasks, func(t model.Task) bool { return t.Status == status }) {
+				b.WriteString("\n")
+			}
+			line, err := markdownTask(t)
+			if err != nil {
+				return nil, err
+			}
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+	if len(store.TagColors) > 0 {
+		colors, err := json.Marshal(store.TagColors)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString("<!-- tag_colors: " + string(colors) + " -->\n")
+	}
+	if store.NextNumber > 0 {
+		b.WriteString("<!-- next_number: " + strconv.Itoa(store.NextNumber) + " -->\n")
+	}
+	if len(store.Journal) > 0 {
+		journal, err := json.Marshal(store.Journal)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString("<!-- journal: " + string(journal) + " -->\n")
+	}
+	return b.Bytes(), nil
+}
+
+// markdownTask returns the checklist item of a task, with its subtasks
+func markdownTask(t model.Task) (string, error) {
+	box := "[ ]"
+	if t.Status.IsDone() {
+		box = "[x]"
+	}
+
+	// JSON escapes < and >, so no description closes the comment early
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	var meta map[string]json.RawMessage
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return "", err
+	}
+	// The fields written as tokens are left out of the comment, but a due
+	// date with a time of day is kept
+	for _, field := range []string{"title", "tags", "priority", "status", "estimate", "subtasks"} {
+		delete(meta, field)
+	}
+	if t.DueDate != nil && t.DueDate.Equal(dayStart(*t.DueDate)) {
+		delete(meta, "due_date")
+	}
+	if data, err = json.Marshal(meta); err != nil {
+		return "", err
+	}
+
+	line := "- " + box + " " + parse.Format(t) + " <!-- " + string(data) + " -->\n"
+	for _, s := range t.Subtasks {
+		sub := "  - [ ] "
+		if s.Done {
+			sub = "  - [x] "
+		}
+		line += sub + s.Title + "\n"
+	}
+	return line, nil
+}
+
+func (markdownFormat) Unmarshal(data []byte, store *model.TaskStore) error {
+	now := time.Now()
+	var status model.Status = model.StatusTodo
+	seen := map[string]int{}
+	var current *model.Task
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(line, " \t")
+		nested := len(trimmed) < len(line)
+
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			status = headingStatus(m[1], m[2])
+			current = nil
+			continue
+		}
+		if m := mdTagColors.FindStringSubmatch(line); m != nil {
+			if err := json.Unmarshal([]byte(m[1]), &store.TagColors); err != nil {
+				return err
+			}
+			continue
+		}
+		if m := mdNextNumber.FindStringSubmatch(line); m != nil {
+			store.NextNumber, _ = strconv.Atoi(m[1])
+			continue
+		}
+		if m := mdJournal.FindStringSubmatch(line); m != nil {
+			if err := json.Unmarshal([]byte(m[1]), &store.Journal); err != nil {
+				return err
+			}
+			continue
+		}
+		m := mdTask.FindStringSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+		done := m[2] != " "
+
+		if nested {
+			if current != nil {
+				current.Subtasks = append(current.Subtasks, model.Subtask{Title: m[3], Done: done})
+			}
+			continue
+		}
+
+		t := model.Task{CreatedAt: now, UpdatedAt: now}
+		if m[4] != "" {
+			if err := json.Unmarshal([]byte(m[4]), &t); err != nil {
+				return err
+			}
+		}
+		applyMarkdownLine(&t, m[3], now)
+		if t.ID == "" {
+			// Added by hand: an ID stable across loads until saved
+			key := t.Title + "#" + strconv.Itoa(seen[t.Title])
+			seen[t.Title]++
+			t.ID = uuid.NewSHA1(markdownNamespace, []byte(key)).String()
+		}
+
+		// A box checked or unchecked by hand moves the task
+		t.Status = status
+		if done && !status.IsDone() {
+			t.Status = model.StatusDone
+		} else if !done && status.IsDone() {
+			t.Status = model.StatusTodo
+		}
+
+		store.Tasks = append(store.Tasks, t)
+		current = &store.Tasks[len(store.Tasks)-1]
+	}
+	return scanner.Err()
+}
+
+// applyMarkdownLine sets the title, tags, priority, due date and estimate
+// of a task from the tokens of its line
+func applyMarkdownLine(t *model.Task, text string, now time.Time) {
+	q := parse.ParseQuickAdd(text, now)
+	t.Title = q.Title
+	t.Tags = q.Tags
+	if t.Tags == nil {
+		t.Tags = []string{}
+	}
+	t.Priority = q.Priority
+	if t.Priority == "" {
+		t.Priority = model.DefaultPriority()
+	}
+	// The due date of the comment has the time of day
+	if q.Due == nil || t.DueDate == nil || !dayStart(*t.DueDate).Equal(*q.Due) {
+		t.DueDate = q.Due
+	}
+	t.Estimate = q.Estimate
+}
+
+// headingStatus returns the status of a heading: the one of its comment,
+// else the status whose label or value it is
+func headingStatus(label, value string) model.Status {
+	if value != "" {
+		return model.Status(value)
+	}
+	for _, s := range model.AllStatuses() {
+		if strings.EqualFold(label, s.Label()) || strings.EqualFold(label, string(s)) {
+			return s
+		}
+	}
+	return model.Status(strings.ReplaceAll(strings.ToLower(label), " ", "_"))
+}
+
+// dayStart returns midnight of the day of t, in the local time zone
+func dayStart(t time.Time) time.Time {
+	y, m, d := t.In(time.Local).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrSnapshotNotFound is returned when restoring a local backup that
+// doesn't exist
+var ErrSnapshotNotFound = errors.New("sauvegarde locale introuvable")
+
+// snapshotTimeFormat is the UTC time in the names of local backups
+const snapshotTimeFormat = "20060102T150405Z"
+
+// Rotation is the retention of the local backups, copies of the tasks file
+// in a .backups directory next to it, taken before each save and before
+// opening it in an editor
+type Rotation struct {
+	// Keep is the number of backups kept, all when zero
+	Keep int
+	// MaxAge deletes the older backups, none when zero
+	MaxAge time.Duration
+}
+
+// Snapshot is a local backup of the tasks file
+type Snapshot struct {
+	Name string
+	Path string
+	At   time.Time
+}
+
+// SetRotation enables the local backups with their retention, or disables
+// them when nil
+func (s *Storage) SetRotation(r *Rotation) {
+	s.rotation = r
+}
+
+// BackupDir returns the directory of the local backups
+func (s *Storage) BackupDir() string {
+	return filepath.Join(filepath.Dir(s.FilePath), ".backups")
+}
+
+// snapshotName splits the name of the tasks file around the time of its
+// backups: tasks.yaml is backed up as tasks-<time>.yaml
+func (s *Storage) snapshotName() (prefix, ext string) {
+	base := filepath.Base(s.FilePath)
+	ext = filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// Snapshots returns the local backups of the tasks file, oldest first
+func (s *Storage) Snapshots() ([]Snapshot, error) {
+	entries, err := os.ReadDir(s.BackupDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, ext := s.snapshotName()
+	var snapshots []Snapshot
+	for _, e := range entries {
+		stamp, ok := strings.CutPrefix(e.Name(), prefix)
+		if !ok || e.IsDir() {
+			continue
+		}
+		stamp, ok = strings.CutSuffix(stamp, ext)
+		if !ok {
+			continue
+		}
+		at, err := time.Parse(snapshotTimeFormat, stamp)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, Snapshot{
+			Name: e.Name(),
+			Path: filepath.Join(s.BackupDir(), e.Name()),
+			At:   at,
+		})
+	}
+	slices.SortFunc(snapshots, func(a, b Snapshot) int { return a.At.Compare(b.At) })
+	return snapshots, nil
+}
+
+// Snapshot copies the tasks file to the backups directory, unless the
+// local backups are disabled, the file is empty or its latest backup has
+// the same content, then deletes the backups past the retention
+func (s *Storage) Snapshot(now time.Time) error {
+	if s.rotation == nil {
+		return nil
+	}
+	data, err := os.ReadFile(s.FilePath)
+	if errors.Is(err, os.ErrNotExist) || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := s.Snapshots()
+	if err != nil {
+		return err
+	}
+	if n := len(snapshots); n > 0 {
+		if latest, err := os.ReadFile(snapshots[n-1].Path); err == nil && bytes.Equal(latest, data) {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(s.BackupDir(), 0755); err != nil {
+		return err
+	}
+	prefix, ext := s.snapshotName()
+	name := prefix + now.UTC().Format(snapshotTimeFormat) + ext
+	// Two saves in the same second: the first backup is the one to keep
+	if !slices.ContainsFunc(snapshots, func(sn Snapshot) bool { return sn.Name == name }) {
+		if err := writeAtomic(filepath.Join(s.BackupDir(), name), data, 0644); err != nil {
+			return err
+		}
+		snapshots = append(snapshots, Snapshot{Name: name, Path: filepath.Join(s.BackupDir(), name), At: now.UTC()})
+	}
+	return s.prune(snapshots, now)
+}
+
+// prune deletes the backups past the retention, always keeping the latest
+func (s *Storage) prune(snapshots []Snapshot, now time.Time) error {
+	for i, sn := range snapshots[:max(len(snapshots)-1, 0)] {
+		tooMany := s.rotation.Keep > 0 && len(snapshots)-i > s.rotation.Keep
+		tooOld := s.rotation.MaxAge > 0 && now.Sub(sn.At) > s.rotation.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(sn.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindSnapshot returns the local backup of a name, or of a path; the
+// latest when name is empty
+func (s *Storage) FindSnapshot(name string) (Snapshot, error) {
+	snapshots, err := s.Snapshots()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if name == "" {
+		if len(snapshots) == 0 {
+			return Snapshot{}, ErrSnapshotNotFound
+		}
+		return snapshots[len(snapshots)-1], nil
+	}
+	for _, sn := range snapshots {
+		if sn.Name == name {
+			return sn, nil
+		}
+	}
+	// A copy outside the backups directory
+	if info, err := os.Stat(name); err == nil && !info.IsDir() {
+		return Snapshot{Name: filepath.Base(name), Path: name, At: info.ModTime()}, nil
+	}
+	return Snapshot{}, ErrSnapshotNotFound
+}
+
+// RestoreSnapshot replaces the tasks file with a local backup; the current
+// content is backed up first by WriteRaw
+func (s *Storage) RestoreSnapshot(sn Snapshot) error {
+	data, err := os.ReadFile(sn.Path)
+	if err != nil {
+		return err
+	}
+	return s.WriteRaw(data)
+}
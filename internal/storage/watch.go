@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce groups bursts of events (editors often write several times)
+const watchDebounce = 200 * time.Millisecond
+
+// Watcher notifies about changes made to the tasks file by other programs
+type Watcher struct {
+	watcher *fsnotify.Watcher
+	changes chan struct{}
+}
+
+// Watch starts watching the tasks file for external changes. The parent
+// directory is watched since atomic saves replace the file.
+func (s *Storage) Watch() (*Watcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Add(filepath.Dir(s.FilePath)); err != nil {
+		fw.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		watcher: fw,
+		changes: make(chan struct{}, 1),
+	}
+	go w.run(s)
+	return w, nil
+}
+
+// Changes returns a channel receiving a value after each external change
+func (w *Watcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	return w.watcher.Close()
+}
+
+// run forwards debounced events concerning the tasks file
+func (w *Watcher) run(s *Storage) {
+	name := filepath.Clean(s.FilePath)
+	var timer <-chan time.Time
+
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != name {
+				continue
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) {
+				timer = time.After(watchDebounce)
+			}
+		case _, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+		case <-timer:
+			timer = nil
+			if s.changedExternally() {
+				select {
+				case w.changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// changedExternally returns true if the file content differs from what
+// this instance last loaded or saved
+func (s *Storage) changedExternally() bool {
+	data, err := s.ReadRaw()
+	if err != nil {
+		return false
+	}
+	sum := sha256.Sum256(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sum != s.lastSum
+}
+
+// remember records the content last loaded or saved by this instance
+func (s *Storage) remember(data []byte) {
+	sum := sha256.Sum256(data)
+
+	s.mu.Lock()
+	s.lastSum = sum
+	s.mu.Unlock()
+}
@@ -1,10 +1,17 @@
 package storage
 
 import (
+	"crypto/sha256"
+	"errors"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"lazy-todo/internal/model"
@@ -12,14 +19,51 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// Storage handles persistence of tasks to YAML file
+// ErrNotTasks is returned when text read as tasks isn't tasks in YAML
+var ErrNotTasks = errors.New("le texte n'est pas une tâche en YAML")
+
+// Storage handles persistence of tasks to a YAML (or JSON) file
 type Storage struct {
 	FilePath string
+	format   Format
+
+	mu      sync.Mutex
+	lastSum [32]byte             // hash of the content last loaded or saved
+	written map[string]time.Time // update times written by this instance
+	// Colors of the tags, kept with the tasks of the file
+	tagColors map[string]string
+	// Number of the next task created, kept with the tasks of the file
+	nextNumber int
+	// Notes about days, kept with the tasks of the file
+	journal []model.JournalEntry
+	// Retention of the local backups, disabled when nil
+	rotation *Rotation
+	// readOnly refuses every write, see SetReadOnly
+	readOnly bool
+	// Problems of the tasks repaired by the last Load
+	problems []model.Problem
+	// Tasks last loaded or saved, with the hash of their content and their
+	// index, so that loading the same content again doesn't parse it
+	cache    []model.Task
+	cacheSum [32]byte
+	index    *model.Index
+
+	// Changes waiting to be saved by Flush, see Queue
+	pending   Changes
+	saveDelay time.Duration
+	saveTimer *time.Timer
+	flushing  sync.Mutex // held while saving the pending changes
+	saves     chan Saved
 }
 
 // NewStorage creates a new Storage instance
 func NewStorage(filePath string) *Storage {
-	return &Storage{FilePath: filePath}
+	return &Storage{
+		FilePath: filePath,
+		format:   FormatOf(filePath),
+		written:  map[string]time.Time{},
+		saves:    make(chan Saved, 1),
+	}
 }
 
 // DefaultFilePath returns the default path for the tasks file
@@ -44,106 +88,396 @@ func DefaultFilePath() string {
 	return filepath.Join(appDir, "tasks.yaml")
 }
 
-// Load reads tasks from the YAML file
+// Load reads tasks from the tasks file
 func (s *Storage) Load() ([]model.Task, error) {
-	data, err := os.ReadFile(s.FilePath)
+	data, err := readLimited(s.FilePath)
 	if err != nil {
 		if os.IsNotExist(err) {
+			s.setCache([32]byte{}, nil)
 			return []model.Task{}, nil
 		}
 		return nil, err
 	}
 
+	sum := sha256.Sum256(data)
+	if tasks, ok := s.cached(sum); ok {
+		return tasks, nil
+	}
+
 	var store model.TaskStore
-	if err := yaml.Unmarshal(data, &store); err != nil {
+	err = decode(s.format, data, &store)
+	if err := s.verify(data, err); err != nil {
 		return nil, err
 	}
+	s.remember(data)
+	migrate(store.Tasks)
 
+	// Tasks edited by hand are repaired and tasks added by hand or by an
+	// older version get a number, saved with the next change
+	modified := time.Now()
+	if info, err := os.Stat(s.FilePath); err == nil {
+		modified = info.ModTime()
+	}
+	problems := model.RepairTasks(store.Tasks, store.BadTimes, modified)
+	next := model.NumberTasks(store.Tasks, store.NextNumber)
+	s.mu.Lock()
+	s.tagColors = store.TagColors
+	s.journal = store.Journal
+	s.nextNumber = next
+	s.problems = problems
+	s.mu.Unlock()
+	s.setCache(sum, store.Tasks)
 	return store.Tasks, nil
 }
 
-// Save writes tasks to the YAML file
-func (s *Storage) Save(tasks []model.Task) error {
-	// Ensure directory exists
-	dir := filepath.Dir(s.FilePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
+// cached returns a copy of the tasks last loaded or saved if their content
+// has the given hash
+func (s *Storage) cached(sum [32]byte) ([]model.Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.index == nil || sum != s.cacheSum {
+		return nil, false
 	}
+	return model.CloneTasks(s.cache), true
+}
 
-	store := model.TaskStore{Tasks: tasks}
-	data, err := yaml.Marshal(&store)
-	if err != nil {
-		return err
+// setCache keeps and indexes a copy of the tasks loaded or saved, which
+// callers modify in place
+func (s *Storage) setCache(sum [32]byte, tasks []model.Task) {
+	cache := model.CloneTasks(tasks)
+	index := model.NewIndex(cache)
+	s.mu.Lock()
+	s.cache, s.cacheSum, s.index = cache, sum, index
+	s.mu.Unlock()
+}
+
+// Index returns the index of the tasks last loaded or saved, nil before
+// the first Load; none are when the file doesn't exist
+func (s *Storage) Index() *model.Index {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.index
+}
+
+// Problems returns the problems of the tasks repaired by the last Load
+func (s *Storage) Problems() []model.Problem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.problems
+}
+
+// migrate updates legacy priority and status values
+func migrate(tasks []model.Task) {
+	for i := range tasks {
+		tasks[i].Priority = model.MigratePriority(tasks[i].Priority)
+		tasks[i].Status = model.MigrateStatus(tasks[i].Status)
 	}
+}
 
-	return os.WriteFile(s.FilePath, data, 0644)
+// Save writes tasks to the tasks file
+func (s *Storage) Save(tasks []model.Task) error {
+	return s.withLock(func() error {
+		return s.save(tasks)
+	})
 }
 
-// AddTask adds a new task and saves
-func (s *Storage) AddTask(task model.Task) ([]model.Task, error) {
-	tasks, err := s.Load()
+// save atomically writes tasks to the tasks file, the caller holds the lock
+func (s *Storage) save(tasks []model.Task) error {
+	s.mu.Lock()
+	s.nextNumber = model.NumberTasks(tasks, s.nextNumber)
+	store := model.TaskStore{Tasks: tasks, TagColors: s.tagColors, NextNumber: s.nextNumber, Journal: s.journal}
+	s.mu.Unlock()
+	data, err := s.format.Marshal(&store)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	tasks = append(tasks, task)
-	if err := s.Save(tasks); err != nil {
-		return nil, err
+	if err := s.Snapshot(time.Now()); err != nil {
+		return err
 	}
-
-	return tasks, nil
+	if err := writeAtomic(s.FilePath, data, 0644); err != nil {
+		return err
+	}
+	s.remember(data)
+	s.setCache(sha256.Sum256(data), tasks)
+	// Saved with their repairs
+	s.mu.Lock()
+	s.problems = nil
+	s.mu.Unlock()
+	return s.writeChecksum(data, len(tasks))
 }
 
-// UpdateTask updates an existing task
-func (s *Storage) UpdateTask(task model.Task) ([]model.Task, error) {
-	tasks, err := s.Load()
+// modify runs a load/modify/save cycle under the file lock and returns the
+// saved tasks
+func (s *Storage) modify(fn func(tasks []model.Task) ([]model.Task, error)) ([]model.Task, error) {
+	var result []model.Task
+	err := s.withLock(func() error {
+		tasks, err := s.Load()
+		if err != nil {
+			return err
+		}
+		tasks, err = fn(tasks)
+		if err != nil {
+			return err
+		}
+		result = tasks
+		return s.save(tasks)
+	})
 	if err != nil {
 		return nil, err
 	}
+	return result, nil
+}
 
-	task.UpdatedAt = time.Now()
+// Repair saves the tasks file with the repairs of Load, and returns the
+// problems repaired
+func (s *Storage) Repair() ([]model.Problem, error) {
+	var problems []model.Problem
+	_, err := s.modify(func(tasks []model.Task) ([]model.Task, error) {
+		problems = s.Problems()
+		return tasks, nil
+	})
+	return problems, err
+}
 
-	for i, t := range tasks {
-		if t.ID == task.ID {
-			tasks[i] = task
-			break
+// AddTask adds a new task and saves
+func (s *Storage) AddTask(task model.Task) ([]model.Task, error) {
+	return s.Commit(Changes{Added: []model.Task{task}})
+}
+
+// AddTasks adds several tasks at once and saves. Tasks whose ID already
+// exists are skipped; the number of added tasks is returned.
+func (s *Storage) AddTasks(newTasks []model.Task) ([]model.Task, int, error) {
+	added := 0
+	tasks, err := s.modify(func(tasks []model.Task) ([]model.Task, error) {
+		existing := make(map[string]bool, len(tasks))
+		for _, t := range tasks {
+			existing[t.ID] = true
 		}
-	}
+		for _, t := range newTasks {
+			if existing[t.ID] {
+				continue
+			}
+			existing[t.ID] = true
+			tasks = append(tasks, t)
+			added++
+		}
+		return tasks, nil
+	})
+	return tasks, added, err
+}
 
-	if err := s.Save(tasks); err != nil {
-		return nil, err
-	}
+// UpdateTask updates an existing task. It fails with ErrConflict if the
+// stored task was modified by another instance since the given copy was
+// loaded.
+func (s *Storage) UpdateTask(task model.Task) ([]model.Task, error) {
+	return s.Commit(Changes{Updated: []model.Task{task}})
+}
 
-	return tasks, nil
+// UpdateTasks updates several tasks at once, with the same conflict
+// detection as UpdateTask
+func (s *Storage) UpdateTasks(updated []model.Task) ([]model.Task, error) {
+	return s.Commit(Changes{Updated: updated})
+}
+
+// wroteVersion returns true if the stored version of a task was written by
+// this instance, e.g. by a previous update not yet reloaded by the UI
+func (s *Storage) wroteVersion(t model.Task) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	written, ok := s.written[t.ID]
+	return ok && written.Equal(t.UpdatedAt)
+}
+
+// ReorderTasks sets the manual order of tasks by ID without touching
+// their update time
+func (s *Storage) ReorderTasks(order map[string]int) ([]model.Task, error) {
+	return s.Commit(Changes{Order: order})
 }
 
 // DeleteTask removes a task by ID
 func (s *Storage) DeleteTask(id string) ([]model.Task, error) {
-	tasks, err := s.Load()
+	return s.Commit(Changes{Deleted: []string{id}})
+}
+
+// TagColors returns the colors of the tags, as loaded or set last
+func (s *Storage) TagColors() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.tagColors)
+}
+
+// SetTagColors sets the color of tags and saves; an empty color removes
+// the tag's
+func (s *Storage) SetTagColors(colors map[string]string) ([]model.Task, error) {
+	return s.modify(func(tasks []model.Task) ([]model.Task, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for tag, color := range colors {
+			if color == "" {
+				delete(s.tagColors, tag)
+				continue
+			}
+			if s.tagColors == nil {
+				s.tagColors = map[string]string{}
+			}
+			s.tagColors[tag] = color
+		}
+		return tasks, nil
+	})
+}
+
+// Journal returns the notes about days, as loaded or added last
+func (s *Storage) Journal() []model.JournalEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.journal)
+}
+
+// AddJournalEntry adds a note about a day and saves
+func (s *Storage) AddJournalEntry(entry model.JournalEntry) ([]model.Task, error) {
+	return s.modify(func(tasks []model.Task) ([]model.Task, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.journal = append(s.journal, entry)
+		return tasks, nil
+	})
+}
+
+// SetJournalDay replaces the notes about a day by text and saves; a blank
+// text removes them
+func (s *Storage) SetJournalDay(day, text string, now time.Time) ([]model.Task, error) {
+	return s.modify(func(tasks []model.Task) ([]model.Task, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.journal = model.SetDayJournal(s.journal, day, text, now)
+		return tasks, nil
+	})
+}
+
+// ReadRaw returns the raw content of the tasks file
+func (s *Storage) ReadRaw() ([]byte, error) {
+	data, err := readLimited(s.FilePath)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return []byte{}, nil
+		}
 		return nil, err
 	}
+	return data, nil
+}
 
-	var newTasks []model.Task
-	for _, t := range tasks {
-		if t.ID != id {
-			newTasks = append(newTasks, t)
+// WriteRaw replaces the tasks file with data, which must hold valid tasks.
+// The previous content is kept next to it, with a .bak extension.
+func (s *Storage) WriteRaw(data []byte) error {
+	tasks, err := Validate(data)
+	if err != nil {
+		return err
+	}
+	return s.withLock(func() error {
+		old, err := s.ReadRaw()
+		if err != nil {
+			return err
+		}
+		if len(old) > 0 {
+			if err := writeAtomic(s.FilePath+".bak", old, 0644); err != nil {
+				return err
+			}
 		}
+		if err := s.Snapshot(time.Now()); err != nil {
+			return err
+		}
+		if err := writeAtomic(s.FilePath, data, 0644); err != nil {
+			return err
+		}
+		s.remember(data)
+		return s.writeChecksum(data, tasks)
+	})
+}
+
+// ReadLocalBackup returns the previous content kept by WriteRaw, and when
+// it was written
+func (s *Storage) ReadLocalBackup() ([]byte, time.Time, error) {
+	path := s.FilePath + ".bak"
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
 	}
+	data, err := os.ReadFile(path)
+	return data, info.ModTime(), err
+}
+
+// MarshalTask returns the YAML representation of a single task
+func MarshalTask(task model.Task) ([]byte, error) {
+	return yaml.Marshal(&task)
+}
 
-	if err := s.Save(newTasks); err != nil {
+// UnmarshalTasks reads tasks copied as YAML: a single task as written by
+// MarshalTask, a list of tasks or a whole tasks file. It fails with
+// ErrNotTasks for any other text, and ErrTooLarge or ErrUnsafe for text
+// past the limits.
+func UnmarshalTasks(data []byte) ([]model.Task, error) {
+	if len(data) > MaxFileSize {
+		return nil, ErrTooLarge
+	}
+	doc, err := parseYAML(data)
+	if errors.Is(err, ErrUnsafe) {
 		return nil, err
 	}
+	if err != nil || doc.Kind == 0 {
+		return nil, ErrNotTasks
+	}
 
-	return newTasks, nil
+	var tasks []model.Task
+	var store model.TaskStore
+	var task model.Task
+	switch {
+	case doc.Decode(&store) == nil && len(store.Tasks) > 0:
+		tasks = store.Tasks
+	case doc.Decode(&tasks) == nil && len(tasks) > 0:
+	case doc.Decode(&task) == nil && task.Title != "":
+		tasks = []model.Task{task}
+	default:
+		return nil, ErrNotTasks
+	}
+	for _, t := range tasks {
+		if t.Title == "" {
+			return nil, ErrNotTasks
+		}
+	}
+	migrate(tasks)
+	return tasks, nil
 }
 
-// OpenInEditor opens the YAML file in the default editor
-func (s *Storage) OpenInEditor() error {
+// Editor returns the editor configured through $EDITOR or $VISUAL
+func Editor() string {
 	editor := os.Getenv("EDITOR")
 	if editor == "" {
 		editor = os.Getenv("VISUAL")
 	}
+	return editor
+}
+
+// EditorAvailable returns true if the configured editor can be executed
+func EditorAvailable() bool {
+	editor := Editor()
+	if editor == "" {
+		return false
+	}
+	_, err := exec.LookPath(editor)
+	return err == nil
+}
+
+// EditorCommand returns a command opening path in the user's editor
+func EditorCommand(path string) *exec.Cmd {
+	return EditorCommandAt(path, 0)
+}
+
+// EditorCommandAt returns a command opening path in the user's editor at
+// a line, for the editors known to take one; at the start of the file for
+// the others or when line is 0
+func EditorCommandAt(path string, line int) *exec.Cmd {
+	editor := Editor()
 	if editor == "" {
 		// Default editors based on OS
 		switch runtime.GOOS {
@@ -155,8 +489,57 @@ func (s *Storage) OpenInEditor() error {
 			editor = "nano"
 		}
 	}
+	if line <= 0 {
+		return exec.Command(editor, path)
+	}
+
+	at := strconv.Itoa(line)
+	switch strings.TrimSuffix(filepath.Base(editor), ".exe") {
+	case "vi", "vim", "nvim", "gvim", "nano", "emacs", "emacsclient", "micro", "kak", "joe", "ne", "mg":
+		return exec.Command(editor, "+"+at, path)
+	case "code", "codium", "cursor":
+		return exec.Command(editor, "--wait", "-g", path+":"+at)
+	case "subl", "hx", "helix", "zed":
+		return exec.Command(editor, path+":"+at)
+	}
+	return exec.Command(editor, path)
+}
+
+// EditCommand returns a command opening the tasks file in the user's
+// editor at a line, for tea.ExecProcess; see OpenInEditor
+func (s *Storage) EditCommand(line int) (*exec.Cmd, error) {
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+	if err := s.Snapshot(time.Now()); err != nil {
+		return nil, err
+	}
+	return EditorCommandAt(s.FilePath, line), nil
+}
 
-	cmd := exec.Command(editor, s.FilePath)
+// Overwrite replaces the tasks file with tasks, keeping the previous
+// content next to it like WriteRaw: it puts back the last tasks read from
+// a file that was corrupted since
+func (s *Storage) Overwrite(tasks []model.Task) error {
+	s.mu.Lock()
+	store := model.TaskStore{Tasks: tasks, TagColors: s.tagColors, NextNumber: s.nextNumber, Journal: s.journal}
+	s.mu.Unlock()
+	data, err := s.format.Marshal(&store)
+	if err != nil {
+		return err
+	}
+	return s.WriteRaw(data)
+}
+
+// OpenInEditor opens the YAML file in the default editor
+func (s *Storage) OpenInEditor() error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if err := s.Snapshot(time.Now()); err != nil {
+		return err
+	}
+	cmd := EditorCommand(s.FilePath)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"lazy-todo/internal/model"
+)
+
+// loadFile loads a tasks file of the given content
+func loadFile(t *testing.T, name, content string) (*Storage, []model.Task) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := NewStorage(path)
+	tasks, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return s, tasks
+}
+
+// problemKinds returns the kinds of problems, in order
+func problemKinds(problems []model.Problem) []model.ProblemKind {
+	var kinds []model.ProblemKind
+	for _, p := range problems {
+		kinds = append(kinds, p.Kind)
+	}
+	return kinds
+}
+
+func TestLoadBadTimes(t *testing.T) {
+	files := map[string]string{
+		"tasks.yaml": `tasks:
+  - id: a
+    title: Bad
+    priority: high
+    status: todo
+    created_at: yesterday
+    updated_at: 2026-10-01T10:00:00Z
+    due_date: soon
+  - id: b
+    title: Missing
+    priority: high
+    status: todo
+`,
+		"tasks.json": `{"tasks": [
+  {"id": "a", "title": "Bad", "priority": "high", "status": "todo",
+   "created_at": "yesterday", "updated_at": "2026-10-01T10:00:00Z", "due_date": "soon"},
+  {"id": "b", "title": "Missing", "priority": "high", "status": "todo"}
+]}`,
+	}
+	for name, content := range files {
+		s, tasks := loadFile(t, name, content)
+		want := []model.ProblemKind{model.ProblemBadTime, model.ProblemBadTime, model.ProblemNoCreatedAt, model.ProblemNoUpdatedAt}
+		if got := problemKinds(s.Problems()); !slices.Equal(got, want) {
+			t.Errorf("%s: problems %v, want %v", name, got, want)
+		}
+		if len(tasks) != 2 {
+			t.Fatalf("%s: %d tasks, want 2", name, len(tasks))
+		}
+		if !tasks[0].CreatedAt.Equal(tasks[0].UpdatedAt) || tasks[0].DueDate != nil {
+			t.Errorf("%s: created %v, updated %v, due %v", name, tasks[0].CreatedAt, tasks[0].UpdatedAt, tasks[0].DueDate)
+		}
+		if tasks[1].CreatedAt.IsZero() || tasks[1].UpdatedAt.IsZero() {
+			t.Errorf("%s: times left out", name)
+		}
+
+		// Saved repaired, nothing left
+		if _, err := s.Repair(); err != nil {
+			t.Fatal(err)
+		}
+		s2 := NewStorage(s.GetFilePath())
+		if _, err := s2.Load(); err != nil {
+			t.Fatal(err)
+		}
+		if problems := s2.Problems(); len(problems) > 0 {
+			t.Errorf("%s: problems left after repair: %v", name, problemKinds(problems))
+		}
+	}
+}
+
+func TestLoadKeepsUnknownPriority(t *testing.T) {
+	levels, def := model.AllPriorities(), model.DefaultPriority()
+	model.SetPriorities([]model.Priority{"trivial", "minor", "major", "blocker"}, "major")
+	t.Cleanup(func() { model.SetPriorities(levels, def) })
+
+	s, tasks := loadFile(t, "tasks.yaml", `tasks:
+  - id: a
+    title: High
+    priority: high
+    status: todo
+    created_at: 2026-10-01T10:00:00Z
+    updated_at: 2026-10-01T10:00:00Z
+`)
+	if tasks[0].Priority != "high" {
+		t.Errorf("priority %q, want high kept", tasks[0].Priority)
+	}
+	problems := s.Problems()
+	if len(problems) != 1 || problems[0].Kind != model.ProblemPriority || problems[0].Repaired() {
+		t.Fatalf("problems %v, want an unknown priority only reported", problemKinds(problems))
+	}
+
+	if _, err := s.Repair(); err != nil {
+		t.Fatal(err)
+	}
+	if tasks, _ := NewStorage(s.GetFilePath()).Load(); tasks[0].Priority != "high" {
+		t.Errorf("priority %q after repair, want high kept", tasks[0].Priority)
+	}
+}
+
+// hostileFixtures are tasks content past the limits or malformed, the
+// seeds of the fuzz tests
+func hostileFixtures() map[string]string {
+	// Billion laughs: each level holds 20 aliases of the one before
+	bomb := "a: &a [x, x, x, x, x, x, x, x, x, x]\n"
+	for i, prev := 0, "a"; i < 15; i++ {
+		name := string(rune('b' + i))
+		bomb += name + ": &" + name + " [" + strings.Repeat("*"+prev+", ", 19) + "*" + prev + "]\n"
+		prev = name
+	}
+	deepMaps := ""
+	for i := 1; i <= maxDepth+10; i++ {
+		deepMaps += strings.Repeat("  ", i) + "a:\n"
+	}
+	return map[string]string{
+		"valid":      "tasks:\n  - id: a\n    title: Tâche\n    priority: high\n    status: todo\n",
+		"empty":      "",
+		"unclosed":   "tasks: [\n",
+		"tabs":       "tasks:\n  - id: a\n\ttitle: x\n",
+		"not tasks":  "tasks: 12\n",
+		"bad time":   "tasks:\n  - id: a\n    title: x\n    created_at: [1, 2]\n",
+		"deep":       "tasks: " + strings.Repeat("[", maxDepth+10) + strings.Repeat("]", maxDepth+10) + "\n",
+		"deep maps":  "tasks:\n" + deepMaps,
+		"alias bomb": bomb + "tasks: []\n",
+		"huge value": "tasks:\n  - id: a\n    title: x\n    description: " + strings.Repeat("x", maxValueSize+1) + "\n",
+		"json":       `{"tasks": [{"id": "a", "title": "x", "created_at": "yesterday"}]}`,
+		"nul":        "tasks: []\n\x00",
+	}
+}
+
+// limitError returns ErrUnsafe when YAML content is past the nesting,
+// alias or value size limits, nil otherwise
+func limitError(data []byte) error {
+	if _, err := parseYAML(data); errors.Is(err, ErrUnsafe) {
+		return ErrUnsafe
+	}
+	return nil
+}
+
+func FuzzLoad(f *testing.F) {
+	for _, content := range hostileFixtures() {
+		f.Add([]byte(content))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		path := filepath.Join(t.TempDir(), "tasks.yaml")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		tasks, err := NewStorage(path).Load()
+		if limit := limitError(data); limit != nil && !errors.Is(err, limit) {
+			t.Fatalf("content past the limits loaded with %v, want %v", err, limit)
+		}
+		if err != nil {
+			// Without a checksum, content that can't be read is corrupted
+			if !errors.Is(err, ErrCorrupted) {
+				t.Fatalf("untyped error %v", err)
+			}
+			return
+		}
+		ids := make(map[string]bool, len(tasks))
+		for _, task := range tasks {
+			if task.ID == "" || ids[task.ID] || task.Title == "" || task.CreatedAt.IsZero() {
+				t.Fatalf("task loaded unrepaired: %+v", task)
+			}
+			ids[task.ID] = true
+		}
+	})
+}
+
+func FuzzUnmarshalTasks(f *testing.F) {
+	for _, content := range hostileFixtures() {
+		f.Add([]byte(content))
+	}
+	f.Add([]byte("- id: a\n  title: x\n"))
+	f.Add([]byte("id: a\ntitle: x\n"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tasks, err := UnmarshalTasks(data)
+		if limit := limitError(data); limit != nil && !errors.Is(err, limit) {
+			t.Fatalf("content past the limits read with %v, want %v", err, limit)
+		}
+		if err != nil {
+			if !errors.Is(err, ErrNotTasks) && !errors.Is(err, ErrUnsafe) && !errors.Is(err, ErrTooLarge) {
+				t.Fatalf("untyped error %v", err)
+			}
+			return
+		}
+		if len(tasks) == 0 {
+			t.Fatal("no tasks and no error")
+		}
+		for _, task := range tasks {
+			if task.Title == "" {
+				t.Fatalf("task without title: %+v", task)
+			}
+		}
+	})
+}
+
+func TestLoadPastLimits(t *testing.T) {
+	fixtures := hostileFixtures()
+	for _, name := range []string{"deep", "deep maps", "alias bomb", "huge value"} {
+		path := filepath.Join(t.TempDir(), "tasks.yaml")
+		if err := os.WriteFile(path, []byte(fixtures[name]), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewStorage(path).Load(); !errors.Is(err, ErrUnsafe) {
+			t.Errorf("%s: Load %v, want ErrUnsafe", name, err)
+		}
+		if _, err := UnmarshalTasks([]byte(fixtures[name])); !errors.Is(err, ErrUnsafe) {
+			t.Errorf("%s: UnmarshalTasks %v, want ErrUnsafe", name, err)
+		}
+	}
+}
+
+func TestLoadTooLarge(t *testing.T) {
+	data := []byte("tasks: []\n# " + strings.Repeat("x", MaxFileSize))
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewStorage(path).Load(); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Load of a file past MaxFileSize: %v, want ErrTooLarge", err)
+	}
+	if _, err := UnmarshalTasks(data); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("UnmarshalTasks past MaxFileSize: %v, want ErrTooLarge", err)
+	}
+}
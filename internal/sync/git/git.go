@@ -0,0 +1,199 @@
+// Package git versions the tasks file in the git repository containing it,
+// and syncs it with a remote, by running the git command.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultRemote is the remote used when none is configured
+const DefaultRemote = "origin"
+
+// Errors returned when the tasks file can't be synced
+var (
+	ErrNoGit    = errors.New("git introuvable")
+	ErrNotRepo  = errors.New("le fichier n'est pas dans un dépôt git")
+	ErrNoRemote = errors.New("aucun dépôt distant configuré")
+	ErrNoBranch = errors.New("aucune branche courante")
+	ErrConflict = errors.New("la récupération a échoué, modifications distantes en conflit")
+)
+
+// Repo is the git repository containing a tasks file
+type Repo struct {
+	mu     sync.Mutex // git commands share the index
+	dir    string     // root of the work tree
+	file   string     // tasks file, relative to dir
+	remote string
+}
+
+// Open returns the repository containing the tasks file at path
+func Open(path, remote string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, ErrNoGit
+	}
+	if remote == "" {
+		remote = DefaultRemote
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	// Resolve symlinks so that the path is relative to the real work tree
+	if real, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		abs = filepath.Join(real, filepath.Base(abs))
+	}
+
+	r := &Repo{dir: filepath.Dir(abs), remote: remote}
+	top, err := r.run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, ErrNotRepo
+	}
+	r.dir = top
+	if r.file, err = filepath.Rel(top, abs); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Commit commits the tasks file with message. Nothing is committed when
+// the file didn't change; other files of the repository are left alone.
+func (r *Repo) Commit(message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.commit(message)
+}
+
+func (r *Repo) commit(message string) error {
+	if _, err := os.Stat(filepath.Join(r.dir, r.file)); os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := r.run("add", "--", r.file); err != nil {
+		return err
+	}
+	// Exits with 1 when the staged file differs from HEAD
+	if _, err := r.run("diff", "--cached", "--quiet", "--", r.file); err == nil {
+		return nil
+	}
+	_, err := r.run("commit", "--quiet", "--no-verify", "-m", message, "--", r.file)
+	return err
+}
+
+// Pull commits the tasks file if needed, then rebases the current branch
+// on the remote. A rebase that conflicts is aborted, leaving the local
+// history as it was.
+func (r *Repo) Pull() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pull()
+}
+
+func (r *Repo) pull() error {
+	branch, err := r.branch()
+	if err != nil {
+		return err
+	}
+	if err := r.commit("Update tasks"); err != nil {
+		return err
+	}
+	if err := r.checkRemote(); err != nil {
+		return err
+	}
+	// The branch doesn't exist on the remote until the first push: ls-remote
+	// exits with 2 when no ref matches
+	if _, err := r.run("ls-remote", "--exit-code", "--heads", r.remote, branch); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+			return nil
+		}
+		return err
+	}
+	if _, err := r.run("pull", "--quiet", "--rebase", "--autostash", r.remote, branch); err != nil {
+		if _, abortErr := r.run("rebase", "--abort"); abortErr == nil {
+			return ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// Push pushes the current branch to the remote
+func (r *Repo) Push() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.push()
+}
+
+func (r *Repo) push() error {
+	branch, err := r.branch()
+	if err != nil {
+		return err
+	}
+	if err := r.checkRemote(); err != nil {
+		return err
+	}
+	_, err = r.run("push", "--quiet", r.remote, branch)
+	return err
+}
+
+// Sync pulls the remote changes, then pushes the local ones
+func (r *Repo) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.pull(); err != nil {
+		return err
+	}
+	return r.push()
+}
+
+// branch returns the current branch
+func (r *Repo) branch() (string, error) {
+	branch, err := r.run("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return "", ErrNoBranch
+	}
+	return branch, nil
+}
+
+// checkRemote returns ErrNoRemote if the remote isn't configured
+func (r *Repo) checkRemote() error {
+	if _, err := r.run("remote", "get-url", r.remote); err != nil {
+		return ErrNoRemote
+	}
+	return nil
+}
+
+// run runs git in the work tree and returns its trimmed output. Prompts
+// are disabled: they would hang behind the interface.
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if os.Getenv("GIT_SSH_COMMAND") == "" {
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], firstLine(msg))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// firstLine returns the first line of s
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+)
+
+// Message describes a batch of changes as a commit message: a subject
+// naming the task when a single one changed, and a line per task in the
+// body otherwise. tasks are the tasks before the batch, used to name the
+// deleted ones.
+func Message(c storage.Changes, tasks []model.Task) string {
+	title := func(id string) string {
+		for _, t := range tasks {
+			if t.ID == id {
+				return t.Title
+			}
+		}
+		return id
+	}
+
+	var lines []string
+	for _, t := range c.Added {
+		lines = append(lines, fmt.Sprintf("Add %q", t.Title))
+	}
+	for _, t := range c.Updated {
+		if t.Status.IsDone() && wasOpen(tasks, t.ID) {
+			lines = append(lines, fmt.Sprintf("Complete %q", t.Title))
+		} else {
+			lines = append(lines, fmt.Sprintf("Update %q", t.Title))
+		}
+	}
+	for _, id := range c.Deleted {
+		lines = append(lines, fmt.Sprintf("Delete %q", title(id)))
+	}
+
+	switch {
+	case len(lines) == 1:
+		return lines[0]
+	case len(lines) == 0 && len(c.Order) > 0:
+		return "Reorder tasks"
+	case len(lines) == 0:
+		return "Update tasks"
+	}
+
+	var counts []string
+	for _, n := range []struct {
+		count int
+		verb  string
+	}{{len(c.Added), "added"}, {len(c.Updated), "updated"}, {len(c.Deleted), "deleted"}} {
+		if n.count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n.count, n.verb))
+		}
+	}
+	return "Update tasks (" + strings.Join(counts, ", ") + ")\n\n" + strings.Join(lines, "\n")
+}
+
+// wasOpen returns true if the task with the given ID wasn't done before
+func wasOpen(tasks []model.Task, id string) bool {
+	for _, t := range tasks {
+		if t.ID == id {
+			return !t.Status.IsDone()
+		}
+	}
+	return false
+}
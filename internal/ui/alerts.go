@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/notify"
+	"lazy-todo/internal/storage"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// alertInterval is how often the reminders are checked
+	alertInterval = 30 * time.Second
+	// alertFlashRate is how fast the footer banner flashes
+	alertFlashRate = 500 * time.Millisecond
+	// alertFlashes is how many times the banner flashes when a reminder
+	// goes off, before staying highlighted
+	alertFlashes = 12
+)
+
+// alert is a reminder of a task that went off
+type alert struct {
+	taskID string
+	title  string
+	// Index of the reminder in the task's reminders
+	index int
+	at    time.Time
+}
+
+type alertTickMsg struct{}
+type alertFlashMsg struct{}
+
+// alertTick checks the reminders again after alertInterval
+func (a *App) alertTick() tea.Cmd {
+	return tea.Tick(alertInterval, func(time.Time) tea.Msg { return alertTickMsg{} })
+}
+
+// refreshAlerts lists the reminders that went off, flashing the footer and
+// ringing the bell when there are new ones
+func (a *App) refreshAlerts(now time.Time) tea.Cmd {
+	previous := a.alerts
+	a.alerts = nil
+	for _, t := range a.tasks {
+		for _, i := range t.PendingReminders(now) {
+			at, _ := t.Reminders[i].Time(t.DueDate)
+			a.alerts = append(a.alerts, alert{taskID: t.ID, title: t.Title, index: i, at: at})
+		}
+	}
+	slices.SortStableFunc(a.alerts, func(x, y alert) int { return x.at.Compare(y.at) })
+	a.alertCursor = min(a.alertCursor, max(len(a.alerts)-1, 0))
+	if len(a.alerts) == 0 && a.state == StateAlerts {
+		a.state = StateNormal
+	}
+
+	fresh := false
+	for _, al := range a.alerts {
+		if !slices.Contains(previous, al) {
+			fresh = true
+			break
+		}
+	}
+	if !fresh {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	if a.config.Reminders.Bell {
+		cmds = append(cmds, ringBell)
+	}
+	a.alertFlash = true
+	if !a.config.Display.ReducedMotion {
+		if a.alertFlashes == 0 {
+			cmds = append(cmds, alertFlashTick())
+		}
+		a.alertFlashes = alertFlashes
+	}
+	return tea.Batch(cmds...)
+}
+
+// alertFlashTick toggles the footer banner after alertFlashRate
+func alertFlashTick() tea.Cmd {
+	return tea.Tick(alertFlashRate, func(time.Time) tea.Msg { return alertFlashMsg{} })
+}
+
+// ringBell rings the terminal bell
+func ringBell() tea.Msg {
+	os.Stdout.WriteString("\a")
+	return nil
+}
+
+// handleAlertsKeys handles keys in the list of the reminders that went off
+func (a *App) handleAlertsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "!":
+		a.state = StateNormal
+	case "j", "down":
+		if a.alertCursor < len(a.alerts)-1 {
+			a.alertCursor++
+		}
+	case "k", "up":
+		if a.alertCursor > 0 {
+			a.alertCursor--
+		}
+	case "enter", " ":
+		if a.alertCursor < len(a.alerts) {
+			return a, a.acknowledgeAlerts(a.alerts[a.alertCursor:a.alertCursor+1], false)
+		}
+	case "s":
+		if a.alertCursor < len(a.alerts) {
+			return a, a.acknowledgeAlerts(a.alerts[a.alertCursor:a.alertCursor+1], true)
+		}
+	case "a", "A":
+		return a, a.acknowledgeAlerts(a.alerts, false)
+	}
+	return a, nil
+}
+
+// acknowledgeAlerts marks reminders as shown, or postpones them by the
+// snooze duration
+func (a *App) acknowledgeAlerts(alerts []alert, snooze bool) tea.Cmd {
+	now := time.Now()
+	until := now.Add(a.snoozeDuration())
+	var changes storage.Changes
+	for _, t := range a.tasks {
+		var indices []int
+		for _, al := range alerts {
+			if al.taskID == t.ID {
+				indices = append(indices, al.index)
+			}
+		}
+		if len(indices) == 0 {
+			continue
+		}
+		t.Reminders = append([]model.Reminder(nil), t.Reminders...)
+		for _, i := range indices {
+			if i >= len(t.Reminders) {
+				continue
+			}
+			if snooze {
+				t.Reminders[i].Snoozed = &until
+			} else {
+				t.Reminders[i].Notified = &now
+				t.Reminders[i].Snoozed = nil
+			}
+		}
+		changes.Updated = append(changes.Updated, t)
+	}
+
+	// Hidden right away, without waiting for the save
+	done := slices.Clone(alerts)
+	a.alerts = slices.DeleteFunc(a.alerts, func(al alert) bool { return slices.Contains(done, al) })
+	a.alertCursor = min(a.alertCursor, max(len(a.alerts)-1, 0))
+	if len(a.alerts) == 0 {
+		a.state = StateNormal
+	}
+	if changes.IsEmpty() {
+		return nil
+	}
+	return a.commit(changes)
+}
+
+// snoozeDuration returns how long a snoozed reminder is postponed
+func (a *App) snoozeDuration() time.Duration {
+	if a.config.Reminders.Snooze > 0 {
+		return a.config.Reminders.Snooze
+	}
+	return notify.DefaultSnooze
+}
+
+// renderAlertBanner renders the footer shown while reminders went off
+func (a *App) renderAlertBanner() string {
+	text := "🔔 " + i18n.Tf("Rappel: %s", a.alerts[0].title)
+	if len(a.alerts) > 1 {
+		text += fmt.Sprintf(" (+%d)", len(a.alerts)-1)
+	}
+	text += "  " + i18n.T("!: rappels")
+
+	style := lipgloss.NewStyle().Padding(0, 1).Bold(true)
+	if a.alertFlash {
+		style = style.Background(colorYellow).Foreground(colorBase)
+	} else {
+		style = style.Background(colorMantle).Foreground(colorYellow)
+	}
+	return style.Width(a.width).Render(text)
+}
+
+// renderAlerts renders the list of the reminders that went off
+func (a *App) renderAlerts() string {
+	title := a.styles.DialogTitle.Render(i18n.Tf("Rappels (%d)", len(a.alerts)))
+
+	var lines []string
+	for i, al := range a.alerts {
+		line := al.at.Local().Format("2006-01-02 15:04") + "  " + al.title
+		if i == a.alertCursor {
+			lines = append(lines, lipgloss.NewStyle().Foreground(colorYellow).Bold(true).Render("> "+line))
+		} else {
+			lines = append(lines, lipgloss.NewStyle().Foreground(colorText).Render("  "+line))
+		}
+	}
+
+	help := lipgloss.NewStyle().
+		Foreground(colorOverlay0).
+		Render(i18n.Tf("Entrée: acquitter │ a: tout acquitter │ s: reporter de %s │ Esc: fermer",
+			model.Reminder{Before: a.snoozeDuration()}))
+
+	content := title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
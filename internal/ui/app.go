@@ -1,14 +1,27 @@
 package ui
 
 import (
-	"fmt"
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"lazy-todo/internal/backup"
+	"lazy-todo/internal/clipboard"
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/export"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/integrations/jira"
 	"lazy-todo/internal/keys"
 	"lazy-todo/internal/model"
+	"lazy-todo/internal/parse"
+	"lazy-todo/internal/share"
 	"lazy-todo/internal/storage"
+	"lazy-todo/internal/sync/git"
+	"lazy-todo/internal/webhook"
 
+	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -21,8 +34,21 @@ type ViewMode int
 const (
 	ViewList ViewMode = iota
 	ViewKanban
+	ViewCalendar
+	ViewToday // the list narrowed to the working set of the day
+
+	viewModeCount = iota
 )
 
+// taskView is a view in which a task can be selected
+type taskView interface {
+	MoveUp()
+	MoveDown()
+	SelectedTask() *model.Task
+	SelectedIndex() int
+	SelectTask(id string) bool
+}
+
 // AppState represents the current app state
 type AppState int
 
@@ -33,68 +59,426 @@ const (
 	StateSearch
 	StateConfirmDelete
 	StateTagInput
+	StateYAMLView
+	StateDescEditor
+	StateConfirmReload
+	StateChecklist
+	StateConfirmDone
+	StateExport
+	StateQuickAdd
+	StateNoteInput
+	StateConfirmRestore
+	StateConfirmWIP
+	StateAlerts
+	StateShare
+	StateSprintReview
+	StateVelocity
+	StateKeyConflicts
+	StateTags
+	StateGoTo
+	StateProblems
+	StateListColumns
+	StateWeekPlan
+	StateJournal
+	StateTimeStats
+	StateIdle
+	StateDoctor
 )
 
 // App is the main application model
 type App struct {
-	storage    *storage.Storage
-	tasks      []model.Task
-	styles     Styles
-	keys       keys.KeyMap
-	viewMode   ViewMode
-	state      AppState
-	listView   *ListView
-	kanbanView *KanbanView
-	taskForm   *TaskForm
-	helpPanel  *HelpPanel
+	storage     *storage.Storage
+	config      *config.Config
+	watcher     *storage.Watcher
+	tasks       []model.Task
+	index       *model.Index // of tasks, rebuilt by refreshViews
+	styles      Styles
+	keys        keys.KeyMap
+	viewMode    ViewMode
+	sortBy      model.SortBy
+	state       AppState
+	listView    *ListView
+	kanbanView  *KanbanView
+	calendar    *CalendarView
+	taskForm    *TaskForm
+	helpPanel   *HelpPanel
+	yamlViewer  *YAMLViewer
+	descEditor  *DescriptionEditor
+	checklist   *ChecklistPanel
 	searchInput textinput.Model
 	tagInput    textinput.Model
-	width      int
-	height     int
-	err        error
-	message    string
+	quickInput  textinput.Model
+	noteInput   textinput.Model
+	gotoInput   textinput.Model
+	width       int
+	height      int
+	err         error
+	message     string
 	messageTime time.Time
+
+	// State to resume when the reload prompt is dismissed
+	reloadReturn AppState
+
+	// Lines pasted in quick-add, waiting for confirmation
+	quickPaste []string
+
+	// Deleted task that can still be brought back, nil when none
+	trash     *pendingDelete
+	deleteSeq int
+
+	// Saving on quit failed: quitting again exits without saving, any
+	// other key keeps the changes pending for the next save
+	quitConfirm bool
+
+	// Git repository versioning the tasks file, when enabled
+	repo *git.Repo
+	// Tasks as last read from the file, to describe the commits
+	stored []model.Task
+
+	// Jira site the issues of the tasks imported from it are moved through
+	// the workflow on, when configured
+	jira *jira.Client
+	// Webhooks called on task events, nil when none is configured
+	webhooks *webhook.Sender
+
+	// Paste service tasks are shared through, when configured
+	share *share.Uploader
+	// State of the share prompt: uploading, or the link once uploaded
+	sharing   bool
+	shareLink string
+	shareErr  error
+
+	// Keys typed after the leader key, nil outside of a chord
+	chord []string
+
+	// Set when the tasks file was opened read-only: the keys changing it
+	// are disabled
+	readOnly bool
+
+	// Keys of the configuration bound to several actions or naming unknown
+	// actions, and the row selected on the resolution screen
+	keyConflicts   []keys.Conflict
+	unknownKeys    []string
+	conflictCursor int
+
+	// Problems of the tasks repaired on load, last shown on the problems
+	// screen
+	problems []model.Problem
+
+	// Row selected on the tags screen, and the rename, merge or delete
+	// being entered
+	tagCursor int
+	tagAction string
+
+	// Row selected on the maintenance report
+	doctorCursor int
+
+	// Row selected on the list columns screen
+	columnCursor int
+
+	// Progress by priority shown under the header
+	progressExpanded bool
+
+	// Week planning in progress, nil when closed
+	plan *weekPlan
+
+	// Period of the time stats, in timeStatsPeriods
+	statsPeriod int
+
+	// Idle detection of the timer: the last key pressed, the time idle
+	// (until zero while it lasts) and the state to go back to once asked
+	// what to do with it
+	lastInput  time.Time
+	idleSince  time.Time
+	idleUntil  time.Time
+	idleReturn AppState
+
+	// Journal of the day being edited
+	journalEditor *DescriptionEditor
+	journalDay    time.Time
+
+	// End-of-sprint summary shown, and sprints already summarized
+	sprintReview   *sprintReview
+	sprintReviewed map[string]bool
+
+	// Task moved into a column at its WIP limit, waiting for confirmation
+	wipMove *model.Task
+
+	// Reminders that went off and weren't acknowledged, shown in the
+	// footer for terminals without desktop notifications
+	alerts      []alert
+	alertCursor int
+	// The footer banner is in its highlighted phase
+	alertFlash bool
+	// Flashes left before the banner stays highlighted
+	alertFlashes int
+
+	// Remote backups taken every config.Backup.Interval, when configured
+	backups *backup.Backups
+	// Backup offered when the tasks file is corrupted, nil while searching
+	// or when there is none
+	restore *restoreCandidate
+	// The search for a backup to restore ended
+	restoreSearched bool
 }
 
 // NewApp creates a new App instance
-func NewApp(store *storage.Storage) *App {
+func NewApp(store *storage.Storage, cfg *config.Config) *App {
+	ApplyIcons(cfg)
+
 	styles := DefaultStyles()
+	if cfg.Urgency.Enabled && !cfg.Display.ReducedMotion {
+		styles.Escalated = styles.Escalated.Blink(true)
+	}
 	keyMap := keys.DefaultKeyMap()
+	unknownKeys := keyMap.Override(cfg.KeyOverrides())
+	keyMap.OverrideChords(cfg.Chords)
+	unknownKeys = append(unknownKeys, keyMap.UnknownChordActions()...)
 
 	searchInput := textinput.New()
-	searchInput.Placeholder = "Rechercher..."
+	searchInput.Placeholder = i18n.T("Rechercher...")
 	searchInput.CharLimit = 50
 
 	tagInput := textinput.New()
-	tagInput.Placeholder = "Nouveau tag..."
+	tagInput.Placeholder = i18n.T("Nouveau tag...")
 	tagInput.CharLimit = 30
+	tagInput.ShowSuggestions = true
+
+	quickInput := textinput.New()
+	quickInput.Placeholder = i18n.T("Titre #tag !priorité @date...")
+	quickInput.CharLimit = 200
+
+	noteInput := textinput.New()
+	noteInput.Placeholder = i18n.T("Nouvelle note...")
+	noteInput.CharLimit = 500
+
+	gotoInput := textinput.New()
+	gotoInput.Placeholder = model.ShortIDPrefix + "42"
+	gotoInput.CharLimit = 40
 
 	app := &App{
-		storage:     store,
-		tasks:       []model.Task{},
-		styles:      styles,
-		keys:        keyMap,
-		viewMode:    ViewList,
-		state:       StateNormal,
-		listView:    NewListView(styles),
-		kanbanView:  NewKanbanView(styles),
-		taskForm:    NewTaskForm(styles),
-		helpPanel:   NewHelpPanel(styles),
-		searchInput: searchInput,
-		tagInput:    tagInput,
+		storage:       store,
+		config:        cfg,
+		tasks:         []model.Task{},
+		styles:        styles,
+		keys:          keyMap,
+		viewMode:      ViewList,
+		state:         StateNormal,
+		lastInput:     time.Now(),
+		readOnly:      store.ReadOnly(),
+		index:         model.NewIndex(nil),
+		listView:      NewListView(styles),
+		kanbanView:    NewKanbanView(styles),
+		calendar:      NewCalendarView(styles),
+		taskForm:      NewTaskForm(styles),
+		helpPanel:     NewHelpPanel(styles),
+		yamlViewer:    NewYAMLViewer(styles),
+		descEditor:    NewDescriptionEditor(styles),
+		journalEditor: NewDescriptionEditor(styles),
+		checklist:     NewChecklistPanel(styles),
+		searchInput:   searchInput,
+		tagInput:      tagInput,
+		quickInput:    quickInput,
+		noteInput:     noteInput,
+		gotoInput:     gotoInput,
+
+		sprintReviewed: map[string]bool{},
+		unknownKeys:    unknownKeys,
+	}
+
+	app.listView.SetStaleDays(cfg.StaleInProgress.Days)
+	app.listView.SetColumns(listColumns(cfg.List.Columns))
+	app.listView.SetDetailed(cfg.List.Density == config.ListDensityDetailed)
+	app.kanbanView.SetStaleDays(cfg.StaleInProgress.Days)
+	if cfg.Urgency.Enabled {
+		app.sortBy = model.SortByUrgency
+		app.listView.SetSortBy(app.sortBy)
+		app.kanbanView.SetSortBy(app.sortBy)
+		app.calendar.SetSortBy(app.sortBy)
+		app.listView.SetEscalate(true)
+		app.kanbanView.SetEscalate(true)
+	}
+	for _, status := range model.AllStatuses() {
+		if status.IsDone() {
+			app.kanbanView.SetColumnHidden(status, cfg.Kanban.HideDone)
+		}
+		app.kanbanView.SetWIPLimit(status, cfg.Kanban.WIPLimits[string(status)])
+	}
+	app.SetColumnTheme(cfg.Kanban.ColumnTheme)
+	switch cfg.Kanban.Swimlanes {
+	case config.SwimlanesPriority:
+		app.kanbanView.SetGroupBy(model.GroupByPriority)
+		app.kanbanView.SetLanes(true)
+	case config.SwimlanesTag:
+		app.kanbanView.SetGroupBy(model.GroupByTag)
+		app.kanbanView.SetLanes(true)
+	}
+
+	app.checkKeyConflicts()
+	app.refreshHints()
+
+	if cfg.Display.StartFilter != "" {
+		app.searchInput.SetValue(cfg.Display.StartFilter)
+		app.setFilter(cfg.Display.StartFilter)
+	}
+
+	app.taskForm.SetLongDescription(cfg.Display.LongDescription)
+	if !cfg.Display.HideImages {
+		app.taskForm.SetImages(detectGraphics(os.Getenv), filepath.Dir(store.GetFilePath()))
+	}
+
+	if cfg.Display.ReducedMotion {
+		staticCursor(&app.searchInput, &app.tagInput, &app.quickInput, &app.noteInput, &app.gotoInput)
+		app.taskForm.SetReducedMotion()
+		app.checklist.SetReducedMotion()
+		app.descEditor.SetReducedMotion()
+		app.journalEditor.SetReducedMotion()
+	}
+
+	if cfg.Git.Enabled && !store.ReadOnly() {
+		repo, err := git.Open(store.GetFilePath(), cfg.Git.Remote)
+		if err != nil {
+			app.setMessage(i18n.T("Git désactivé: ") + i18n.T(err.Error()))
+		}
+		app.repo = repo
+	}
+
+	// Also used to recover from a corrupted file, without an interval
+	if target, err := backup.NewTarget(cfg.Backup); err == nil {
+		app.backups = backup.New(store, target, cfg.Backup.Keep)
+	} else if cfg.Backup.Interval > 0 {
+		app.setMessage(i18n.T("Sauvegardes désactivées: ") + i18n.T(err.Error()))
+	}
+
+	if cfg.Jira.URL != "" {
+		client, err := jira.New(cfg.Jira)
+		if err != nil {
+			app.setMessage(i18n.T("Jira désactivé: ") + i18n.T(err.Error()))
+		}
+		app.jira = client
+	}
+
+	if cfg.Share.URL != "" {
+		app.share, _ = share.New(cfg.Share)
+	}
+
+	if len(cfg.Webhooks) > 0 {
+		sender, err := webhook.New(cfg.Webhooks)
+		if err != nil {
+			app.setMessage(i18n.T("Webhooks désactivés: ") + i18n.T(err.Error()))
+		}
+		app.webhooks = sender
+	}
+
+	if cfg.Autosave.Mode == config.AutosaveDebounced {
+		delay := cfg.Autosave.Delay
+		if delay <= 0 {
+			delay = config.DefaultAutosaveDelay
+		}
+		store.SetSaveDelay(delay)
+	}
+
+	// Auto-reload on external changes; without a watcher, r still works
+	if w, err := store.Watch(); err == nil {
+		app.watcher = w
 	}
 
 	return app
 }
 
+// staticCursor stops the cursor of text inputs from blinking
+func staticCursor(inputs ...*textinput.Model) {
+	for _, in := range inputs {
+		in.Cursor.SetMode(cursor.CursorStatic)
+	}
+}
+
+// SetColumnTheme sets the colors and background of the kanban columns; an
+// unknown color leaves its column with the default
+func (a *App) SetColumnTheme(theme config.ColumnTheme) {
+	for _, status := range model.AllStatuses() {
+		c, _ := paletteValue(theme.ColumnColors[string(status)])
+		a.kanbanView.SetColumnColor(status, c)
+	}
+	a.kanbanView.SetColumnBackground(theme.ColumnBackground != nil && *theme.ColumnBackground)
+}
+
+// ApplyIcons applies the user's icon and color overrides
+func ApplyIcons(cfg *config.Config) {
+	for value, l := range cfg.Labels.Status {
+		if l.Icon != "" {
+			SetStatusIcon(model.Status(value), l.Icon)
+		}
+	}
+	for value, l := range cfg.Labels.Priority {
+		if l.Icon != "" {
+			SetPriorityIcon(model.Priority(value), l.Icon)
+		}
+	}
+	for _, level := range cfg.Priorities {
+		if level.Icon != "" {
+			SetPriorityIcon(model.Priority(level.Value), level.Icon)
+		}
+		if level.Color != "" {
+			SetPriorityColor(model.Priority(level.Value), level.Color)
+		}
+	}
+	for _, st := range cfg.Workflow {
+		if st.Icon != "" {
+			SetStatusIcon(model.Status(st.Value), st.Icon)
+		}
+		if st.Color != "" {
+			SetStatusColor(model.Status(st.Value), st.Color)
+		}
+	}
+}
+
 // Init initializes the app
 func (a *App) Init() tea.Cmd {
+	var pull tea.Cmd
+	if a.repo != nil && a.config.Git.PullOnStart {
+		pull = a.gitSync(a.repo.Pull)
+	}
 	return tea.Batch(
 		a.loadTasks,
+		a.waitForFileChange(),
+		a.waitForSave(),
+		pull,
+		a.backup(),
+		a.alertTick(),
 		tea.EnterAltScreen,
 	)
 }
 
+// backup takes a remote backup in the background when the last one is
+// older than the configured interval
+func (a *App) backup() tea.Cmd {
+	if a.backups == nil || a.config.Backup.Interval <= 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		due, err := a.backups.Due(a.config.Backup.Interval, time.Now())
+		if err != nil || !due {
+			return backedUpMsg{err: err}
+		}
+		name, err := a.backups.Run(time.Now())
+		return backedUpMsg{name, err}
+	}
+}
+
+// waitForFileChange waits for the tasks file to be changed by another program
+func (a *App) waitForFileChange() tea.Cmd {
+	if a.watcher == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		if _, ok := <-a.watcher.Changes(); !ok {
+			return nil
+		}
+		return fileChangedMsg{}
+	}
+}
+
 // loadTasks loads tasks from storage
 func (a *App) loadTasks() tea.Msg {
 	tasks, err := a.storage.Load()
@@ -109,6 +493,36 @@ type errMsg struct{ error }
 type tasksLoadedMsg struct{ tasks []model.Task }
 type tasksSavedMsg struct{}
 type editorClosedMsg struct{ err error }
+type yamlContentMsg struct {
+	title   string
+	content string
+}
+type clipboardMsg struct{ err error }
+type exportedMsg struct {
+	path string
+	err  error
+}
+type fileChangedMsg struct{}
+type flushedMsg struct {
+	storage.Saved
+	background bool // saved after the autosave delay
+}
+type descriptionEditedMsg struct {
+	text string
+	err  error
+}
+type gitCommittedMsg struct{ err error }
+type gitSyncedMsg struct{ err error }
+type backedUpMsg struct {
+	name string // empty when no backup was due
+	err  error
+}
+type backupTickMsg struct{}
+type jiraTransitionedMsg struct {
+	key string
+	err error
+}
+type webhooksSentMsg struct{ err error }
 
 // Update handles messages and updates the model
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -121,25 +535,192 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case errMsg:
 		a.err = msg.error
-		a.setMessage("Erreur: " + msg.Error())
+		a.setMessage(i18n.T("Erreur: ") + i18n.T(msg.Error()))
+		if errors.Is(msg.error, storage.ErrConflict) {
+			// Show the other instance's changes instead of overwriting them
+			return a, a.loadTasks
+		}
+		if errors.Is(msg.error, storage.ErrCorrupted) && a.state != StateConfirmRestore {
+			return a, a.offerRestore()
+		}
 		return a, nil
 
 	case tasksLoadedMsg:
+		if a.state == StateConfirmRestore {
+			// Fixed or restored
+			a.state = StateNormal
+			a.err = nil
+		}
 		a.tasks = msg.tasks
+		// Copied with their slices: the selected task is edited in place
+		// before being saved
+		a.stored = model.CloneTasks(msg.tasks)
+		if pending := a.storage.Pending(); !pending.IsEmpty() {
+			// Keep showing the changes that aren't saved yet
+			a.tasks = pending.Apply(a.tasks)
+		}
+		a.tasks = a.hideTrash(a.tasks)
+		a.refreshTagColors()
+		a.calendar.SetJournal(a.storage.Journal())
 		a.refreshViews()
+		a.checklist.Refresh(a.tasks)
+		a.checkProblems()
+		a.checkSprintReview()
+		return a, tea.Batch(a.moveStaleTasks(), a.refreshAlerts(time.Now()))
+
+	case deleteExpiredMsg:
+		if a.trash != nil && a.trash.seq == msg.seq {
+			return a, a.commitDelete()
+		}
 		return a, nil
 
 	case tasksSavedMsg:
-		a.setMessage("Tâches sauvegardées")
+		a.setMessage(i18n.T("Tâches sauvegardées"))
+		return a, nil
+
+	case flushedMsg:
+		var wait tea.Cmd
+		if msg.background {
+			wait = a.waitForSave()
+		}
+		if msg.Err != nil {
+			// The storage keeps the changes for the next attempt
+			_, cmd := a.Update(errMsg{msg.Err})
+			return a, tea.Batch(cmd, wait)
+		}
+		if msg.Changes.IsEmpty() {
+			// Saved by another flush in the meantime
+			return a, wait
+		}
+		if a.savesLater() {
+			a.setMessage(i18n.T("Tâches sauvegardées"))
+		}
+		message := a.commitMessage(msg.Changes)
+		transition, hooks := a.jiraTransition(msg.Changes), a.sendWebhooks(msg.Changes)
+		_, cmd := a.Update(tasksLoadedMsg{msg.Tasks})
+		return a, tea.Batch(cmd, a.gitCommit(message), transition, hooks, wait)
+
+	case webhooksSentMsg:
+		if msg.err != nil {
+			a.setMessage(i18n.T("Erreur de webhook: ") + i18n.T(msg.err.Error()))
+		}
+		return a, nil
+
+	case jiraTransitionedMsg:
+		if msg.err != nil {
+			a.setMessage(i18n.Tf("Transition Jira de %s impossible: %s", msg.key, i18n.T(msg.err.Error())))
+		}
+		return a, nil
+
+	case gitCommittedMsg:
+		if msg.err != nil {
+			a.setMessage(i18n.T("Erreur git: ") + i18n.T(msg.err.Error()))
+		}
+		return a, nil
+
+	case gitSyncedMsg:
+		if msg.err != nil {
+			a.setMessage(i18n.T("Erreur git: ") + i18n.T(msg.err.Error()))
+			return a, nil
+		}
+		a.setMessage(i18n.T("Synchronisé avec le dépôt git"))
+		return a, a.loadTasks
+
+	case backedUpMsg:
+		if msg.err != nil {
+			a.setMessage(i18n.T("Erreur de sauvegarde distante: ") + i18n.T(msg.err.Error()))
+		} else if msg.name != "" {
+			a.setMessage(i18n.Tf("Sauvegardé: %s", msg.name))
+		}
+		// Check again at the next interval
+		return a, tea.Tick(a.config.Backup.Interval, func(time.Time) tea.Msg { return backupTickMsg{} })
+
+	case backupTickMsg:
+		return a, a.backup()
+
+	case alertTickMsg:
+		a.checkIdle(time.Now())
+		return a, tea.Batch(a.refreshAlerts(time.Now()), a.alertTick())
+
+	case alertFlashMsg:
+		a.alertFlashes--
+		if a.alertFlashes <= 0 {
+			a.alertFlashes = 0
+			a.alertFlash = true
+			return a, nil
+		}
+		a.alertFlash = !a.alertFlash
+		return a, alertFlashTick()
+
+	case restoreFoundMsg:
+		a.restore = msg.candidate
+		a.restoreSearched = true
 		return a, nil
 
 	case editorClosedMsg:
 		if msg.err != nil {
-			a.setMessage("Erreur lors de l'ouverture de l'éditeur")
+			a.setMessage(i18n.T("Erreur lors de l'ouverture de l'éditeur"))
 		}
 		return a, a.loadTasks
 
+	case bulkEditedMsg:
+		return a, a.bulkEdited(msg)
+
+	case yamlContentMsg:
+		a.yamlViewer.SetContent(msg.title, msg.content)
+		a.state = StateYAMLView
+		return a, nil
+
+	case descriptionEditedMsg:
+		if msg.err != nil {
+			a.setMessage(i18n.T("Erreur lors de l'ouverture de l'éditeur"))
+		} else {
+			a.taskForm.SetDescription(msg.text)
+		}
+		return a, nil
+
+	case fileChangedMsg:
+		editing := a.state
+		if editing == StateIdle {
+			editing = a.idleReturn
+		}
+		if editing == StateForm || editing == StateDescEditor || editing == StateJournal {
+			// Don't silently drop what is being edited
+			a.reloadReturn = editing
+			a.state = StateConfirmReload
+			return a, a.waitForFileChange()
+		}
+		a.setMessage(i18n.T("Fichier modifié sur le disque, rechargé"))
+		return a, tea.Batch(a.loadTasks, a.waitForFileChange())
+
+	case clipboardMsg:
+		if msg.err != nil {
+			a.setMessage(i18n.T("Erreur: ") + msg.err.Error())
+		} else {
+			a.setMessage(i18n.T("Copié dans le presse-papiers"))
+		}
+		return a, nil
+
+	case pastedMsg:
+		return a, a.paste(msg)
+
+	case sharedMsg:
+		return a, a.shared(msg)
+
+	case exportedMsg:
+		if msg.err != nil {
+			a.setMessage(i18n.T("Erreur d'export: ") + msg.err.Error())
+		} else {
+			a.setMessage(i18n.T("Exporté vers ") + msg.path)
+		}
+		return a, nil
+
 	case tea.KeyMsg:
+		// The key ends the time idle; it isn't an answer to the question
+		// it brings up
+		if a.endIdle(time.Now()) {
+			return a, nil
+		}
 		return a.handleKeyPress(msg)
 	}
 
@@ -154,7 +735,35 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if a.state == StateSearch {
 		var cmd tea.Cmd
 		a.searchInput, cmd = a.searchInput.Update(msg)
-		a.listView.SetFilter(a.searchInput.Value())
+		a.setFilter(a.searchInput.Value())
+		return a, cmd
+	}
+
+	// Handle quick-add input
+	if a.state == StateQuickAdd {
+		var cmd tea.Cmd
+		a.quickInput, cmd = a.quickInput.Update(msg)
+		return a, cmd
+	}
+
+	// Handle description editor
+	if a.state == StateDescEditor {
+		var cmd tea.Cmd
+		a.descEditor, cmd = a.descEditor.Update(msg)
+		return a, cmd
+	}
+
+	// Handle journal editor
+	if a.state == StateJournal {
+		var cmd tea.Cmd
+		a.journalEditor, cmd = a.journalEditor.Update(msg)
+		return a, cmd
+	}
+
+	// Handle checklist input
+	if a.state == StateChecklist {
+		var cmd tea.Cmd
+		a.checklist, cmd = a.checklist.Update(msg)
 		return a, cmd
 	}
 
@@ -165,14 +774,38 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, cmd
 	}
 
+	// Handle note input
+	if a.state == StateNoteInput {
+		var cmd tea.Cmd
+		a.noteInput, cmd = a.noteInput.Update(msg)
+		return a, cmd
+	}
+
+	// Handle go to input
+	if a.state == StateGoTo {
+		var cmd tea.Cmd
+		a.gotoInput, cmd = a.gotoInput.Update(msg)
+		return a, cmd
+	}
+
 	return a, nil
 }
 
 // handleKeyPress handles key press events
 func (a *App) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	quitting := key.Matches(msg, a.keys.Quit) && a.state == StateNormal
+	if !quitting {
+		a.quitConfirm = false
+	}
+
+	// Keys of a chord, after the leader key
+	if a.chord != nil && a.state == StateNormal {
+		return a.handleChordKey(msg)
+	}
+
 	// Global keys
-	if key.Matches(msg, a.keys.Quit) && a.state == StateNormal {
-		return a, tea.Quit
+	if quitting {
+		return a.quit()
 	}
 
 	// State-specific handling
@@ -187,15 +820,78 @@ func (a *App) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a.handleDeleteConfirmKeys(msg)
 	case StateTagInput:
 		return a.handleTagInputKeys(msg)
+	case StateYAMLView:
+		return a.handleYAMLViewKeys(msg)
+	case StateDescEditor:
+		return a.handleDescEditorKeys(msg)
+	case StateConfirmReload:
+		return a.handleReloadConfirmKeys(msg)
+	case StateChecklist:
+		return a.handleChecklistKeys(msg)
+	case StateConfirmDone:
+		return a.handleDoneConfirmKeys(msg)
+	case StateConfirmWIP:
+		return a.handleWIPConfirmKeys(msg)
+	case StateAlerts:
+		return a.handleAlertsKeys(msg)
+	case StateShare:
+		return a.handleShareKeys(msg)
+	case StateSprintReview:
+		return a.handleSprintReviewKeys(msg)
+	case StateVelocity:
+		return a.handleVelocityKeys(msg)
+	case StateTags:
+		return a.handleTagsKeys(msg)
+	case StateKeyConflicts:
+		return a.handleKeyConflictsKeys(msg)
+	case StateProblems:
+		return a.handleProblemsKeys(msg)
+	case StateListColumns:
+		return a.handleListColumnsKeys(msg)
+	case StateWeekPlan:
+		return a.handleWeekPlanKeys(msg)
+	case StateJournal:
+		return a.handleJournalKeys(msg)
+	case StateTimeStats:
+		return a.handleTimeStatsKeys(msg)
+	case StateIdle:
+		return a.handleIdleKeys(msg)
+	case StateDoctor:
+		return a.handleDoctorKeys(msg)
+	case StateExport:
+		return a.handleExportKeys(msg)
+	case StateQuickAdd:
+		return a.handleQuickAddKeys(msg)
+	case StateNoteInput:
+		return a.handleNoteInputKeys(msg)
+	case StateGoTo:
+		return a.handleGoToKeys(msg)
+	case StateConfirmRestore:
+		return a.handleRestoreConfirmKeys(msg)
 	default:
 		return a.handleNormalKeys(msg)
 	}
 }
 
+// refuseWrite tells that a key changing the tasks file is disabled, and
+// returns true; false for the other keys
+func (a *App) refuseWrite(msg tea.KeyMsg) bool {
+	act, ok := a.keys.Writing(msg)
+	if !ok {
+		return false
+	}
+	a.setMessage(i18n.Tf("Lecture seule: « %s » désactivé", act.Binding.Help().Desc))
+	return true
+}
+
 // handleNormalKeys handles keys in normal state
 func (a *App) handleNormalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	// Navigation
+	case a.viewMode == ViewCalendar && a.handleCalendarKeys(msg):
+		return a, nil
+	case a.readOnly && a.refuseWrite(msg):
+		return a, nil
 	case key.Matches(msg, a.keys.Up):
 		a.moveUp()
 	case key.Matches(msg, a.keys.Down):
@@ -213,22 +909,64 @@ func (a *App) handleNormalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, a.keys.MoveLeft):
 		if a.viewMode == ViewKanban {
 			if task := a.kanbanView.MoveTaskLeft(); task != nil {
-				return a, a.updateTask(*task)
+				return a, a.moveTask(*task)
 			}
 		}
 	case key.Matches(msg, a.keys.MoveRight):
 		if a.viewMode == ViewKanban {
 			if task := a.kanbanView.MoveTaskRight(); task != nil {
-				return a, a.updateTask(*task)
+				return a, a.moveTask(*task)
 			}
 		}
 
+	// Kanban column widths
+	case key.Matches(msg, a.keys.Widen):
+		if a.viewMode == ViewKanban {
+			a.kanbanView.ResizeColumn(1)
+		}
+	case key.Matches(msg, a.keys.Narrow):
+		if a.viewMode == ViewKanban {
+			a.kanbanView.ResizeColumn(-1)
+		}
+
+	// Kanban swimlanes
+	case key.Matches(msg, a.keys.Lanes):
+		if a.viewMode == ViewKanban {
+			a.toggleLanes()
+		}
+	case key.Matches(msg, a.keys.PrevLane):
+		if a.viewMode == ViewKanban {
+			a.kanbanView.MoveLane(-1)
+		}
+	case key.Matches(msg, a.keys.NextLane):
+		if a.viewMode == ViewKanban {
+			a.kanbanView.MoveLane(1)
+		}
+
+	// Manual ordering
+	case key.Matches(msg, a.keys.MoveUp):
+		return a, a.moveTaskManual(-1)
+	case key.Matches(msg, a.keys.MoveDown):
+		return a, a.moveTaskManual(1)
+
 	// Actions
 	case key.Matches(msg, a.keys.Add):
 		a.taskForm.SetTask(nil)
 		a.taskForm.SetSize(a.width, a.height)
 		a.state = StateForm
+	case key.Matches(msg, a.keys.QuickAdd):
+		a.quickInput.SetValue("")
+		a.quickPaste = nil
+		a.state = StateQuickAdd
+		return a, a.quickInput.Focus()
 	case key.Matches(msg, a.keys.Edit), key.Matches(msg, a.keys.Enter):
+		if a.selectedTask() == nil && key.Matches(msg, a.keys.Enter) && !a.readOnly {
+			return a, a.captureSearch()
+		}
+		if task := a.selectedTask(); task != nil && a.readOnly {
+			// Shown instead of the form, which would save it
+			return a, a.viewTaskYAML(*task)
+		}
 		if task := a.selectedTask(); task != nil {
 			a.taskForm.SetTask(task)
 			a.taskForm.SetSize(a.width, a.height)
@@ -238,58 +976,167 @@ func (a *App) handleNormalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if a.selectedTask() != nil {
 			a.state = StateConfirmDelete
 		}
+	case key.Matches(msg, a.keys.Undo):
+		a.undoDelete()
 	case key.Matches(msg, a.keys.Priority):
 		if task := a.selectedTask(); task != nil {
 			task.Priority = task.Priority.Next()
 			return a, a.updateTask(*task)
 		}
+	case key.Matches(msg, a.keys.Severity):
+		if task := a.selectedTask(); task != nil {
+			task.Severity = task.Severity.Next()
+			return a, a.updateTask(*task)
+		}
+	case key.Matches(msg, a.keys.Sprint):
+		if task := a.selectedTask(); task != nil {
+			return a, a.cycleSprint(*task)
+		}
+	case key.Matches(msg, a.keys.Estimate):
+		if task := a.selectedTask(); task != nil {
+			task.Estimate = model.NextEstimate(task.Estimate)
+			return a, a.updateTask(*task)
+		}
+	case key.Matches(msg, a.keys.Block):
+		return a, a.cycleBlockReason()
+	case key.Matches(msg, a.keys.Private):
+		if task := a.selectedTask(); task != nil {
+			task.Private = !task.Private
+			return a, a.updateTask(*task)
+		}
+	case key.Matches(msg, a.keys.PinToday):
+		return a, a.pinToday()
+	case key.Matches(msg, a.keys.Pin):
+		if task := a.selectedTask(); task != nil {
+			task.Pinned = !task.Pinned
+			return a, a.updateTask(*task)
+		}
+	case key.Matches(msg, a.keys.Checklist):
+		if task := a.selectedTask(); task != nil {
+			a.checklist.SetTask(*task)
+			a.state = StateChecklist
+		}
 	case key.Matches(msg, a.keys.Tag):
 		if a.selectedTask() != nil {
 			a.tagInput.SetValue("")
+			a.tagInput.SetSuggestions(model.AllTags(a.tasks))
 			a.tagInput.Focus()
 			a.state = StateTagInput
 		}
+	case key.Matches(msg, a.keys.Note):
+		if a.selectedTask() != nil {
+			a.noteInput.SetValue("")
+			a.noteInput.Focus()
+			a.state = StateNoteInput
+		}
 
 	// Quick status change
 	case key.Matches(msg, a.keys.StatusTodo):
-		return a, a.setTaskStatus(model.StatusTodo)
+		return a, a.setTaskStatus(model.StatusOfKind(model.StatusTodo))
 	case key.Matches(msg, a.keys.StatusInProgress):
-		return a, a.setTaskStatus(model.StatusInProgress)
+		return a, a.setTaskStatus(model.StatusOfKind(model.StatusInProgress))
 	case key.Matches(msg, a.keys.StatusBlocked):
-		return a, a.setTaskStatus(model.StatusBlocked)
+		return a, a.setTaskStatus(model.StatusOfKind(model.StatusBlocked))
 	case key.Matches(msg, a.keys.StatusDone):
-		return a, a.setTaskStatus(model.StatusDone)
+		return a, a.setTaskStatus(model.StatusOfKind(model.StatusDone))
 
 	// Views
 	case key.Matches(msg, a.keys.ToggleView):
-		if a.viewMode == ViewList {
-			a.viewMode = ViewKanban
-			// Sync selection
-			if task := a.listView.SelectedTask(); task != nil {
-				a.kanbanView.SetActiveColumn(task.Status.Index())
-			}
-		} else {
-			a.viewMode = ViewList
-		}
+		a.setViewMode((a.viewMode + 1) % viewModeCount)
 	case key.Matches(msg, a.keys.GroupBy):
 		// Cycle through grouping modes
-		if a.viewMode == ViewList {
+		switch a.viewMode {
+		case ViewList, ViewToday:
 			a.listView.CycleGroupBy()
-			a.setMessage("Grouper par: " + a.listView.GetGroupBy().Label())
-		} else {
+			a.setMessage(i18n.T("Grouper par: ") + a.listView.GetGroupBy().Label())
+		case ViewKanban:
 			a.kanbanView.CycleGroupBy()
-			a.setMessage("Grouper par: " + a.kanbanView.GetGroupBy().Label())
+			a.setMessage(i18n.T("Grouper par: ") + a.kanbanView.GetGroupBy().Label())
 		}
+	case key.Matches(msg, a.keys.SortBy):
+		a.setSortBy(a.sortBy.Next())
+	case key.Matches(msg, a.keys.Leader):
+		a.chord = []string{}
 	case key.Matches(msg, a.keys.Search):
 		a.searchInput.SetValue("")
 		a.searchInput.Focus()
 		a.state = StateSearch
+	case key.Matches(msg, a.keys.GoTo):
+		a.gotoInput.SetValue("")
+		a.state = StateGoTo
+		return a, a.gotoInput.Focus()
 	case key.Matches(msg, a.keys.Help):
 		a.state = StateHelp
 	case key.Matches(msg, a.keys.Refresh):
 		return a, a.loadTasks
 	case key.Matches(msg, a.keys.OpenEditor):
 		return a, a.openEditor()
+	case key.Matches(msg, a.keys.BulkEdit):
+		return a, a.bulkEdit()
+	case key.Matches(msg, a.keys.ViewYAML):
+		if task := a.selectedTask(); task != nil {
+			return a, a.viewTaskYAML(*task)
+		}
+	case key.Matches(msg, a.keys.ViewFile):
+		return a, a.viewFileYAML()
+	case key.Matches(msg, a.keys.Yank):
+		if task := a.selectedTask(); task != nil {
+			return a, copyTaskYAML(*task)
+		}
+	case key.Matches(msg, a.keys.YankLine):
+		if task := a.selectedTask(); task != nil {
+			return a, copyToClipboard(parse.Format(*task))
+		}
+	case key.Matches(msg, a.keys.Paste):
+		return a, pasteFromClipboard
+	case key.Matches(msg, a.keys.Export):
+		a.state = StateExport
+	case key.Matches(msg, a.keys.Share):
+		a.openShare()
+	case key.Matches(msg, a.keys.Sync):
+		if a.repo == nil {
+			a.setMessage(i18n.T("Synchronisation git désactivée (git.enabled dans la configuration)"))
+			return a, nil
+		}
+		a.setMessage(i18n.T("Synchronisation git..."))
+		return a, a.gitSync(a.repo.Sync)
+	case key.Matches(msg, a.keys.Velocity):
+		a.state = StateVelocity
+	case key.Matches(msg, a.keys.TimeStats):
+		a.state = StateTimeStats
+	case key.Matches(msg, a.keys.Doctor):
+		a.openDoctor()
+	case key.Matches(msg, a.keys.Timer):
+		return a, a.toggleTimer()
+	case key.Matches(msg, a.keys.PlanWeek):
+		a.openWeekPlan()
+	case key.Matches(msg, a.keys.Journal):
+		return a, a.openJournal()
+	case key.Matches(msg, a.keys.Tags):
+		a.openTags()
+	case key.Matches(msg, a.keys.Columns):
+		a.openListColumns()
+	case key.Matches(msg, a.keys.Density):
+		return a, a.toggleDensity()
+	case key.Matches(msg, a.keys.HideDone):
+		a.toggleHideDone()
+	case key.Matches(msg, a.keys.Progress):
+		a.progressExpanded = !a.progressExpanded
+		a.updateSizes()
+	case key.Matches(msg, a.keys.Alerts):
+		if len(a.alerts) == 0 {
+			a.setMessage(i18n.T("Aucun rappel échu"))
+			return a, nil
+		}
+		a.alertCursor = 0
+		a.state = StateAlerts
+		return a, nil
+	case key.Matches(msg, a.keys.Save):
+		if !a.storage.Dirty() {
+			a.setMessage(i18n.T("Aucune modification en attente"))
+			return a, nil
+		}
+		return a, a.flush()
 	}
 
 	return a, nil
@@ -297,10 +1144,27 @@ func (a *App) handleNormalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleFormKeys handles keys in form state
 func (a *App) handleFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The paste prompt and the date picker take esc and enter
+	if a.taskForm.HasPastePrompt() || a.taskForm.IsPickingDate() {
+		a.taskForm, _ = a.taskForm.Update(msg)
+		return a, nil
+	}
+
 	switch msg.String() {
 	case "esc":
 		a.state = StateNormal
 		return a, nil
+	case "ctrl+o":
+		if a.taskForm.IsFocusedOnDescription() {
+			return a, a.editDescription(a.taskForm.Description())
+		}
+	case "ctrl+f":
+		if a.taskForm.IsFocusedOnDescription() {
+			a.state = StateDescEditor
+			cmd := a.descEditor.SetValue(a.taskForm.Description())
+			a.descEditor.SetZen(true)
+			return a, cmd
+		}
 	case "enter":
 		if a.taskForm.IsFocusedOnSubmit() {
 			if a.taskForm.IsValid() {
@@ -309,7 +1173,7 @@ func (a *App) handleFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if a.taskForm.isNew {
 					return a, a.addTask(task)
 				}
-				return a, a.updateTask(task)
+				return a, a.moveTask(task)
 			}
 		} else if a.taskForm.IsFocusedOnCancel() {
 			a.state = StateNormal
@@ -322,6 +1186,23 @@ func (a *App) handleFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, cmd
 }
 
+// handleDescEditorKeys handles keys in the embedded description editor
+func (a *App) handleDescEditorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.state = StateForm
+		return a, nil
+	case "ctrl+s":
+		a.taskForm.SetDescription(strings.TrimRight(a.descEditor.Value(), "\n"))
+		a.state = StateForm
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.descEditor, cmd = a.descEditor.Update(msg)
+	return a, cmd
+}
+
 // handleHelpKeys handles keys in help state
 func (a *App) handleHelpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
@@ -331,25 +1212,126 @@ func (a *App) handleHelpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
-// handleSearchKeys handles keys in search state
-func (a *App) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		a.searchInput.SetValue("")
-		a.listView.SetFilter("")
+// handleYAMLViewKeys handles keys in the YAML viewer
+func (a *App) handleYAMLViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc", msg.String() == "q":
 		a.state = StateNormal
 		return a, nil
-	case "enter":
-		a.state = StateNormal
+	case key.Matches(msg, a.keys.Yank):
+		return a, copyToClipboard(a.yamlViewer.Raw())
+	}
+
+	var cmd tea.Cmd
+	a.yamlViewer, cmd = a.yamlViewer.Update(msg)
+	return a, cmd
+}
+
+// handleSearchKeys handles keys in search state
+func (a *App) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.searchInput.SetValue("")
+		a.setFilter("")
+		a.state = StateNormal
 		return a, nil
+	case "enter":
+		a.state = StateNormal
+		return a, a.captureSearch()
 	}
 
 	var cmd tea.Cmd
 	a.searchInput, cmd = a.searchInput.Update(msg)
-	a.listView.SetFilter(a.searchInput.Value())
+	a.setFilter(a.searchInput.Value())
 	return a, cmd
 }
 
+// captureSearch opens the quick-add bar filled with the search when it
+// found no task, to create the one that was looked for. The search is
+// kept, so the new task shows up if it matches.
+func (a *App) captureSearch() tea.Cmd {
+	if a.searchInput.Value() == "" || len(a.listView.FilteredTasks()) > 0 {
+		return nil
+	}
+	line := parse.FromSearch(a.searchInput.Value())
+	if line == "" {
+		return nil
+	}
+	a.quickInput.SetValue(line + " ")
+	a.quickInput.CursorEnd()
+	a.quickPaste = nil
+	a.state = StateQuickAdd
+	return a.quickInput.Focus()
+}
+
+// handleQuickAddKeys handles the quick-add bar. Pasting several lines
+// offers to create one task per line.
+func (a *App) handleQuickAddKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.quickPaste != nil {
+		switch msg.String() {
+		case "y", "Y", "enter":
+			lines := a.quickPaste
+			a.quickPaste = nil
+			a.state = StateNormal
+			tasks := make([]model.Task, 0, len(lines))
+			for _, line := range lines {
+				if task, ok := quickTask(line); ok {
+					tasks = append(tasks, task)
+				}
+			}
+			a.setMessage(i18n.N(len(tasks), "%d tâche créée", "%d tâches créées"))
+			return a, a.commit(storage.Changes{Added: tasks})
+		case "n", "N", "esc":
+			a.quickPaste = nil
+		}
+		return a, nil
+	}
+
+	if msg.Paste {
+		if lines := pastedLines(string(msg.Runes)); len(lines) > 1 {
+			a.quickPaste = lines
+			return a, nil
+		}
+	}
+
+	switch msg.String() {
+	case "esc":
+		a.quickInput.Blur()
+		a.state = StateNormal
+		return a, nil
+	case "enter":
+		a.quickInput.Blur()
+		a.state = StateNormal
+		task, ok := quickTask(a.quickInput.Value())
+		if !ok {
+			return a, nil
+		}
+		return a, a.addTask(task)
+	}
+
+	var cmd tea.Cmd
+	a.quickInput, cmd = a.quickInput.Update(msg)
+	return a, cmd
+}
+
+// quickTask creates a task from a quick-add line with inline metadata
+// (#tag !priority @date). It returns false if the line has no title.
+func quickTask(line string) (model.Task, bool) {
+	return parse.Capture(line, nil, time.Now())
+}
+
+// pastedLines splits pasted text into trimmed, non-empty lines
+func pastedLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var lines []string
+	for _, line := range strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 // handleDeleteConfirmKeys handles delete confirmation
 func (a *App) handleDeleteConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -362,6 +1344,112 @@ func (a *App) handleDeleteConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// handleReloadConfirmKeys handles the prompt shown when the file changed
+// on disk while edits were pending
+func (a *App) handleReloadConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r", "R":
+		a.state = StateNormal
+		return a, a.loadTasks
+	case "c", "C", "esc":
+		a.state = a.reloadReturn
+	}
+	return a, nil
+}
+
+// handleChecklistKeys handles keys in the checklist panel
+func (a *App) handleChecklistKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.checklist.IsAdding() {
+		switch msg.String() {
+		case "esc":
+			a.checklist.CancelAdding()
+			return a, nil
+		case "enter":
+			if task, ok := a.checklist.Add(); ok {
+				return a, a.updateTask(task)
+			}
+			return a, nil
+		}
+		var cmd tea.Cmd
+		a.checklist, cmd = a.checklist.Update(msg)
+		return a, cmd
+	}
+
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, a.keys.Checklist):
+		a.state = StateNormal
+	case key.Matches(msg, a.keys.Up):
+		a.checklist.MoveUp()
+	case key.Matches(msg, a.keys.Down):
+		a.checklist.MoveDown()
+	case key.Matches(msg, a.keys.Add):
+		return a, a.checklist.StartAdding()
+	case key.Matches(msg, a.keys.Delete):
+		if task, ok := a.checklist.Delete(); ok {
+			return a, a.updateTask(task)
+		}
+	case msg.String() == " ", msg.String() == "x", key.Matches(msg, a.keys.Enter):
+		before := a.checklist.Task()
+		if task, ok := a.checklist.Toggle(); ok {
+			return a, a.saveChecklist(before, task)
+		}
+	}
+	return a, nil
+}
+
+// saveChecklist persists a toggled checklist. With auto_advance_status, the
+// task moves to in progress when its first item is checked, and marking it
+// done is offered once all items are.
+func (a *App) saveChecklist(before, after model.Task) tea.Cmd {
+	if a.config.AutoAdvanceStatus {
+		doneBefore, _ := before.SubtaskProgress()
+		doneAfter, total := after.SubtaskProgress()
+		if doneBefore == 0 && doneAfter > 0 && after.Status.Kind() == model.StatusTodo {
+			after.Status = model.StatusOfKind(model.StatusInProgress)
+			a.setMessage(i18n.Tf("Tâche passée à « %s »", after.Status.Label()))
+		}
+		if doneAfter == total && doneBefore < total && !after.Status.IsDone() {
+			a.state = StateConfirmDone
+		}
+	}
+	return a.updateTask(after)
+}
+
+// handleDoneConfirmKeys handles the prompt offered when the last subtask
+// is checked
+func (a *App) handleDoneConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		a.state = StateNormal
+		task := a.checklist.Task()
+		task.Status = model.StatusOfKind(model.StatusDone)
+		return a, a.updateTask(task)
+	case "n", "N", "esc":
+		a.state = StateChecklist
+	}
+	return a, nil
+}
+
+// handleExportKeys handles the export format prompt. Lowercase keys write
+// a file next to the tasks file, uppercase keys copy to the clipboard.
+func (a *App) handleExportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	formats := map[string]export.Format{
+		"m": export.FormatMarkdown,
+		"j": export.FormatJSON,
+		"c": export.FormatCSV,
+		"i": export.FormatICS,
+	}
+	k := msg.String()
+	if f, ok := formats[strings.ToLower(k)]; ok {
+		a.state = StateNormal
+		return a, a.exportTasks(f, k != strings.ToLower(k))
+	}
+	if k == "esc" {
+		a.state = StateNormal
+	}
+	return a, nil
+}
+
 // handleTagInputKeys handles tag input
 func (a *App) handleTagInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -399,53 +1487,137 @@ func (a *App) handleTagInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, cmd
 }
 
+// handleNoteInputKeys handles the note input: enter appends the note to
+// the selected task's log
+func (a *App) handleNoteInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.state = StateNormal
+		return a, nil
+	case "enter":
+		a.state = StateNormal
+		if task := a.selectedTask(); task != nil {
+			t := *task
+			if t.AddNote(a.noteInput.Value(), time.Now()) {
+				a.setMessage(i18n.T("Note ajoutée"))
+				return a, a.updateTask(t)
+			}
+		}
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.noteInput, cmd = a.noteInput.Update(msg)
+	return a, cmd
+}
+
+// handleCalendarKeys handles the calendar navigation: h/j/k/l move the
+// selected day, H/L the month and J/K the selection in the agenda. It
+// returns false for the other keys.
+func (a *App) handleCalendarKeys(msg tea.KeyMsg) bool {
+	switch {
+	case key.Matches(msg, a.keys.Left):
+		a.calendar.MoveDays(-1)
+	case key.Matches(msg, a.keys.Right):
+		a.calendar.MoveDays(1)
+	case key.Matches(msg, a.keys.Up):
+		a.calendar.MoveDays(-7)
+	case key.Matches(msg, a.keys.Down):
+		a.calendar.MoveDays(7)
+	case key.Matches(msg, a.keys.MoveLeft):
+		a.calendar.MoveMonths(-1)
+	case key.Matches(msg, a.keys.MoveRight):
+		a.calendar.MoveMonths(1)
+	case key.Matches(msg, a.keys.MoveUp):
+		a.calendar.MoveUp()
+	case key.Matches(msg, a.keys.MoveDown):
+		a.calendar.MoveDown()
+	default:
+		return false
+	}
+	return true
+}
+
+// setSortBy sorts every view
+func (a *App) setSortBy(sortBy model.SortBy) {
+	a.sortBy = sortBy
+	a.listView.SetSortBy(a.sortBy)
+	a.kanbanView.SetSortBy(a.sortBy)
+	a.calendar.SetSortBy(a.sortBy)
+	a.setMessage(i18n.T("Trier par: ") + a.sortBy.Label())
+}
+
+// setFilter filters the list and the kanban board with a search query
+func (a *App) setFilter(filter string) {
+	a.listView.SetFilter(filter)
+	a.kanbanView.SetFilter(filter)
+}
+
+// currentView returns the view being displayed
+func (a *App) currentView() taskView {
+	switch a.viewMode {
+	case ViewKanban:
+		return a.kanbanView
+	case ViewCalendar:
+		return a.calendar
+	default:
+		return a.listView
+	}
+}
+
+// setViewMode switches to another view. The selected task stays selected
+// when the new view shows it; each view keeps its own cursors otherwise.
+func (a *App) setViewMode(mode ViewMode) {
+	task := a.selectedTask()
+	a.viewMode = mode
+	a.listView.SetToday(mode == ViewToday)
+	if task != nil {
+		a.currentView().SelectTask(task.ID)
+	}
+}
+
 // selectedTask returns the currently selected task
 func (a *App) selectedTask() *model.Task {
-	if a.viewMode == ViewList {
-		return a.listView.SelectedTask()
-	}
-	return a.kanbanView.SelectedTask()
+	return a.currentView().SelectedTask()
 }
 
 // selectedIndex returns the index of the selected task
 func (a *App) selectedIndex() int {
-	if a.viewMode == ViewList {
-		return a.listView.SelectedIndex()
-	}
-	return a.kanbanView.SelectedIndex()
+	return a.currentView().SelectedIndex()
 }
 
 // moveUp moves selection up
 func (a *App) moveUp() {
-	if a.viewMode == ViewList {
-		a.listView.MoveUp()
-	} else {
-		a.kanbanView.MoveUp()
-	}
+	a.currentView().MoveUp()
 }
 
 // moveDown moves selection down
 func (a *App) moveDown() {
-	if a.viewMode == ViewList {
-		a.listView.MoveDown()
-	} else {
-		a.kanbanView.MoveDown()
-	}
+	a.currentView().MoveDown()
 }
 
 // updateSizes updates component sizes
 func (a *App) updateSizes() {
-	contentHeight := a.height - 4 // Header + Footer
+	contentHeight := a.contentHeight()
 	a.listView.SetSize(a.width, contentHeight)
 	a.kanbanView.SetSize(a.width, contentHeight)
+	a.calendar.SetSize(a.width, contentHeight)
 	a.taskForm.SetSize(a.width, a.height)
 	a.helpPanel.SetSize(a.width-10, a.height-10)
+	a.yamlViewer.SetSize(a.width-10, a.height-4)
+	a.descEditor.SetSize(a.width-10, a.height-4)
+	a.journalEditor.SetSize(a.width-10, a.height-4)
+	a.checklist.SetSize(min(a.width-10, 70), a.height-4)
+	a.noteInput.Width = max(min(a.width-20, 60), 10)
 }
 
 // refreshViews refreshes all views with current tasks
 func (a *App) refreshViews() {
+	a.index = model.NewIndex(a.tasks)
 	a.listView.SetTasks(a.tasks)
 	a.kanbanView.SetTasks(a.tasks)
+	a.calendar.SetTasks(a.tasks)
+	a.taskForm.SetTasks(a.tasks)
 }
 
 // setMessage sets a temporary status message
@@ -457,23 +1629,15 @@ func (a *App) setMessage(msg string) {
 // Task operations
 
 func (a *App) addTask(task model.Task) tea.Cmd {
-	return func() tea.Msg {
-		tasks, err := a.storage.AddTask(task)
-		if err != nil {
-			return errMsg{err}
-		}
-		return tasksLoadedMsg{tasks}
+	// Tasks added from the today view stay in it
+	if now := time.Now(); a.viewMode == ViewToday && !task.InToday(now) {
+		task.PinToday(now)
 	}
+	return a.commit(storage.Changes{Added: []model.Task{task}})
 }
 
 func (a *App) updateTask(task model.Task) tea.Cmd {
-	return func() tea.Msg {
-		tasks, err := a.storage.UpdateTask(task)
-		if err != nil {
-			return errMsg{err}
-		}
-		return tasksLoadedMsg{tasks}
-	}
+	return a.commit(storage.Changes{Updated: []model.Task{task}})
 }
 
 func (a *App) deleteSelectedTask() tea.Cmd {
@@ -481,13 +1645,160 @@ func (a *App) deleteSelectedTask() tea.Cmd {
 	if task == nil {
 		return nil
 	}
+	return a.deleteLater(*task)
+}
+
+// commit saves a batch of changes right away, or queues it according to
+// the autosave mode
+func (a *App) commit(c storage.Changes) tea.Cmd {
+	if a.readOnly {
+		a.setMessage(i18n.T(storage.ErrReadOnly.Error()))
+		return nil
+	}
+	// Shown right away, saved by the storage after the autosave delay or
+	// by flush
+	if err := a.storage.Queue(c); err != nil {
+		a.setMessage(i18n.T("Erreur: ") + i18n.T(err.Error()))
+		return nil
+	}
+	a.tasks = c.Apply(a.tasks)
+	a.refreshViews()
+	a.checklist.Refresh(a.tasks)
+	if a.savesLater() {
+		return nil
+	}
+	// Changes made while a save runs are saved together by the next one
+	return a.flush()
+}
+
+// savesLater returns true if changes aren't saved as soon as they're made
+func (a *App) savesLater() bool {
+	mode := a.config.Autosave.Mode
+	return mode == config.AutosaveDebounced || mode == config.AutosaveManual
+}
+
+// flush saves the pending changes
+func (a *App) flush() tea.Cmd {
+	if !a.storage.Dirty() {
+		return nil
+	}
 	return func() tea.Msg {
-		tasks, err := a.storage.DeleteTask(task.ID)
-		if err != nil {
-			return errMsg{err}
+		return flushedMsg{Saved: a.storage.Flush()}
+	}
+}
+
+// waitForSave waits for the storage to save the pending changes in the
+// background, in debounced autosave mode
+func (a *App) waitForSave() tea.Cmd {
+	if a.config.Autosave.Mode != config.AutosaveDebounced {
+		return nil
+	}
+	return func() tea.Msg {
+		return flushedMsg{Saved: <-a.storage.Saves(), background: true}
+	}
+}
+
+// commitMessage describes a batch of changes for the git history
+func (a *App) commitMessage(c storage.Changes) string {
+	if a.repo == nil {
+		return ""
+	}
+	return git.Message(c, a.stored)
+}
+
+// sendWebhooks sends the events of a saved batch of changes to the
+// webhooks, in the background. Like jiraTransition, it compares with the
+// tasks as last read.
+func (a *App) sendWebhooks(c storage.Changes) tea.Cmd {
+	if a.webhooks == nil {
+		return nil
+	}
+	events := webhook.Events(c, a.stored, a.storage.GetFilePath(), time.Now())
+	if len(events) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		return webhooksSentMsg{a.webhooks.Send(events)}
+	}
+}
+
+// gitCommit commits the saved tasks file, when git is enabled
+func (a *App) gitCommit(message string) tea.Cmd {
+	if a.repo == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return gitCommittedMsg{a.repo.Commit(message)}
+	}
+}
+
+// jiraTransition moves through the Jira workflow the issues whose task
+// changed status in a saved batch of changes, in the background. It
+// compares with the tasks as last read, so it runs before they're
+// replaced by the saved ones.
+func (a *App) jiraTransition(c storage.Changes) tea.Cmd {
+	if a.jira == nil {
+		return nil
+	}
+	before := make(map[string]model.Status, len(a.stored))
+	for _, t := range a.stored {
+		before[t.ID] = t.Status
+	}
+	var cmds []tea.Cmd
+	for _, t := range c.Updated {
+		key, ok := jira.IssueKey(t)
+		if !ok || before[t.ID] == t.Status {
+			continue
 		}
-		return tasksLoadedMsg{tasks}
+		status := t.Status
+		cmds = append(cmds, func() tea.Msg {
+			return jiraTransitionedMsg{key, a.jira.Transition(key, status)}
+		})
 	}
+	return tea.Batch(cmds...)
+}
+
+// gitSync runs a pull, push or sync of the git repository in the
+// background, once the pending changes are saved and committed
+func (a *App) gitSync(sync func() error) tea.Cmd {
+	flush := a.flush()
+	message := a.commitMessage(a.storage.Pending())
+	return func() tea.Msg {
+		if flush != nil {
+			flushed := flush().(flushedMsg)
+			if flushed.Err != nil {
+				return flushed
+			}
+			if err := a.repo.Commit(message); err != nil {
+				return gitCommittedMsg{err}
+			}
+		}
+		return gitSyncedMsg{sync()}
+	}
+}
+
+// quit saves the pending changes and exits. If they can't be saved, they
+// stay pending and quitting again right away exits without saving them.
+func (a *App) quit() (tea.Model, tea.Cmd) {
+	// Saved below, without waiting for its toast
+	a.commitDelete()
+	if a.storage.Dirty() && !a.quitConfirm {
+		message := a.commitMessage(a.storage.Pending())
+		if saved := a.storage.Flush(); saved.Err != nil {
+			a.quitConfirm = true
+			a.setMessage(i18n.T("Erreur: ") + i18n.T(saved.Err.Error()) + " — " +
+				i18n.T("q à nouveau pour quitter sans enregistrer"))
+			return a, nil
+		}
+		if a.repo != nil {
+			a.repo.Commit(message)
+		}
+	}
+	// Commits stay local when the push fails, until the next sync
+	if a.repo != nil && a.config.Git.PushOnExit {
+		a.repo.Push()
+	}
+	return a, tea.Quit
 }
 
 func (a *App) setTaskStatus(status model.Status) tea.Cmd {
@@ -495,8 +1806,65 @@ func (a *App) setTaskStatus(status model.Status) tea.Cmd {
 	if task == nil {
 		return nil
 	}
-	task.Status = status
-	return a.updateTask(*task)
+	moved := *task
+	moved.Status = status
+	return a.moveTask(moved)
+}
+
+// moveTaskManual swaps the selected task with its neighbor in the manual
+// order and persists the new order
+func (a *App) moveTaskManual(delta int) tea.Cmd {
+	if a.sortBy != model.SortByManual {
+		a.setMessage(i18n.T("Déplacement disponible en tri manuel uniquement (s)"))
+		return nil
+	}
+
+	var task, neighbor *model.Task
+	if a.viewMode == ViewList || a.viewMode == ViewToday {
+		task, neighbor = a.listView.SelectedTask(), a.listView.AdjacentTask(delta)
+	} else {
+		task, neighbor = a.kanbanView.SelectedTask(), a.kanbanView.AdjacentTask(delta)
+	}
+	if task == nil || neighbor == nil {
+		return nil
+	}
+
+	order := model.ManualOrder(a.tasks)
+	order[task.ID], order[neighbor.ID] = order[neighbor.ID], order[task.ID]
+
+	// Keep the cursor on the moved task once the new order is loaded
+	if delta < 0 {
+		a.moveUp()
+	} else {
+		a.moveDown()
+	}
+
+	return a.commit(storage.Changes{Order: order})
+}
+
+// moveStaleTasks moves in progress tasks without recent updates back to
+// todo, when the stale rule is configured to do so
+func (a *App) moveStaleTasks() tea.Cmd {
+	rule := a.config.StaleInProgress
+	if rule.Action != config.StaleActionMove {
+		return nil
+	}
+
+	now := time.Now()
+	var stale []model.Task
+	for _, t := range a.tasks {
+		if t.IsStale(rule.Days, now) {
+			t.Status = model.StatusOfKind(model.StatusTodo)
+			t.AddNote(i18n.N(rule.Days, "Remise à faire: aucune activité depuis %d jour", "Remise à faire: aucune activité depuis %d jours"), now)
+			stale = append(stale, t)
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	a.setMessage(i18n.N(len(stale), "%d tâche inactive remise à faire", "%d tâches inactives remises à faire"))
+	return a.commit(storage.Changes{Updated: stale})
 }
 
 func (a *App) openEditor() tea.Cmd {
@@ -506,10 +1874,87 @@ func (a *App) openEditor() tea.Cmd {
 	}
 }
 
+func (a *App) viewTaskYAML(task model.Task) tea.Cmd {
+	return func() tea.Msg {
+		data, err := storage.MarshalTask(task)
+		if err != nil {
+			return errMsg{err}
+		}
+		return yamlContentMsg{title: task.Title, content: string(data)}
+	}
+}
+
+func (a *App) viewFileYAML() tea.Cmd {
+	return func() tea.Msg {
+		data, err := a.storage.ReadRaw()
+		if err != nil {
+			return errMsg{err}
+		}
+		return yamlContentMsg{title: a.storage.GetFilePath(), content: string(data)}
+	}
+}
+
+// editDescription edits the description in $EDITOR when available, or in
+// the embedded editor otherwise
+func (a *App) editDescription(text string) tea.Cmd {
+	if !storage.EditorAvailable() {
+		a.state = StateDescEditor
+		return a.descEditor.SetValue(text)
+	}
+
+	f, err := os.CreateTemp("", "lazy-todo-*.md")
+	if err != nil {
+		return func() tea.Msg { return errMsg{err} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(text)
+	f.Close()
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return errMsg{err} }
+	}
+
+	return tea.ExecProcess(storage.EditorCommand(path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return descriptionEditedMsg{err: err}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return descriptionEditedMsg{err: err}
+		}
+		return descriptionEditedMsg{text: strings.TrimRight(string(data), "\n")}
+	})
+}
+
+// exportTasks renders the tasks matching the current filter and writes them
+// to a timestamped file or to the clipboard
+func (a *App) exportTasks(f export.Format, toClipboard bool) tea.Cmd {
+	data, err := export.Render(f, a.listView.FilteredTasks(), a.config.ICS)
+	if err != nil {
+		return func() tea.Msg { return exportedMsg{err: err} }
+	}
+	if toClipboard {
+		return copyToClipboard(string(data))
+	}
+
+	name := "lazy-todo-export-" + time.Now().Format("20060102-150405") + f.Extension()
+	path := filepath.Join(filepath.Dir(a.storage.GetFilePath()), name)
+	return func() tea.Msg {
+		return exportedMsg{path: path, err: os.WriteFile(path, data, 0644)}
+	}
+}
+
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		return clipboardMsg{clipboard.Copy(text)}
+	}
+}
+
 // View renders the app
 func (a *App) View() string {
 	if a.width == 0 || a.height == 0 {
-		return "Chargement..."
+		return i18n.T("Chargement...")
 	}
 
 	var content string
@@ -523,10 +1968,59 @@ func (a *App) View() string {
 		content = a.renderDeleteConfirm()
 	case StateTagInput:
 		content = a.renderTagInput()
+	case StateNoteInput:
+		content = a.renderNoteInput()
+	case StateYAMLView:
+		content = a.renderYAMLViewer()
+	case StateDescEditor:
+		content = a.renderDescEditor()
+	case StateConfirmReload:
+		content = a.renderReloadConfirm()
+	case StateChecklist:
+		content = a.renderChecklist()
+	case StateConfirmDone:
+		content = a.renderDoneConfirm()
+	case StateConfirmWIP:
+		content = a.renderWIPConfirm()
+	case StateAlerts:
+		content = a.renderAlerts()
+	case StateShare:
+		content = a.renderSharePrompt()
+	case StateSprintReview:
+		content = a.renderSprintReview()
+	case StateVelocity:
+		content = a.renderVelocity()
+	case StateTags:
+		content = a.renderTags()
+	case StateKeyConflicts:
+		content = a.renderKeyConflicts()
+	case StateProblems:
+		content = a.renderProblems()
+	case StateListColumns:
+		content = a.renderListColumns()
+	case StateWeekPlan:
+		content = a.renderWeekPlan()
+	case StateJournal:
+		content = a.renderJournalEditor()
+	case StateTimeStats:
+		content = a.renderTimeStats()
+	case StateIdle:
+		content = a.renderIdlePrompt()
+	case StateDoctor:
+		content = a.renderDoctor()
+	case StateExport:
+		content = a.renderExportPrompt()
+	case StateConfirmRestore:
+		content = a.renderRestoreConfirm()
 	default:
 		content = a.renderMainView()
 	}
 
+	// Images drawn with the kitty protocol stay over the text until deleted
+	if a.state != StateForm && a.taskForm.graphics == graphicsKitty {
+		content = kittyClear + content
+	}
+
 	return content
 }
 
@@ -536,14 +2030,20 @@ func (a *App) renderMainView() string {
 
 	// Header
 	sections = append(sections, a.renderHeader())
+	if a.progressExpanded {
+		sections = append(sections, a.renderProgressBreakdown())
+	}
 
 	// Content
-	contentHeight := a.height - 4
+	contentHeight := a.contentHeight()
 	var viewContent string
-	if a.viewMode == ViewList {
-		viewContent = a.listView.Render()
-	} else {
+	switch a.viewMode {
+	case ViewKanban:
 		viewContent = a.kanbanView.Render()
+	case ViewCalendar:
+		viewContent = a.calendar.Render()
+	default:
+		viewContent = a.listView.Render()
 	}
 
 	// Add search bar if searching
@@ -552,13 +2052,44 @@ func (a *App) renderMainView() string {
 		viewContent = searchBar + "\n" + viewContent
 	}
 
+	// Add go to bar
+	if a.state == StateGoTo {
+		bar := a.styles.FormInputFocus.Render(": " + a.gotoInput.View())
+		viewContent = bar + "\n" + viewContent
+	}
+
+	// Add quick-add bar
+	if a.state == StateQuickAdd {
+		bar := "+ " + a.quickInput.View()
+		if a.quickPaste != nil {
+			bar = "+ " + lipgloss.NewStyle().
+				Foreground(colorYellow).
+				Render(i18n.Tf("Créer %d tâches, une par ligne collée? (y/n)", len(a.quickPaste)))
+		}
+		viewContent = a.styles.FormInputFocus.Render(bar) + "\n" + viewContent
+	}
+
 	contentStyle := lipgloss.NewStyle().
 		Height(contentHeight).
 		Width(a.width)
-	sections = append(sections, contentStyle.Render(viewContent))
-
-	// Footer
-	sections = append(sections, RenderFooter(a.styles, a.viewMode == ViewKanban))
+	viewContent = contentStyle.Render(viewContent)
+	if a.chord != nil {
+		// Which keys continue the chord, over the bottom of the view
+		viewContent = overlayBottom(viewContent, a.renderChordPopup())
+	}
+	sections = append(sections, viewContent)
+
+	// Footer, replaced by the chord being typed or the reminders that went
+	// off
+	if a.chord != nil {
+		sections = append(sections, a.renderChordFooter())
+	} else if a.trash != nil {
+		sections = append(sections, a.renderDeleteToast())
+	} else if len(a.alerts) > 0 {
+		sections = append(sections, a.renderAlertBanner())
+	} else {
+		sections = append(sections, RenderFooter(a.styles, a.viewMode, a.readOnly))
+	}
 
 	return strings.Join(sections, "\n")
 }
@@ -577,11 +2108,17 @@ func (a *App) renderHeader() string {
 		Italic(true).
 		Render(filePath)
 
+	// Unsaved changes indicator
+	if a.storage.Dirty() {
+		fileInfo += lipgloss.NewStyle().Foreground(colorPeach).Render(" ●")
+	}
+
 	// Grouping indicator
 	var groupBy model.GroupBy
-	if a.viewMode == ViewList {
+	switch a.viewMode {
+	case ViewList, ViewToday:
 		groupBy = a.listView.GetGroupBy()
-	} else {
+	case ViewKanban:
 		groupBy = a.kanbanView.GetGroupBy()
 	}
 	var groupInfo string
@@ -591,23 +2128,55 @@ func (a *App) renderHeader() string {
 			Render(" [" + groupBy.Label() + "]")
 	}
 
-	// View tabs
-	listTab := a.styles.HeaderTab
-	kanbanTab := a.styles.HeaderTab
-	if a.viewMode == ViewList {
-		listTab = a.styles.HeaderTabSel
-	} else {
-		kanbanTab = a.styles.HeaderTabSel
+	// Sorting indicator
+	var sortInfo string
+	if a.sortBy != model.SortByManual {
+		sortInfo = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#89b4fa")).
+			Render(" ↕ " + a.sortBy.Label())
 	}
 
-	tabs := listTab.Render("Liste") + " " + kanbanTab.Render("Kanban")
+	// View tabs, with their badges
+	var tabs []string
+	badges := a.tabBadges(time.Now())
+	for mode, name := range []string{i18n.T("Liste"), i18n.T("Kanban"), i18n.T("Calendrier"), i18n.T("Aujourd'hui")} {
+		style := a.styles.HeaderTab
+		if ViewMode(mode) == a.viewMode {
+			style = a.styles.HeaderTabSel
+		}
+		tabs = append(tabs, renderTab(style, name, badges[mode]))
+	}
 
 	// Task count
-	count := fmt.Sprintf("%d tâches", len(a.tasks))
+	count := i18n.N(len(a.tasks), "%d tâche", "%d tâches")
+	if hidden := a.hiddenCount(); hidden > 0 {
+		count += " " + i18n.N(hidden, "(%d masquée)", "(%d masquées)")
+	}
 	countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#a6adc8"))
 
-	leftSide := title + "  " + fileInfo + groupInfo
-	rightSide := countStyle.Render(count) + "  " + tabs
+	// Filter kept after the search was closed
+	var filterInfo string
+	if filter := a.searchInput.Value(); filter != "" && a.state != StateSearch {
+		filterInfo = lipgloss.NewStyle().
+			Foreground(colorYellow).
+			Render(" / " + truncate(filter, 24))
+	}
+
+	leftSide := title + "  " + fileInfo + groupInfo + sortInfo + filterInfo + renderSprintInfo(a.tasks, time.Now()) + a.renderTimer(time.Now())
+	rightSide := countStyle.Render(count) + "  " + strings.Join(tabs, " ")
+
+	// Progress of the tasks of the search, then the due date load for the
+	// coming days, when there is room for them
+	if bar := a.renderProgress(); bar != "" {
+		if a.width-lipgloss.Width(leftSide)-lipgloss.Width(rightSide)-lipgloss.Width(bar) > 6 {
+			rightSide = bar + "  " + rightSide
+		}
+	}
+	if strip := renderHeatStrip(a.index, time.Now()); strip != "" {
+		if a.width-lipgloss.Width(leftSide)-lipgloss.Width(rightSide)-lipgloss.Width(strip) > 6 {
+			rightSide = strip + "  " + rightSide
+		}
+	}
 
 	// Calculate spacing
 	gap := a.width - lipgloss.Width(leftSide) - lipgloss.Width(rightSide) - 2
@@ -659,6 +2228,99 @@ func (a *App) renderHelpOverlay() string {
 	)
 }
 
+// renderYAMLViewer renders the YAML viewer overlay
+func (a *App) renderYAMLViewer() string {
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.yamlViewer.Render(),
+	)
+}
+
+// renderDescEditor renders the embedded description editor overlay
+func (a *App) renderDescEditor() string {
+	if a.descEditor.IsZen() {
+		return lipgloss.Place(
+			a.width, a.height,
+			lipgloss.Center, lipgloss.Top,
+			a.descEditor.Render(),
+		)
+	}
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.descEditor.Render(),
+	)
+}
+
+// renderReloadConfirm renders the external change prompt
+func (a *App) renderReloadConfirm() string {
+	title := a.styles.DialogTitle.Render(i18n.T("Fichier modifié sur le disque"))
+	text := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#cdd6f4")).
+		Render(i18n.T("Le fichier de tâches a été modifié par un autre programme.\nVos modifications en cours n'ont pas été enregistrées."))
+
+	buttons := a.styles.FormButton.Render(i18n.T("(R)echarger")) + "  " +
+		a.styles.FormButtonFocus.Render(i18n.T("(C)ontinuer l'édition"))
+
+	content := title + "\n\n" + text + "\n\n" + buttons
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
+
+// renderChecklist renders the checklist overlay
+func (a *App) renderChecklist() string {
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.checklist.Render(),
+	)
+}
+
+// renderDoneConfirm renders the prompt shown when all subtasks are done
+func (a *App) renderDoneConfirm() string {
+	title := a.styles.DialogTitle.Render(i18n.T("Checklist terminée"))
+	taskTitle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#cdd6f4")).
+		Render(i18n.Tf("Marquer « %s » comme %s?", a.checklist.Task().Title, model.StatusOfKind(model.StatusDone).Label()))
+
+	buttons := a.styles.FormButtonFocus.Render("(Y)es") + "  " +
+		a.styles.FormButton.Render("(N)o")
+
+	content := title + "\n\n" + taskTitle + "\n\n" + buttons
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
+
+// renderExportPrompt renders the export format prompt
+func (a *App) renderExportPrompt() string {
+	count := len(a.listView.FilteredTasks())
+	title := a.styles.DialogTitle.Render(i18n.N(count, "Exporter %d tâche", "Exporter %d tâches"))
+	text := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#cdd6f4")).
+		Render(i18n.T("Fichier:         (m)arkdown  (j)son  (c)sv  (i)calendar\nPresse-papiers:  (M)arkdown  (J)son  (C)sv  (I)calendar"))
+
+	help := lipgloss.NewStyle().
+		Foreground(colorOverlay0).
+		Render(i18n.T("Esc: annuler"))
+
+	content := title + "\n\n" + text + "\n\n" + help
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
+
 // renderDeleteConfirm renders the delete confirmation dialog
 func (a *App) renderDeleteConfirm() string {
 	task := a.selectedTask()
@@ -666,7 +2328,7 @@ func (a *App) renderDeleteConfirm() string {
 		return a.renderMainView()
 	}
 
-	title := a.styles.DialogTitle.Render("Supprimer la tâche?")
+	title := a.styles.DialogTitle.Render(i18n.T("Supprimer la tâche?"))
 	taskTitle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#cdd6f4")).
 		Render(task.Title)
@@ -692,7 +2354,7 @@ func (a *App) renderTagInput() string {
 		return a.renderMainView()
 	}
 
-	title := a.styles.DialogTitle.Render("Ajouter/Retirer un tag")
+	title := a.styles.DialogTitle.Render(i18n.T("Ajouter/Retirer un tag"))
 
 	// Show current tags
 	var tagList string
@@ -701,21 +2363,84 @@ func (a *App) renderTagInput() string {
 		tagList = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#a6adc8")).
 			Italic(true).
-			Render("Tags actuels: " + tags)
+			Render(i18n.T("Tags actuels: ") + tags)
 	} else {
 		tagList = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#6c7086")).
 			Italic(true).
-			Render("Aucun tag")
+			Render(i18n.T("Aucun tag"))
 	}
 
 	input := a.styles.FormInputFocus.Render(a.tagInput.View())
 
+	// Known tags as a tree, narrowed to those starting with the input
+	prefix := strings.ToLower(strings.TrimSpace(a.tagInput.Value()))
+	var known []string
+	for _, tag := range model.AllTags(a.tasks) {
+		if strings.HasPrefix(strings.ToLower(tag), prefix) {
+			known = append(known, tag)
+		}
+	}
+	selected := make(map[string]bool, len(task.Tags))
+	for _, tag := range task.Tags {
+		selected[tag] = true
+	}
+	tree := renderTagTree(model.TagTree(known), selected)
+	if len(tree) > maxTagTreeLines {
+		tree = append(tree[:maxTagTreeLines], lipgloss.NewStyle().
+			Foreground(colorOverlay0).
+			Render(i18n.Tf("… %d de plus", len(tree)-maxTagTreeLines)))
+	}
+
 	help := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#6c7086")).
-		Render("Enter: ajouter/retirer, Esc: annuler")
+		Render(i18n.T("Enter: ajouter/retirer, Tab: compléter, Esc: annuler"))
+
+	content := title + "\n\n" + tagList + "\n\n" + input
+	if len(tree) > 0 {
+		content += "\n\n" + strings.Join(tree, "\n")
+	}
+	content += "\n\n" + help
+
+	dialog := a.styles.Dialog.Render(content)
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		dialog,
+	)
+}
+
+// renderNoteInput renders the note dialog with the task's notes log
+func (a *App) renderNoteInput() string {
+	task := a.selectedTask()
+	if task == nil {
+		return a.renderMainView()
+	}
+
+	title := a.styles.DialogTitle.Render(i18n.T("Ajouter une note"))
+	subtitle := lipgloss.NewStyle().
+		Foreground(colorSubtext0).
+		Italic(true).
+		Render(truncate(task.Title, a.noteInput.Width+4))
+
+	var history string
+	if len(task.Notes) > 0 {
+		history = strings.Join(renderNotes(task.Notes, a.noteInput.Width+4), "\n")
+	} else {
+		history = lipgloss.NewStyle().
+			Foreground(colorOverlay0).
+			Italic(true).
+			Render(i18n.T("Aucune note"))
+	}
+
+	input := a.styles.FormInputFocus.Render(a.noteInput.View())
+
+	help := lipgloss.NewStyle().
+		Foreground(colorOverlay0).
+		Render(i18n.T("Enter: ajouter, Esc: annuler"))
 
-	content := title + "\n\n" + tagList + "\n\n" + input + "\n\n" + help
+	content := title + "\n" + subtitle + "\n\n" + history + "\n\n" + input + "\n\n" + help
 
 	dialog := a.styles.Dialog.Render(content)
 
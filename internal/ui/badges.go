@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tabBadge is the count shown next to the name of a view tab, telling
+// whether switching to it is worth it; hidden when zero
+type tabBadge struct {
+	count int
+	color lipgloss.Color
+}
+
+// tabBadges returns the badge of each view, by ViewMode: the inbox for the
+// list (open tasks with a tag of capture.tags, not triaged yet), the
+// blocked tasks for the kanban board, the overdue tasks for the calendar,
+// or else the ones due today, and the open tasks of the today view
+func (a *App) tabBadges(now time.Time) []tabBadge {
+	tasks := a.index.Tasks()
+	inbox := 0
+	counted := map[int]bool{}
+	for _, tag := range a.config.Capture.Tags {
+		for _, i := range a.index.Tagged(tag) {
+			if !counted[i] && !tasks[i].Status.IsDone() {
+				counted[i] = true
+				inbox++
+			}
+		}
+	}
+	blocked := a.index.OpenOfKind(model.StatusBlocked)
+	load, overdue := a.index.DueLoad(now, 1)
+	today := load[0]
+
+	due := tabBadge{count: overdue, color: colorRed}
+	if overdue == 0 {
+		due = tabBadge{count: today, color: colorYellow}
+	}
+	return []tabBadge{
+		ViewList:     {count: inbox, color: colorBlue},
+		ViewKanban:   {count: blocked, color: colorPeach},
+		ViewCalendar: due,
+		ViewToday:    {count: a.index.OpenToday(now), color: colorYellow},
+	}
+}
+
+// renderTab renders the tab of a view with its badge, in the style of the
+// tab so the selected one keeps its background
+func renderTab(style lipgloss.Style, name string, badge tabBadge) string {
+	if badge.count == 0 {
+		return style.Render(name)
+	}
+	return style.PaddingRight(0).Render(name) +
+		style.Foreground(badge.color).PaddingLeft(1).Render(itoa(badge.count))
+}
+
+// privateBadge marks a private task, hidden from the restricted tokens of
+// the server
+func privateBadge(task model.Task) string {
+	if !task.Private {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(colorMauve).Render(i18n.T("privée"))
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cycleBlockReason gives the selected task the next reason it is blocked
+// for, none after the last one, blocking it for the first one when it
+// isn't blocked
+func (a *App) cycleBlockReason() tea.Cmd {
+	task := a.selectedTask()
+	if task == nil {
+		return nil
+	}
+	t := *task
+	if t.Status.Kind() == model.StatusBlocked {
+		t.BlockedReason = model.NextBlockReason(t.BlockedReason)
+	} else {
+		t.Status = model.StatusOfKind(model.StatusBlocked)
+		t.BlockedReason = model.BlockReasons()[0]
+	}
+	if t.BlockedReason == "" {
+		a.setMessage(i18n.T("Raison du blocage retirée"))
+	} else {
+		a.setMessage(i18n.Tf("Bloquée: %s", model.BlockReasonLabel(t.BlockedReason)))
+	}
+	return a.moveTask(t)
+}
@@ -0,0 +1,318 @@
+package ui
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Board is a tasks file opened as a tab
+type Board struct {
+	Name    string
+	Storage *storage.Storage
+	// Theme colors the columns of the board over the kanban settings
+	Theme config.ColumnTheme
+}
+
+// NewBoard creates a board for a tasks file, named after the file when
+// name is empty
+func NewBoard(name string, store *storage.Storage) Board {
+	if name == "" {
+		base := filepath.Base(store.GetFilePath())
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return Board{Name: name, Storage: store}
+}
+
+// Boards shows several boards as tabs, each an App with its own storage
+// and view state, followed by an overview of their urgent tasks. gt and gT
+// switch to the next and previous tab.
+type Boards struct {
+	names    []string
+	apps     []*App
+	overview *Overview
+	active   int // len(apps) when the overview is shown
+	styles   Styles
+	width    int
+	height   int
+
+	// g was pressed, waiting for t or T
+	leader bool
+}
+
+// boardMsg is a message produced by the commands of a board, routed back
+// to it whichever board is active
+type boardMsg struct {
+	board int
+	msg   tea.Msg
+}
+
+// boardQuitMsg is sent when a board has saved its changes and quits
+type boardQuitMsg struct{ board int }
+
+// NewBoards creates the tabs of the given boards
+func NewBoards(boards []Board, cfg *config.Config) *Boards {
+	b := &Boards{styles: DefaultStyles()}
+	for _, board := range boards {
+		b.names = append(b.names, board.Name)
+		app := NewApp(board.Storage, cfg)
+		app.SetColumnTheme(cfg.Kanban.ColumnTheme.Merge(board.Theme))
+		b.apps = append(b.apps, app)
+	}
+	b.overview = NewOverview(b.styles, b.names)
+	if cfg.Display.ReducedMotion {
+		b.overview.SetReducedMotion()
+	}
+	return b
+}
+
+// Init initializes every board
+func (b *Boards) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, len(b.apps))
+	for i, app := range b.apps {
+		cmds[i] = b.wrap(i, app.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+// wrap tags the messages of a board's command so that they reach it.
+// Bubble Tea's own messages (alt screen, external processes) go to the
+// program untouched, except the quit request, which must first save the
+// other boards.
+func (b *Boards) wrap(board int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case nil:
+			return nil
+		case tea.QuitMsg:
+			return boardQuitMsg{board}
+		case tea.BatchMsg:
+			cmds := make(tea.BatchMsg, len(msg))
+			for i, c := range msg {
+				cmds[i] = b.wrap(board, c)
+			}
+			return cmds
+		default:
+			if reflect.TypeOf(msg).PkgPath() == reflect.TypeOf(tea.QuitMsg{}).PkgPath() {
+				return msg
+			}
+			return boardMsg{board, msg}
+		}
+	}
+}
+
+// Update routes messages to the boards
+func (b *Boards) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.width = msg.Width
+		b.height = msg.Height
+		size := msg
+		if len(b.apps) > 1 {
+			size.Height-- // tab bar
+		}
+		cmds := make([]tea.Cmd, len(b.apps))
+		for i := range b.apps {
+			cmds[i] = b.update(i, size)
+		}
+		b.overview.SetSize(size.Width, size.Height)
+		return b, tea.Batch(cmds...)
+
+	case boardMsg:
+		return b, b.update(msg.board, msg.msg)
+
+	case boardQuitMsg:
+		return b, b.quit(msg.board)
+
+	case tea.KeyMsg:
+		if cmd, ok := b.handleLeader(msg); ok {
+			return b, cmd
+		}
+		if b.showsOverview() {
+			return b, b.handleOverviewKeys(msg)
+		}
+		if len(b.apps) > 1 && b.apps[b.active].state == StateNormal && key.Matches(msg, b.apps[b.active].keys.SearchAll) {
+			b.active = len(b.apps)
+			return b, b.overview.StartSearch()
+		}
+	}
+
+	if b.showsOverview() {
+		return b, b.overview.UpdateSearch(msg)
+	}
+	return b, b.update(b.active, msg)
+}
+
+// quit saves the pending changes of every board but the one that already
+// did, and exits. A board that can't be saved is shown instead.
+func (b *Boards) quit(saved int) tea.Cmd {
+	for i, app := range b.apps {
+		if i == saved {
+			continue
+		}
+		if _, cmd := app.quit(); cmd == nil {
+			b.active = i
+			return nil
+		}
+	}
+	return tea.Quit
+}
+
+// showsOverview returns true if the overview tab is active
+func (b *Boards) showsOverview() bool {
+	return b.active == len(b.apps)
+}
+
+// tabCount returns the number of tabs, the overview included
+func (b *Boards) tabCount() int {
+	if len(b.apps) < 2 {
+		return len(b.apps)
+	}
+	return len(b.apps) + 1
+}
+
+// refreshOverview collects the tasks of every board in the overview
+func (b *Boards) refreshOverview() {
+	tasks := make([][]model.Task, len(b.apps))
+	for i, app := range b.apps {
+		tasks[i] = app.tasks
+	}
+	b.overview.SetTasks(tasks, time.Now())
+}
+
+// handleOverviewKeys handles keys in the overview: actions apply to the
+// task in its own board
+func (b *Boards) handleOverviewKeys(msg tea.KeyMsg) tea.Cmd {
+	if b.overview.IsSearching() {
+		return b.overview.HandleSearchKey(msg)
+	}
+
+	keyMap := b.apps[0].keys
+	b.refreshOverview()
+	entry := b.overview.Selected()
+
+	switch {
+	case key.Matches(msg, keyMap.Search), key.Matches(msg, keyMap.SearchAll):
+		return b.overview.StartSearch()
+	case key.Matches(msg, keyMap.Cancel):
+		// Back to the urgent tasks
+		return b.overview.HandleSearchKey(msg)
+	case key.Matches(msg, keyMap.Quit):
+		return b.quit(-1)
+	case key.Matches(msg, keyMap.Up):
+		b.overview.MoveUp()
+	case key.Matches(msg, keyMap.Down):
+		b.overview.MoveDown()
+	case entry == nil:
+		return nil
+	case key.Matches(msg, keyMap.Submit):
+		// Open the task in its board
+		b.active = entry.board
+		b.apps[entry.board].currentView().SelectTask(entry.task.ID)
+	case key.Matches(msg, keyMap.StatusTodo):
+		return b.setStatus(entry, model.StatusOfKind(model.StatusTodo))
+	case key.Matches(msg, keyMap.StatusInProgress):
+		return b.setStatus(entry, model.StatusOfKind(model.StatusInProgress))
+	case key.Matches(msg, keyMap.StatusBlocked):
+		return b.setStatus(entry, model.StatusOfKind(model.StatusBlocked))
+	case key.Matches(msg, keyMap.StatusDone):
+		return b.setStatus(entry, model.StatusOfKind(model.StatusDone))
+	}
+	return nil
+}
+
+// setStatus changes the status of a task of the overview, saved by its board
+func (b *Boards) setStatus(entry *overviewEntry, status model.Status) tea.Cmd {
+	task := entry.task
+	task.Status = status
+	return b.wrap(entry.board, b.apps[entry.board].updateTask(task))
+}
+
+// handleLeader handles gt and gT in the normal state of the active board.
+// It returns false when the key is for the board.
+func (b *Boards) handleLeader(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if len(b.apps) < 2 || b.overview.IsSearching() ||
+		(!b.showsOverview() && b.apps[b.active].state != StateNormal) {
+		b.leader = false
+		return nil, false
+	}
+
+	if !b.leader {
+		if msg.String() == "g" {
+			b.leader = true
+			return nil, true
+		}
+		return nil, false
+	}
+
+	b.leader = false
+	switch msg.String() {
+	case "t":
+		b.active = (b.active + 1) % b.tabCount()
+		return nil, true
+	case "T":
+		b.active = (b.active + b.tabCount() - 1) % b.tabCount()
+		return nil, true
+	}
+	if b.showsOverview() {
+		return b.handleOverviewKeys(msg), true
+	}
+	// Not a tab switch: g keeps its meaning, followed by this key
+	g := b.update(b.active, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	return tea.Batch(g, b.update(b.active, msg)), true
+}
+
+// update sends a message to a board
+func (b *Boards) update(board int, msg tea.Msg) tea.Cmd {
+	_, cmd := b.apps[board].Update(msg)
+	return b.wrap(board, cmd)
+}
+
+// View renders the tab bar above the active board
+func (b *Boards) View() string {
+	if len(b.apps) < 2 || b.width == 0 {
+		return b.apps[0].View()
+	}
+	if b.showsOverview() {
+		b.refreshOverview()
+		return b.renderTabs() + "\n" + b.overview.Render(time.Now())
+	}
+	return b.renderTabs() + "\n" + b.apps[b.active].View()
+}
+
+// renderTabs renders the names of the boards, the active one highlighted
+func (b *Boards) renderTabs() string {
+	var tabs []string
+	for i, name := range b.names {
+		style := b.styles.HeaderTab
+		if i == b.active {
+			style = b.styles.HeaderTabSel
+		}
+		if b.apps[i].storage.Dirty() {
+			name += " ●"
+		}
+		tabs = append(tabs, style.Render(itoa(i+1)+" "+name))
+	}
+	style := b.styles.HeaderTab
+	if b.showsOverview() {
+		style = b.styles.HeaderTabSel
+	}
+	tabs = append(tabs, style.Render("★ "+i18n.T("Urgent")))
+	return lipgloss.NewStyle().
+		Width(b.width).
+		MaxWidth(b.width).
+		Render(strings.Join(tabs, " "))
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"os"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bulkEditedMsg is sent when the editor of a bulk edit is closed, with the
+// changes to save. On error the snippet is kept at path, not to lose the
+// edits.
+type bulkEditedMsg struct {
+	changes storage.Changes
+	path    string
+	err     error
+}
+
+// bulkTasks returns the tasks of a bulk edit: the ones matching the
+// search, or the selected task without one
+func (a *App) bulkTasks() []model.Task {
+	if a.searchInput.Value() != "" {
+		return a.listView.FilteredTasks()
+	}
+	if task := a.selectedTask(); task != nil {
+		return []model.Task{*task}
+	}
+	return nil
+}
+
+// bulkEdit opens the tasks of the search, or the selected task, in
+// $EDITOR as a snippet in the format of the tasks file. Once it's closed,
+// the edits are merged back by ID: tasks left out are deleted and new
+// ones added.
+func (a *App) bulkEdit() tea.Cmd {
+	tasks := a.bulkTasks()
+	if len(tasks) == 0 {
+		return nil
+	}
+	data, ext, err := a.storage.EncodeTasks(tasks)
+	if err != nil {
+		return func() tea.Msg { return errMsg{err} }
+	}
+	f, err := os.CreateTemp("", "lazy-todo-edit-*"+ext)
+	if err != nil {
+		return func() tea.Msg { return errMsg{err} }
+	}
+	path := f.Name()
+	_, err = f.Write(data)
+	f.Close()
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return errMsg{err} }
+	}
+
+	return tea.ExecProcess(storage.EditorCommand(path), func(err error) tea.Msg {
+		if err != nil {
+			os.Remove(path)
+			return bulkEditedMsg{err: err}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return bulkEditedMsg{path: path, err: err}
+		}
+		edited, err := a.storage.DecodeTasks(data)
+		if err != nil {
+			return bulkEditedMsg{path: path, err: err}
+		}
+		changes, err := storage.EditChanges(tasks, edited, time.Now())
+		if err != nil {
+			return bulkEditedMsg{path: path, err: err}
+		}
+		os.Remove(path)
+		// An emptied snippet cancels, like an emptied commit message
+		if len(edited) == 0 {
+			return bulkEditedMsg{}
+		}
+		return bulkEditedMsg{changes: changes}
+	})
+}
+
+// bulkEdited saves the changes of a bulk edit
+func (a *App) bulkEdited(msg bulkEditedMsg) tea.Cmd {
+	switch {
+	case msg.err != nil && msg.path != "":
+		a.setMessage(i18n.Tf("Modifications refusées (%s), gardées dans %s", i18n.T(msg.err.Error()), msg.path))
+		return nil
+	case msg.err != nil:
+		a.setMessage(i18n.T("Erreur lors de l'ouverture de l'éditeur"))
+		return nil
+	case msg.changes.IsEmpty():
+		a.setMessage(i18n.T("Aucune modification"))
+		return nil
+	}
+	c := msg.changes
+	a.setMessage(i18n.N(len(c.Added)+len(c.Updated)+len(c.Deleted), "%d tâche modifiée", "%d tâches modifiées"))
+	return a.commit(c)
+}
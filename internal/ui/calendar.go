@@ -0,0 +1,316 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// calendarCellWidth is the width of a day in the month grid
+const calendarCellWidth = 7
+
+// CalendarView shows a month grid with the number of tasks due per day,
+// next to the agenda and the journal of the selected day
+type CalendarView struct {
+	tasks   []model.Task
+	journal []model.JournalEntry
+	day     time.Time // selected day, at midnight
+	agenda  []int     // indices of the tasks due on day
+	cursor  int       // selected agenda entry
+	sortBy  model.SortBy
+	styles  Styles
+	width   int
+	height  int
+}
+
+// NewCalendarView creates a new calendar view, on today
+func NewCalendarView(styles Styles) *CalendarView {
+	return &CalendarView{
+		tasks:  []model.Task{},
+		day:    dayOf(time.Now()),
+		styles: styles,
+	}
+}
+
+// dayOf returns midnight of the day of t
+func dayOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// SetTasks sets the tasks to display
+func (c *CalendarView) SetTasks(tasks []model.Task) {
+	id := c.selectedID()
+	c.tasks = tasks
+	c.buildAgenda()
+	c.reselect(id)
+}
+
+// SetJournal sets the journal shown under the agenda
+func (c *CalendarView) SetJournal(journal []model.JournalEntry) {
+	c.journal = journal
+}
+
+// Day returns the selected day, at midnight
+func (c *CalendarView) Day() time.Time {
+	return c.day
+}
+
+// SetSortBy sets the sorting mode of the agenda
+func (c *CalendarView) SetSortBy(sortBy model.SortBy) {
+	id := c.selectedID()
+	c.sortBy = sortBy
+	c.buildAgenda()
+	c.reselect(id)
+}
+
+// SetSize sets the view dimensions
+func (c *CalendarView) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+// buildAgenda lists the tasks due on the selected day
+func (c *CalendarView) buildAgenda() {
+	c.agenda = c.agenda[:0]
+	for i, t := range c.tasks {
+		if t.IsDueOn(c.day) {
+			c.agenda = append(c.agenda, i)
+		}
+	}
+	model.SortIndices(c.tasks, c.agenda, c.sortBy)
+}
+
+// reselect keeps the cursor on the task with the given ID, or clamps it
+func (c *CalendarView) reselect(id string) {
+	for i, idx := range c.agenda {
+		if c.tasks[idx].ID == id {
+			c.cursor = i
+			return
+		}
+	}
+	c.cursor = max(min(c.cursor, len(c.agenda)-1), 0)
+}
+
+// selectedID returns the ID of the selected task, or "" if none
+func (c *CalendarView) selectedID() string {
+	if task := c.SelectedTask(); task != nil {
+		return task.ID
+	}
+	return ""
+}
+
+// MoveDays moves the selected day by n days (weeks are 7 days)
+func (c *CalendarView) MoveDays(n int) {
+	c.setDay(c.day.AddDate(0, 0, n))
+}
+
+// MoveMonths moves the selected day by n months, staying in the target
+// month when it has fewer days
+func (c *CalendarView) MoveMonths(n int) {
+	first := time.Date(c.day.Year(), c.day.Month()+time.Month(n), 1, 0, 0, 0, 0, time.Local)
+	last := first.AddDate(0, 1, -1).Day()
+	c.setDay(first.AddDate(0, 0, min(c.day.Day(), last)-1))
+}
+
+// setDay selects a day and shows its agenda from the top
+func (c *CalendarView) setDay(day time.Time) {
+	c.day = dayOf(day)
+	c.cursor = 0
+	c.buildAgenda()
+}
+
+// MoveUp selects the previous task of the agenda
+func (c *CalendarView) MoveUp() {
+	if c.cursor > 0 {
+		c.cursor--
+	}
+}
+
+// MoveDown selects the next task of the agenda
+func (c *CalendarView) MoveDown() {
+	if c.cursor < len(c.agenda)-1 {
+		c.cursor++
+	}
+}
+
+// SelectedTask returns the selected task of the agenda
+func (c *CalendarView) SelectedTask() *model.Task {
+	if c.cursor < 0 || c.cursor >= len(c.agenda) {
+		return nil
+	}
+	return &c.tasks[c.agenda[c.cursor]]
+}
+
+// SelectedIndex returns the index of the selected task in the original slice
+func (c *CalendarView) SelectedIndex() int {
+	if c.cursor < 0 || c.cursor >= len(c.agenda) {
+		return -1
+	}
+	return c.agenda[c.cursor]
+}
+
+// SelectTask moves to the due day of the task with the given ID and
+// selects it. It returns false, leaving the selection unchanged, if the
+// task has no due date.
+func (c *CalendarView) SelectTask(id string) bool {
+	for _, t := range c.tasks {
+		if t.ID == id && t.DueDate != nil {
+			c.setDay(t.DueDate.In(time.Local))
+			c.reselect(id)
+			return true
+		}
+	}
+	return false
+}
+
+// Render renders the month grid and the agenda side by side
+func (c *CalendarView) Render() string {
+	grid := c.renderMonth()
+	agendaWidth := max(c.width-lipgloss.Width(grid)-6, 20)
+	agenda := c.renderAgenda(agendaWidth)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Padding(1, 2).Render(grid),
+		lipgloss.NewStyle().Padding(1, 0).Render(agenda),
+	)
+}
+
+// renderMonth renders the grid of the selected month, weeks starting on
+// Monday, with the number of open tasks due on each day
+func (c *CalendarView) renderMonth() string {
+	first := time.Date(c.day.Year(), c.day.Month(), 1, 0, 0, 0, 0, time.Local)
+	today := dayOf(time.Now())
+
+	// Open tasks due per day of the month
+	counts := make(map[int]int)
+	for _, t := range c.tasks {
+		if t.DueDate == nil || t.Status.IsDone() {
+			continue
+		}
+		due := t.DueDate.In(time.Local)
+		if due.Year() == first.Year() && due.Month() == first.Month() {
+			counts[due.Day()]++
+		}
+	}
+
+	titleStyle := lipgloss.NewStyle().Foreground(colorMauve).Bold(true)
+	weekdayStyle := lipgloss.NewStyle().Foreground(colorOverlay1)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(capitalize(monthLabel(first.Month()))+" "+itoa(first.Year())), "")
+
+	var header strings.Builder
+	for i := 0; i < 7; i++ {
+		name := weekdayLabel(time.Weekday((i + 1) % 7))
+		header.WriteString(weekdayStyle.Render(fmt.Sprintf("%-*s", calendarCellWidth, " "+capitalize(shortLabel(name)))))
+	}
+	lines = append(lines, header.String())
+
+	// Monday is the first column
+	col := (int(first.Weekday()) + 6) % 7
+	week := strings.Repeat(" ", col*calendarCellWidth)
+	days := first.AddDate(0, 1, -1).Day()
+	for d := 1; d <= days; d++ {
+		date := first.AddDate(0, 0, d-1)
+		week += c.renderDay(date, counts[d], today)
+		col++
+		if col == 7 || d == days {
+			lines = append(lines, "", week)
+			week, col = "", 0
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderDay renders a cell of the month grid
+func (c *CalendarView) renderDay(date time.Time, count int, today time.Time) string {
+	base := lipgloss.NewStyle()
+	if date.Equal(c.day) {
+		base = base.Background(colorSurface1)
+	}
+
+	numStyle := base.Foreground(colorText)
+	if date.Equal(today) {
+		numStyle = numStyle.Foreground(colorMauve).Bold(true).Underline(true)
+	}
+	cell := numStyle.Render(fmt.Sprintf("%3d", date.Day())) + base.Render(" ")
+
+	if count > 0 {
+		color := heatColor(count)
+		if date.Before(today) {
+			color = colorRed
+		}
+		cell += base.Foreground(color).Render("●" + itoa(count))
+	}
+
+	return cell + base.Render(strings.Repeat(" ", max(calendarCellWidth-lipgloss.Width(cell), 0)))
+}
+
+// renderAgenda renders the tasks due on the selected day, then its journal
+func (c *CalendarView) renderAgenda(width int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(colorMauve).Bold(true)
+	title := capitalize(i18n.Tf("%s %d %s %d", weekdayLabel(c.day.Weekday()), c.day.Day(),
+		monthLabel(c.day.Month()), c.day.Year()))
+
+	lines := []string{titleStyle.Render(title), ""}
+	if len(c.agenda) == 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(colorOverlay0).
+			Italic(true).
+			Render(i18n.T("Aucune tâche pour ce jour")))
+	}
+
+	for i, idx := range c.agenda {
+		task := c.tasks[idx]
+		line := c.styles.PriorityStyle(task.Priority).Render(PriorityIcon(task.Priority)) + " " +
+			c.styles.StatusStyle(task.Status).Render(StatusIcon(task.Status)) + " " +
+			truncate(task.Title, width-8)
+		if i == c.cursor {
+			lines = append(lines, c.styles.ListItemSelected.Width(width).Render(line))
+		} else {
+			lines = append(lines, c.styles.ListItem.Width(width).Render(line))
+		}
+	}
+	lines = append(lines, renderJournal(c.journal, c.day, width)...)
+	return strings.Join(lines, "\n")
+}
+
+// shortLabel returns the first two letters of a label
+func shortLabel(s string) string {
+	runes := []rune(s)
+	return string(runes[:min(2, len(runes))])
+}
+
+// capitalize returns s with its first letter in upper case
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// monthLabel returns the name of a month in the current language
+func monthLabel(m time.Month) string {
+	names := [...]string{
+		i18n.T("janvier"), i18n.T("février"), i18n.T("mars"), i18n.T("avril"),
+		i18n.T("mai"), i18n.T("juin"), i18n.T("juillet"), i18n.T("août"),
+		i18n.T("septembre"), i18n.T("octobre"), i18n.T("novembre"), i18n.T("décembre"),
+	}
+	return names[m-1]
+}
+
+// weekdayLabel returns the name of a weekday in the current language
+func weekdayLabel(d time.Weekday) string {
+	names := [...]string{
+		i18n.T("dimanche"), i18n.T("lundi"), i18n.T("mardi"), i18n.T("mercredi"),
+		i18n.T("jeudi"), i18n.T("vendredi"), i18n.T("samedi"),
+	}
+	return names[d]
+}
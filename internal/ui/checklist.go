@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChecklistPanel displays and edits the subtasks of a task
+type ChecklistPanel struct {
+	task   model.Task
+	cursor int
+	adding bool
+	input  textinput.Model
+	styles Styles
+	width  int
+	height int
+}
+
+// NewChecklistPanel creates a new checklist panel
+func NewChecklistPanel(styles Styles) *ChecklistPanel {
+	input := textinput.New()
+	input.Placeholder = i18n.T("Nouvel élément...")
+	input.CharLimit = 100
+
+	return &ChecklistPanel{
+		styles: styles,
+		input:  input,
+	}
+}
+
+// SetReducedMotion stops the cursor from blinking
+func (c *ChecklistPanel) SetReducedMotion() {
+	staticCursor(&c.input)
+}
+
+// SetTask sets the task whose checklist is edited
+func (c *ChecklistPanel) SetTask(task model.Task) {
+	c.task = task
+	c.adding = false
+	if c.cursor >= len(task.Subtasks) {
+		c.cursor = len(task.Subtasks) - 1
+	}
+	if c.cursor < 0 {
+		c.cursor = 0
+	}
+}
+
+// Refresh reloads the edited task from an updated task slice
+func (c *ChecklistPanel) Refresh(tasks []model.Task) {
+	for _, t := range tasks {
+		if t.ID == c.task.ID {
+			adding := c.adding
+			c.SetTask(t)
+			c.adding = adding
+			return
+		}
+	}
+}
+
+// Task returns the edited task
+func (c *ChecklistPanel) Task() model.Task {
+	return c.task
+}
+
+// IsAdding returns true while a new item is being typed
+func (c *ChecklistPanel) IsAdding() bool {
+	return c.adding
+}
+
+// SetSize sets the panel dimensions
+func (c *ChecklistPanel) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+	c.input.Width = width - 12
+}
+
+// MoveUp moves the cursor up
+func (c *ChecklistPanel) MoveUp() {
+	if c.cursor > 0 {
+		c.cursor--
+	}
+}
+
+// MoveDown moves the cursor down
+func (c *ChecklistPanel) MoveDown() {
+	if c.cursor < len(c.task.Subtasks)-1 {
+		c.cursor++
+	}
+}
+
+// Toggle toggles the selected item and returns the updated task
+func (c *ChecklistPanel) Toggle() (model.Task, bool) {
+	if c.cursor >= len(c.task.Subtasks) {
+		return c.task, false
+	}
+	subtasks := append([]model.Subtask(nil), c.task.Subtasks...)
+	subtasks[c.cursor].Done = !subtasks[c.cursor].Done
+	c.task.Subtasks = subtasks
+	return c.task, true
+}
+
+// Delete removes the selected item and returns the updated task
+func (c *ChecklistPanel) Delete() (model.Task, bool) {
+	if c.cursor >= len(c.task.Subtasks) {
+		return c.task, false
+	}
+	var subtasks []model.Subtask
+	subtasks = append(subtasks, c.task.Subtasks[:c.cursor]...)
+	subtasks = append(subtasks, c.task.Subtasks[c.cursor+1:]...)
+	c.task.Subtasks = subtasks
+	if c.cursor >= len(subtasks) && c.cursor > 0 {
+		c.cursor--
+	}
+	return c.task, true
+}
+
+// StartAdding shows the input for a new item
+func (c *ChecklistPanel) StartAdding() tea.Cmd {
+	c.adding = true
+	c.input.SetValue("")
+	return c.input.Focus()
+}
+
+// CancelAdding hides the input
+func (c *ChecklistPanel) CancelAdding() {
+	c.adding = false
+	c.input.Blur()
+}
+
+// Add appends the typed item and returns the updated task
+func (c *ChecklistPanel) Add() (model.Task, bool) {
+	title := strings.TrimSpace(c.input.Value())
+	c.CancelAdding()
+	if title == "" {
+		return c.task, false
+	}
+	subtasks := append([]model.Subtask(nil), c.task.Subtasks...)
+	c.task.Subtasks = append(subtasks, model.Subtask{Title: title})
+	c.cursor = len(c.task.Subtasks) - 1
+	return c.task, true
+}
+
+// Update handles text input while adding
+func (c *ChecklistPanel) Update(msg tea.Msg) (*ChecklistPanel, tea.Cmd) {
+	var cmd tea.Cmd
+	if c.adding {
+		c.input, cmd = c.input.Update(msg)
+	}
+	return c, cmd
+}
+
+// Render renders the panel
+func (c *ChecklistPanel) Render() string {
+	done, total := c.task.SubtaskProgress()
+	title := c.styles.DialogTitle.Render(i18n.T("Checklist: ") + c.task.Title)
+	progress := lipgloss.NewStyle().
+		Foreground(colorSubtext0).
+		Render(i18n.Nf(done, "%d/%d terminé", "%d/%d terminés", done, total))
+
+	var lines []string
+	if total == 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(colorOverlay0).
+			Italic(true).
+			Render(i18n.T("Aucun élément")))
+	}
+	for i, st := range c.task.Subtasks {
+		box := c.styles.StatusTodo.Render("☐")
+		text := st.Title
+		if st.Done {
+			box = c.styles.StatusDone.Render("☑")
+			text = lipgloss.NewStyle().Foreground(colorOverlay1).Strikethrough(true).Render(text)
+		}
+		line := box + " " + text
+		if i == c.cursor && !c.adding {
+			line = c.styles.ListItemSelected.Render(line)
+		} else {
+			line = c.styles.ListItem.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	content := title + "\n" + progress + "\n\n" + strings.Join(lines, "\n")
+
+	if c.adding {
+		content += "\n\n" + c.styles.FormInputFocus.Render(c.input.View())
+	}
+
+	help := lipgloss.NewStyle().
+		Foreground(colorOverlay0).
+		Render(i18n.T("Espace: cocher, a: ajouter, d: supprimer, Esc: fermer"))
+	content += "\n\n" + help
+
+	return c.styles.Dialog.Width(c.width).Render(content)
+}
@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/keys"
+	"lazy-todo/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// chordKeys are the key messages of the named keys an action can be
+// bound to, to run it from a chord
+var chordKeys = map[string]tea.KeyType{
+	"enter":  tea.KeyEnter,
+	"tab":    tea.KeyTab,
+	"delete": tea.KeyDelete,
+	"up":     tea.KeyUp,
+	"down":   tea.KeyDown,
+	"left":   tea.KeyLeft,
+	"right":  tea.KeyRight,
+	"ctrl+s": tea.KeyCtrlS,
+}
+
+// chordDescriptions describes the chord actions in the chord popup
+var chordDescriptions = map[string]string{
+	keys.ChordSortManual:    "trier: manuel",
+	keys.ChordSortCreated:   "trier: création",
+	keys.ChordSortUpdated:   "trier: modification",
+	keys.ChordSortPriority:  "trier: priorité",
+	keys.ChordSortDue:       "trier: échéance",
+	keys.ChordSortTitle:     "trier: titre",
+	keys.ChordSortUrgency:   "trier: urgence",
+	keys.ChordGroupNone:     "grouper: aucun",
+	keys.ChordGroupStatus:   "grouper: état",
+	keys.ChordGroupPriority: "grouper: priorité",
+	keys.ChordGroupTag:      "grouper: tag",
+	keys.ChordViewList:      "vue: liste",
+	keys.ChordViewKanban:    "vue: kanban",
+	keys.ChordViewCalendar:  "vue: calendrier",
+	keys.ChordViewToday:     "vue: aujourd'hui",
+}
+
+// handleChordKey adds a key to the chord being typed, and runs its action
+// once complete. Esc or a key no chord continues with cancels it.
+func (a *App) handleChordKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		a.chord = nil
+		return a, nil
+	}
+	typed := append(a.chord, msg.String())
+	action, pending := a.keys.ChordAction(typed)
+	if pending {
+		a.chord = typed
+		return a, nil
+	}
+	a.chord = nil
+	if action == "" {
+		return a, nil
+	}
+	return a.runChordAction(action)
+}
+
+// runChordAction runs a chord action, or an action of the key map as if
+// its first key was pressed
+func (a *App) runChordAction(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case keys.ChordSortManual:
+		a.setSortBy(model.SortByManual)
+	case keys.ChordSortCreated:
+		a.setSortBy(model.SortByCreated)
+	case keys.ChordSortUpdated:
+		a.setSortBy(model.SortByUpdated)
+	case keys.ChordSortPriority:
+		a.setSortBy(model.SortByPriority)
+	case keys.ChordSortDue:
+		a.setSortBy(model.SortByDueDate)
+	case keys.ChordSortTitle:
+		a.setSortBy(model.SortByTitle)
+	case keys.ChordSortUrgency:
+		a.setSortBy(model.SortByUrgency)
+	case keys.ChordGroupNone:
+		a.setGroupBy(model.GroupByNone)
+	case keys.ChordGroupStatus:
+		a.setGroupBy(model.GroupByStatus)
+	case keys.ChordGroupPriority:
+		a.setGroupBy(model.GroupByPriority)
+	case keys.ChordGroupTag:
+		a.setGroupBy(model.GroupByTag)
+	case keys.ChordViewList:
+		a.setViewMode(ViewList)
+	case keys.ChordViewKanban:
+		a.setViewMode(ViewKanban)
+	case keys.ChordViewCalendar:
+		a.setViewMode(ViewCalendar)
+	case keys.ChordViewToday:
+		a.setViewMode(ViewToday)
+	default:
+		for _, act := range a.keys.Actions() {
+			if act.Name != action || act.Form || len(act.Binding.Keys()) == 0 {
+				continue
+			}
+			k := act.Binding.Keys()[0]
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			if t, ok := chordKeys[k]; ok {
+				msg = tea.KeyMsg{Type: t}
+			}
+			return a.handleKeyPress(msg)
+		}
+	}
+	return a, nil
+}
+
+// setGroupBy groups the list and the kanban board
+func (a *App) setGroupBy(groupBy model.GroupBy) {
+	a.listView.SetGroupBy(groupBy)
+	a.kanbanView.SetGroupBy(groupBy)
+}
+
+// renderChordFooter renders the chord being typed in place of the footer
+func (a *App) renderChordFooter() string {
+	typed := append([]string{a.keys.Leader.Help().Key}, a.chord...)
+	return a.styles.Footer.Render(
+		lipgloss.NewStyle().Foreground(colorMauve).Bold(true).Render(strings.Join(typed, " ")+" …") +
+			a.styles.HelpSep.Render(" │ ") + a.styles.HelpValue.Render(i18n.T("Esc: annuler")))
+}
+
+// chordDescription describes the action of a chord: a chord action, or an
+// action of the key map by its help
+func (a *App) chordDescription(action string) string {
+	if desc, ok := chordDescriptions[action]; ok {
+		return i18n.T(desc)
+	}
+	for _, act := range a.keys.Actions() {
+		if act.Name == action {
+			return act.Binding.Help().Desc
+		}
+	}
+	return action
+}
+
+// renderChordPopup renders the keys that continue the chord being typed,
+// in columns, with the action each runs or the number of chords it leads to
+func (a *App) renderChordPopup() string {
+	keyStyle := lipgloss.NewStyle().Foreground(colorMauve).Bold(true)
+	descStyle := lipgloss.NewStyle().Foreground(colorText)
+	groupStyle := lipgloss.NewStyle().Foreground(colorBlue)
+
+	var entries []string
+	for _, c := range a.keys.Continuations(a.chord) {
+		desc := descStyle.Render(a.chordDescription(c.Action))
+		if c.Action == "" {
+			desc = groupStyle.Render(i18n.N(c.Count, "+%d accord", "+%d accords"))
+		}
+		entries = append(entries, keyStyle.Render(c.Key)+" → "+desc)
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+
+	// Lays the entries out in as many columns as fit
+	width := 0
+	for _, e := range entries {
+		width = max(width, lipgloss.Width(e))
+	}
+	width += 4
+	inner := max(a.width-4, width)
+	cols := max(inner/width, 1)
+	var rows []string
+	for i := 0; i < len(entries); i += cols {
+		var row string
+		for _, e := range entries[i:min(i+cols, len(entries))] {
+			row += e + strings.Repeat(" ", width-lipgloss.Width(e))
+		}
+		rows = append(rows, strings.TrimRight(row, " "))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorMauve).
+		Padding(0, 1).
+		Width(a.width - 2).
+		Render(strings.Join(rows, "\n"))
+}
+
+// overlayBottom draws a panel over the last lines of content
+func overlayBottom(content, panel string) string {
+	if panel == "" {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	panelLines := strings.Split(panel, "\n")
+	start := max(len(lines)-len(panelLines), 0)
+	return strings.Join(append(lines[:start], panelLines...), "\n")
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"errors"
+	"time"
+
+	"lazy-todo/internal/clipboard"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type pastedMsg struct {
+	text string
+	err  error
+}
+
+// copyTaskYAML copies a task as YAML, to paste it in another tasks file
+func copyTaskYAML(task model.Task) tea.Cmd {
+	return func() tea.Msg {
+		data, err := storage.MarshalTask(task)
+		if err != nil {
+			return clipboardMsg{err}
+		}
+		return clipboardMsg{clipboard.Copy(string(data))}
+	}
+}
+
+// pasteFromClipboard reads the clipboard to create tasks from it
+func pasteFromClipboard() tea.Msg {
+	text, err := clipboard.Paste()
+	return pastedMsg{text, err}
+}
+
+// paste creates the tasks copied as YAML, or one task per line of text,
+// asking first when there are several lines
+func (a *App) paste(msg pastedMsg) tea.Cmd {
+	if msg.err != nil {
+		a.setMessage(i18n.T("Erreur: ") + i18n.T(msg.err.Error()))
+		return nil
+	}
+
+	tasks, err := storage.UnmarshalTasks([]byte(msg.text))
+	if err == nil {
+		tasks = a.pastedTasks(tasks)
+		a.setMessage(i18n.N(len(tasks), "%d tâche collée", "%d tâches collées"))
+		return a.commit(storage.Changes{Added: tasks})
+	}
+	if !errors.Is(err, storage.ErrNotTasks) {
+		a.setMessage(i18n.T("Erreur: ") + i18n.T(err.Error()))
+		return nil
+	}
+
+	lines := pastedLines(msg.text)
+	switch len(lines) {
+	case 0:
+		a.setMessage(i18n.T("Presse-papiers vide"))
+		return nil
+	case 1:
+		task, ok := quickTask(lines[0])
+		if !ok {
+			return nil
+		}
+		return a.addTask(task)
+	}
+	// Confirmed like lines pasted in quick-add
+	a.quickInput.SetValue("")
+	a.quickPaste = lines
+	a.state = StateQuickAdd
+	return nil
+}
+
+// pastedTasks prepares tasks copied from a tasks file to be added: tasks
+// pasted again or copied from the same file get a new ID
+func (a *App) pastedTasks(tasks []model.Task) []model.Task {
+	now := time.Now()
+	ids := make(map[string]bool, len(a.tasks))
+	for _, t := range a.tasks {
+		ids[t.ID] = true
+	}
+	for i := range tasks {
+		t := &tasks[i]
+		if t.ID == "" || ids[t.ID] {
+			t.ID = model.NewTask("").ID
+		}
+		ids[t.ID] = true
+		// Statuses and positions of another workflow or file don't apply
+		if t.Status.Index() < 0 {
+			t.Status = model.StatusOfKind(model.StatusTodo)
+		}
+		if t.Priority == "" {
+			t.Priority = model.DefaultPriority()
+		}
+		if t.CreatedAt.IsZero() {
+			t.CreatedAt = now
+		}
+		t.UpdatedAt = now
+		t.Order = 0
+		t.Number = 0
+	}
+	return tasks
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/parse"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// IsPickingDate returns true while the due date picker is open, which
+// takes esc and enter
+func (f *TaskForm) IsPickingDate() bool {
+	return f.picker != nil
+}
+
+// openDatePicker opens the month calendar on the due date typed, or today
+func (f *TaskForm) openDatePicker() {
+	f.picker = NewCalendarView(f.styles)
+	if due := f.dueDate(); due != nil {
+		f.picker.setDay(due.In(time.Local))
+	}
+}
+
+// handleDatePickerKeys moves the day of the picker: h/l by day, j/k by
+// week, H/L by month, t to today. Enter picks the day, x clears the due
+// date, esc closes the picker unchanged.
+func (f *TaskForm) handleDatePickerKeys(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "h", "left":
+		f.picker.MoveDays(-1)
+	case "l", "right":
+		f.picker.MoveDays(1)
+	case "k", "up":
+		f.picker.MoveDays(-7)
+	case "j", "down":
+		f.picker.MoveDays(7)
+	case "H", "pgup":
+		f.picker.MoveMonths(-1)
+	case "L", "pgdown":
+		f.picker.MoveMonths(1)
+	case "t":
+		f.picker.setDay(time.Now())
+	case "enter":
+		f.dueInput.SetValue(f.picker.day.Format(time.DateOnly))
+		f.dueInput.CursorEnd()
+		f.picker = nil
+	case "x", "backspace":
+		f.dueInput.SetValue("")
+		f.picker = nil
+	case "esc":
+		f.picker = nil
+	}
+}
+
+// dueDate parses the due date field: nil when empty, and the task's own
+// due date, with its time, when unchanged or not understood
+func (f *TaskForm) dueDate() *time.Time {
+	value := strings.TrimSpace(f.dueInput.Value())
+	if value == "" {
+		return nil
+	}
+	var previous *time.Time
+	if f.task != nil {
+		previous = f.task.DueDate
+	}
+	if previous != nil && value == previous.In(time.Local).Format(time.DateOnly) {
+		return previous
+	}
+	due, ok := parse.ParseDate(value, time.Now())
+	if !ok {
+		return previous
+	}
+	return &due
+}
+
+// renderDueHint renders the day a typed due date falls on, or a warning
+// when it isn't understood, and how to open the picker
+func (f *TaskForm) renderDueHint() string {
+	hint := lipgloss.NewStyle().Foreground(colorOverlay0).Italic(true)
+	focused := f.focusedField == FieldDue && f.picker == nil
+
+	var parts []string
+	if value := strings.TrimSpace(f.dueInput.Value()); value != "" {
+		if due, ok := parse.ParseDate(value, time.Now()); ok {
+			if focused {
+				parts = append(parts, hint.Render("→ "+capitalize(i18n.Tf("%s %d %s %d",
+					weekdayLabel(due.Weekday()), due.Day(), monthLabel(due.Month()), due.Year()))))
+			}
+		} else {
+			parts = append(parts, lipgloss.NewStyle().Foreground(colorYellow).
+				Render(i18n.T("Date non reconnue, échéance inchangée")))
+		}
+	}
+	if focused {
+		parts = append(parts, hint.Render(i18n.T("Entrée: calendrier")))
+	}
+	return strings.Join(parts, hint.Render(" │ "))
+}
+
+// datePickerCellWidth is the width of a day in the picker
+const datePickerCellWidth = 4
+
+// renderDatePicker renders the month of the picker, compact, with a dot on
+// the days open tasks are due, and its keys
+func (f *TaskForm) renderDatePicker() string {
+	day := f.picker.day
+	first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.Local)
+	today := dayOf(time.Now())
+
+	due := make(map[int]bool)
+	for _, t := range f.tasks {
+		if t.DueDate == nil || t.Status.IsDone() {
+			continue
+		}
+		d := t.DueDate.In(time.Local)
+		if d.Year() == first.Year() && d.Month() == first.Month() {
+			due[d.Day()] = true
+		}
+	}
+
+	titleStyle := lipgloss.NewStyle().Foreground(colorMauve).Bold(true)
+	weekdayStyle := lipgloss.NewStyle().Foreground(colorOverlay1)
+	dotStyle := lipgloss.NewStyle().Foreground(colorPeach)
+
+	lines := []string{titleStyle.Render(capitalize(monthLabel(first.Month())) + " " + itoa(first.Year()))}
+	var header string
+	for i := 0; i < 7; i++ {
+		name := capitalize(shortLabel(weekdayLabel(time.Weekday((i + 1) % 7))))
+		header += weekdayStyle.Render(strings.Repeat(" ", datePickerCellWidth-1-lipgloss.Width(name)) + name + " ")
+	}
+	lines = append(lines, header)
+
+	// Monday is the first column
+	col := (int(first.Weekday()) + 6) % 7
+	week := strings.Repeat(" ", col*datePickerCellWidth)
+	days := first.AddDate(0, 1, -1).Day()
+	for d := 1; d <= days; d++ {
+		date := first.AddDate(0, 0, d-1)
+		style := lipgloss.NewStyle().Foreground(colorText)
+		if date.Equal(today) {
+			style = style.Foreground(colorMauve).Bold(true).Underline(true)
+		}
+		if date.Equal(day) {
+			style = style.Background(colorBlue).Foreground(colorCrust)
+		}
+		mark := " "
+		if due[d] {
+			mark = dotStyle.Render("•")
+		}
+		week += style.Render(fmt.Sprintf("%3d", d)) + mark
+		col++
+		if col == 7 || d == days {
+			lines = append(lines, week)
+			week, col = "", 0
+		}
+	}
+
+	help := lipgloss.NewStyle().Foreground(colorOverlay0).
+		Render(i18n.T("hjkl: jour/semaine │ H/L: mois │ t: aujourd'hui") + "\n" +
+			i18n.T("Entrée: choisir │ x: aucune │ Esc: fermer"))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorMauve).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n") + "\n\n" + help)
+}
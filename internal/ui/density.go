@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// detailIndent is the margin of the lines under the title of a task in the
+// detailed list
+const detailIndent = "    "
+
+// descriptionLines is the most lines of description shown under a task in
+// the detailed list
+const descriptionLines = 2
+
+// SetDetailed shows each task of the list on several lines: its title, a
+// snippet of its description and a line of dates
+func (l *ListView) SetDetailed(detailed bool) {
+	l.detailed = detailed
+}
+
+// Detailed returns true if the list shows the details of the tasks
+func (l *ListView) Detailed() bool {
+	return l.detailed
+}
+
+// itemHeight returns the number of lines an item takes
+func (l *ListView) itemHeight(i int, now time.Time) int {
+	switch {
+	case l.items[i].isHeader:
+		return 2 // margin above
+	case l.detailed:
+		return 1 + len(l.detailLines(l.tasks[l.items[i].taskIndex], now))
+	}
+	return 1
+}
+
+// scroll moves the first visible item as little as possible so that the
+// cursor's item is in view, visible being in lines
+func (l *ListView) scroll(visible int, now time.Time) {
+	visible = max(visible, 1)
+	if l.cursor < l.offset {
+		l.offset = l.cursor
+	}
+	// Lowest first item keeping the cursor's in view
+	first, lines := l.cursor, l.itemHeight(l.cursor, now)
+	for first > l.offset && lines+l.itemHeight(first-1, now) <= visible {
+		first--
+		lines += l.itemHeight(first, now)
+	}
+	l.offset = first
+
+	// Don't leave blank space at the bottom when items were removed
+	for l.offset > 0 && l.linesFrom(l.offset-1, visible, now) <= visible {
+		l.offset--
+	}
+}
+
+// linesFrom returns the lines taken by the items from the i-th to the last
+// one, counted up to just past limit
+func (l *ListView) linesFrom(i, limit int, now time.Time) int {
+	lines := 0
+	for ; i < len(l.items) && lines <= limit; i++ {
+		lines += l.itemHeight(i, now)
+	}
+	return lines
+}
+
+// detailLines returns the lines under the title of a task in the detailed
+// list: the start of its description, then its dates
+func (l *ListView) detailLines(task model.Task, now time.Time) []string {
+	width := l.width - 4 - len(detailIndent)
+	if width < minTitleWidth {
+		return nil
+	}
+
+	var lines []string
+	descStyle := lipgloss.NewStyle().Foreground(colorSubtext0)
+	for _, line := range wrapWords(task.Description, width, descriptionLines) {
+		lines = append(lines, detailIndent+descStyle.Render(line))
+	}
+
+	muted := lipgloss.NewStyle().Foreground(colorOverlay1)
+	var meta []string
+	if task.DueDate != nil {
+		due := i18n.T("Échéance") + " " + task.DueDate.In(now.Location()).Format("2006-01-02")
+		if task.IsOverdue(now) {
+			meta = append(meta, lipgloss.NewStyle().Foreground(colorRed).Render(due+" · "+i18n.T("en retard")))
+		} else {
+			meta = append(meta, muted.Render(due))
+		}
+	}
+	if !task.CreatedAt.IsZero() {
+		meta = append(meta, muted.Render(i18n.T("créée")+" "+ago(task.CreatedAt, now)))
+	}
+	if task.UpdatedAt.After(task.CreatedAt) {
+		meta = append(meta, muted.Render(i18n.T("modifiée")+" "+ago(task.UpdatedAt, now)))
+	}
+	if len(task.Notes) > 0 {
+		meta = append(meta, muted.Render(i18n.N(len(task.Notes), "%d note", "%d notes")))
+	}
+	if len(meta) > 0 {
+		line := strings.Join(meta, muted.Render(" · "))
+		if lipgloss.Width(line) > width {
+			line = truncate(line, width)
+		}
+		lines = append(lines, detailIndent+line)
+	}
+	return lines
+}
+
+// wrapWords wraps text to width, on at most maxLines lines; the last one
+// ends with … when the text goes on
+func wrapWords(text string, width, maxLines int) []string {
+	var lines []string
+	more := false
+	for _, word := range strings.Fields(text) {
+		n := len(lines)
+		if n > 0 && lipgloss.Width(lines[n-1])+1+lipgloss.Width(word) <= width {
+			lines[n-1] += " " + word
+			continue
+		}
+		if n == maxLines {
+			more = true
+			break
+		}
+		lines = append(lines, word)
+	}
+	for i, line := range lines {
+		if lipgloss.Width(line) > width {
+			lines[i] = truncate(line, width)
+		}
+	}
+	if last := len(lines) - 1; more && !strings.HasSuffix(lines[last], "…") {
+		if lipgloss.Width(lines[last])+1 > width {
+			lines[last] = strings.TrimSuffix(truncate(lines[last], width), "…")
+		}
+		lines[last] += "…"
+	}
+	return lines
+}
+
+// toggleDensity switches the list between compact and detailed, and
+// remembers the choice in the config file
+func (a *App) toggleDensity() tea.Cmd {
+	a.listView.SetDetailed(!a.listView.Detailed())
+	density := config.ListDensityCompact
+	if a.listView.Detailed() {
+		density = config.ListDensityDetailed
+	}
+	a.config.List.Density = density
+	cfg := a.config
+	return func() tea.Msg {
+		if err := cfg.Set(density, "list", "density"); err != nil {
+			return errMsg{err}
+		}
+		return nil
+	}
+}
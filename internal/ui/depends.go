@@ -0,0 +1,16 @@
+package ui
+
+import (
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dependencyBadge renders the number of open tasks the task waits on
+func dependencyBadge(task model.Task, open map[string]bool) string {
+	waiting := len(task.WaitingOn(open))
+	if waiting == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(colorPeach).Render("⛓ " + itoa(waiting))
+}
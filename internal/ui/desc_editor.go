@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"strings"
+
+	"lazy-todo/internal/i18n"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DescriptionEditor is a small nano-style multi-line editor used when no
+// external $EDITOR is available
+type DescriptionEditor struct {
+	styles   Styles
+	title    string
+	textarea textarea.Model
+	cutBuf   string
+	lastCut  bool
+	zen      bool
+	width    int
+	height   int
+}
+
+// zenColumnWidth is the maximum text width in zen mode
+const zenColumnWidth = 80
+
+// NewDescriptionEditor creates a new description editor
+func NewDescriptionEditor(styles Styles) *DescriptionEditor {
+	ta := textarea.New()
+	ta.Placeholder = "Description..."
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	// ctrl+k / ctrl+u are handled as nano-style cut/uncut
+	ta.KeyMap.DeleteAfterCursor.SetEnabled(false)
+	ta.KeyMap.DeleteBeforeCursor.SetEnabled(false)
+
+	return &DescriptionEditor{
+		styles:   styles,
+		title:    i18n.T("Éditer la description"),
+		textarea: ta,
+	}
+}
+
+// SetTitle sets the title of the editor and the placeholder shown while
+// the text is empty
+func (e *DescriptionEditor) SetTitle(title, placeholder string) {
+	e.title = title
+	e.textarea.Placeholder = placeholder
+}
+
+// SetReducedMotion stops the cursor from blinking
+func (e *DescriptionEditor) SetReducedMotion() {
+	e.textarea.Cursor.SetMode(cursor.CursorStatic)
+}
+
+// SetSize sets the editor dimensions
+func (e *DescriptionEditor) SetSize(width, height int) {
+	e.width = width
+	e.height = height
+	e.resize()
+}
+
+// resize fits the textarea to the editor dimensions and mode
+func (e *DescriptionEditor) resize() {
+	if e.zen {
+		e.textarea.SetWidth(min(zenColumnWidth, e.width-4))
+		e.textarea.SetHeight(e.height - 4)
+		return
+	}
+	e.textarea.SetWidth(e.width - 8)
+	e.textarea.SetHeight(e.height - 8)
+}
+
+// SetZen switches the distraction-free full-screen mode on or off
+func (e *DescriptionEditor) SetZen(zen bool) {
+	e.zen = zen
+	e.resize()
+}
+
+// IsZen returns true in full-screen mode
+func (e *DescriptionEditor) IsZen() bool {
+	return e.zen
+}
+
+// WordCount returns the number of words in the edited text
+func (e *DescriptionEditor) WordCount() int {
+	return wordCount(e.textarea.Value())
+}
+
+// SetValue loads text into the editor and focuses it
+func (e *DescriptionEditor) SetValue(text string) tea.Cmd {
+	e.textarea.SetValue(text)
+	e.cutBuf = ""
+	e.lastCut = false
+	e.SetZen(false)
+	return e.textarea.Focus()
+}
+
+// Value returns the edited text
+func (e *DescriptionEditor) Value() string {
+	return e.textarea.Value()
+}
+
+// Update handles editing keys
+func (e *DescriptionEditor) Update(msg tea.Msg) (*DescriptionEditor, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+k":
+			e.cutLine()
+			return e, nil
+		case "ctrl+u":
+			e.lastCut = false
+			e.textarea.InsertString(e.cutBuf)
+			return e, nil
+		case "ctrl+f":
+			e.SetZen(!e.zen)
+			return e, nil
+		}
+		e.lastCut = false
+	}
+
+	var cmd tea.Cmd
+	e.textarea, cmd = e.textarea.Update(msg)
+	return e, cmd
+}
+
+// cutLine removes the current line and stores it in the cut buffer.
+// Consecutive cuts accumulate, like in nano.
+func (e *DescriptionEditor) cutLine() {
+	row := e.textarea.Line()
+	lines := strings.Split(e.textarea.Value(), "\n")
+	if row >= len(lines) {
+		return
+	}
+
+	if !e.lastCut {
+		e.cutBuf = ""
+	}
+	e.cutBuf += lines[row] + "\n"
+	e.lastCut = true
+
+	lines = append(lines[:row], lines[row+1:]...)
+	e.textarea.SetValue(strings.Join(lines, "\n"))
+
+	// SetValue leaves the cursor at the end, move it back to the cut row
+	for e.textarea.Line() > row {
+		e.textarea.CursorUp()
+	}
+	e.textarea.CursorStart()
+}
+
+// Render renders the editor
+func (e *DescriptionEditor) Render() string {
+	if e.zen {
+		return e.renderZen()
+	}
+
+	title := e.styles.DialogTitle.Render(e.title)
+
+	help := lipgloss.NewStyle().
+		Foreground(colorOverlay0).
+		Render(i18n.T("ctrl+s: enregistrer, ctrl+k: couper ligne, ctrl+u: coller, ctrl+f: zen, Esc: annuler"))
+
+	content := title + "\n\n" + e.textarea.View() + "\n\n" + help
+
+	return e.styles.Dialog.
+		Width(e.width - 4).
+		Render(content)
+}
+
+// renderZen renders the full-screen mode: a centered text column with a
+// word count and reading time, without borders
+func (e *DescriptionEditor) renderZen() string {
+	dim := lipgloss.NewStyle().Foreground(colorOverlay0)
+
+	status := dim.Render(renderReadingTime(e.WordCount()) + i18n.T(" · ctrl+s: enregistrer, ctrl+f: quitter le mode zen, Esc: annuler"))
+
+	return "\n" + e.textarea.View() + "\n\n" + status
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// findings returns what is worth tidying up in the tasks, for the
+// maintenance report
+func (a *App) findings() []model.Finding {
+	return model.Hygiene(a.tasks, a.storage.TagColors(), a.config.Doctor.Priority(), time.Now())
+}
+
+// openDoctor opens the maintenance report
+func (a *App) openDoctor() {
+	a.doctorCursor = 0
+	a.state = StateDoctor
+}
+
+// handleDoctorKeys handles the maintenance report: j/k move, Enter fixes
+// the selected finding
+func (a *App) handleDoctorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	findings := a.findings()
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, a.keys.Doctor):
+		a.state = StateNormal
+	case key.Matches(msg, a.keys.Down):
+		if a.doctorCursor < len(findings)-1 {
+			a.doctorCursor++
+		}
+	case key.Matches(msg, a.keys.Up):
+		if a.doctorCursor > 0 {
+			a.doctorCursor--
+		}
+	case msg.String() == "enter", msg.String() == " ":
+		if a.doctorCursor < len(findings) {
+			return a, a.fixFinding(findings[a.doctorCursor])
+		}
+	}
+	return a, nil
+}
+
+// fixFinding fixes a finding of the maintenance report: right away when it
+// needs no input, or else on its task, with the tag input or the form
+func (a *App) fixFinding(f model.Finding) tea.Cmd {
+	if f.Kind == model.FindingUnusedTag {
+		return a.setTagColors(map[string]string{f.Title: ""})
+	}
+	i := model.FindTask(a.tasks, f.TaskID)
+	if i < 0 {
+		return nil
+	}
+	t := a.tasks[i]
+	switch f.Kind {
+	case model.FindingChecklistDone:
+		t.Status = model.StatusOfKind(model.StatusDone)
+		return a.moveTask(t)
+	case model.FindingUntagged:
+		a.state = StateNormal
+		a.goTo(t.ID)
+		a.tagInput.SetValue("")
+		a.tagInput.SetSuggestions(model.AllTags(a.tasks))
+		a.tagInput.Focus()
+		a.state = StateTagInput
+		return nil
+	case model.FindingNoDueDate:
+		a.state = StateNormal
+		a.goTo(t.ID)
+		if task := a.selectedTask(); task != nil && task.ID == t.ID {
+			a.taskForm.SetTask(task)
+			a.taskForm.SetSize(a.width, a.height)
+			a.state = StateForm
+		}
+		return nil
+	}
+	if !t.Fix(f, time.Now()) {
+		return nil
+	}
+	return a.updateTask(t)
+}
+
+// findingFix describes what Enter does with a finding
+func findingFix(f model.Finding) string {
+	switch f.Kind {
+	case model.FindingUnusedTag:
+		return i18n.T("retirer la couleur")
+	case model.FindingUntagged:
+		return i18n.T("ajouter des tags")
+	case model.FindingNoDueDate:
+		return i18n.T("éditer la tâche")
+	case model.FindingChecklistDone:
+		return i18n.T("marquer terminée")
+	case model.FindingDoneTimer:
+		return i18n.T("arrêter le chrono")
+	case model.FindingDonePinned:
+		return i18n.T("désépingler")
+	}
+	return ""
+}
+
+// renderDoctor renders the findings of the maintenance report, one per
+// line under the task or tag it's about
+func (a *App) renderDoctor() string {
+	title := a.styles.DialogTitle.Render(i18n.T("Maintenance"))
+	textStyle := lipgloss.NewStyle().Foreground(colorText)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+	findingStyle := lipgloss.NewStyle().Foreground(colorYellow)
+	selectedStyle := lipgloss.NewStyle().Foreground(colorBlue).Bold(true)
+
+	findings := a.findings()
+	if len(findings) == 0 {
+		content := title + "\n\n" + textStyle.Render(i18n.T("Rien à ranger")) +
+			"\n\n" + mutedStyle.Render(i18n.T("Esc: fermer"))
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.styles.Dialog.Render(content))
+	}
+	a.doctorCursor = min(a.doctorCursor, len(findings)-1)
+
+	// Scrolls to keep the cursor in the rows that fit
+	rows := max(a.height-14, 3)
+	start := max(min(a.doctorCursor-rows/2, len(findings)-rows), 0)
+	end := min(start+rows, len(findings))
+
+	var lines []string
+	for i := start; i < end; i++ {
+		f := findings[i]
+		cursor := "  "
+		if i == a.doctorCursor {
+			cursor = selectedStyle.Render("▸ ")
+		}
+		subject := a.styles.TagStyle(f.Title).Render("#" + f.Title)
+		if f.TaskID != "" {
+			subject = textStyle.Render(truncate(f.Title, 40))
+			if j := model.FindTask(a.tasks, f.TaskID); j >= 0 && a.tasks[j].ShortID() != "" {
+				subject = mutedStyle.Render(a.tasks[j].ShortID()+" ") + subject
+			}
+		}
+		line := cursor + subject + "  " + findingStyle.Render(f.String())
+		if i == a.doctorCursor {
+			line += mutedStyle.Render("  → " + findingFix(f))
+		}
+		lines = append(lines, line)
+	}
+	if start > 0 {
+		lines = append([]string{mutedStyle.Render("  ↑")}, lines...)
+	}
+	if end < len(findings) {
+		lines = append(lines, mutedStyle.Render("  ↓"))
+	}
+
+	help := mutedStyle.Render(i18n.T("j/k: naviguer │ Entrée: corriger │ Esc: fermer"))
+	content := title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
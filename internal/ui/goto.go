@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleGoToKeys handles the go to bar: enter selects the task of the
+// short ID (or ID) typed
+func (a *App) handleGoToKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.state = StateNormal
+		return a, nil
+	case "enter":
+		a.state = StateNormal
+		if ref := strings.TrimSpace(a.gotoInput.Value()); ref != "" {
+			a.goTo(ref)
+		}
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.gotoInput, cmd = a.gotoInput.Update(msg)
+	return a, cmd
+}
+
+// goTo selects the task of a reference in the current view. A task hidden
+// by the search clears it; one the view doesn't show, like a task without
+// due date in the calendar, is selected in the list.
+func (a *App) goTo(ref string) {
+	i := model.FindTask(a.tasks, ref)
+	if i < 0 {
+		a.setMessage(i18n.Tf("Aucune tâche %s", ref))
+		return
+	}
+	task := a.tasks[i]
+	if a.currentView().SelectTask(task.ID) {
+		return
+	}
+	if a.searchInput.Value() != "" {
+		a.searchInput.SetValue("")
+		a.setFilter("")
+		if a.currentView().SelectTask(task.ID) {
+			return
+		}
+	}
+	if a.viewMode != ViewList {
+		mode := a.viewMode
+		a.setViewMode(ViewList)
+		if a.listView.SelectTask(task.ID) {
+			return
+		}
+		a.setViewMode(mode)
+	}
+	a.setMessage(i18n.Tf("%s n'est pas affichée", ref))
+}
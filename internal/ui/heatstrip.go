@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// heatStripDays is the number of days shown in the header strip
+const heatStripDays = 30
+
+// renderHeatStrip renders one colored block per day for the coming days,
+// by number of open tasks due, preceded by the overdue count. It returns
+// an empty string when no open task has a due date.
+func renderHeatStrip(index *model.Index, now time.Time) string {
+	load, overdue := index.DueLoad(now, heatStripDays)
+
+	planned := 0
+	for _, n := range load {
+		planned += n
+	}
+	if planned == 0 && overdue == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	if overdue > 0 {
+		b.WriteString(lipgloss.NewStyle().
+			Foreground(colorRed).
+			Bold(true).
+			Render("⚠" + itoa(overdue) + " "))
+	}
+
+	for day, n := range load {
+		style := lipgloss.NewStyle().Foreground(heatColor(n))
+		if day == 0 {
+			style = style.Underline(true)
+		}
+		block := "▪"
+		if n > 0 {
+			block = "■"
+		}
+		b.WriteString(style.Render(block))
+	}
+
+	return b.String()
+}
+
+// heatColor returns the block color for a number of tasks due on a day
+func heatColor(n int) lipgloss.Color {
+	switch {
+	case n == 0:
+		return colorSurface1
+	case n == 1:
+		return colorGreen
+	case n == 2:
+		return colorYellow
+	case n == 3:
+		return colorPeach
+	default:
+		return colorRed
+	}
+}
@@ -3,6 +3,8 @@ package ui
 import (
 	"strings"
 
+	"lazy-todo/internal/i18n"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -28,7 +30,7 @@ func (h *HelpPanel) SetSize(width, height int) {
 
 // Render renders the help panel
 func (h *HelpPanel) Render() string {
-	title := h.styles.HelpPanelTitle.Render("Raccourcis Clavier")
+	title := h.styles.HelpPanelTitle.Render(i18n.T("Raccourcis Clavier"))
 
 	sections := []struct {
 		title string
@@ -38,78 +40,155 @@ func (h *HelpPanel) Render() string {
 		}
 	}{
 		{
-			title: "Navigation",
+			title: i18n.T("Navigation"),
 			items: []struct {
 				key  string
 				desc string
 			}{
-				{"j / ↓", "Descendre"},
-				{"k / ↑", "Monter"},
-				{"h / ←", "Gauche (kanban)"},
-				{"l / →", "Droite (kanban)"},
+				{"j / ↓", i18n.T("Descendre")},
+				{"k / ↑", i18n.T("Monter")},
+				{"h / ←", i18n.T("Gauche (kanban)")},
+				{"l / →", i18n.T("Droite (kanban)")},
 			},
 		},
 		{
-			title: "Actions",
+			title: i18n.T("Actions"),
 			items: []struct {
 				key  string
 				desc string
 			}{
-				{"a", "Ajouter une tâche"},
-				{"e", "Éditer la tâche"},
-				{"d", "Supprimer la tâche"},
-				{"p", "Changer la priorité"},
-				{"t", "Gérer les tags"},
-				{"Enter", "Voir/Éditer détails"},
+				{"a", i18n.T("Ajouter une tâche")},
+				{"A", i18n.T("Ajout rapide: #tag !priorité @date ~points (coller plusieurs lignes crée une tâche par ligne)")},
+				{"e", i18n.T("Éditer la tâche")},
+				{"d", i18n.T("Supprimer la tâche")},
+				{"p", i18n.T("Changer la priorité")},
+				{"S", i18n.T("Changer la sévérité")},
+				{"t", i18n.T("Gérer les tags")},
+				{"n", i18n.T("Ajouter une note au journal de la tâche")},
+				{"c", i18n.T("Checklist (sous-tâches)")},
+				{"i", i18n.T("Planifier dans le sprint en cours, le suivant ou aucun")},
+				{"E", i18n.T("Changer l'estimation (points: 1, 2, 3, 5, 8, 13)")},
+				{"b", i18n.T("Bloquer la tâche, puis changer la raison: revue, déploiement, externe, aucune")},
+				{"y / Y", i18n.T("Copier la tâche en YAML / en une ligne")},
+				{"P", i18n.T("Coller une tâche en YAML ou du texte comme nouvelle(s) tâche(s)")},
+				{"Enter", i18n.T("Voir/Éditer détails")},
 			},
 		},
 		{
-			title: "États rapides",
+			title: i18n.T("États rapides"),
 			items: []struct {
 				key  string
 				desc string
 			}{
-				{"1", "À faire"},
-				{"2", "En cours"},
-				{"3", "Bloqué"},
-				{"4", "Terminé"},
+				{"1", i18n.T("À faire")},
+				{"2", i18n.T("En cours")},
+				{"3", i18n.T("Bloqué")},
+				{"4", i18n.T("Terminé")},
 			},
 		},
 		{
-			title: "Kanban",
+			title: i18n.T("Kanban"),
 			items: []struct {
 				key  string
 				desc string
 			}{
-				{"H / Shift+←", "Déplacer tâche à gauche"},
-				{"L / Shift+→", "Déplacer tâche à droite"},
+				{"H / Shift+←", i18n.T("Déplacer tâche à gauche")},
+				{"L / Shift+→", i18n.T("Déplacer tâche à droite")},
+				{"> / <", i18n.T("Élargir / rétrécir la colonne")},
+				{"w", i18n.T("Groupes en couloirs à travers les colonnes (par priorité ou tag)")},
+				{"[ / ]", i18n.T("Couloir précédent / suivant")},
 			},
 		},
 		{
-			title: "Général",
+			title: i18n.T("Calendrier"),
 			items: []struct {
 				key  string
 				desc string
 			}{
-				{"Tab", "Changer de vue"},
-				{"g", "Changer le groupage"},
-				{"/", "Rechercher"},
-				{"o", "Ouvrir le fichier YAML"},
-				{"r", "Rafraîchir"},
-				{"?", "Afficher/Masquer l'aide"},
-				{"q / Ctrl+C", "Quitter"},
+				{"h / l", i18n.T("Jour précédent / suivant")},
+				{"k / j", i18n.T("Semaine précédente / suivante")},
+				{"H / L", i18n.T("Mois précédent / suivant")},
+				{"K / J", i18n.T("Tâche précédente / suivante de l'agenda")},
+				{"N", i18n.T("Journal du jour sélectionné (aujourd'hui dans les autres vues)")},
 			},
 		},
 		{
-			title: "Formulaire",
+			title: i18n.T("Tri"),
 			items: []struct {
 				key  string
 				desc string
 			}{
-				{"Tab", "Champ suivant"},
-				{"Shift+Tab", "Champ précédent"},
-				{"Enter", "Valider"},
-				{"Esc", "Annuler"},
+				{"s", i18n.T("Changer le tri")},
+				{"K / Shift+↑", i18n.T("Monter la tâche (tri manuel)")},
+				{"J / Shift+↓", i18n.T("Descendre la tâche (tri manuel)")},
+			},
+		},
+		{
+			title: i18n.T("Accords (espace puis touches)"),
+			items: []struct {
+				key  string
+				desc string
+			}{
+				{"s o/c/m/p/d/t/u", i18n.T("Trier: manuel, création, modification, priorité, échéance, titre, urgence")},
+				{"g n/s/p/t", i18n.T("Grouper: aucun, état, priorité, tag")},
+				{"v l/k/c/t", i18n.T("Vue: liste, kanban, calendrier, aujourd'hui")},
+			},
+		},
+		{
+			title: i18n.T("Général"),
+			items: []struct {
+				key  string
+				desc string
+			}{
+				{"Tab", i18n.T("Changer de vue")},
+				{"gt / gT", i18n.T("Tableau suivant / précédent (plusieurs fichiers)")},
+				{"g", i18n.T("Changer le groupage")},
+				{"/", i18n.T("Rechercher")},
+				{"/#tag", i18n.T("Filtrer par tag et ses sous-tags (#travail/)")},
+				{"F", i18n.T("Rechercher dans tous les tableaux ouverts")},
+				{"/status:", i18n.T("Filtres: tag:, status:, priority:, severity:, sprint:current, is:actionable (a,b = l'un ou l'autre, -x = exclure)")},
+				{"z", i18n.T("Masquer/afficher les tâches terminées et la colonne Terminé du kanban")},
+				{"o", i18n.T("Ouvrir le fichier YAML")},
+				{"v", i18n.T("Voir le YAML de la tâche")},
+				{"V", i18n.T("Voir le fichier YAML")},
+				{"x", i18n.T("Exporter (Markdown, JSON, CSV, iCalendar)")},
+				{"X", i18n.T("Partager par lien (service de partage)")},
+				{"r", i18n.T("Rafraîchir")},
+				{"Ctrl+S", i18n.T("Enregistrer maintenant")},
+				{"G", i18n.T("Synchroniser avec le dépôt git (pull puis push)")},
+				{"!", i18n.T("Rappels échus: acquitter ou reporter")},
+				{"I", i18n.T("Vélocité des sprints et engagement suggéré")},
+				{"f", i18n.T("Démarrer/arrêter le chrono de la tâche (un seul à la fois)")},
+				{"$", i18n.T("Temps suivi par jour et par tag, avec les pomodoros")},
+				{"M", i18n.T("Maintenance: tags inutilisés, tâches sans tag ou sans échéance...")},
+				{"T", i18n.T("Tags: renommer, fusionner, supprimer, couleur")},
+				{"?", i18n.T("Afficher/Masquer l'aide")},
+				{"q / Ctrl+C", i18n.T("Quitter")},
+			},
+		},
+		{
+			title: i18n.T("Visionneuse YAML"),
+			items: []struct {
+				key  string
+				desc string
+			}{
+				{"j / k", i18n.T("Défiler")},
+				{"y", i18n.T("Copier dans le presse-papiers")},
+				{"Esc / q", i18n.T("Fermer")},
+			},
+		},
+		{
+			title: i18n.T("Formulaire"),
+			items: []struct {
+				key  string
+				desc string
+			}{
+				{"Tab", i18n.T("Champ suivant")},
+				{"Shift+Tab", i18n.T("Champ précédent")},
+				{"Enter", i18n.T("Valider (nouvelle ligne dans la description)")},
+				{"Esc", i18n.T("Annuler")},
+				{"Ctrl+O", i18n.T("Éditer la description ($EDITOR)")},
+				{"Ctrl+F", i18n.T("Éditer la description en plein écran (zen)")},
 			},
 		},
 	}
@@ -149,23 +228,42 @@ func padRight(s string, length int) string {
 	return s + strings.Repeat(" ", length-len(s))
 }
 
-// RenderFooter renders the footer help bar
-func RenderFooter(styles Styles, isKanban bool) string {
+// RenderFooter renders the footer help bar; a file opened read-only is
+// flagged first, without the keys that change it
+func RenderFooter(styles Styles, mode ViewMode, readOnly bool) string {
 	var items []string
+	if readOnly {
+		items = append(items, lipgloss.NewStyle().Bold(true).Foreground(colorPeach).Render(i18n.T("LECTURE SEULE")))
+	}
 
 	addItem := func(key, desc string) {
-		items = append(items, styles.HelpKey.Render(key)+styles.HelpSep.Render(":")+styles.HelpValue.Render(desc))
+		items = append(items, styles.HelpKey.Render(key)+styles.HelpSep.Render(":")+styles.HelpValue.Render(i18n.T(desc)))
 	}
 
-	addItem("j/k", "nav")
-	if isKanban {
+	switch mode {
+	case ViewKanban:
+		addItem("j/k", "nav")
 		addItem("h/l", "colonnes")
-		addItem("H/L", "déplacer")
+		if !readOnly {
+			addItem("H/L", "déplacer")
+		}
+	case ViewCalendar:
+		addItem("h/j/k/l", "jour")
+		addItem("H/L", "mois")
+		addItem("J/K", "agenda")
+		if !readOnly {
+			addItem("N", "journal")
+		}
+	default:
+		addItem("j/k", "nav")
+	}
+	if !readOnly {
+		addItem("a", "ajouter")
+		addItem("d", "supprimer")
+		addItem("1-4", "état")
 	}
-	addItem("a", "ajouter")
-	addItem("d", "supprimer")
-	addItem("1-4", "état")
 	addItem("g", "grouper")
+	addItem("s", "trier")
 	addItem("Tab", "vue")
 	addItem("?", "aide")
 	addItem("q", "quitter")
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+)
+
+// SetHideDone leaves the done tasks out of the list, or shows them again.
+// They still count in the progress of the search.
+func (l *ListView) SetHideDone(hidden bool) {
+	if l.hideDone == hidden {
+		return
+	}
+	id := l.selectedID()
+	l.hideDone = hidden
+	l.applyFilter()
+	l.organizeItems()
+	if !l.SelectTask(id) {
+		l.cursor = 0
+		l.adjustCursor()
+	}
+}
+
+// HideDone returns true when the done tasks are left out of the list
+func (l *ListView) HideDone() bool {
+	return l.hideDone
+}
+
+// dropDone leaves the done tasks out of the filtered ones when they are
+// hidden, keeping their order
+func (l *ListView) dropDone() {
+	if !l.hideDone {
+		return
+	}
+	kept := l.filtered[:0]
+	for _, i := range l.filtered {
+		if !l.tasks[i].Status.IsDone() {
+			kept = append(kept, i)
+		}
+	}
+	l.filtered = kept
+}
+
+// doneHidden returns true when the current view leaves the done tasks
+// out: the list, or the Done column of the board
+func (a *App) doneHidden() bool {
+	switch a.viewMode {
+	case ViewList, ViewToday:
+		return a.listView.HideDone()
+	case ViewKanban:
+		for _, status := range model.AllStatuses() {
+			if status.IsDone() && a.kanbanView.ColumnHidden(status) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toggleHideDone hides the done tasks in the list and the Done column of
+// the board, or shows them again, as the current view had them
+func (a *App) toggleHideDone() {
+	hidden := !a.doneHidden()
+	a.listView.SetHideDone(hidden)
+	for _, status := range model.AllStatuses() {
+		if status.IsDone() {
+			a.kanbanView.SetColumnHidden(status, hidden)
+		}
+	}
+	if !hidden {
+		a.setMessage(i18n.T("Tâches terminées affichées"))
+		return
+	}
+	a.setMessage(i18n.N(a.hiddenCount(), "%d tâche terminée masquée", "%d tâches terminées masquées"))
+}
+
+// hiddenCount returns the number of done tasks the current view leaves
+// out, for the header
+func (a *App) hiddenCount() int {
+	if !a.doneHidden() {
+		return 0
+	}
+	n := 0
+	for _, t := range a.tasks {
+		if t.Status.IsDone() {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/keys"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// emptyHints holds the keys empty views suggest, as bound in the key map.
+// Views without hints (display.hide_hints) keep a bare message.
+type emptyHints struct {
+	add, quickAdd, search, enter, moveLeft, moveRight, pinToday, help string
+}
+
+// newEmptyHints returns the hints of a key map
+func newEmptyHints(k keys.KeyMap) *emptyHints {
+	return &emptyHints{
+		add:       k.Add.Help().Key,
+		quickAdd:  k.QuickAdd.Help().Key,
+		search:    k.Search.Help().Key,
+		enter:     k.Enter.Help().Key,
+		moveLeft:  k.MoveLeft.Help().Key,
+		moveRight: k.MoveRight.Help().Key,
+		pinToday:  k.PinToday.Help().Key,
+		help:      k.Help.Help().Key,
+	}
+}
+
+// refreshHints gives the empty views the hints of the current key map,
+// unless display.hide_hints is set
+func (a *App) refreshHints() {
+	var hints *emptyHints
+	if !a.config.Display.HideHints {
+		hints = newEmptyHints(a.keys)
+	}
+	a.listView.SetHints(hints)
+	a.kanbanView.SetHints(hints)
+}
+
+// forTasks returns the hints of a view without any task: how to add one,
+// with a sample of the quick-add syntax
+func (h *emptyHints) forTasks() []string {
+	if h == nil {
+		return nil
+	}
+	return []string{
+		i18n.Tf("%s: ajouter une tâche", h.add),
+		i18n.Tf("%s: ajout rapide, par exemple « Appeler le dentiste #perso !haute @vendredi »", h.quickAdd),
+		i18n.Tf("%s: toutes les touches", h.help),
+	}
+}
+
+// forSearch returns the hints of a search without results: how to change
+// or clear it, how to create the task looked for when the search has text
+// to fill it, with a sample of the filters
+func (h *emptyHints) forSearch(create bool) []string {
+	if h == nil {
+		return nil
+	}
+	hints := []string{i18n.Tf("%s: modifier la recherche, Esc pour l'effacer", h.search)}
+	if create {
+		hints = append(hints, i18n.Tf("%s: créer la tâche cherchée", h.enter))
+	}
+	return append(hints, i18n.T("Filtres: tag:travail status:todo,blocked -priority:low is:actionable"))
+}
+
+// forColumn returns the hint of an empty kanban column: adding a task in
+// the first one, moving one into the others
+func (h *emptyHints) forColumn(first bool) []string {
+	if h == nil {
+		return nil
+	}
+	if first {
+		return []string{i18n.Tf("%s: ajouter une tâche", h.add)}
+	}
+	return []string{i18n.Tf("%s/%s: déplacer une tâche ici", h.moveLeft, h.moveRight)}
+}
+
+// renderEmpty renders the message of an empty view with its hints under
+// it, wrapped to width
+func renderEmpty(message string, hints []string, width int) string {
+	messageStyle := lipgloss.NewStyle().Foreground(colorOverlay0).Italic(true)
+	hintStyle := lipgloss.NewStyle().Foreground(colorOverlay1)
+	if width > 0 {
+		messageStyle = messageStyle.Width(width)
+		hintStyle = hintStyle.Width(width)
+	}
+
+	lines := []string{messageStyle.Render(message)}
+	if len(hints) > 0 {
+		lines = append(lines, "")
+	}
+	for _, hint := range hints {
+		lines = append(lines, hintStyle.Render(hint))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderHistory renders the last status and priority changes of a task,
+// one per line with how long ago they were made, truncated to width
+func renderHistory(history []model.Change, width int, now time.Time) []string {
+	dateStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+	textStyle := lipgloss.NewStyle().Foreground(colorSubtext1)
+
+	var lines []string
+	if hidden := len(history) - maxNoteLines; hidden > 0 {
+		lines = append(lines, dateStyle.Render(i18n.N(hidden, "… %d changement plus ancien", "… %d changements plus anciens")))
+		history = history[hidden:]
+	}
+	for _, c := range history {
+		when := ago(c.At, now)
+		text := truncate(c.Describe(), width-lipgloss.Width(when)-2)
+		lines = append(lines, dateStyle.Render(when)+"  "+textStyle.Render(text))
+	}
+	return lines
+}
+
+// ago returns how long before now a time was, like "il y a 2 j"
+func ago(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return i18n.T("à l'instant")
+	case d < time.Hour:
+		return i18n.Tf("il y a %d min", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return i18n.Tf("il y a %d h", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return i18n.Tf("il y a %d j", int(d.Hours()/24))
+	}
+	return t.Local().Format("2006-01-02")
+}
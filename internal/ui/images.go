@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"lazy-todo/internal/i18n"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// graphics is the protocol the terminal draws images with
+type graphics int
+
+const (
+	graphicsNone  graphics = iota
+	graphicsKitty          // kitty, WezTerm, Ghostty
+	graphicsITerm          // iTerm2 inline images
+)
+
+// Images of the description are previewed in a box of this many cells, at
+// most maxImages of them
+const (
+	imageCols = 24
+	imageRows = 6
+	maxImages = 3
+)
+
+// maxImageSize is the size past which an image isn't sent inline to iTerm2,
+// which gets the whole file in the escape sequence
+const maxImageSize = 1 << 20
+
+// kittyClear deletes the images placed with the kitty protocol, left on
+// screen until then
+const kittyClear = "\x1b_Ga=d,q=2\x1b\\"
+
+// imageLink matches a Markdown image, ![alt](path "title")
+var imageLink = regexp.MustCompile(`!\[[^\]]*\]\(<?([^)\s>]+)>?(?:\s+"[^"]*")?\)`)
+
+// detectGraphics guesses the image protocol of the terminal from its
+// environment. tmux and screen only pass the escape sequences through when
+// configured to, so no images are drawn in them.
+func detectGraphics(getenv func(string) string) graphics {
+	if getenv("TMUX") != "" || strings.HasPrefix(getenv("TERM"), "screen") {
+		return graphicsNone
+	}
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "", getenv("TERM") == "xterm-kitty",
+		getenv("TERM") == "xterm-ghostty", getenv("TERM_PROGRAM") == "WezTerm":
+		return graphicsKitty
+	case getenv("TERM_PROGRAM") == "iTerm.app", getenv("LC_TERMINAL") == "iTerm2":
+		return graphicsITerm
+	}
+	return graphicsNone
+}
+
+// imageLinks returns the local images linked in a Markdown text, relative
+// paths resolved from dir, without URLs and duplicates
+func imageLinks(text, dir string) []string {
+	var paths []string
+	for _, m := range imageLink.FindAllStringSubmatch(text, -1) {
+		path := m[1]
+		if strings.Contains(path, "://") || !isImage(path) {
+			continue
+		}
+		if strings.HasPrefix(path, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, path[2:])
+			}
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// isImage reports whether a path has the extension of an image
+func isImage(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg", ".gif", ".webp":
+		return true
+	}
+	return false
+}
+
+// SetImages sets how the images of the description are previewed, and the
+// directory their relative paths are resolved from
+func (f *TaskForm) SetImages(g graphics, dir string) {
+	f.graphics, f.imageDir = g, dir
+}
+
+// renderImages renders the images linked in the description, drawn with
+// the protocol of the terminal when it has one, by name otherwise
+func (f *TaskForm) renderImages() []string {
+	paths := imageLinks(f.Description(), f.imageDir)
+	if len(paths) == 0 {
+		return nil
+	}
+	hint := lipgloss.NewStyle().Foreground(colorOverlay0)
+
+	var lines []string
+	hidden := len(paths) - maxImages
+	if hidden > 0 {
+		paths = paths[:maxImages]
+	}
+	for i, path := range paths {
+		name := filepath.Base(path)
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			lines = append(lines, hint.Render(i18n.Tf("%s (introuvable)", name)))
+			continue
+		}
+		if image := f.imageSequence(i+1, path, info.Size()); image != "" {
+			// The image is drawn from its first line over the cells left blank
+			lines = append(lines, image)
+			lines = append(lines, make([]string, imageRows-1)...)
+		}
+		lines = append(lines, hint.Render(name))
+	}
+	if hidden > 0 {
+		lines = append(lines, hint.Render(i18n.N(hidden, "… %d autre image", "… %d autres images")))
+	}
+	return lines
+}
+
+// imageSequence returns the escape sequence drawing an image in a box of
+// imageCols by imageRows without moving the cursor, empty when the
+// terminal can't draw it. Kitty images are numbered so that drawing one
+// again replaces it.
+func (f *TaskForm) imageSequence(id int, path string, size int64) string {
+	switch f.graphics {
+	case graphicsKitty:
+		// The terminal reads the file itself, PNG only
+		if strings.ToLower(filepath.Ext(path)) != ".png" {
+			return ""
+		}
+		return fmt.Sprintf("\x1b_Ga=T,f=100,t=f,i=%d,p=1,c=%d,r=%d,C=1,q=2;%s\x1b\\",
+			id, imageCols, imageRows, base64.StdEncoding.EncodeToString([]byte(path)))
+	case graphicsITerm:
+		if size > maxImageSize {
+			return ""
+		}
+		// Read once: the form is rendered on every frame
+		key := fmt.Sprintf("%s:%d", path, size)
+		if seq, ok := f.imageCache[key]; ok {
+			return seq
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return ""
+		}
+		seq := fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;doNotMoveCursor=1:%s\a",
+			len(data), imageCols, imageRows, base64.StdEncoding.EncodeToString(data))
+		if f.imageCache == nil {
+			f.imageCache = make(map[string]string)
+		}
+		f.imageCache[key] = seq
+		return seq
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDetectGraphics(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want graphics
+	}{
+		{"none", nil, graphicsNone},
+		{"kitty", map[string]string{"TERM": "xterm-kitty"}, graphicsKitty},
+		{"kitty window", map[string]string{"KITTY_WINDOW_ID": "1"}, graphicsKitty},
+		{"wezterm", map[string]string{"TERM_PROGRAM": "WezTerm"}, graphicsKitty},
+		{"iterm", map[string]string{"TERM_PROGRAM": "iTerm.app"}, graphicsITerm},
+		{"iterm over ssh", map[string]string{"LC_TERMINAL": "iTerm2"}, graphicsITerm},
+		{"kitty in tmux", map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux"}, graphicsNone},
+		{"screen", map[string]string{"TERM": "screen-256color", "TERM_PROGRAM": "WezTerm"}, graphicsNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := detectGraphics(getenv); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageLinks(t *testing.T) {
+	text := "Avant ![schéma](img/a.png) et ![](/abs/b.JPG \"titre\")\n" +
+		"![distante](https://example.com/c.png) ![doc](notes.pdf) [lien](d.png) ![encore](img/a.png)"
+	want := []string{filepath.Join("/base", "img/a.png"), "/abs/b.JPG"}
+	if got := imageLinks(text, "/base"); !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderImages(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.png"), []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewTaskForm(DefaultStyles())
+	f.descInput.SetValue("![](a.png) ![](absente.png)")
+
+	f.SetImages(graphicsNone, dir)
+	got := strings.Join(f.renderImages(), "\n")
+	if strings.Contains(got, "\x1b_G") || !strings.Contains(got, "a.png") || !strings.Contains(got, "absente.png") {
+		t.Errorf("without graphics: %q", got)
+	}
+
+	f.SetImages(graphicsKitty, dir)
+	lines := f.renderImages()
+	if !strings.HasPrefix(lines[0], "\x1b_G") {
+		t.Fatalf("no kitty image drawn: %q", lines[0])
+	}
+	if len(lines) != imageRows+2 {
+		t.Errorf("%d lines, want the image rows, its name and the missing one", len(lines))
+	}
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openJournal edits the journal of the day selected in the calendar, or
+// of today in the other views
+func (a *App) openJournal() tea.Cmd {
+	day := dayOf(time.Now())
+	if a.viewMode == ViewCalendar {
+		day = a.calendar.Day()
+	}
+	a.journalDay = day
+	a.journalEditor.SetTitle(i18n.Tf("Journal du %s", journalDayLabel(day)),
+		i18n.T("Ce qui s'est passé ce jour-là..."))
+	a.state = StateJournal
+	return a.journalEditor.SetValue(model.DayJournalText(a.storage.Journal(), model.JournalDay(day)))
+}
+
+// handleJournalKeys saves the journal of the day on ctrl+s and drops the
+// changes on Esc
+func (a *App) handleJournalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.state = StateNormal
+		return a, nil
+	case "ctrl+s":
+		a.state = StateNormal
+		return a, a.saveJournal(model.JournalDay(a.journalDay), a.journalEditor.Value())
+	}
+
+	var cmd tea.Cmd
+	a.journalEditor, cmd = a.journalEditor.Update(msg)
+	return a, cmd
+}
+
+// saveJournal replaces the journal of a day in the tasks file
+func (a *App) saveJournal(day, text string) tea.Cmd {
+	if strings.TrimSpace(text) == strings.TrimSpace(model.DayJournalText(a.storage.Journal(), day)) {
+		return nil
+	}
+	return func() tea.Msg {
+		tasks, err := a.storage.SetJournalDay(day, text, time.Now())
+		if err != nil {
+			return errMsg{err}
+		}
+		return tasksLoadedMsg{tasks}
+	}
+}
+
+// renderJournalEditor renders the journal editor overlay
+func (a *App) renderJournalEditor() string {
+	vertical := lipgloss.Center
+	if a.journalEditor.IsZen() {
+		vertical = lipgloss.Top
+	}
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, vertical, a.journalEditor.Render())
+}
+
+// journalDayLabel names a day of the journal, like "vendredi 16 octobre"
+func journalDayLabel(day time.Time) string {
+	return i18n.Tf("%s %d %s", weekdayLabel(day.Weekday()), day.Day(), monthLabel(day.Month()))
+}
+
+// renderJournal renders the journal of a day under the agenda, empty when
+// there is none
+func renderJournal(journal []model.JournalEntry, day time.Time, width int) []string {
+	entries := model.DayJournal(journal, model.JournalDay(day))
+	if len(entries) == 0 {
+		return nil
+	}
+	titleStyle := lipgloss.NewStyle().Foreground(colorSubtext0).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(colorSubtext1).Width(width)
+	lines := []string{"", titleStyle.Render(i18n.T("Journal"))}
+	for _, e := range entries {
+		lines = append(lines, textStyle.Render(e.Text))
+	}
+	return lines
+}
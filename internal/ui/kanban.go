@@ -1,13 +1,25 @@
 package ui
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
+	"lazy-todo/internal/i18n"
 	"lazy-todo/internal/model"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Column widths are shares of the board width: each column starts with
+// defaultColumnWeight shares, and < / > move one share from or to the others
+const (
+	defaultColumnWeight = 4
+	minColumnWeight     = 1
+	maxColumnWeight     = 16
+	minColumnWidth      = 20
+)
+
 // KanbanItem represents an item in a column (task or group header)
 type KanbanItem struct {
 	isHeader   bool
@@ -21,41 +33,77 @@ type KanbanColumn struct {
 	tasks  []int        // indices in the main tasks slice
 	items  []KanbanItem // items to display (headers + tasks)
 	cursor int
+	offset int // first visible line, kept while the cursor stays in view
+
+	// Layout of the items, known without rendering them so that only the
+	// visible ones are: the first line and end of each item, margins
+	// excluded, and the lines of all of them
+	spans [][2]int
+	lines int
+	// before counts the tasks before each item, and after the last one
+	before []int
 }
 
 // KanbanView represents the kanban board view
 type KanbanView struct {
-	tasks       []model.Task
-	columns     [4]KanbanColumn
-	activeCol   int
-	styles      Styles
-	width       int
-	height      int
-	columnWidth int
-	groupBy     model.GroupBy
+	tasks     []model.Task
+	columns   []KanbanColumn // one per workflow status
+	activeCol int
+	styles    Styles
+	width     int
+	height    int
+	weights   []int            // share of the board width of each column
+	widths    []int            // resulting content width of each column
+	hidden    []bool           // columns left out of the board
+	wip       []int            // work in progress limit of each column, none when zero
+	colors    []lipgloss.Color // color of each column's title and border, none when empty
+	shaded    bool             // columns are filled with a shade of their color
+	groupBy   model.GroupBy
+	query     model.Query     // search filter
+	open      map[string]bool // IDs of the open tasks, for dependencies
+	sortBy    model.SortBy
+	loads     []int // tasks in each column, whatever the search
+	staleDays int
+	escalate  bool        // overdue tasks at a high priority stand out
+	hints     *emptyHints // keys suggested in empty columns, none when nil
+
+	// Groups shown as swimlanes across the columns rather than as headers
+	// within each, with the lanes in order and their number of tasks
+	lanes      bool
+	laneOrder  []string
+	laneCounts map[string]int
 }
 
-// NewKanbanView creates a new kanban view
+// NewKanbanView creates a new kanban view, with a column for each status
+// of the workflow
 func NewKanbanView(styles Styles) *KanbanView {
-	return &KanbanView{
+	statuses := model.AllStatuses()
+	k := &KanbanView{
 		tasks:     []model.Task{},
 		activeCol: 0,
 		styles:    styles,
 		groupBy:   model.GroupByNone,
-		columns: [4]KanbanColumn{
-			{status: model.StatusTodo, tasks: []int{}, items: []KanbanItem{}, cursor: 0},
-			{status: model.StatusInProgress, tasks: []int{}, items: []KanbanItem{}, cursor: 0},
-			{status: model.StatusBlocked, tasks: []int{}, items: []KanbanItem{}, cursor: 0},
-			{status: model.StatusDone, tasks: []int{}, items: []KanbanItem{}, cursor: 0},
-		},
-	}
+		columns:   make([]KanbanColumn, len(statuses)),
+		weights:   make([]int, len(statuses)),
+		widths:    make([]int, len(statuses)),
+		hidden:    make([]bool, len(statuses)),
+		wip:       make([]int, len(statuses)),
+		colors:    make([]lipgloss.Color, len(statuses)),
+		loads:     make([]int, len(statuses)),
+	}
+	for i, status := range statuses {
+		k.columns[i] = KanbanColumn{status: status, tasks: []int{}, items: []KanbanItem{}}
+		k.weights[i] = defaultColumnWeight
+	}
+	return k
 }
 
 // SetGroupBy sets the grouping mode
 func (k *KanbanView) SetGroupBy(groupBy model.GroupBy) {
+	ids := k.selectedIDs()
 	k.groupBy = groupBy
 	k.organizeItems()
-	k.adjustCursors()
+	k.reselect(ids)
 }
 
 // GetGroupBy returns the current grouping mode
@@ -65,8 +113,94 @@ func (k *KanbanView) GetGroupBy() model.GroupBy {
 
 // CycleGroupBy cycles to the next grouping mode
 func (k *KanbanView) CycleGroupBy() {
+	ids := k.selectedIDs()
 	k.groupBy = k.groupBy.Next()
 	k.organizeItems()
+	k.reselect(ids)
+}
+
+// SetSortBy sets the sorting mode used within columns
+func (k *KanbanView) SetSortBy(sortBy model.SortBy) {
+	ids := k.selectedIDs()
+	k.sortBy = sortBy
+	k.organizeTasks()
+	k.organizeItems()
+	k.reselect(ids)
+}
+
+// SetFilter sets the search filter
+func (k *KanbanView) SetFilter(filter string) {
+	ids := k.selectedIDs()
+	query := model.ParseQuery(filter)
+	narrows := query.Narrows(k.query)
+	k.query = query
+	if narrows {
+		k.refineColumns()
+	} else {
+		k.organizeTasks()
+	}
+	k.organizeItems()
+	k.reselect(ids)
+}
+
+// SetStaleDays sets the idle threshold for flagging in progress tasks
+func (k *KanbanView) SetStaleDays(days int) {
+	k.staleDays = days
+}
+
+// SetEscalate makes overdue tasks at a high priority stand out
+func (k *KanbanView) SetEscalate(enabled bool) {
+	k.escalate = enabled
+}
+
+// selectedIDs returns the ID of the task under each column's cursor
+func (k *KanbanView) selectedIDs() []string {
+	ids := make([]string, len(k.columns))
+	for i, col := range k.columns {
+		if col.cursor >= 0 && col.cursor < len(col.items) && !col.items[col.cursor].isHeader {
+			ids[i] = k.tasks[col.items[col.cursor].taskIndex].ID
+		}
+	}
+	return ids
+}
+
+// SelectTask activates the column of the task with the given ID and moves
+// its cursor to it. It returns false, leaving the selection unchanged, if
+// the task isn't on the board.
+func (k *KanbanView) SelectTask(id string) bool {
+	if id == "" {
+		return false
+	}
+	for c := range k.columns {
+		if !k.hidden[c] && k.selectInColumn(c, id) {
+			k.activeCol = c
+			return true
+		}
+	}
+	return false
+}
+
+// selectInColumn moves a column's cursor to the task with the given ID, if
+// it's in that column
+func (k *KanbanView) selectInColumn(colIdx int, id string) bool {
+	col := &k.columns[colIdx]
+	for i, item := range col.items {
+		if !item.isHeader && k.tasks[item.taskIndex].ID == id {
+			col.cursor = i
+			return true
+		}
+	}
+	return false
+}
+
+// reselect keeps each column's cursor on the task it was on after the items
+// were rebuilt, or on a valid item near its old position if it left
+func (k *KanbanView) reselect(ids []string) {
+	for i, id := range ids {
+		if id != "" {
+			k.selectInColumn(i, id)
+		}
+	}
 	k.adjustCursors()
 }
 
@@ -84,9 +218,7 @@ func (k *KanbanView) adjustColumnCursor(colIdx int) {
 		col.cursor = 0
 		return
 	}
-	if col.cursor >= len(col.items) {
-		col.cursor = len(col.items) - 1
-	}
+	col.cursor = max(min(col.cursor, len(col.items)-1), 0)
 	// Move cursor to next task if on header
 	for col.cursor < len(col.items) && col.items[col.cursor].isHeader {
 		col.cursor++
@@ -106,12 +238,54 @@ func (k *KanbanView) adjustColumnCursor(colIdx int) {
 
 // organizeItems organizes items within each column based on groupBy
 func (k *KanbanView) organizeItems() {
+	k.organizeLanes()
 	for i := range k.columns {
 		k.organizeColumnItems(i)
+		k.layoutColumn(i)
+	}
+	k.alignLanes()
+}
+
+// layoutColumn sets the lines each item of a column takes. Cards have one
+// line, two with a tag line, in their frame; group headers and the lines
+// of cards are cut to the width of the column, so they don't wrap.
+func (k *KanbanView) layoutColumn(colIdx int) {
+	col := &k.columns[colIdx]
+	card := k.styles.KanbanCard
+	frame, margin := card.GetVerticalBorderSize()+card.GetVerticalPadding(), card.GetMarginBottom()
+
+	col.spans = make([][2]int, len(col.items))
+	col.before = make([]int, len(col.items)+1)
+	line, tasks := 0, 0
+	for i, item := range col.items {
+		col.before[i] = tasks
+		if item.isHeader {
+			col.spans[i] = [2]int{line, line + 1}
+			line++
+			continue
+		}
+		height := frame + 1
+		if k.hasTagLine(k.tasks[item.taskIndex]) {
+			height++
+		}
+		col.spans[i] = [2]int{line, line + height}
+		line += height + margin
+		tasks++
 	}
+	col.before[len(col.items)] = tasks
+	col.lines = line
 }
 
-// organizeColumnItems organizes items in a single column
+// hasTagLine returns true if the card of a task has a line under its
+// title, for its tags and badges
+func (k *KanbanView) hasTagLine(task model.Task) bool {
+	_, subtasks := task.SubtaskProgress()
+	return len(task.Tags) > 0 || subtasks > 0 || task.ExternalKey() != "" || task.Sprint != "" ||
+		task.Estimate > 0 || task.Private || dependencyBadge(task, k.open) != ""
+}
+
+// organizeColumnItems organizes items in a single column. As swimlanes,
+// every column has the header of every lane, so that they line up.
 func (k *KanbanView) organizeColumnItems(colIdx int) {
 	col := &k.columns[colIdx]
 	col.items = []KanbanItem{}
@@ -124,25 +298,48 @@ func (k *KanbanView) organizeColumnItems(colIdx int) {
 		return
 	}
 
-	// Group tasks within the column
-	groups := make(map[string][]int)
-	groupOrder := []string{}
+	groupOrder, groups := k.groupTasks(col.tasks)
+	if k.inLanes() {
+		groupOrder = k.laneOrder
+	}
 
-	for _, idx := range col.tasks {
-		task := k.tasks[idx]
-		var key string
+	// Build items with headers
+	for _, groupKey := range groupOrder {
+		taskIndices := groups[groupKey]
+		// Add header
+		col.items = append(col.items, KanbanItem{
+			isHeader:   true,
+			headerText: groupKey,
+		})
+		// Add tasks
+		for _, idx := range taskIndices {
+			col.items = append(col.items, KanbanItem{taskIndex: idx})
+		}
+	}
+}
 
-		switch k.groupBy {
-		case model.GroupByPriority:
-			key = task.Priority.Label()
-		case model.GroupByTag:
-			if len(task.Tags) > 0 {
-				key = task.Tags[0]
-			} else {
-				key = "Sans tag"
-			}
+// groupOf returns the group of a task for the grouping mode
+func (k *KanbanView) groupOf(task model.Task) string {
+	switch k.groupBy {
+	case model.GroupByPriority:
+		return task.Priority.Label()
+	case model.GroupByTag:
+		if len(task.Tags) > 0 {
+			return task.Tags[0]
 		}
+		return i18n.T("Sans tag")
+	}
+	return ""
+}
+
+// groupTasks splits tasks by group, the groups in the order of the
+// priorities or else in the order the tasks come in
+func (k *KanbanView) groupTasks(indices []int) ([]string, map[string][]int) {
+	groups := make(map[string][]int)
+	groupOrder := []string{}
 
+	for _, idx := range indices {
+		key := k.groupOf(k.tasks[idx])
 		if _, exists := groups[key]; !exists {
 			groupOrder = append(groupOrder, key)
 		}
@@ -152,35 +349,31 @@ func (k *KanbanView) organizeColumnItems(colIdx int) {
 	// Sort groups by their natural order for priority
 	if k.groupBy == model.GroupByPriority {
 		orderedKeys := []string{}
+		known := map[string]bool{}
 		for _, p := range model.AllPriorities() {
+			known[p.Label()] = true
 			if _, exists := groups[p.Label()]; exists {
 				orderedKeys = append(orderedKeys, p.Label())
 			}
 		}
-		groupOrder = orderedKeys
-	}
-
-	// Build items with headers
-	for _, groupKey := range groupOrder {
-		taskIndices := groups[groupKey]
-		// Add header
-		col.items = append(col.items, KanbanItem{
-			isHeader:   true,
-			headerText: groupKey,
-		})
-		// Add tasks
-		for _, idx := range taskIndices {
-			col.items = append(col.items, KanbanItem{taskIndex: idx})
+		// Values that are not configured levels go last
+		for _, key := range groupOrder {
+			if !known[key] {
+				orderedKeys = append(orderedKeys, key)
+			}
 		}
+		groupOrder = orderedKeys
 	}
+	return groupOrder, groups
 }
 
 // SetTasks sets the tasks to display
 func (k *KanbanView) SetTasks(tasks []model.Task) {
+	ids := k.selectedIDs()
 	k.tasks = tasks
 	k.organizeTasks()
 	k.organizeItems()
-	k.adjustCursors()
+	k.reselect(ids)
 }
 
 // organizeTasks organizes tasks into columns
@@ -188,14 +381,39 @@ func (k *KanbanView) organizeTasks() {
 	// Reset columns
 	for i := range k.columns {
 		k.columns[i].tasks = []int{}
+		k.loads[i] = 0
 	}
 
 	// Distribute tasks to columns
+	k.open = model.OpenIDs(k.tasks)
+	query := k.query.WithTasks(k.tasks)
 	for i, task := range k.tasks {
-		colIdx := task.Status.Index()
-		if colIdx >= 0 && colIdx < 4 {
-			k.columns[colIdx].tasks = append(k.columns[colIdx].tasks, i)
+		colIdx := task.Status.Column()
+		k.loads[colIdx]++
+		if !query.Matches(task) {
+			continue
 		}
+		k.columns[colIdx].tasks = append(k.columns[colIdx].tasks, i)
+	}
+
+	for i := range k.columns {
+		model.SortIndices(k.tasks, k.columns[i].tasks, k.sortBy)
+	}
+}
+
+// refineColumns filters the tasks of the columns, for a search narrowing
+// the one they were organized for
+func (k *KanbanView) refineColumns() {
+	query := k.query.WithTasks(k.tasks)
+	for c := range k.columns {
+		col := &k.columns[c]
+		kept := col.tasks[:0]
+		for _, i := range col.tasks {
+			if query.Matches(k.tasks[i]) {
+				kept = append(kept, i)
+			}
+		}
+		col.tasks = kept
 	}
 }
 
@@ -203,11 +421,137 @@ func (k *KanbanView) organizeTasks() {
 func (k *KanbanView) SetSize(width, height int) {
 	k.width = width
 	k.height = height
-	// Calculate column width (4 columns with gaps)
-	k.columnWidth = (width - 12) / 4
-	if k.columnWidth < 20 {
-		k.columnWidth = 20
+	k.layout()
+}
+
+// layout splits the board width between the visible columns by weight
+func (k *KanbanView) layout() {
+	visible, total := 0, 0
+	for i, w := range k.weights {
+		if !k.hidden[i] {
+			visible++
+			total += w
+		}
+	}
+	if visible == 0 {
+		return
+	}
+
+	// Each column has a border on both sides
+	available := k.width - 4 - 2*visible
+	for i, w := range k.weights {
+		k.widths[i] = max(available*w/total, minColumnWidth)
+	}
+}
+
+// ResizeColumn gives the active column delta more shares of the board
+// width, taken from or given back to the other columns. It returns false
+// when the column is already at its narrowest or widest.
+func (k *KanbanView) ResizeColumn(delta int) bool {
+	w := k.weights[k.activeCol] + delta
+	if w < minColumnWeight || w > maxColumnWeight {
+		return false
+	}
+	k.weights[k.activeCol] = w
+	k.layout()
+	return true
+}
+
+// SetColumnHidden shows or hides the column of a status. When the active
+// column gets hidden, the nearest visible column becomes active.
+func (k *KanbanView) SetColumnHidden(status model.Status, hidden bool) {
+	col := status.Index()
+	if col < 0 {
+		return
+	}
+	k.hidden[col] = hidden
+	// Keep at least one column on the board
+	if k.visibleColumns() == 0 {
+		k.hidden[col] = false
+	}
+	k.layout()
+	if k.inLanes() {
+		// The lanes are those of the visible columns
+		ids := k.selectedIDs()
+		k.organizeItems()
+		k.reselect(ids)
+	}
+
+	if k.hidden[k.activeCol] {
+		if !k.step(-1) {
+			k.step(1)
+		}
+	}
+}
+
+// ColumnHidden returns true when the column of a status is hidden
+func (k *KanbanView) ColumnHidden(status model.Status) bool {
+	col := status.Index()
+	return col >= 0 && k.hidden[col]
+}
+
+// SetWIPLimit sets the maximum number of tasks in the column of a status,
+// none when zero
+func (k *KanbanView) SetWIPLimit(status model.Status, limit int) {
+	if col := status.Index(); col >= 0 {
+		k.wip[col] = max(limit, 0)
+	}
+}
+
+// WIPLimit returns the maximum number of tasks in the column of a status,
+// zero when there is none
+func (k *KanbanView) WIPLimit(status model.Status) int {
+	return k.wip[status.Column()]
+}
+
+// SetColumnColor sets the color of the title and border of the column of
+// a status, the default when empty
+func (k *KanbanView) SetColumnColor(status model.Status, color lipgloss.Color) {
+	if col := status.Index(); col >= 0 {
+		k.colors[col] = color
+	}
+}
+
+// SetColumnBackground fills the columns with a shade of their color, or of
+// the surface color for the columns without one
+func (k *KanbanView) SetColumnBackground(enabled bool) {
+	k.shaded = enabled
+}
+
+// IsFull returns true if the column of a status is at its WIP limit, so
+// that moving another task into it would exceed it
+func (k *KanbanView) IsFull(status model.Status) bool {
+	col := status.Column()
+	return k.wip[col] > 0 && k.loads[col] >= k.wip[col]
+}
+
+// IsColumnHidden returns true if the column of a status is hidden
+func (k *KanbanView) IsColumnHidden(status model.Status) bool {
+	col := status.Index()
+	return col >= 0 && k.hidden[col]
+}
+
+// visibleColumns returns the number of columns on the board
+func (k *KanbanView) visibleColumns() int {
+	n := 0
+	for _, h := range k.hidden {
+		if !h {
+			n++
+		}
+	}
+	return n
+}
+
+// step activates the nearest visible column in the direction of dir. It
+// returns false if there is none.
+func (k *KanbanView) step(dir int) bool {
+	for i := k.activeCol + dir; i >= 0 && i < len(k.columns); i += dir {
+		if !k.hidden[i] {
+			k.activeCol = i
+			return true
+		}
 	}
+	return false
 }
 
 // MoveUp moves the cursor up in the current column
@@ -226,6 +570,8 @@ func (k *KanbanView) MoveUp() {
 			}
 		}
 	}
+	// A column of swimlane headers only has no task to land on
+	k.adjustColumnCursor(k.activeCol)
 }
 
 // MoveDown moves the cursor down in the current column
@@ -246,23 +592,28 @@ func (k *KanbanView) MoveDown() {
 			}
 		}
 	}
+	// A column of swimlane headers only has no task to land on
+	k.adjustColumnCursor(k.activeCol)
 }
 
-// MoveLeft moves to the previous column
+// MoveLeft moves to the previous column, in the same swimlane if it has
+// a task there
 func (k *KanbanView) MoveLeft() {
-	if k.activeCol > 0 {
-		k.activeCol--
+	if !k.stepInLane(-1) {
+		k.step(-1)
 	}
 }
 
-// MoveRight moves to the next column
+// MoveRight moves to the next column, in the same swimlane if it has a
+// task there
 func (k *KanbanView) MoveRight() {
-	if k.activeCol < 3 {
-		k.activeCol++
+	if !k.stepInLane(1) {
+		k.step(1)
 	}
 }
 
-// MoveTaskLeft moves the selected task to the previous column
+// MoveTaskLeft returns the selected task moved to the previous column, to
+// be saved
 func (k *KanbanView) MoveTaskLeft() *model.Task {
 	if k.activeCol == 0 {
 		return nil
@@ -273,14 +624,15 @@ func (k *KanbanView) MoveTaskLeft() *model.Task {
 		return nil
 	}
 
-	newStatus := model.StatusFromIndex(k.activeCol - 1)
-	task.Status = newStatus
-	return task
+	moved := *task
+	moved.Status = k.columns[k.activeCol-1].status
+	return &moved
 }
 
-// MoveTaskRight moves the selected task to the next column
+// MoveTaskRight returns the selected task moved to the next column, to be
+// saved
 func (k *KanbanView) MoveTaskRight() *model.Task {
-	if k.activeCol >= 3 {
+	if k.activeCol >= len(k.columns)-1 {
 		return nil
 	}
 
@@ -289,9 +641,9 @@ func (k *KanbanView) MoveTaskRight() *model.Task {
 		return nil
 	}
 
-	newStatus := model.StatusFromIndex(k.activeCol + 1)
-	task.Status = newStatus
-	return task
+	moved := *task
+	moved.Status = k.columns[k.activeCol+1].status
+	return &moved
 }
 
 // SelectedTask returns the currently selected task
@@ -310,6 +662,21 @@ func (k *KanbanView) SelectedTask() *model.Task {
 	return nil
 }
 
+// AdjacentTask returns the task right above (delta < 0) or below (delta > 0)
+// the selection in the active column, or nil if there is none within the
+// same group
+func (k *KanbanView) AdjacentTask(delta int) *model.Task {
+	if k.SelectedTask() == nil {
+		return nil
+	}
+	col := k.columns[k.activeCol]
+	i := col.cursor + delta
+	if i < 0 || i >= len(col.items) || col.items[i].isHeader {
+		return nil
+	}
+	return &k.tasks[col.items[i].taskIndex]
+}
+
 // SelectedIndex returns the index of the selected task in the original slice
 func (k *KanbanView) SelectedIndex() int {
 	col := k.columns[k.activeCol]
@@ -326,11 +693,19 @@ func (k *KanbanView) SelectedIndex() int {
 	return -1
 }
 
+// SetHints sets the keys suggested in empty columns
+func (k *KanbanView) SetHints(hints *emptyHints) {
+	k.hints = hints
+}
+
 // Render renders the kanban board
 func (k *KanbanView) Render() string {
 	var columns []string
 
-	for i := 0; i < 4; i++ {
+	for i := range k.columns {
+		if k.hidden[i] {
+			continue
+		}
 		col := k.renderColumn(i)
 		columns = append(columns, col)
 	}
@@ -340,77 +715,217 @@ func (k *KanbanView) Render() string {
 
 // renderColumn renders a single column
 func (k *KanbanView) renderColumn(colIdx int) string {
-	col := k.columns[colIdx]
+	col := &k.columns[colIdx]
 	isActive := colIdx == k.activeCol
 
-	// Column title
-	title := col.status.Label()
-	count := len(col.tasks)
-	titleText := k.styles.KanbanColumnTitle.Render(title + " (" + itoa(count) + ")")
+	// Column title, with the WIP limit turning red once exceeded
+	title := col.status.Label() + " (" + itoa(len(col.tasks)) + ")"
+	titleStyle := k.styles.KanbanColumnTitle
+	if c := k.colors[colIdx]; c != "" {
+		titleStyle = titleStyle.Foreground(c)
+	}
+	if limit := k.wip[colIdx]; limit > 0 {
+		load := k.loads[colIdx]
+		title = col.status.Label() + " (" + itoa(load) + "/" + itoa(limit) + ")"
+		if load > limit {
+			titleStyle = titleStyle.Foreground(colorRed)
+		}
+	}
+	titleText := titleStyle.Render(title)
 
-	// Render items (cards and headers)
-	var items []string
-	cardHeight := k.height - 6 // Account for title and borders
+	// Only the items in view are rendered, the layout of the column
+	// telling which
+	var lines []string
+	height := k.height - 6 // Account for title and borders
+	if col.lines > height {
+		lines = k.scrollColumn(colIdx, isActive, height)
+	} else {
+		col.offset = 0
+		lines = k.renderLines(colIdx, isActive, 0, col.lines)
+	}
 
-	// Calculate visible range
-	visibleItems := cardHeight / 3 // Approximate items per column (headers are smaller)
-	if visibleItems < 1 {
-		visibleItems = 1
+	if len(col.items) == 0 && k.hints != nil {
+		message, hints := i18n.T("Aucune tâche"), k.hints.forColumn(col.status == model.StatusOfKind(model.StatusTodo))
+		if !k.query.IsEmpty() {
+			message, hints = i18n.T("Aucun résultat"), k.hints.forSearch(false)[:1]
+		}
+		lines = strings.Split(renderEmpty(message, hints, k.widths[colIdx]), "\n")
 	}
 
-	scrollOffset := 0
-	if col.cursor >= visibleItems {
-		scrollOffset = col.cursor - visibleItems + 1
+	content := titleText + "\n" + strings.Join(lines, "\n")
+
+	// Apply column style, the active column keeping its border color
+	var colStyle lipgloss.Style
+	if isActive {
+		colStyle = k.styles.KanbanColumnSelected.Width(k.widths[colIdx]).Height(k.height - 4)
+	} else {
+		colStyle = k.styles.KanbanColumn.Width(k.widths[colIdx]).Height(k.height - 4)
+		if c := k.colors[colIdx]; c != "" {
+			colStyle = colStyle.BorderForeground(c)
+		}
+	}
+	if k.shaded {
+		bg := shade(k.colors[colIdx])
+		colStyle = colStyle.Background(bg)
+		content = keepBackground(content, bg)
+	}
+
+	return colStyle.Render(content)
+}
+
+// scrollColumn returns the visible part of a column taller than height.
+// The selected card is kept in view, scrolling line by line as little as
+// possible, and the first and last lines tell how many tasks are hidden
+// above and below; cards at the edges are cut rather than skipped.
+func (k *KanbanView) scrollColumn(colIdx int, isActive bool, height int) []string {
+	col := &k.columns[colIdx]
+	spans := col.spans
+	window := max(height-2, 1)
+
+	if k.inLanes() {
+		// Swimlanes scroll together, following the active column
+		k.follow(k.activeCol, window)
+		col.offset = k.columns[k.activeCol].offset
+	} else {
+		k.follow(colIdx, window)
 	}
 
-	for i := scrollOffset; i < len(col.items) && i < scrollOffset+visibleItems; i++ {
+	// Tasks starting above the window, and tasks after them ending below it
+	top := sort.Search(len(spans), func(i int) bool { return spans[i][0] >= col.offset })
+	cut := sort.Search(len(spans), func(i int) bool { return spans[i][1] > col.offset+window })
+	above := col.before[top]
+	below := col.before[len(spans)] - col.before[max(top, cut)]
+
+	indicator := lipgloss.NewStyle().Foreground(colorOverlay0)
+	var first, last string
+	if above > 0 {
+		first = indicator.Render(i18n.Tf("↑ %d de plus", above))
+	}
+	if below > 0 {
+		last = indicator.Render(i18n.Tf("↓ %d de plus", below))
+	}
+
+	visible := make([]string, 0, window+2)
+	visible = append(visible, first)
+	visible = append(visible, k.renderLines(colIdx, isActive, col.offset, min(col.offset+window, col.lines))...)
+	return append(visible, last)
+}
+
+// follow scrolls a column of a window of lines as little as possible to
+// keep its selected card in view
+func (k *KanbanView) follow(colIdx, window int) {
+	col := &k.columns[colIdx]
+	spans := col.spans
+	if col.cursor < len(spans) {
+		start, end := spans[col.cursor][0], spans[col.cursor][1]
+		// Show the group header with the first card of a group
+		if col.cursor > 0 && col.items[col.cursor-1].isHeader && end-spans[col.cursor-1][0] <= window {
+			start = spans[col.cursor-1][0]
+		}
+		if end > col.offset+window {
+			col.offset = end - window
+		}
+		if start < col.offset {
+			col.offset = start
+		}
+	}
+	col.offset = max(min(col.offset, col.lines-window), 0)
+}
+
+// renderLines renders the lines from..to of a column, going through the
+// items on them only. Each item fills the lines its layout gives it, its
+// margin left blank.
+func (k *KanbanView) renderLines(colIdx int, isActive bool, from, to int) []string {
+	col := &k.columns[colIdx]
+	lines := make([]string, max(to-from, 0))
+	first := sort.Search(len(col.spans), func(i int) bool { return col.spans[i][1] > from })
+	for i := first; i < len(col.items) && col.spans[i][0] < to; i++ {
 		item := col.items[i]
-		if item.isHeader {
-			header := k.renderGroupHeader(item.headerText)
-			items = append(items, header)
+		var block string
+		if item.isHeader && k.inLanes() {
+			block = k.renderLaneHeader(colIdx, item.headerText)
+		} else if item.isHeader {
+			block = k.renderGroupHeader(item.headerText, k.widths[colIdx])
 		} else {
-			task := k.tasks[item.taskIndex]
 			isSelected := isActive && i == col.cursor
-			card := k.renderCard(task, isSelected)
-			items = append(items, card)
+			block = k.renderCard(k.tasks[item.taskIndex], isSelected, k.widths[colIdx])
+		}
+		start, end := col.spans[i][0], col.spans[i][1]
+		blockLines := strings.Split(block, "\n")
+		for j := range min(end-start, len(blockLines)) {
+			if n := start + j - from; n >= 0 && n < len(lines) {
+				lines[n] = blockLines[j]
+			}
 		}
 	}
+	return lines
+}
 
-	content := titleText + "\n" + strings.Join(items, "\n")
+// shadeRatio is the share of a column's color in its background
+const shadeRatio = 0.12
 
-	// Apply column style
-	var colStyle lipgloss.Style
-	if isActive {
-		colStyle = k.styles.KanbanColumnSelected.Width(k.columnWidth).Height(k.height - 4)
-	} else {
-		colStyle = k.styles.KanbanColumn.Width(k.columnWidth).Height(k.height - 4)
+// shade returns the background of a column: its color blended into the
+// base color, or the surface color when it has none or isn't in hex
+func shade(c lipgloss.Color) lipgloss.Color {
+	r, g, b, ok := hexRGB(c)
+	br, bg, bb, _ := hexRGB(colorBase)
+	if !ok {
+		return colorMantle
 	}
+	mix := func(base, v int) int { return base + int(float64(v-base)*shadeRatio) }
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", mix(br, r), mix(bg, g), mix(bb, b)))
+}
 
-	return colStyle.Render(content)
+// hexRGB returns the components of a "#rrggbb" color
+func hexRGB(c lipgloss.Color) (r, g, b int, ok bool) {
+	if _, err := fmt.Sscanf(string(c), "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return 0, 0, 0, false
+	}
+	return r, g, b, true
 }
 
-// renderGroupHeader renders a group header within a column
-func (k *KanbanView) renderGroupHeader(text string) string {
+// keepBackground sets the background again after each reset of the styled
+// parts of rendered content, which would otherwise clear the background of
+// the style rendering it for the rest of the line
+func keepBackground(content string, bg lipgloss.Color) string {
+	start, _, ok := strings.Cut(lipgloss.NewStyle().Background(bg).Render("x"), "x")
+	if !ok || start == "" {
+		return content
+	}
+	for _, reset := range []string{"\x1b[0m", "\x1b[m"} {
+		content = strings.ReplaceAll(content, reset, reset+start)
+	}
+	return content
+}
+
+// renderGroupHeader renders a group header within a column, cut to its
+// width
+func (k *KanbanView) renderGroupHeader(text string, columnWidth int) string {
 	headerStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#cba6f7")).
 		Bold(true).
 		Italic(true)
 
-	return headerStyle.Render("─ " + text + " ─")
+	return headerStyle.Render(truncate("─ "+text+" ─", columnWidth))
 }
 
 // renderCard renders a single task card
-func (k *KanbanView) renderCard(task model.Task, selected bool) string {
+func (k *KanbanView) renderCard(task model.Task, selected bool, columnWidth int) string {
 	// Priority icon
 	priorityIcon := PriorityIcon(task.Priority)
 	priorityStyle := k.styles.PriorityStyle(task.Priority)
 
-	// Title (truncated)
-	title := task.Title
-	maxTitleLen := k.columnWidth - 8
-	if len(title) > maxTitleLen {
-		title = title[:maxTitleLen-1] + "…"
+	// Short ID and title, truncated to the width of the card
+	var shortID string
+	if id := task.ShortID(); id != "" {
+		shortID = k.styles.ShortID.Render(id) + " "
+	}
+	icons := priorityStyle.Render(priorityIcon)
+	if task.Severity != model.SeverityNone {
+		icons += k.styles.SeverityStyle(task.Severity).Render(SeverityIcon(task.Severity))
 	}
+	prefix := icons + " " + pinMarker(task) + staleMarker(k.styles, task, k.staleDays) + shortID
+	title := escalatedTitle(k.styles, task, truncate(task.Title, columnWidth-6-lipgloss.Width(prefix)), k.escalate)
 
 	// Tags (first 2 only)
 	var tagStr string
@@ -419,27 +934,48 @@ func (k *KanbanView) renderCard(task model.Task, selected bool) string {
 		if len(task.Tags) < maxTags {
 			maxTags = len(task.Tags)
 		}
-		tagStr = strings.Join(task.Tags[:maxTags], ", ")
+		tagStyle := lipgloss.NewStyle().Foreground(colorOverlay0).Italic(true)
+		var tags []string
+		for _, tag := range task.Tags[:maxTags] {
+			if c, ok := k.styles.TagColor(tag); ok {
+				tags = append(tags, tagStyle.Foreground(c).Render(tag))
+			} else {
+				tags = append(tags, tagStyle.Render(tag))
+			}
+		}
+		tagStr = strings.Join(tags, tagStyle.Render(", "))
 		if len(task.Tags) > 2 {
-			tagStr += "…"
+			tagStr += tagStyle.Render("…")
 		}
 	}
 
 	// Build card content
-	var lines []string
-	lines = append(lines, priorityStyle.Render(priorityIcon)+" "+title)
-	if tagStr != "" {
-		tagLine := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6c7086")).
-			Italic(true).
-			Render(tagStr)
-		lines = append(lines, tagLine)
+	lines := []string{prefix + title}
+	if done, total := task.SubtaskProgress(); total > 0 {
+		progress := lipgloss.NewStyle().Foreground(colorOverlay0).Italic(true).
+			Render("☑ " + itoa(done) + "/" + itoa(total))
+		if tagStr != "" {
+			tagStr = progress + "  " + tagStr
+		} else {
+			tagStr = progress
+		}
+	}
+	if k.hasTagLine(task) {
+		key, waiting := task.ExternalKey(), dependencyBadge(task, k.open)
+		tagLine := tagStr
+		if badge := strings.TrimSpace(sprintBadge(k.styles, task) + " " + estimateBadge(task) + " " + waiting + " " + privateBadge(task)); badge != "" {
+			tagLine = strings.TrimSpace(badge + " " + tagLine)
+		}
+		if key != "" {
+			tagLine = strings.TrimSpace(k.styles.ExternalKey.Render(key) + " " + tagLine)
+		}
+		lines = append(lines, truncate(tagLine, columnWidth-6))
 	}
 
 	content := strings.Join(lines, "\n")
 
 	// Apply card style
-	cardWidth := k.columnWidth - 4
+	cardWidth := columnWidth - 4
 	if selected {
 		return k.styles.KanbanCardSelected.Width(cardWidth).Render(content)
 	}
@@ -448,7 +984,7 @@ func (k *KanbanView) renderCard(task model.Task, selected bool) string {
 
 // SetActiveColumn sets the active column
 func (k *KanbanView) SetActiveColumn(col int) {
-	if col >= 0 && col < 4 {
+	if col >= 0 && col < len(k.columns) && !k.hidden[col] {
 		k.activeCol = col
 	}
 }
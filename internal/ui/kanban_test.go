@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"testing"
+
+	"lazy-todo/internal/model"
+)
+
+func TestKanbanLanesEmptyColumn(t *testing.T) {
+	k := NewKanbanView(DefaultStyles())
+	k.SetSize(120, 40)
+	k.SetTasks(testTasks(8))
+	k.SetGroupBy(model.GroupByPriority)
+	k.SetLanes(true)
+
+	// The in progress column holds the lane headers only
+	for c, col := range k.columns {
+		if col.status == model.StatusOfKind(model.StatusInProgress) {
+			k.activeCol = c
+		}
+	}
+	if len(k.columns[k.activeCol].items) == 0 {
+		t.Fatal("no lane headers in the empty column")
+	}
+	for range 3 {
+		k.MoveDown()
+	}
+	k.MoveUp()
+	k.MoveDown()
+	if col := k.columns[k.activeCol]; col.cursor < 0 || col.cursor >= len(col.items) {
+		t.Fatalf("cursor %d out of the %d items of the column", col.cursor, len(col.items))
+	}
+	if task := k.SelectedTask(); task != nil {
+		t.Errorf("selected %q in an empty column", task.Title)
+	}
+	k.SetFilter("Tâche 1")
+	k.SetGroupBy(model.GroupByTag)
+	k.SetSortBy(model.SortByPriority)
+}
+
+func BenchmarkKanbanRender(b *testing.B) {
+	for _, n := range renderSizes {
+		b.Run(itoa(n), func(b *testing.B) {
+			k := NewKanbanView(DefaultStyles())
+			k.SetSize(120, 40)
+			k.SetTasks(testTasks(n))
+			for range n / 2 {
+				k.MoveDown()
+			}
+			for b.Loop() {
+				k.Render()
+			}
+		})
+	}
+}
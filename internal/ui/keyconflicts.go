@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/keys"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// checkKeyConflicts opens the resolution screen when the keys of the
+// configuration bind a key to several actions or name unknown actions
+func (a *App) checkKeyConflicts() {
+	a.keyConflicts = a.keys.Conflicts()
+	if len(a.keyConflicts) == 0 && len(a.unknownKeys) == 0 {
+		if a.state == StateKeyConflicts {
+			a.state = StateNormal
+		}
+		return
+	}
+	a.conflictCursor = min(a.conflictCursor, max(a.conflictRows()-1, 0))
+	a.state = StateKeyConflicts
+}
+
+// conflictRows returns the number of actions listed, one row each
+func (a *App) conflictRows() int {
+	n := 0
+	for _, c := range a.keyConflicts {
+		n += len(c.Actions)
+	}
+	return n
+}
+
+// conflictAt returns the conflict and the action of a row
+func (a *App) conflictAt(row int) (keys.Conflict, string) {
+	for _, c := range a.keyConflicts {
+		if row < len(c.Actions) {
+			return c, c.Actions[row]
+		}
+		row -= len(c.Actions)
+	}
+	return keys.Conflict{}, ""
+}
+
+// handleKeyConflictsKeys handles the resolution screen: enter keeps the key
+// for the selected action, d restores the default keys of the actions in
+// conflict, esc keeps the first action of each conflict. Resolutions last
+// until the configuration is fixed.
+func (a *App) handleKeyConflictsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if a.conflictCursor < a.conflictRows()-1 {
+			a.conflictCursor++
+		}
+	case "k", "up":
+		if a.conflictCursor > 0 {
+			a.conflictCursor--
+		}
+	case "enter":
+		conflict, keep := a.conflictAt(a.conflictCursor)
+		for _, name := range conflict.Actions {
+			if name != keep {
+				a.keys.Unbind(name, conflict.Key)
+			}
+		}
+		a.checkKeyConflicts()
+	case "d":
+		conflict, _ := a.conflictAt(a.conflictCursor)
+		for _, name := range conflict.Actions {
+			a.keys.Reset(name)
+		}
+		a.checkKeyConflicts()
+	case "esc", "q":
+		for _, conflict := range a.keyConflicts {
+			for _, name := range conflict.Actions[1:] {
+				a.keys.Unbind(name, conflict.Key)
+			}
+		}
+		a.unknownKeys = nil
+		a.keyConflicts = nil
+		a.state = StateNormal
+	}
+	a.refreshHints()
+	return a, nil
+}
+
+// renderKeyConflicts renders the keys bound to several actions, each action
+// with its description
+func (a *App) renderKeyConflicts() string {
+	title := a.styles.DialogTitle.Render(i18n.T("Raccourcis en conflit"))
+	keyStyle := lipgloss.NewStyle().Foreground(colorPeach).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(colorText)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+	selectedStyle := lipgloss.NewStyle().Foreground(colorBlue).Bold(true)
+
+	descriptions := map[string]string{}
+	for _, action := range a.keys.Actions() {
+		descriptions[action.Name] = action.Binding.Help().Desc
+	}
+
+	var lines []string
+	row := 0
+	for _, c := range a.keyConflicts {
+		lines = append(lines, keyStyle.Render(c.Key))
+		for _, name := range c.Actions {
+			line := "  " + name + "  " + mutedStyle.Render(descriptions[name])
+			if row == a.conflictCursor {
+				line = selectedStyle.Render("▸ "+name) + "  " + mutedStyle.Render(descriptions[name])
+			} else {
+				line = textStyle.Render(line)
+			}
+			lines = append(lines, line)
+			row++
+		}
+	}
+	if len(a.unknownKeys) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorYellow).
+			Render(i18n.Tf("Actions inconnues, ignorées: %s", strings.Join(a.unknownKeys, ", "))))
+	}
+
+	help := mutedStyle.Render(i18n.T("Esc: continuer"))
+	if len(a.keyConflicts) > 0 {
+		help = mutedStyle.Render(i18n.T("Entrée: garder la touche pour cette action │ d: touches par défaut │ Esc: la première action l'emporte"))
+	}
+	help += "\n" + mutedStyle.Render(i18n.T("Corrigez la section keys de la configuration pour ne plus voir cet écran"))
+
+	content := title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
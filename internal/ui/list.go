@@ -1,10 +1,14 @@
 package ui
 
 import (
-	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"lazy-todo/internal/i18n"
 	"lazy-todo/internal/model"
+	"lazy-todo/internal/parse"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -20,13 +24,31 @@ type ListItem struct {
 type ListView struct {
 	tasks    []model.Task
 	cursor   int
+	offset   int // first visible item, kept while the cursor stays in view
 	styles   Styles
 	width    int
 	height   int
 	filter   string
-	filtered []int      // indices of filtered tasks
+	query    model.Query     // parsed filter
+	open     map[string]bool // IDs of the open tasks, for dependencies
+	filtered []int           // indices of filtered tasks
 	groupBy  model.GroupBy
+	sortBy   model.SortBy
 	items    []ListItem // items to display (headers + tasks)
+	columns  []listColumn
+	detailed bool // tasks on several lines, see SetDetailed
+	today    bool // only the working set of the day, see SetToday
+	hideDone bool // done tasks left out, see SetHideDone
+
+	// Done tasks among the filtered ones, see countProgress
+	progress   progress
+	byPriority map[model.Priority]progress
+
+	showSeverity bool // at least one task has a severity
+	staleDays    int  // in progress tasks idle for this long are flagged
+	escalate     bool // overdue tasks at a high priority stand out
+
+	hints *emptyHints // keys suggested when empty, none when nil
 }
 
 // NewListView creates a new list view
@@ -38,22 +60,33 @@ func NewListView(styles Styles) *ListView {
 		filtered: []int{},
 		groupBy:  model.GroupByNone,
 		items:    []ListItem{},
+		columns:  listColumns(nil),
 	}
 }
 
 // SetTasks sets the tasks to display
 func (l *ListView) SetTasks(tasks []model.Task) {
+	// Taken before the items, which index the previous tasks, are rebuilt
+	id := l.selectedID()
 	l.tasks = tasks
+	l.showSeverity = false
+	for _, t := range tasks {
+		if t.Severity != model.SeverityNone {
+			l.showSeverity = true
+			break
+		}
+	}
 	l.applyFilter()
 	l.organizeItems()
-	l.adjustCursor()
+	l.reselect(id)
 }
 
 // SetGroupBy sets the grouping mode
 func (l *ListView) SetGroupBy(groupBy model.GroupBy) {
+	id := l.selectedID()
 	l.groupBy = groupBy
 	l.organizeItems()
-	l.adjustCursor()
+	l.reselect(id)
 }
 
 // GetGroupBy returns the current grouping mode
@@ -63,9 +96,65 @@ func (l *ListView) GetGroupBy() model.GroupBy {
 
 // CycleGroupBy cycles to the next grouping mode
 func (l *ListView) CycleGroupBy() {
+	id := l.selectedID()
 	l.groupBy = l.groupBy.Next()
 	l.organizeItems()
-	l.adjustCursor()
+	l.reselect(id)
+}
+
+// SetSortBy sets the sorting mode
+func (l *ListView) SetSortBy(sortBy model.SortBy) {
+	id := l.selectedID()
+	l.sortBy = sortBy
+	l.applyFilter()
+	l.organizeItems()
+	l.reselect(id)
+}
+
+// SetStaleDays sets the idle threshold for flagging in progress tasks
+func (l *ListView) SetStaleDays(days int) {
+	l.staleDays = days
+}
+
+// SetEscalate makes overdue tasks at a high priority stand out
+func (l *ListView) SetEscalate(enabled bool) {
+	l.escalate = enabled
+}
+
+// GetSortBy returns the current sorting mode
+func (l *ListView) GetSortBy() model.SortBy {
+	return l.sortBy
+}
+
+// selectedID returns the ID of the selected task, or "" if none
+func (l *ListView) selectedID() string {
+	if task := l.SelectedTask(); task != nil {
+		return task.ID
+	}
+	return ""
+}
+
+// SelectTask moves the cursor to the task with the given ID. It returns
+// false, leaving the cursor unchanged, if the task isn't displayed.
+func (l *ListView) SelectTask(id string) bool {
+	if id == "" {
+		return false
+	}
+	for i, item := range l.items {
+		if !item.isHeader && l.tasks[item.taskIndex].ID == id {
+			l.cursor = i
+			return true
+		}
+	}
+	return false
+}
+
+// reselect keeps the cursor on the task with the given ID after the items
+// were rebuilt, or on a valid item near its old position if it's gone
+func (l *ListView) reselect(id string) {
+	if !l.SelectTask(id) {
+		l.adjustCursor()
+	}
 }
 
 // adjustCursor ensures cursor is on a valid task item
@@ -123,7 +212,7 @@ func (l *ListView) organizeItems() {
 			if len(task.Tags) > 0 {
 				key = task.Tags[0] // Group by first tag
 			} else {
-				key = "Sans tag"
+				key = i18n.T("Sans tag")
 			}
 		}
 
@@ -144,11 +233,19 @@ func (l *ListView) organizeItems() {
 		groupOrder = orderedKeys
 	} else if l.groupBy == model.GroupByPriority {
 		orderedKeys := []string{}
+		known := map[string]bool{}
 		for _, p := range model.AllPriorities() {
+			known[p.Label()] = true
 			if _, exists := groups[p.Label()]; exists {
 				orderedKeys = append(orderedKeys, p.Label())
 			}
 		}
+		// Values that are not configured levels go last
+		for _, key := range groupOrder {
+			if !known[key] {
+				orderedKeys = append(orderedKeys, key)
+			}
+		}
 		groupOrder = orderedKeys
 	}
 
@@ -158,7 +255,7 @@ func (l *ListView) organizeItems() {
 		// Add header
 		l.items = append(l.items, ListItem{
 			isHeader:   true,
-			headerText: groupKey + " (" + itoa(len(taskIndices)) + ")",
+			headerText: groupKey + " (" + i18n.N(len(taskIndices), "%d tâche", "%d tâches") + ")",
 		})
 		// Add tasks
 		for _, idx := range taskIndices {
@@ -175,47 +272,69 @@ func (l *ListView) SetSize(width, height int) {
 
 // SetFilter sets the search filter
 func (l *ListView) SetFilter(filter string) {
+	id := l.selectedID()
+	query := model.ParseQuery(filter)
+	narrows := query.Narrows(l.query)
 	l.filter = strings.ToLower(filter)
-	l.applyFilter()
+	l.query = query
+	if narrows {
+		l.refineFilter()
+	} else {
+		l.applyFilter()
+	}
 	l.organizeItems()
-	l.cursor = 0
-	l.adjustCursor()
+	// Stay on the selected task while it still matches
+	if !l.SelectTask(id) {
+		l.cursor = 0
+		l.adjustCursor()
+	}
 }
 
 // applyFilter filters tasks based on the current filter
 func (l *ListView) applyFilter() {
+	l.open = model.OpenIDs(l.tasks)
+	l.query = l.query.WithTasks(l.tasks)
 	l.filtered = []int{}
 	for i, task := range l.tasks {
 		if l.matchesFilter(task) {
 			l.filtered = append(l.filtered, i)
 		}
 	}
+	model.SortIndices(l.tasks, l.filtered, l.sortBy)
+	l.countProgress()
+	l.dropDone()
 }
 
-// matchesFilter checks if a task matches the current filter
-func (l *ListView) matchesFilter(task model.Task) bool {
-	if l.filter == "" {
-		return true
-	}
-
-	// Check title
-	if strings.Contains(strings.ToLower(task.Title), l.filter) {
-		return true
+// refineFilter filters the tasks kept by the previous filter, which the
+// current one narrows, keeping their order
+func (l *ListView) refineFilter() {
+	l.query = l.query.WithTasks(l.tasks)
+	kept := l.filtered[:0]
+	for _, i := range l.filtered {
+		if l.matchesFilter(l.tasks[i]) {
+			kept = append(kept, i)
+		}
 	}
+	l.filtered = kept
+	l.countProgress()
+	l.dropDone()
+}
 
-	// Check description
-	if strings.Contains(strings.ToLower(task.Description), l.filter) {
-		return true
+// FilteredTasks returns the tasks matching the current filter, in display order
+func (l *ListView) FilteredTasks() []model.Task {
+	tasks := make([]model.Task, 0, len(l.filtered))
+	for _, idx := range l.filtered {
+		tasks = append(tasks, l.tasks[idx])
 	}
+	return tasks
+}
 
-	// Check tags
-	for _, tag := range task.Tags {
-		if strings.Contains(strings.ToLower(tag), l.filter) {
-			return true
-		}
+// matchesFilter checks if a task matches the current filter
+func (l *ListView) matchesFilter(task model.Task) bool {
+	if l.today && !task.InToday(time.Now()) {
+		return false
 	}
-
-	return false
+	return l.query.Matches(task)
 }
 
 // MoveUp moves the cursor up
@@ -269,6 +388,19 @@ func (l *ListView) SelectedTask() *model.Task {
 	return nil
 }
 
+// AdjacentTask returns the task right above (delta < 0) or below (delta > 0)
+// the selection, or nil if there is none within the same group
+func (l *ListView) AdjacentTask(delta int) *model.Task {
+	if l.SelectedTask() == nil {
+		return nil
+	}
+	i := l.cursor + delta
+	if i < 0 || i >= len(l.items) || l.items[i].isHeader {
+		return nil
+	}
+	return &l.tasks[l.items[i].taskIndex]
+}
+
 // SelectedIndex returns the index of the selected task in the original slice
 func (l *ListView) SelectedIndex() int {
 	if len(l.items) == 0 {
@@ -284,31 +416,66 @@ func (l *ListView) SelectedIndex() int {
 	return -1
 }
 
+// SetHints sets the keys suggested when the list is empty
+func (l *ListView) SetHints(hints *emptyHints) {
+	l.hints = hints
+}
+
 // Render renders the list view
 func (l *ListView) Render() string {
 	if len(l.items) == 0 {
-		emptyMsg := "Aucune tâche"
+		emptyMsg, hints := i18n.T("Aucune tâche"), l.hints.forTasks()
+		if l.today {
+			emptyMsg, hints = i18n.T("Rien pour aujourd'hui: ni échéance, ni retard, ni tâche ajoutée"), l.hints.forToday()
+		}
+		if l.hideDone && l.progress.done > 0 {
+			emptyMsg = i18n.N(l.progress.done, "Aucune tâche ouverte, %d tâche terminée masquée", "Aucune tâche ouverte, %d tâches terminées masquées")
+		}
 		if l.filter != "" {
-			emptyMsg = fmt.Sprintf("Aucun résultat pour \"%s\"", l.filter)
+			emptyMsg, hints = i18n.Tf("Aucun résultat pour \"%s\"", l.filter), l.hints.forSearch(parse.FromSearch(l.filter) != "")
 		}
 		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6c7086")).
-			Italic(true).
 			Padding(1, 2).
-			Render(emptyMsg)
+			Render(renderEmpty(emptyMsg, hints, l.width-4))
 	}
 
 	var lines []string
-	visibleHeight := l.height - 2 // Account for padding
+	height := max(l.height-2, 0) // Account for padding
+	visibleHeight := height
+	now := time.Now()
+
+	// Keep the scroll position while the cursor stays in view
+	l.scroll(visibleHeight, now)
+
+	// Pin the header of the group scrolled through at the top
+	sticky := l.stickyHeader()
+	if sticky >= 0 {
+		visibleHeight--
+		l.scroll(visibleHeight, now)
+		sticky = l.stickyHeader()
+	}
+	if sticky >= 0 {
+		lines = append(lines, l.groupHeaderStyle().Render("▾ "+l.items[sticky].headerText))
+	}
+
+	// Items in view, the last one possibly cut
+	end := l.offset
+	for used := 0; end < len(l.items) && used < visibleHeight; end++ {
+		used += l.itemHeight(end, now)
+	}
 
-	// Calculate scroll offset
-	scrollOffset := 0
-	if l.cursor >= visibleHeight {
-		scrollOffset = l.cursor - visibleHeight + 1
+	// Columns fitted to the tasks in view
+	var visible []model.Task
+	for _, item := range l.items[l.offset:end] {
+		if !item.isHeader {
+			visible = append(visible, l.tasks[item.taskIndex])
+		}
 	}
+	columns := l.shownColumns()
+	widths := l.columnWidths(columns, visible, now)
 
 	// Render visible items
-	for i := scrollOffset; i < len(l.items) && i < scrollOffset+visibleHeight; i++ {
+	for i := l.offset; i < end; i++ {
 		item := l.items[i]
 		if item.isHeader {
 			line := l.renderGroupHeader(item.headerText)
@@ -316,84 +483,137 @@ func (l *ListView) Render() string {
 		} else {
 			task := l.tasks[item.taskIndex]
 			isSelected := i == l.cursor
-			line := l.renderTaskLine(task, isSelected)
+			line := l.renderTaskLine(task, isSelected, columns, widths, now)
 			lines = append(lines, line)
 		}
 	}
 
-	return strings.Join(lines, "\n")
+	lines = strings.Split(strings.Join(lines, "\n"), "\n")
+	return strings.Join(lines[:min(len(lines), height)], "\n")
 }
 
-// renderGroupHeader renders a group header
-func (l *ListView) renderGroupHeader(text string) string {
-	headerStyle := lipgloss.NewStyle().
+// stickyHeader returns the index of the header of the group the first
+// visible item belongs to, when that header is scrolled out of view, or -1
+func (l *ListView) stickyHeader() int {
+	if l.offset <= 0 || l.offset >= len(l.items) || l.items[l.offset].isHeader {
+		return -1
+	}
+	for i := l.offset - 1; i >= 0; i-- {
+		if l.items[i].isHeader {
+			return i
+		}
+	}
+	return -1
+}
+
+// groupHeaderStyle returns the style of group headers
+func (l *ListView) groupHeaderStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#cba6f7")).
 		Bold(true).
 		Padding(0, 1).
-		MarginTop(1)
+		Width(l.width - 2)
+}
 
-	return headerStyle.Width(l.width - 2).Render("▸ " + text)
+// renderGroupHeader renders a group header
+func (l *ListView) renderGroupHeader(text string) string {
+	return l.groupHeaderStyle().MarginTop(1).Render("▸ " + text)
 }
 
-// renderTaskLine renders a single task line
-func (l *ListView) renderTaskLine(task model.Task, selected bool) string {
-	// Priority icon
-	priorityIcon := PriorityIcon(task.Priority)
-	priorityStyle := l.styles.PriorityStyle(task.Priority)
+// renderTaskLine renders a single task line, a cell per column
+func (l *ListView) renderTaskLine(task model.Task, selected bool, columns []listColumn, widths []int, now time.Time) string {
+	cells := make([]string, len(columns))
+	for i, c := range columns {
+		if c.field == listFieldTitle {
+			cells[i] = l.renderTitleCell(task, widths[i], !slices.ContainsFunc(columns, func(c listColumn) bool {
+				return c.field == listFieldTags
+			}))
+			continue
+		}
+		cells[i] = renderCell(l.cellParts(task, c, now), widths[i])
+	}
+	content := strings.Join(cells, " ")
 
-	// Status icon
-	statusIcon := StatusIcon(task.Status)
-	statusStyle := l.styles.StatusStyle(task.Status)
+	// Cut when the columns don't fit
+	if lineWidth := l.width - 4; lineWidth > 0 && lipgloss.Width(content) > lineWidth {
+		content = truncate(content, lineWidth)
+	}
+	if l.detailed {
+		content = strings.Join(append([]string{content}, l.detailLines(task, now)...), "\n")
+	}
 
-	// Status label for right side
-	statusLabel := task.Status.Label()
-	statusLabelRendered := statusStyle.Render(statusLabel)
-	statusLabelWidth := lipgloss.Width(statusLabelRendered)
+	// Apply selection style
+	if selected {
+		return l.styles.ListItemSelected.Width(l.width - 2).Render(content)
+	}
+	return l.styles.ListItem.Width(l.width - 2).Render(content)
+}
 
+// renderTitleCell renders the title with its markers and badges, and its
+// tags unless they have their column, cut or padded to width
+func (l *ListView) renderTitleCell(task model.Task, width int, withTags bool) string {
 	// Tags
 	var tagStr string
-	if len(task.Tags) > 0 {
+	if len(task.Tags) > 0 && withTags {
 		var tags []string
 		for _, tag := range task.Tags {
-			tags = append(tags, l.styles.Tag.Render(tag))
+			tags = append(tags, l.styles.TagStyle(tag).Render(tag))
 		}
 		tagStr = " " + strings.Join(tags, " ")
 	}
 
-	// Build the left part of the line
-	leftContent := fmt.Sprintf(
-		"%s %s %s%s",
-		priorityStyle.Render(priorityIcon),
-		statusStyle.Render(statusIcon),
-		task.Title,
-		tagStr,
-	)
-
-	// Calculate available width for left content
-	lineWidth := l.width - 4
-	rightPartWidth := statusLabelWidth + 2 // padding for status label
-	maxLeftWidth := lineWidth - rightPartWidth
-
-	// Truncate left content if needed
-	if maxLeftWidth > 0 && lipgloss.Width(leftContent) > maxLeftWidth {
-		leftContent = truncate(leftContent, maxLeftWidth)
+	// Checklist progress
+	if _, total := task.SubtaskProgress(); total > 0 {
+		tagStr = " " + l.renderProgress(task) + tagStr
+	}
+	if badge := sprintBadge(l.styles, task); badge != "" {
+		tagStr += " " + badge
+	}
+	if badge := estimateBadge(task); badge != "" {
+		tagStr += " " + badge
+	}
+	if badge := dependencyBadge(task, l.open); badge != "" {
+		tagStr += " " + badge
+	}
+	if badge := privateBadge(task); badge != "" {
+		tagStr += " " + badge
+	}
+	if badge := todayBadge(task, time.Now()); badge != "" {
+		tagStr += " " + badge
 	}
 
-	// Calculate spacing between left and right
-	leftWidth := lipgloss.Width(leftContent)
-	spacing := lineWidth - leftWidth - statusLabelWidth
-	if spacing < 1 {
-		spacing = 1
+	content := pinMarker(task) + staleMarker(l.styles, task, l.staleDays) + escalatedTitle(l.styles, task, task.Title, l.escalate) + tagStr
+	if lipgloss.Width(content) > width {
+		content = truncate(content, width)
 	}
+	return content + strings.Repeat(" ", max(width-lipgloss.Width(content), 0))
+}
 
-	// Build full line with status on the right
-	content := leftContent + strings.Repeat(" ", spacing) + statusLabelRendered
+// renderProgress renders the checklist progress of a task
+func (l *ListView) renderProgress(task model.Task) string {
+	done, total := task.SubtaskProgress()
+	style := l.styles.StatusTodo
+	if done == total {
+		style = l.styles.StatusDone
+	}
+	return style.Render("[" + itoa(done) + "/" + itoa(total) + "]")
+}
 
-	// Apply selection style
-	if selected {
-		return l.styles.ListItemSelected.Width(l.width - 2).Render(content)
+// scrollOffset returns the first visible item so that cursor is in view,
+// moving the previous offset as little as possible
+func scrollOffset(offset, cursor, visible, total int) int {
+	if visible < 1 {
+		visible = 1
 	}
-	return l.styles.ListItem.Width(l.width - 2).Render(content)
+	if cursor < offset {
+		offset = cursor
+	}
+	if cursor >= offset+visible {
+		offset = cursor - visible + 1
+	}
+	// Don't leave blank space at the bottom when items were removed
+	offset = min(offset, total-visible)
+	return max(offset, 0)
 }
 
 // truncate truncates a string to a maximum width
@@ -402,15 +622,14 @@ func truncate(s string, maxWidth int) string {
 		return s
 	}
 
-	// Simple truncation - could be improved for ANSI sequences
+	// Simple truncation - could be improved for ANSI sequences. The width
+	// grows with the prefix kept, so the longest one fitting is searched
+	// by halves rather than measuring every one of a long title.
 	runes := []rune(s)
-	for i := len(runes) - 1; i >= 0; i-- {
-		truncated := string(runes[:i]) + "…"
-		if lipgloss.Width(truncated) <= maxWidth {
-			return truncated
-		}
-	}
-	return "…"
+	n := sort.Search(len(runes), func(i int) bool {
+		return lipgloss.Width(string(runes[:i+1])+"…") > maxWidth
+	})
+	return string(runes[:n]) + "…"
 }
 
 // Count returns the number of visible tasks
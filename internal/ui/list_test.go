@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"lazy-todo/internal/model"
+)
+
+// testTasks returns tasks of each priority, in todo
+func testTasks(n int) []model.Task {
+	priorities := model.AllPriorities()
+	tasks := make([]model.Task, n)
+	for i := range tasks {
+		tasks[i] = model.NewTask("Tâche " + itoa(i+1))
+		tasks[i].Priority = priorities[i%len(priorities)]
+		tasks[i].Status = model.StatusOfKind(model.StatusTodo)
+	}
+	return tasks
+}
+
+func TestListRenderTinyHeight(t *testing.T) {
+	for _, detailed := range []bool{false, true} {
+		for _, height := range []int{-1, 0, 1, 2, 3, 5} {
+			l := NewListView(DefaultStyles())
+			l.SetTasks(testTasks(12))
+			l.SetGroupBy(model.GroupByPriority)
+			l.SetDetailed(detailed)
+			l.SetSize(36, height)
+			for range 8 {
+				l.MoveDown()
+				l.Render()
+			}
+			if got := len(strings.Split(l.Render(), "\n")); height > 2 && got > height-2 {
+				t.Errorf("detailed=%v height=%d: %d lines, want at most %d", detailed, height, got, height-2)
+			}
+		}
+	}
+}
+
+// renderSizes are the task counts of the render benchmarks: the viewport
+// stays the same, so the cost per render should too
+var renderSizes = []int{100, 5_000, 50_000}
+
+func BenchmarkListRender(b *testing.B) {
+	for _, n := range renderSizes {
+		b.Run(itoa(n), func(b *testing.B) {
+			l := NewListView(DefaultStyles())
+			l.SetSize(120, 40)
+			l.SetTasks(testTasks(n))
+			l.SetGroupBy(model.GroupByPriority)
+			for range n / 2 {
+				l.MoveDown()
+			}
+			for b.Loop() {
+				l.Render()
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// listField is a field of the tasks shown as a column of the list
+type listField string
+
+// Fields of the list, as named by the list.columns setting
+const (
+	listFieldID       listField = "id"
+	listFieldPriority listField = "priority"
+	listFieldSeverity listField = "severity"
+	listFieldStatus   listField = "status"
+	listFieldTitle    listField = "title"
+	listFieldTags     listField = "tags"
+	listFieldDue      listField = "due"
+	listFieldAge      listField = "age"
+	listFieldSpent    listField = "spent"
+)
+
+// listFields are the fields of the list, in the order the columns left out
+// of the config are offered on the columns screen
+var listFields = []listField{
+	listFieldID, listFieldPriority, listFieldSeverity, listFieldStatus, listFieldTitle,
+	listFieldTags, listFieldDue, listFieldAge, listFieldSpent,
+}
+
+// maxColumnWidth caps the width of a column fitted to its values
+const maxColumnWidth = 30
+
+// minTitleWidth is the narrowest the title gets before the line is cut
+const minTitleWidth = 10
+
+// listColumn is a column of the list
+type listColumn struct {
+	field  listField
+	width  int // in cells, fitted to the values when zero
+	hidden bool
+}
+
+// label returns the name of the field on the columns screen
+func (f listField) label() string {
+	switch f {
+	case listFieldID:
+		return i18n.T("Identifiant")
+	case listFieldPriority:
+		return i18n.T("Priorité")
+	case listFieldSeverity:
+		return i18n.T("Sévérité")
+	case listFieldStatus:
+		return i18n.T("Statut")
+	case listFieldTitle:
+		return i18n.T("Titre")
+	case listFieldTags:
+		return i18n.T("Tags")
+	case listFieldDue:
+		return i18n.T("Échéance")
+	case listFieldAge:
+		return i18n.T("Âge")
+	case listFieldSpent:
+		return i18n.T("Temps passé")
+	}
+	return string(f)
+}
+
+// defaultListColumns are the columns when the config sets none: the
+// icons, the short ID and the title, then the status
+func defaultListColumns() []config.ListColumn {
+	return []config.ListColumn{
+		{Field: string(listFieldPriority), Width: 1},
+		{Field: string(listFieldSeverity), Width: 1},
+		{Field: string(listFieldStatus), Width: 1},
+		{Field: string(listFieldID)},
+		{Field: string(listFieldTitle)},
+		{Field: string(listFieldStatus)},
+	}
+}
+
+// listColumns returns the columns of the config, unknown fields left out,
+// followed by the fields it doesn't show, hidden. The title is always
+// shown, last when the config leaves it out.
+func listColumns(configured []config.ListColumn) []listColumn {
+	if len(configured) == 0 {
+		configured = defaultListColumns()
+	}
+	var columns []listColumn
+	shown := map[listField]bool{}
+	for _, c := range configured {
+		field := listField(c.Field)
+		if !slices.Contains(listFields, field) || (field == listFieldTitle && shown[field]) {
+			continue
+		}
+		columns = append(columns, listColumn{field: field, width: max(c.Width, 0)})
+		shown[field] = true
+	}
+	if !shown[listFieldTitle] {
+		columns = append(columns, listColumn{field: listFieldTitle})
+	}
+	for _, field := range listFields {
+		if !shown[field] && field != listFieldTitle {
+			columns = append(columns, listColumn{field: field, hidden: true})
+		}
+	}
+	return columns
+}
+
+// cellPart is a piece of a cell with its style
+type cellPart struct {
+	text  string
+	style lipgloss.Style
+}
+
+// shownColumns returns the columns drawn: the ones not hidden, severity
+// only when tasks have one
+func (l *ListView) shownColumns() []listColumn {
+	var columns []listColumn
+	for _, c := range l.columns {
+		if c.hidden || (c.field == listFieldSeverity && !l.showSeverity) {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+// columnWidths returns the width of each column for the tasks in view: its
+// own, or the widest of their values; the title takes the rest of the line
+func (l *ListView) columnWidths(columns []listColumn, tasks []model.Task, now time.Time) []int {
+	widths := make([]int, len(columns))
+	used, title := len(columns)-1, -1 // a space between columns
+	for i, c := range columns {
+		switch {
+		case c.field == listFieldTitle:
+			title = i
+			continue
+		case c.width > 0:
+			widths[i] = c.width
+		default:
+			for _, task := range tasks {
+				w := 0
+				for _, part := range l.cellParts(task, c, now) {
+					w += lipgloss.Width(part.text)
+				}
+				widths[i] = max(widths[i], min(w, maxColumnWidth))
+			}
+		}
+		used += widths[i]
+	}
+	if title >= 0 {
+		widths[title] = max(l.width-4-used, minTitleWidth)
+	}
+	return widths
+}
+
+// cellParts returns the content of a column other than the title for a
+// task; priority, severity and status are shown as their icon in a column
+// of 1 or 2 cells
+func (l *ListView) cellParts(task model.Task, c listColumn, now time.Time) []cellPart {
+	icon := c.width > 0 && c.width <= 2
+	muted := lipgloss.NewStyle().Foreground(colorOverlay1)
+	switch c.field {
+	case listFieldID:
+		var parts []cellPart
+		if id := task.ShortID(); id != "" {
+			parts = append(parts, cellPart{id, l.styles.ShortID})
+		}
+		if k := task.ExternalKey(); k != "" {
+			if len(parts) > 0 {
+				parts = append(parts, cellPart{" ", lipgloss.NewStyle()})
+			}
+			parts = append(parts, cellPart{k, l.styles.ExternalKey})
+		}
+		return parts
+	case listFieldPriority:
+		if icon {
+			return []cellPart{{PriorityIcon(task.Priority), l.styles.PriorityStyle(task.Priority)}}
+		}
+		return []cellPart{{task.Priority.Label(), l.styles.PriorityStyle(task.Priority)}}
+	case listFieldSeverity:
+		if icon {
+			return []cellPart{{SeverityIcon(task.Severity), l.styles.SeverityStyle(task.Severity)}}
+		}
+		if task.Severity == model.SeverityNone {
+			return nil
+		}
+		return []cellPart{{task.Severity.Label(), l.styles.SeverityStyle(task.Severity)}}
+	case listFieldStatus:
+		if icon {
+			return []cellPart{{StatusIcon(task.Status), l.styles.StatusStyle(task.Status)}}
+		}
+		return []cellPart{{task.Status.Label(), l.styles.StatusStyle(task.Status)}}
+	case listFieldTags:
+		var parts []cellPart
+		for i, tag := range task.Tags {
+			if i > 0 {
+				parts = append(parts, cellPart{" ", lipgloss.NewStyle()})
+			}
+			// Padded by the text, so that cutting the cell counts it
+			parts = append(parts, cellPart{" " + tag + " ", l.styles.TagStyle(tag).UnsetPadding()})
+		}
+		return parts
+	case listFieldDue:
+		if task.DueDate == nil {
+			return nil
+		}
+		style := muted
+		switch {
+		case task.IsOverdue(now):
+			style = lipgloss.NewStyle().Foreground(colorRed)
+		case task.IsDueOn(now):
+			style = lipgloss.NewStyle().Foreground(colorYellow)
+		}
+		return []cellPart{{task.DueDate.In(now.Location()).Format("2006-01-02"), style}}
+	case listFieldAge:
+		if task.CreatedAt.IsZero() {
+			return nil
+		}
+		return []cellPart{{shortDuration(now.Sub(task.CreatedAt)), muted}}
+	case listFieldSpent:
+		if d := task.TimeInProgress(now); d > 0 {
+			return []cellPart{{shortDuration(d), muted}}
+		}
+	}
+	return nil
+}
+
+// renderCell renders the parts of a cell, cut or padded to its width
+func renderCell(parts []cellPart, width int) string {
+	var b strings.Builder
+	room := width
+	for _, part := range parts {
+		if room <= 0 {
+			break
+		}
+		text := part.text
+		if lipgloss.Width(text) > room {
+			text = truncate(text, room)
+		}
+		room -= lipgloss.Width(text)
+		b.WriteString(part.style.Render(text))
+	}
+	return b.String() + strings.Repeat(" ", max(room, 0))
+}
+
+// shortDuration returns a duration in its largest unit, like "3 j"
+func shortDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return i18n.Tf("%d min", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return i18n.Tf("%d h", int(d.Hours()))
+	}
+	return i18n.Tf("%d j", int(d.Hours()/24))
+}
+
+// SetColumns sets the columns of the list
+func (l *ListView) SetColumns(columns []listColumn) {
+	l.columns = columns
+}
+
+// Columns returns the columns of the list, hidden ones included
+func (l *ListView) Columns() []listColumn {
+	return slices.Clone(l.columns)
+}
+
+// openListColumns opens the screen choosing the columns of the list
+func (a *App) openListColumns() {
+	a.columnCursor = 0
+	a.state = StateListColumns
+}
+
+// handleListColumnsKeys handles the columns screen: space shows or hides a
+// column, K/J move it, >/< change its width. Changes last until the app is
+// closed; list.columns in the config keeps them.
+func (a *App) handleListColumnsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	columns := a.listView.Columns()
+	c := &columns[a.columnCursor]
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, a.keys.Columns):
+		a.state = StateNormal
+		return a, nil
+	case key.Matches(msg, a.keys.MoveUp):
+		if a.columnCursor > 0 {
+			columns[a.columnCursor], columns[a.columnCursor-1] = columns[a.columnCursor-1], columns[a.columnCursor]
+			a.columnCursor--
+		}
+	case key.Matches(msg, a.keys.MoveDown):
+		if a.columnCursor < len(columns)-1 {
+			columns[a.columnCursor], columns[a.columnCursor+1] = columns[a.columnCursor+1], columns[a.columnCursor]
+			a.columnCursor++
+		}
+	case key.Matches(msg, a.keys.Up):
+		a.columnCursor = max(a.columnCursor-1, 0)
+	case key.Matches(msg, a.keys.Down):
+		a.columnCursor = min(a.columnCursor+1, len(columns)-1)
+	case msg.String() == " ", msg.String() == "x", key.Matches(msg, a.keys.Enter):
+		// The title is always shown
+		if c.field != listFieldTitle {
+			c.hidden = !c.hidden
+		}
+	case key.Matches(msg, a.keys.Widen):
+		if c.field != listFieldTitle {
+			c.width = min(c.width+1, maxColumnWidth)
+		}
+	case key.Matches(msg, a.keys.Narrow):
+		// Down to 0, fitted to the values
+		c.width = max(c.width-1, 0)
+	}
+	a.listView.SetColumns(columns)
+	return a, nil
+}
+
+// renderListColumns renders the columns of the list, the ones shown ticked,
+// with their width
+func (a *App) renderListColumns() string {
+	title := a.styles.DialogTitle.Render(i18n.T("Colonnes de la liste"))
+	textStyle := lipgloss.NewStyle().Foreground(colorText)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+	selectedStyle := lipgloss.NewStyle().Foreground(colorBlue).Bold(true)
+
+	columns := a.listView.Columns()
+	width := 0
+	for _, c := range columns {
+		width = max(width, lipgloss.Width(c.field.label()))
+	}
+
+	var lines []string
+	for i, c := range columns {
+		cursor := "  "
+		if i == a.columnCursor {
+			cursor = selectedStyle.Render("▸ ")
+		}
+		box, style := "[x] ", textStyle
+		if c.hidden {
+			box, style = "[ ] ", mutedStyle
+		}
+		size := i18n.T("auto")
+		switch {
+		case c.field == listFieldTitle:
+			size = i18n.T("reste de la ligne")
+		case c.width > 0 && c.width <= 2 && (c.field == listFieldPriority || c.field == listFieldSeverity || c.field == listFieldStatus):
+			size = i18n.T("icône")
+		case c.width > 0:
+			size = itoa(c.width)
+		}
+		label := c.field.label()
+		pad := strings.Repeat(" ", width-lipgloss.Width(label))
+		lines = append(lines, cursor+style.Render(box+label)+pad+"  "+mutedStyle.Render(size))
+	}
+
+	help := mutedStyle.Render(i18n.T("Espace: afficher │ K/J: déplacer │ >/<: largeur │ Esc: fermer"))
+	content := title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// markdownCache keeps the last rendered description, as the form is
+// rendered on every frame and glamour is slow
+var markdownCache struct {
+	text, out string
+	width     int
+}
+
+// renderMarkdown renders Markdown text wrapped to the given width. The text
+// is returned as is when it can't be rendered or the terminal has no colors.
+func renderMarkdown(text string, width int) string {
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		return text
+	}
+	if markdownCache.text == text && markdownCache.width == width && markdownCache.out != "" {
+		return markdownCache.out
+	}
+
+	// The dark style without its document margin, to fit in the form
+	style := styles.DarkStyleConfig
+	margin := uint(0)
+	style.Document.Margin = &margin
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStyles(style),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return text
+	}
+	out, err := renderer.Render(text)
+	if err != nil {
+		return text
+	}
+
+	// glamour pads every line to the wrap width
+	lines := strings.Split(strings.Trim(out, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	out = strings.Join(lines, "\n")
+
+	markdownCache.text, markdownCache.width, markdownCache.out = text, width, out
+	return out
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxNoteLines limits how many notes are shown, the most recent last
+const maxNoteLines = 6
+
+// renderNotes renders the last notes of the log, one per line with its
+// timestamp, truncated to width
+func renderNotes(notes []model.Note, width int) []string {
+	dateStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+	textStyle := lipgloss.NewStyle().Foreground(colorSubtext1)
+
+	var lines []string
+	if hidden := len(notes) - maxNoteLines; hidden > 0 {
+		lines = append(lines, dateStyle.Render(i18n.N(hidden, "… %d note plus ancienne", "… %d notes plus anciennes")))
+		notes = notes[hidden:]
+	}
+	for _, n := range notes {
+		date := n.At.Local().Format("2006-01-02 15:04")
+		text := truncate(n.Text, width-len(date)-2)
+		lines = append(lines, dateStyle.Render(date)+"  "+textStyle.Render(text))
+	}
+	return lines
+}
@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// overviewEntry is a task of the overview, with the board it belongs to
+type overviewEntry struct {
+	board int
+	task  model.Task
+}
+
+// Overview lists the urgent tasks of every board: overdue, due today or
+// at the highest priority. With a search query, it lists the tasks of
+// every board matching it instead.
+type Overview struct {
+	entries   []overviewEntry
+	names     []string // board names, for the badges
+	cursor    int
+	offset    int
+	search    textinput.Model
+	searching bool // the search input has the focus
+	styles    Styles
+	width     int
+	height    int
+}
+
+// NewOverview creates a new overview of the given boards
+func NewOverview(styles Styles, names []string) *Overview {
+	search := textinput.New()
+	search.Placeholder = i18n.T("Rechercher dans tous les tableaux...")
+	search.CharLimit = 50
+	return &Overview{styles: styles, names: names, search: search}
+}
+
+// SetReducedMotion stops the cursor of the search input from blinking
+func (o *Overview) SetReducedMotion() {
+	staticCursor(&o.search)
+}
+
+// StartSearch focuses the search input
+func (o *Overview) StartSearch() tea.Cmd {
+	o.searching = true
+	return o.search.Focus()
+}
+
+// IsSearching returns true if the search input has the focus
+func (o *Overview) IsSearching() bool {
+	return o.searching
+}
+
+// HandleSearchKey edits the query: enter keeps the results, esc clears it
+func (o *Overview) HandleSearchKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		o.search.SetValue("")
+		fallthrough
+	case "enter":
+		o.searching = false
+		o.search.Blur()
+		o.cursor = 0
+		return nil
+	}
+	var cmd tea.Cmd
+	o.search, cmd = o.search.Update(msg)
+	o.cursor = 0
+	return cmd
+}
+
+// UpdateSearch forwards other messages, like cursor blinks, to the input
+func (o *Overview) UpdateSearch(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	o.search, cmd = o.search.Update(msg)
+	return cmd
+}
+
+// SetSize sets the view dimensions
+func (o *Overview) SetSize(width, height int) {
+	o.width = width
+	o.height = height
+}
+
+// SetTasks collects the urgent tasks of each board, overdue first, then
+// due today, then by priority; or, when searching, the matching tasks in
+// the order of the boards. The selection follows the selected task.
+func (o *Overview) SetTasks(boards [][]model.Task, now time.Time) {
+	var selected *overviewEntry
+	if e := o.Selected(); e != nil {
+		selected = &overviewEntry{e.board, e.task}
+	}
+
+	query := model.ParseQuery(o.search.Value())
+	o.entries = o.entries[:0]
+	for board, tasks := range boards {
+		for _, t := range tasks {
+			if query.IsEmpty() && t.IsUrgent(now) || !query.IsEmpty() && query.Matches(t) {
+				o.entries = append(o.entries, overviewEntry{board, t})
+			}
+		}
+	}
+	o.cursor = max(min(o.cursor, len(o.entries)-1), 0)
+	if !query.IsEmpty() {
+		o.reselect(selected)
+		return
+	}
+
+	sort.SliceStable(o.entries, func(i, j int) bool {
+		a, b := o.entries[i].task, o.entries[j].task
+		if ra, rb := dueRank(a, now), dueRank(b, now); ra != rb {
+			return ra < rb
+		}
+		if a.DueDate != nil && b.DueDate != nil && !a.DueDate.Equal(*b.DueDate) {
+			return a.DueDate.Before(*b.DueDate)
+		}
+		return a.Priority.Weight() > b.Priority.Weight()
+	})
+	o.reselect(selected)
+}
+
+// reselect moves the cursor to the previously selected task, if still listed
+func (o *Overview) reselect(selected *overviewEntry) {
+	if selected == nil {
+		return
+	}
+	for i, e := range o.entries {
+		if e.board == selected.board && e.task.ID == selected.task.ID {
+			o.cursor = i
+			return
+		}
+	}
+}
+
+// dueRank orders overdue tasks first, then tasks due today, then the others
+func dueRank(t model.Task, now time.Time) int {
+	switch {
+	case t.IsOverdue(now):
+		return 0
+	case t.IsDueOn(now):
+		return 1
+	}
+	return 2
+}
+
+// MoveUp selects the previous task
+func (o *Overview) MoveUp() {
+	if o.cursor > 0 {
+		o.cursor--
+	}
+}
+
+// MoveDown selects the next task
+func (o *Overview) MoveDown() {
+	if o.cursor < len(o.entries)-1 {
+		o.cursor++
+	}
+}
+
+// Selected returns the selected entry, or nil if the overview is empty
+func (o *Overview) Selected() *overviewEntry {
+	if o.cursor < 0 || o.cursor >= len(o.entries) {
+		return nil
+	}
+	return &o.entries[o.cursor]
+}
+
+// Render renders the title, the tasks with their board badge, and the footer
+func (o *Overview) Render(now time.Time) string {
+	titleStyle := lipgloss.NewStyle().Foreground(colorMauve).Bold(true)
+	title := i18n.N(len(o.entries), "Vue d'ensemble: %d tâche urgente", "Vue d'ensemble: %d tâches urgentes")
+	empty := i18n.T("Rien d'urgent: aucune tâche en retard, pour aujourd'hui ou de priorité maximale")
+	if o.search.Value() != "" {
+		title = i18n.N(len(o.entries), "Recherche dans tous les tableaux: %d résultat", "Recherche dans tous les tableaux: %d résultats")
+		empty = i18n.T("Aucune tâche ne correspond")
+	}
+	header := o.styles.Header.Width(o.width).Render(
+		o.styles.HeaderTitle.Render("lazy-todo") + "  " + titleStyle.Render(title))
+
+	height := max(o.height-4, 1)
+	var lines []string
+	if o.searching || o.search.Value() != "" {
+		bar := o.styles.FormInputFocus.Render("/ " + o.search.View())
+		lines = append(lines, bar)
+		height = max(height-lipgloss.Height(bar), 1)
+	}
+	if len(o.entries) == 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(colorOverlay0).
+			Italic(true).
+			Padding(1, 2).
+			Render(empty))
+	} else {
+		o.offset = scrollOffset(o.offset, o.cursor, height, len(o.entries))
+		end := min(o.offset+height, len(o.entries))
+		for i := o.offset; i < end; i++ {
+			lines = append(lines, o.renderEntry(o.entries[i], i == o.cursor, now))
+		}
+	}
+
+	content := lipgloss.NewStyle().Height(height).Width(o.width).Render(strings.Join(lines, "\n"))
+	return header + "\n" + content + "\n" + o.renderFooter()
+}
+
+// renderEntry renders a task line with the badge of its board and when it
+// is due
+func (o *Overview) renderEntry(e overviewEntry, selected bool, now time.Time) string {
+	badge := lipgloss.NewStyle().
+		Foreground(colorCrust).
+		Background(colorBlue).
+		Padding(0, 1).
+		Render(o.names[e.board])
+
+	var due string
+	switch {
+	case e.task.IsOverdue(now):
+		due = lipgloss.NewStyle().Foreground(colorRed).Render(i18n.T("en retard"))
+	case e.task.IsDueOn(now):
+		due = lipgloss.NewStyle().Foreground(colorYellow).Render(i18n.T("aujourd'hui"))
+	}
+
+	left := o.styles.PriorityStyle(e.task.Priority).Render(PriorityIcon(e.task.Priority)) + " " +
+		o.styles.StatusStyle(e.task.Status).Render(StatusIcon(e.task.Status)) + " " +
+		badge + " "
+	title := truncate(e.task.Title, max(o.width-lipgloss.Width(left)-lipgloss.Width(due)-6, 10))
+	gap := max(o.width-lipgloss.Width(left)-lipgloss.Width(title)-lipgloss.Width(due)-4, 1)
+	line := left + title + strings.Repeat(" ", gap) + due
+
+	if selected {
+		return o.styles.ListItemSelected.Width(o.width).Render(line)
+	}
+	return o.styles.ListItem.Width(o.width).Render(line)
+}
+
+// renderFooter renders the keys of the overview
+func (o *Overview) renderFooter() string {
+	var items []string
+	for _, item := range [][2]string{
+		{"j/k", "nav"},
+		{"Enter", "ouvrir"},
+		{"1-4", "état"},
+		{"/", "rechercher partout"},
+		{"gt/gT", "tableaux"},
+		{"q", "quitter"},
+	} {
+		items = append(items, o.styles.HelpKey.Render(item[0])+o.styles.HelpSep.Render(":")+o.styles.HelpValue.Render(i18n.T(item[1])))
+	}
+	return o.styles.Footer.Render(strings.Join(items, o.styles.HelpSep.Render(" │ ")))
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderTaskList renders tasks grouped by status, for the list command:
+// a colored, read-only version of the list view without selection
+func RenderTaskList(tasks []model.Task, width int, now time.Time) string {
+	styles := DefaultStyles()
+	width = max(width, 40)
+
+	var sections []string
+	for _, status := range model.AllStatuses() {
+		var lines []string
+		for _, t := range tasks {
+			if t.Status == status {
+				lines = append(lines, renderPlainTask(styles, t, width, now))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		header := styles.StatusStyle(status).Bold(true).
+			Render(StatusIcon(status) + " " + status.Label() + " (" + itoa(len(lines)) + ")")
+		sections = append(sections, header+"\n"+strings.Join(lines, "\n"))
+	}
+
+	if len(sections) == 0 {
+		return lipgloss.NewStyle().Foreground(colorOverlay0).Italic(true).Render(i18n.T("Aucune tâche")) + "\n"
+	}
+	return strings.Join(sections, "\n\n") + "\n"
+}
+
+// renderPlainTask renders a task line: priority, short ID, title, tags and
+// due date
+func renderPlainTask(styles Styles, t model.Task, width int, now time.Time) string {
+	var due string
+	switch {
+	case t.IsOverdue(now):
+		due = lipgloss.NewStyle().Foreground(colorRed).Render(i18n.T("en retard"))
+	case t.IsDueOn(now):
+		due = lipgloss.NewStyle().Foreground(colorYellow).Render(i18n.T("aujourd'hui"))
+	case t.DueDate != nil:
+		due = lipgloss.NewStyle().Foreground(colorOverlay1).Render(t.DueDate.In(now.Location()).Format("2006-01-02"))
+	}
+
+	var tags string
+	for _, tag := range t.Tags {
+		tags += " " + lipgloss.NewStyle().Foreground(colorMauve).Render("#"+tag)
+	}
+
+	left := "  " + styles.PriorityStyle(t.Priority).Render(PriorityIcon(t.Priority)) + " "
+	if id := t.ShortID(); id != "" {
+		left += styles.ShortID.Render(id) + " "
+	}
+	room := width - lipgloss.Width(left) - lipgloss.Width(tags) - lipgloss.Width(due) - 2
+	title := truncate(t.Title, max(room, 10))
+	if t.Status.IsDone() {
+		title = lipgloss.NewStyle().Foreground(colorOverlay0).Strikethrough(true).Render(title)
+	}
+
+	line := left + title + tags
+	if due != "" {
+		line += strings.Repeat(" ", max(width-lipgloss.Width(line)-lipgloss.Width(due), 1)) + due
+	}
+	return line
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"lazy-todo/internal/i18n"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// checkProblems opens the problems screen when the tasks just loaded had
+// to be repaired, once for each set of problems: the repairs are only
+// saved with the next change, so reloading the file finds them again
+func (a *App) checkProblems() {
+	problems := a.storage.Problems()
+	if slices.Equal(problems, a.problems) {
+		return
+	}
+	a.problems = problems
+	if len(problems) > 0 && a.state == StateNormal {
+		a.state = StateProblems
+	}
+}
+
+// handleProblemsKeys closes the problems screen
+func (a *App) handleProblemsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "enter":
+		a.state = StateNormal
+	}
+	return a, nil
+}
+
+// renderProblems renders the problems of the tasks file and how they were
+// repaired, one line per problem under the task it's about
+func (a *App) renderProblems() string {
+	title := a.styles.DialogTitle.Render(i18n.T("Problèmes du fichier de tâches"))
+	taskStyle := lipgloss.NewStyle().Foreground(colorText).Bold(true)
+	problemStyle := lipgloss.NewStyle().Foreground(colorYellow)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+
+	// Room for the title, help and borders of the dialog
+	room := max(a.height-12, 3)
+	var lines []string
+	for i, p := range a.problems {
+		if len(lines) >= room {
+			lines = append(lines, mutedStyle.Render(i18n.N(len(a.problems)-i, "… %d problème de plus", "… %d problèmes de plus")))
+			break
+		}
+		if i == 0 || p.Index != a.problems[i-1].Index {
+			lines = append(lines, taskStyle.Render(i18n.Tf("Tâche %d: %s", p.Index+1, truncate(p.Title, 50))))
+		}
+		lines = append(lines, "  "+problemStyle.Render(p.String()))
+	}
+
+	help := mutedStyle.Render(i18n.T("Les réparations seront enregistrées à la prochaine modification"))
+	help += "\n" + mutedStyle.Render(i18n.T("Esc: continuer"))
+
+	content := title + "\n\n" + strings.Join(lines, "\n") + "\n\n" + help
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
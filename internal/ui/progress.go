@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// progressBarWidth is the width of the progress bar of the header, in cells
+const progressBarWidth = 10
+
+// progress counts the done tasks among some
+type progress struct {
+	done, total int
+}
+
+// add counts a task
+func (p *progress) add(task model.Task) {
+	p.total++
+	if task.Status.IsDone() {
+		p.done++
+	}
+}
+
+// percent returns the share of done tasks, rounded down
+func (p progress) percent() int {
+	if p.total == 0 {
+		return 0
+	}
+	return p.done * 100 / p.total
+}
+
+// countProgress counts the done tasks among the filtered ones, in all and
+// by priority, for the header
+func (l *ListView) countProgress() {
+	l.progress = progress{}
+	l.byPriority = map[model.Priority]progress{}
+	for _, i := range l.filtered {
+		task := l.tasks[i]
+		l.progress.add(task)
+		p := l.byPriority[task.Priority]
+		p.add(task)
+		l.byPriority[task.Priority] = p
+	}
+}
+
+// Progress returns the done tasks among the ones matching the search, in
+// all and by priority
+func (l *ListView) Progress() (progress, map[model.Priority]progress) {
+	return l.progress, l.byPriority
+}
+
+// renderProgressBar renders a bar filled with the share of done tasks
+func renderProgressBar(p progress, width int) string {
+	filled := 0
+	if p.total > 0 {
+		filled = p.done * width / p.total
+	}
+	return lipgloss.NewStyle().Foreground(colorGreen).Render(strings.Repeat("▰", filled)) +
+		lipgloss.NewStyle().Foreground(colorSurface2).Render(strings.Repeat("▱", width-filled))
+}
+
+// renderProgress renders the progress of the tasks of the search for the
+// header, like "▰▰▰▱▱▱▱▱▱▱ 12/40 30%"; empty when there are none
+func (a *App) renderProgress() string {
+	p, _ := a.listView.Progress()
+	if p.total == 0 {
+		return ""
+	}
+	text := " " + itoa(p.done) + "/" + itoa(p.total) + " " + itoa(p.percent()) + "%"
+	return renderProgressBar(p, progressBarWidth) +
+		lipgloss.NewStyle().Foreground(colorSubtext0).Render(text)
+}
+
+// renderProgressBreakdown renders the progress of each priority, highest
+// first, on the line under the header
+func (a *App) renderProgressBreakdown() string {
+	_, byPriority := a.listView.Progress()
+	var parts []string
+	priorities := model.AllPriorities()
+	// Values that are not configured levels go last
+	for p := range byPriority {
+		if !slices.Contains(priorities, p) {
+			priorities = append([]model.Priority{p}, priorities...)
+		}
+	}
+	for _, priority := range slices.Backward(priorities) {
+		p, ok := byPriority[priority]
+		if !ok {
+			continue
+		}
+		label := a.styles.PriorityStyle(priority).Render(PriorityIcon(priority) + " " + priority.Label())
+		parts = append(parts, label+" "+renderProgressBar(p, 5)+
+			lipgloss.NewStyle().Foreground(colorSubtext0).Render(" "+itoa(p.done)+"/"+itoa(p.total)))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(colorOverlay0).Render(i18n.T("Aucune tâche")))
+	}
+	line := strings.Join(parts, "   ")
+	if lipgloss.Width(line) > a.width-2 {
+		line = truncate(line, a.width-2)
+	}
+	return lipgloss.NewStyle().Padding(0, 1).Width(a.width).Render(line)
+}
+
+// contentHeight returns the height of the task views, between the header,
+// with the progress by priority when expanded, and the footer
+func (a *App) contentHeight() int {
+	if a.progressExpanded {
+		return a.height - 5
+	}
+	return a.height - 4
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"slices"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/storage"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// restoreCandidate is a backup offered to replace a corrupted tasks file
+type restoreCandidate struct {
+	name string
+	at   time.Time
+	data []byte
+}
+
+type restoreFoundMsg struct{ candidate *restoreCandidate }
+
+// offerRestore shows the corruption dialog and looks for the most recent
+// valid backup: the remote ones, and the copy kept by the last restore
+func (a *App) offerRestore() tea.Cmd {
+	a.state = StateConfirmRestore
+	a.restore = nil
+	a.restoreSearched = false
+
+	return func() tea.Msg {
+		var best *restoreCandidate
+		if a.backups != nil {
+			if name, at, data, err := a.backups.LatestValid(); err == nil {
+				best = &restoreCandidate{name, at, data}
+			}
+		}
+		if data, at, err := a.storage.ReadLocalBackup(); err == nil {
+			if n, err := storage.Validate(data); err == nil && n > 0 && (best == nil || at.After(best.at)) {
+				best = &restoreCandidate{a.storage.GetFilePath() + ".bak", at, data}
+			}
+		}
+		return restoreFoundMsg{best}
+	}
+}
+
+// handleRestoreConfirmKeys handles keys in the corruption dialog: r
+// restores the backup, e opens the file in the editor at the error, g
+// writes back the tasks last read, esc leaves it as is
+func (a *App) handleRestoreConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r", "R":
+		if a.restore == nil {
+			return a, nil
+		}
+		data := a.restore.data
+		return a, func() tea.Msg {
+			if err := a.storage.WriteRaw(data); err != nil {
+				return errMsg{err}
+			}
+			return a.loadTasks()
+		}
+	case "e", "E":
+		return a, a.editAtError()
+	case "g", "G", "k", "K":
+		if len(a.stored) == 0 {
+			return a, nil
+		}
+		tasks := slices.Clone(a.stored)
+		return a, func() tea.Msg {
+			if err := a.storage.Overwrite(tasks); err != nil {
+				return errMsg{err}
+			}
+			return a.loadTasks()
+		}
+	case "esc", "i", "I":
+		// Saving stays refused until the file is fixed
+		a.state = StateNormal
+	}
+	return a, nil
+}
+
+// editAtError opens the tasks file in the editor at the line of the parse
+// error, when known. Closing it reloads the file, updating the dialog if
+// it's still corrupted.
+func (a *App) editAtError() tea.Cmd {
+	cmd, err := a.storage.EditCommand(storage.ErrorLine(a.err))
+	if err != nil {
+		return func() tea.Msg { return errMsg{err} }
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg { return editorClosedMsg{err} })
+}
+
+// renderRestoreConfirm renders the dialog shown when the tasks file is
+// corrupted
+func (a *App) renderRestoreConfirm() string {
+	title := a.styles.DialogTitle.Render(i18n.T("Fichier de tâches corrompu"))
+	textStyle := lipgloss.NewStyle().Foreground(colorText).Width(min(70, a.width-10))
+	hint := lipgloss.NewStyle().Foreground(colorOverlay0).Italic(true)
+
+	detail := ""
+	if a.err != nil {
+		if line := storage.ErrorLine(a.err); line > 0 {
+			detail = lipgloss.NewStyle().Foreground(colorRed).Bold(true).Render(i18n.Tf("Erreur à la ligne %d", line)) + "\n"
+		}
+		detail += hint.Render(a.err.Error()) + "\n\n"
+	}
+	text := i18n.T("Le fichier n'a pas pu être lu et n'a pas été modifié. Les modifications sont refusées tant qu'il n'est pas réparé ou restauré.")
+	if len(a.stored) > 0 {
+		text += " " + i18n.N(len(a.stored), "La dernière version lue (%d tâche) reste affichée.", "La dernière version lue (%d tâches) reste affichée.")
+	}
+
+	var offer, buttons string
+	switch {
+	case !a.restoreSearched:
+		offer = hint.Render(i18n.T("Recherche d'une sauvegarde..."))
+	case a.restore == nil:
+		offer = i18n.T("Aucune sauvegarde valide trouvée.")
+	default:
+		offer = i18n.Tf("Sauvegarde la plus récente: %s (%s)", a.restore.name, a.restore.at.Local().Format("2006-01-02 15:04"))
+		buttons = a.styles.FormButtonFocus.Render(i18n.T("(R)estaurer")) + "  "
+	}
+	if len(a.stored) > 0 {
+		buttons += a.styles.FormButton.Render(i18n.T("(G)arder la version affichée")) + "  "
+	}
+	edit := i18n.T("(E)diter le fichier")
+	if line := storage.ErrorLine(a.err); line > 0 {
+		edit = i18n.Tf("(E)diter à la ligne %d", line)
+	}
+	buttons += a.styles.FormButton.Render(edit) + "  " +
+		a.styles.FormButton.Render(i18n.T("(I)gnorer"))
+
+	content := title + "\n\n" + detail + textStyle.Render(text) + "\n\n" + textStyle.Render(offer) + "\n\n" + buttons
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
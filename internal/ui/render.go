@@ -0,0 +1,36 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// viewModes names the views for the command line
+var viewModes = map[string]ViewMode{
+	"list":     ViewList,
+	"kanban":   ViewKanban,
+	"calendar": ViewCalendar,
+	"today":    ViewToday,
+}
+
+// ParseViewMode returns the view of a name: list, kanban, calendar or today
+func ParseViewMode(name string) (ViewMode, bool) {
+	mode, ok := viewModes[name]
+	return mode, ok
+}
+
+// Frame returns a single frame of the boards at a terminal size, their
+// tasks loaded and shown in a view, without running the program: for
+// documentation screenshots and snapshots of the views (--render-once).
+// The commands that would follow, like saves, are dropped.
+func (b *Boards) Frame(width, height int, mode ViewMode) (string, error) {
+	b.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	for _, app := range b.apps {
+		tasks, err := app.storage.Load()
+		if err != nil {
+			return "", err
+		}
+		app.Update(tasksLoadedMsg{tasks})
+		app.setViewMode(mode)
+	}
+	return b.View(), nil
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"time"
+
+	"lazy-todo/internal/export"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/share"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type sharedMsg struct {
+	link string
+	err  error
+}
+
+// openShare opens the share prompt
+func (a *App) openShare() {
+	a.sharing = false
+	a.shareLink = ""
+	a.shareErr = nil
+	a.state = StateShare
+}
+
+// handleShareKeys handles the share prompt. Lowercase keys share the
+// current list, uppercase keys the selected task.
+func (a *App) handleShareKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" || msg.String() == "q" {
+		a.state = StateNormal
+		return a, nil
+	}
+	if a.share == nil || a.sharing || a.shareLink != "" {
+		return a, nil
+	}
+
+	var tasks []model.Task
+	switch msg.String() {
+	case "m", "j":
+		tasks = a.listView.FilteredTasks()
+	case "M", "J":
+		if task := a.selectedTask(); task != nil {
+			tasks = []model.Task{*task}
+		}
+	default:
+		return a, nil
+	}
+	if len(tasks) == 0 {
+		return a, nil
+	}
+	f := export.FormatMarkdown
+	if msg.String() == "j" || msg.String() == "J" {
+		f = export.FormatJSON
+	}
+	a.sharing = true
+	a.shareErr = nil
+	return a, a.shareTasks(f, tasks)
+}
+
+// shareTasks uploads tasks to the paste service
+func (a *App) shareTasks(f export.Format, tasks []model.Task) tea.Cmd {
+	description := a.config.Share.Description
+	uploader := a.share
+	return func() tea.Msg {
+		data, err := share.Render(f, tasks, description)
+		if err != nil {
+			return sharedMsg{err: err}
+		}
+		name := "lazy-todo-" + time.Now().Format("20060102-150405") + f.Extension()
+		link, err := uploader.Upload(name, data)
+		return sharedMsg{link, err}
+	}
+}
+
+// shared shows the link of the shared tasks and copies it
+func (a *App) shared(msg sharedMsg) tea.Cmd {
+	a.sharing = false
+	if msg.err != nil {
+		a.shareErr = msg.err
+		return nil
+	}
+	a.shareLink = msg.link
+	return copyToClipboard(msg.link)
+}
+
+// renderSharePrompt renders the share prompt, then the link
+func (a *App) renderSharePrompt() string {
+	title := a.styles.DialogTitle.Render(i18n.T("Partager par lien"))
+	textStyle := lipgloss.NewStyle().Foreground(colorText)
+	errStyle := lipgloss.NewStyle().Foreground(colorRed)
+
+	var text string
+	switch {
+	case a.share == nil:
+		text = errStyle.Render(i18n.T(share.ErrNoURL.Error()))
+	case a.sharing:
+		text = textStyle.Render(i18n.T("Envoi..."))
+	case a.shareLink != "":
+		text = textStyle.Render(i18n.T("Lien copié dans le presse-papiers:")) + "\n" +
+			lipgloss.NewStyle().Foreground(colorSapphire).Render(a.shareLink)
+	default:
+		text = textStyle.Render(i18n.Tf("Liste filtrée (%d):  (m)arkdown  (j)son\nTâche sélectionnée:  (M)arkdown  (J)son",
+			len(a.listView.FilteredTasks())))
+		if !a.config.Share.Description {
+			text += "\n\n" + lipgloss.NewStyle().
+				Foreground(colorOverlay0).
+				Render(i18n.T("Sans identifiants, notes, rappels ni descriptions"))
+		}
+		if a.shareErr != nil {
+			text += "\n\n" + errStyle.Render(i18n.T("Erreur: ")+i18n.T(a.shareErr.Error()))
+		}
+	}
+
+	help := lipgloss.NewStyle().
+		Foreground(colorOverlay0).
+		Render(i18n.T("Esc: fermer"))
+
+	content := title + "\n\n" + text + "\n\n" + help
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sprintReviewShown is how many unfinished tasks the end-of-sprint summary
+// lists
+const sprintReviewShown = 8
+
+// sprintReview is the summary of a sprint that ended with unfinished tasks
+type sprintReview struct {
+	sprint     model.Sprint
+	done       int
+	unfinished []model.Task
+	// Sprint the unfinished tasks roll forward to, none when there is no
+	// later sprint
+	next    model.Sprint
+	hasNext bool
+}
+
+// renderSprintInfo renders the countdown of the current sprint with its
+// progress, or the start of the next one, for the header
+func renderSprintInfo(tasks []model.Task, now time.Time) string {
+	style := lipgloss.NewStyle().Foreground(colorTeal)
+	sprint, ok := model.CurrentSprint(now)
+	if !ok {
+		next, ok := model.NextSprint(now)
+		if !ok {
+			return ""
+		}
+		return style.Render(" ◷ " + i18n.Tf("%s dans %d j", next.Name, next.DaysUntil(now)))
+	}
+
+	done, total := 0, 0
+	for _, t := range tasks {
+		if t.InSprint(sprint.Name) {
+			total++
+			if t.Status.IsDone() {
+				done++
+			}
+		}
+	}
+	days := sprint.DaysLeft(now)
+	if days <= 1 {
+		style = style.Foreground(colorPeach).Bold(true)
+	}
+	return style.Render(" ◷ " + i18n.Tf("%s · %d j · %d/%d", sprint.Name, days, done, total))
+}
+
+// sprintBadge renders the sprint of a task
+func sprintBadge(s Styles, task model.Task) string {
+	if task.Sprint == "" {
+		return ""
+	}
+	return s.Sprint.Render("◷ " + task.Sprint)
+}
+
+// cycleSprint plans a task in the current sprint, then the next one, then
+// none
+func (a *App) cycleSprint(task model.Task) tea.Cmd {
+	now := time.Now()
+	var choices []string
+	if s, ok := model.CurrentSprint(now); ok {
+		choices = append(choices, s.Name)
+	}
+	if s, ok := model.NextSprint(now); ok {
+		choices = append(choices, s.Name)
+	}
+	if len(choices) == 0 {
+		a.setMessage(i18n.T("Aucun sprint en cours ou à venir (sprints dans la configuration)"))
+		return nil
+	}
+	choices = append(choices, "")
+
+	next := choices[0]
+	for i, name := range choices[:len(choices)-1] {
+		if task.InSprint(name) {
+			next = choices[i+1]
+		}
+	}
+	task.Sprint = next
+	return a.updateTask(task)
+}
+
+// checkSprintReview offers the end-of-sprint summary when the last sprint
+// that ended still has unfinished tasks, once per session and sprint
+func (a *App) checkSprintReview() {
+	if a.state != StateNormal {
+		return
+	}
+	now := time.Now()
+	sprint, ok := model.LastEndedSprint(now)
+	if !ok || a.sprintReviewed[sprint.Name] {
+		return
+	}
+
+	review := sprintReview{sprint: sprint}
+	for _, t := range a.tasks {
+		if !t.InSprint(sprint.Name) {
+			continue
+		}
+		if t.Status.IsDone() {
+			review.done++
+		} else {
+			review.unfinished = append(review.unfinished, t)
+		}
+	}
+	if len(review.unfinished) == 0 {
+		return
+	}
+	review.next, review.hasNext = model.SprintAfter(sprint.Name)
+	a.sprintReview = &review
+	a.state = StateSprintReview
+}
+
+// handleSprintReviewKeys handles the end-of-sprint summary: y rolls the
+// unfinished tasks forward, n keeps them
+func (a *App) handleSprintReviewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	review := a.sprintReview
+	switch msg.String() {
+	case "y", "Y", "enter":
+		a.closeSprintReview()
+		var changes storage.Changes
+		for _, t := range review.unfinished {
+			t.Sprint = ""
+			if review.hasNext {
+				t.Sprint = review.next.Name
+			}
+			changes.Updated = append(changes.Updated, t)
+		}
+		return a, a.commit(changes)
+	case "n", "N", "esc":
+		a.closeSprintReview()
+	}
+	return a, nil
+}
+
+// closeSprintReview closes the summary, which isn't offered again for that
+// sprint until restarted
+func (a *App) closeSprintReview() {
+	a.sprintReviewed[a.sprintReview.sprint.Name] = true
+	a.sprintReview = nil
+	a.state = StateNormal
+}
+
+// renderSprintReview renders the end-of-sprint summary
+func (a *App) renderSprintReview() string {
+	review := a.sprintReview
+	total := review.done + len(review.unfinished)
+	title := a.styles.DialogTitle.Render(i18n.Tf("Fin du sprint « %s »", review.sprint.Name))
+	textStyle := lipgloss.NewStyle().Foreground(colorText)
+
+	lines := []string{
+		textStyle.Render(i18n.Nf(review.done, "%d/%d tâche terminée", "%d/%d tâches terminées", review.done, total) + ", " +
+			i18n.N(len(review.unfinished), "%d non terminée:", "%d non terminées:")),
+	}
+	for i, t := range review.unfinished {
+		if i == sprintReviewShown {
+			lines = append(lines, textStyle.Render(i18n.N(len(review.unfinished)-i, "  … et %d autre", "  … et %d autres")))
+			break
+		}
+		lines = append(lines, textStyle.Render("  "+StatusIcon(t.Status)+" "+truncate(t.Title, 50)))
+	}
+
+	question := i18n.T("Les remettre sans sprint?")
+	if review.hasNext {
+		question = i18n.Tf("Les reporter au sprint « %s »?", review.next.Name)
+	}
+
+	buttons := a.styles.FormButtonFocus.Render("(Y)es") + "  " +
+		a.styles.FormButton.Render("(N)o")
+
+	content := title + "\n\n" + strings.Join(lines, "\n") + "\n\n" +
+		lipgloss.NewStyle().Foreground(colorYellow).Render(question) + "\n\n" + buttons
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"strings"
+	"time"
+
 	"lazy-todo/internal/model"
 
 	"github.com/charmbracelet/lipgloss"
@@ -72,8 +75,28 @@ type Styles struct {
 	StatusBlocked    lipgloss.Style
 	StatusDone       lipgloss.Style
 
+	// Severity colors
+	SeverityCosmetic lipgloss.Style
+	SeverityMinor    lipgloss.Style
+	SeverityMajor    lipgloss.Style
+	SeverityCritical lipgloss.Style
+
+	// Stale in progress marker
+	Stale lipgloss.Style
+	// Title of an overdue task at a high priority
+	Escalated lipgloss.Style
+	// Sprint of a task
+	Sprint lipgloss.Style
+
 	// Tags
 	Tag lipgloss.Style
+	// Colors of the tags by lowercase name, shared by the views
+	TagColors map[string]lipgloss.Color
+
+	// Key of the issue a task was imported from (Jira, GitHub)
+	ExternalKey lipgloss.Style
+	// Short ID of a task (T-42)
+	ShortID lipgloss.Style
 
 	// Footer/Help
 	Footer    lipgloss.Style
@@ -96,6 +119,13 @@ type Styles struct {
 	Dialog      lipgloss.Style
 	DialogTitle lipgloss.Style
 
+	// YAML viewer
+	YAMLKey     lipgloss.Style
+	YAMLString  lipgloss.Style
+	YAMLScalar  lipgloss.Style
+	YAMLPunct   lipgloss.Style
+	YAMLComment lipgloss.Style
+
 	// Borders
 	Border lipgloss.Border
 }
@@ -203,11 +233,42 @@ func DefaultStyles() Styles {
 	s.StatusDone = lipgloss.NewStyle().
 		Foreground(colorGreen)
 
+	// Severities
+	s.SeverityCosmetic = lipgloss.NewStyle().
+		Foreground(colorOverlay1)
+
+	s.SeverityMinor = lipgloss.NewStyle().
+		Foreground(colorYellow)
+
+	s.SeverityMajor = lipgloss.NewStyle().
+		Foreground(colorMaroon)
+
+	s.SeverityCritical = lipgloss.NewStyle().
+		Foreground(colorRed).
+		Bold(true)
+
+	s.Stale = lipgloss.NewStyle().
+		Foreground(colorPeach)
+
+	s.Escalated = lipgloss.NewStyle().
+		Foreground(colorRed).
+		Bold(true)
+
+	s.Sprint = lipgloss.NewStyle().
+		Foreground(colorTeal)
+
 	// Tags
 	s.Tag = lipgloss.NewStyle().
 		Foreground(colorCrust).
 		Background(colorMauve).
 		Padding(0, 1)
+	s.TagColors = map[string]lipgloss.Color{}
+
+	s.ExternalKey = lipgloss.NewStyle().
+		Foreground(colorSapphire).
+		Bold(true)
+	s.ShortID = lipgloss.NewStyle().
+		Foreground(colorOverlay1)
 
 	// Footer
 	s.Footer = lipgloss.NewStyle().
@@ -272,13 +333,62 @@ func DefaultStyles() Styles {
 		Foreground(colorMauve).
 		Bold(true)
 
+	// YAML viewer
+	s.YAMLKey = lipgloss.NewStyle().
+		Foreground(colorBlue)
+
+	s.YAMLString = lipgloss.NewStyle().
+		Foreground(colorGreen)
+
+	s.YAMLScalar = lipgloss.NewStyle().
+		Foreground(colorPeach)
+
+	s.YAMLPunct = lipgloss.NewStyle().
+		Foreground(colorOverlay2)
+
+	s.YAMLComment = lipgloss.NewStyle().
+		Foreground(colorOverlay0).
+		Italic(true)
+
 	s.Border = lipgloss.RoundedBorder()
 
 	return s
 }
 
+// TagColor returns the color of a tag, or of its nearest ancestor that has
+// one (work/api takes the color of work)
+func (s Styles) TagColor(tag string) (lipgloss.Color, bool) {
+	tag = strings.ToLower(tag)
+	for {
+		if c, ok := s.TagColors[tag]; ok {
+			return c, true
+		}
+		i := strings.LastIndex(tag, "/")
+		if i < 0 {
+			return "", false
+		}
+		tag = tag[:i]
+	}
+}
+
+// TagStyle returns the style of a tag, on its color if it has one
+func (s Styles) TagStyle(tag string) lipgloss.Style {
+	if c, ok := s.TagColor(tag); ok {
+		return s.Tag.Background(c)
+	}
+	return s.Tag
+}
+
 // PriorityStyle returns the style for a given priority
 func (s Styles) PriorityStyle(p model.Priority) lipgloss.Style {
+	if color, ok := priorityColors[p]; ok {
+		style := lipgloss.NewStyle().Foreground(color)
+		// The highest level stays bold, like the built-in critical style
+		if levels := model.AllPriorities(); levels[len(levels)-1] == p {
+			style = style.Bold(true)
+		}
+		return style
+	}
 	switch p {
 	case model.PriorityLow:
 		return s.PriorityLow
@@ -295,7 +405,10 @@ func (s Styles) PriorityStyle(p model.Priority) lipgloss.Style {
 
 // StatusStyle returns the style for a given status
 func (s Styles) StatusStyle(st model.Status) lipgloss.Style {
-	switch st {
+	if color, ok := statusColors[st]; ok {
+		return lipgloss.NewStyle().Foreground(color)
+	}
+	switch st.Kind() {
 	case model.StatusTodo:
 		return s.StatusTodo
 	case model.StatusInProgress:
@@ -309,8 +422,97 @@ func (s Styles) StatusStyle(st model.Status) lipgloss.Style {
 	}
 }
 
+// SeverityStyle returns the style for a given severity
+func (s Styles) SeverityStyle(sev model.Severity) lipgloss.Style {
+	switch sev {
+	case model.SeverityCosmetic:
+		return s.SeverityCosmetic
+	case model.SeverityMinor:
+		return s.SeverityMinor
+	case model.SeverityMajor:
+		return s.SeverityMajor
+	case model.SeverityCritical:
+		return s.SeverityCritical
+	default:
+		return s.SeverityCosmetic
+	}
+}
+
+// SeverityIcon returns an icon for the severity (a space when unset)
+func SeverityIcon(sev model.Severity) string {
+	switch sev {
+	case model.SeverityCosmetic:
+		return "▿"
+	case model.SeverityMinor:
+		return "▵"
+	case model.SeverityMajor:
+		return "▲"
+	case model.SeverityCritical:
+		return "⚠"
+	default:
+		return " "
+	}
+}
+
+// staleMarker returns a warning marker for in progress tasks without
+// recent updates, or an empty string
+func staleMarker(s Styles, task model.Task, days int) string {
+	if !task.IsStale(days, time.Now()) {
+		return ""
+	}
+	return s.Stale.Render("⌛") + " "
+}
+
+// pinMarker returns the star of pinned tasks, sorted first
+func pinMarker(task model.Task) string {
+	if !task.Pinned {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(colorYellow).Render("★") + " "
+}
+
+// escalatedTitle renders the title of an overdue task at a high priority
+// in the escalated style, when escalation is enabled
+func escalatedTitle(s Styles, task model.Task, title string, enabled bool) string {
+	if !enabled || !task.IsEscalated(time.Now()) {
+		return title
+	}
+	return s.Escalated.Render(title)
+}
+
+// Icon and color overrides, keyed by stored value
+var (
+	priorityIcons  = map[model.Priority]string{}
+	priorityColors = map[model.Priority]lipgloss.Color{}
+	statusIcons    = map[model.Status]string{}
+	statusColors   = map[model.Status]lipgloss.Color{}
+)
+
+// SetPriorityColor overrides the color of a priority
+func SetPriorityColor(p model.Priority, color string) {
+	priorityColors[p] = lipgloss.Color(color)
+}
+
+// SetPriorityIcon overrides the icon of a priority
+func SetPriorityIcon(p model.Priority, icon string) {
+	priorityIcons[p] = icon
+}
+
+// SetStatusColor overrides the color of a status
+func SetStatusColor(s model.Status, color string) {
+	statusColors[s] = lipgloss.Color(color)
+}
+
+// SetStatusIcon overrides the icon of a status
+func SetStatusIcon(s model.Status, icon string) {
+	statusIcons[s] = icon
+}
+
 // PriorityIcon returns an icon for the priority
 func PriorityIcon(p model.Priority) string {
+	if icon, ok := priorityIcons[p]; ok {
+		return icon
+	}
 	switch p {
 	case model.PriorityLow:
 		return "○"
@@ -325,9 +527,13 @@ func PriorityIcon(p model.Priority) string {
 	}
 }
 
-// StatusIcon returns an icon for the status
+// StatusIcon returns an icon for the status, the icon of its kind for a
+// custom status
 func StatusIcon(s model.Status) string {
-	switch s {
+	if icon, ok := statusIcons[s]; ok {
+		return icon
+	}
+	switch s.Kind() {
 	case model.StatusTodo:
 		return "☐"
 	case model.StatusInProgress:
@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetLanes shows the groups of the board as swimlanes across the columns
+// rather than as headers within each
+func (k *KanbanView) SetLanes(enabled bool) {
+	ids := k.selectedIDs()
+	k.lanes = enabled
+	k.organizeItems()
+	k.reselect(ids)
+}
+
+// Lanes returns true when the groups are shown as swimlanes
+func (k *KanbanView) Lanes() bool {
+	return k.lanes
+}
+
+// inLanes returns true when the board is laid out in swimlanes: they are
+// on and the tasks grouped by priority or tag
+func (k *KanbanView) inLanes() bool {
+	return k.lanes && (k.groupBy == model.GroupByPriority || k.groupBy == model.GroupByTag)
+}
+
+// organizeLanes lists the swimlanes of the tasks of the visible columns,
+// in order, with their number of tasks
+func (k *KanbanView) organizeLanes() {
+	k.laneOrder, k.laneCounts = nil, nil
+	if !k.inLanes() {
+		return
+	}
+	var indices []int
+	for c, col := range k.columns {
+		if !k.hidden[c] {
+			indices = append(indices, col.tasks...)
+		}
+	}
+	order, groups := k.groupTasks(indices)
+	k.laneOrder = order
+	k.laneCounts = make(map[string]int, len(groups))
+	for lane, tasks := range groups {
+		k.laneCounts[lane] = len(tasks)
+	}
+}
+
+// alignLanes moves the items of the columns down so that each swimlane
+// starts on the same line in all of them, as tall as its tallest cell
+func (k *KanbanView) alignLanes() {
+	if !k.inLanes() || len(k.laneOrder) == 0 {
+		return
+	}
+
+	// Height of each lane, over the visible columns
+	heights := make([]int, len(k.laneOrder))
+	for c := range k.columns {
+		if k.hidden[c] {
+			continue
+		}
+		col := &k.columns[c]
+		headers := laneHeaders(col)
+		for l, h := range headers {
+			end := col.lines
+			if l+1 < len(headers) {
+				end = col.spans[headers[l+1]][0]
+			}
+			heights[l] = max(heights[l], end-col.spans[h][0])
+		}
+	}
+
+	total := 0
+	for _, h := range heights {
+		total += h
+	}
+	for c := range k.columns {
+		col := &k.columns[c]
+		headers := laneHeaders(col)
+		start := 0
+		for l, h := range headers {
+			end := len(col.items)
+			if l+1 < len(headers) {
+				end = headers[l+1]
+			}
+			delta := start - col.spans[h][0]
+			for i := h; i < end; i++ {
+				col.spans[i][0] += delta
+				col.spans[i][1] += delta
+			}
+			start += heights[l]
+		}
+		col.lines = total
+	}
+}
+
+// laneHeaders returns the items of a column that are swimlane headers,
+// one per lane
+func laneHeaders(col *KanbanColumn) []int {
+	var headers []int
+	for i, item := range col.items {
+		if item.isHeader {
+			headers = append(headers, i)
+		}
+	}
+	return headers
+}
+
+// laneOf returns the swimlane of an item of a column, -1 above the first
+func laneOf(col *KanbanColumn, item int) int {
+	lane := -1
+	for i := 0; i <= item && i < len(col.items); i++ {
+		if col.items[i].isHeader {
+			lane++
+		}
+	}
+	return lane
+}
+
+// laneTasks returns the items of the tasks of a swimlane in a column
+func laneTasks(col *KanbanColumn, lane int) []int {
+	var items []int
+	current := -1
+	for i, item := range col.items {
+		switch {
+		case item.isHeader:
+			current++
+		case current == lane:
+			items = append(items, i)
+		}
+	}
+	return items
+}
+
+// selectedLane returns the swimlane of the selected task, -1 when none is
+func (k *KanbanView) selectedLane() int {
+	if k.SelectedTask() == nil {
+		return -1
+	}
+	col := &k.columns[k.activeCol]
+	return laneOf(col, col.cursor)
+}
+
+// stepInLane activates the nearest visible column in the direction of dir
+// with a task in the swimlane of the selected one, on the task at the
+// same row of the lane or its last one. It returns false if there is
+// none, or outside of swimlanes.
+func (k *KanbanView) stepInLane(dir int) bool {
+	lane := k.selectedLane()
+	if !k.inLanes() || lane < 0 {
+		return false
+	}
+	row := 0
+	if items := laneTasks(&k.columns[k.activeCol], lane); len(items) > 0 {
+		row = max(k.columns[k.activeCol].cursor-items[0], 0)
+	}
+	for c := k.activeCol + dir; c >= 0 && c < len(k.columns); c += dir {
+		if k.hidden[c] {
+			continue
+		}
+		if items := laneTasks(&k.columns[c], lane); len(items) > 0 {
+			k.activeCol = c
+			k.columns[c].cursor = items[min(row, len(items)-1)]
+			return true
+		}
+	}
+	return false
+}
+
+// MoveLane selects the first task of the next (dir > 0) or previous
+// (dir < 0) swimlane with tasks, in the active column when it has some
+// there or else in the nearest visible column that does. It returns false
+// when there is no such lane, or outside of swimlanes.
+func (k *KanbanView) MoveLane(dir int) bool {
+	if !k.inLanes() {
+		return false
+	}
+	lane := k.selectedLane()
+	if lane < 0 && dir < 0 {
+		lane = len(k.laneOrder)
+	}
+	for l := lane + dir; l >= 0 && l < len(k.laneOrder); l += dir {
+		// The active column, then the others from the nearest
+		for dist := 0; dist < len(k.columns); dist++ {
+			for _, c := range []int{k.activeCol - dist, k.activeCol + dist} {
+				if c < 0 || c >= len(k.columns) || k.hidden[c] {
+					continue
+				}
+				if items := laneTasks(&k.columns[c], l); len(items) > 0 {
+					k.activeCol = c
+					k.columns[c].cursor = items[0]
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// renderLaneHeader renders the line of a swimlane header in a column: the
+// name of the lane and its number of tasks in the first visible column,
+// and a rule continuing it across the others
+func (k *KanbanView) renderLaneHeader(colIdx int, lane string) string {
+	width := k.widths[colIdx] - 2
+	style := lipgloss.NewStyle().Foreground(colorMauve).Bold(true)
+
+	first := 0
+	for first < len(k.hidden) && k.hidden[first] {
+		first++
+	}
+	label := ""
+	if colIdx == first {
+		label = truncate("━ "+lane+" ("+itoa(k.laneCounts[lane])+") ", width)
+	}
+	return style.Render(label + strings.Repeat("━", max(width-lipgloss.Width(label), 0)))
+}
+
+// toggleLanes shows the groups of the board as swimlanes or as headers in
+// the columns again. Swimlanes need a grouping by priority or tag, the
+// board is grouped by priority when it has neither.
+func (a *App) toggleLanes() {
+	k := a.kanbanView
+	if k.Lanes() {
+		k.SetLanes(false)
+		a.setMessage(i18n.T("Couloirs désactivés"))
+		return
+	}
+	if g := k.GetGroupBy(); g != model.GroupByPriority && g != model.GroupByTag {
+		k.SetGroupBy(model.GroupByPriority)
+	}
+	k.SetLanes(true)
+	a.setMessage(i18n.Tf("Couloirs par %s", strings.ToLower(k.GetGroupBy().Label())))
+}
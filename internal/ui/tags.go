@@ -0,0 +1,323 @@
+package ui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Actions of the tags screen waiting for a name or a confirmation
+const (
+	tagActionRename = "rename"
+	tagActionMerge  = "merge"
+	tagActionDelete = "delete"
+)
+
+// paletteColor is a color of the tag palette
+type paletteColor struct {
+	Name  string
+	Color lipgloss.Color
+}
+
+// tagPalette lists the colors c cycles through on the tags screen, by the
+// name saved in the tasks file
+var tagPalette = []paletteColor{
+	{"red", colorRed},
+	{"peach", colorPeach},
+	{"yellow", colorYellow},
+	{"green", colorGreen},
+	{"teal", colorTeal},
+	{"sapphire", colorSapphire},
+	{"blue", colorBlue},
+	{"mauve", colorMauve},
+	{"pink", colorPink},
+}
+
+// paletteValue returns the color of a palette name, or the color itself
+// when written in hex ("#f38ba8")
+func paletteValue(name string) (lipgloss.Color, bool) {
+	for _, p := range tagPalette {
+		if p.Name == name {
+			return p.Color, true
+		}
+	}
+	if strings.HasPrefix(name, "#") {
+		return lipgloss.Color(name), true
+	}
+	return "", false
+}
+
+// nextTagColor returns the palette color after a tag's, and "" to remove
+// it after the last one
+func nextTagColor(current string) string {
+	i := slices.IndexFunc(tagPalette, func(p paletteColor) bool { return p.Name == current })
+	if i == len(tagPalette)-1 {
+		return ""
+	}
+	return tagPalette[i+1].Name
+}
+
+// refreshTagColors replaces the tag colors shared by the views with the
+// ones of the configuration, then of the tasks file
+func (a *App) refreshTagColors() {
+	clear(a.styles.TagColors)
+	for _, colors := range []map[string]string{a.config.Display.TagColors, a.storage.TagColors()} {
+		for tag, name := range colors {
+			if c, ok := paletteValue(name); ok {
+				a.styles.TagColors[strings.ToLower(tag)] = c
+			}
+		}
+	}
+}
+
+// tagNames returns the tags of the tasks, sorted
+func (a *App) tagNames() []string {
+	names := slices.Collect(maps.Keys(model.TagCounts(a.tasks)))
+	slices.SortFunc(names, func(x, y string) int {
+		return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+	})
+	return names
+}
+
+// selectedTag returns the tag under the cursor of the tags screen
+func (a *App) selectedTag() string {
+	names := a.tagNames()
+	if len(names) == 0 {
+		return ""
+	}
+	a.tagCursor = min(a.tagCursor, len(names)-1)
+	return names[a.tagCursor]
+}
+
+// openTags opens the tags screen
+func (a *App) openTags() {
+	a.tagCursor = 0
+	a.tagAction = ""
+	a.state = StateTags
+}
+
+// handleTagsKeys handles the tags screen: j/k move, r renames the tag, m
+// merges it into another, d deletes it from all tasks and c cycles its
+// color
+func (a *App) handleTagsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.tagAction != "" {
+		return a.handleTagActionKeys(msg)
+	}
+
+	tag := a.selectedTag()
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, a.keys.Tags):
+		a.state = StateNormal
+	case key.Matches(msg, a.keys.Down):
+		if a.tagCursor < len(a.tagNames())-1 {
+			a.tagCursor++
+		}
+	case key.Matches(msg, a.keys.Up):
+		if a.tagCursor > 0 {
+			a.tagCursor--
+		}
+	case tag == "":
+	case msg.String() == "r", msg.String() == "m":
+		a.tagAction = tagActionRename
+		a.tagInput.SetValue(tag)
+		if msg.String() == "m" {
+			a.tagAction = tagActionMerge
+			a.tagInput.SetValue("")
+		}
+		a.tagInput.SetSuggestions(a.tagNames())
+		a.tagInput.CursorEnd()
+		a.tagInput.Focus()
+	case msg.String() == "d":
+		a.tagAction = tagActionDelete
+	case msg.String() == "c":
+		color := nextTagColor(a.storage.TagColors()[strings.ToLower(tag)])
+		return a, a.setTagColors(map[string]string{strings.ToLower(tag): color})
+	}
+	return a, nil
+}
+
+// handleTagActionKeys handles the name prompt of a rename or merge, and
+// the confirmation of a delete
+func (a *App) handleTagActionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tag := a.selectedTag()
+	if a.tagAction == tagActionDelete {
+		switch msg.String() {
+		case "y", "Y":
+			a.tagAction = ""
+			return a, a.deleteTag(tag)
+		case "n", "N", "esc":
+			a.tagAction = ""
+		}
+		return a, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		a.tagAction = ""
+		a.tagInput.Blur()
+		return a, nil
+	case "enter":
+		target := strings.TrimPrefix(strings.TrimSpace(a.tagInput.Value()), "#")
+		if target == "" || strings.EqualFold(target, tag) {
+			return a, nil
+		}
+		merge := a.tagAction == tagActionMerge
+		known := slices.ContainsFunc(a.tagNames(), func(t string) bool { return strings.EqualFold(t, target) })
+		if merge && !known {
+			a.setMessage(i18n.Tf("Tag inconnu: %s", target))
+			return a, nil
+		}
+		a.tagAction = ""
+		a.tagInput.Blur()
+		return a, a.renameTag(tag, target, merge)
+	}
+
+	var cmd tea.Cmd
+	a.tagInput, cmd = a.tagInput.Update(msg)
+	return a, cmd
+}
+
+// renameTag renames a tag and its descendants in all tasks. A renamed tag
+// keeps its color, a merged one takes the color of the tag it joins.
+func (a *App) renameTag(from, to string, merge bool) tea.Cmd {
+	var updated []model.Task
+	for _, t := range a.tasks {
+		if tags, ok := model.RenameTag(t.Tags, from, to); ok {
+			t.Tags = tags
+			updated = append(updated, t)
+		}
+	}
+	if merge {
+		a.setMessage(i18n.Nf(len(updated), "Tag %s fusionné dans %s (%d tâche)", "Tag %s fusionné dans %s (%d tâches)", from, to, len(updated)))
+	} else {
+		a.setMessage(i18n.Nf(len(updated), "Tag %s renommé en %s (%d tâche)", "Tag %s renommé en %s (%d tâches)", from, to, len(updated)))
+	}
+
+	colors := a.storage.TagColors()
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	moved := map[string]string{}
+	if color, ok := colors[from]; ok {
+		moved[from] = ""
+		if _, taken := colors[to]; !taken && !merge {
+			moved[to] = color
+		}
+	}
+	cmd := a.commit(storage.Changes{Updated: updated})
+	if len(moved) == 0 {
+		return cmd
+	}
+	return tea.Sequence(cmd, a.setTagColors(moved))
+}
+
+// deleteTag removes a tag from all tasks, with its color. Its descendants
+// are kept.
+func (a *App) deleteTag(tag string) tea.Cmd {
+	var updated []model.Task
+	for _, t := range a.tasks {
+		if tags, ok := model.RemoveTag(t.Tags, tag); ok {
+			t.Tags = tags
+			updated = append(updated, t)
+		}
+	}
+	a.setMessage(i18n.Nf(len(updated), "Tag %s supprimé de %d tâche", "Tag %s supprimé de %d tâches", tag, len(updated)))
+	cmd := a.commit(storage.Changes{Updated: updated})
+	if _, ok := a.storage.TagColors()[strings.ToLower(tag)]; !ok {
+		return cmd
+	}
+	return tea.Sequence(cmd, a.setTagColors(map[string]string{strings.ToLower(tag): ""}))
+}
+
+// setTagColors saves the colors of tags in the tasks file
+func (a *App) setTagColors(colors map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := a.storage.SetTagColors(colors)
+		if err != nil {
+			return errMsg{err}
+		}
+		return tasksLoadedMsg{tasks}
+	}
+}
+
+// renderTags renders the tags with the number of tasks having each, in
+// their color
+func (a *App) renderTags() string {
+	title := a.styles.DialogTitle.Render(i18n.T("Tags"))
+	textStyle := lipgloss.NewStyle().Foreground(colorText)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+	selectedStyle := lipgloss.NewStyle().Foreground(colorBlue).Bold(true)
+
+	names := a.tagNames()
+	if len(names) == 0 {
+		content := title + "\n\n" + textStyle.Render(i18n.T("Aucun tag")) +
+			"\n\n" + mutedStyle.Render(i18n.T("Esc: fermer"))
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.styles.Dialog.Render(content))
+	}
+
+	counts := model.TagCounts(a.tasks)
+	selected := a.selectedTag()
+	width := 0
+	for _, tag := range names {
+		width = max(width, lipgloss.Width(tag))
+	}
+
+	// Scrolls to keep the cursor in the rows that fit
+	rows := max(a.height-14, 3)
+	start := max(min(a.tagCursor-rows/2, len(names)-rows), 0)
+	end := min(start+rows, len(names))
+
+	var lines []string
+	for i := start; i < end; i++ {
+		tag := names[i]
+		cursor := "  "
+		if i == a.tagCursor {
+			cursor = selectedStyle.Render("▸ ")
+		}
+		pad := strings.Repeat(" ", width-lipgloss.Width(tag))
+		lines = append(lines, cursor+a.styles.TagStyle(tag).Render(tag)+pad+"  "+
+			mutedStyle.Render(i18n.N(counts[tag], "%d tâche", "%d tâches")))
+	}
+	if start > 0 {
+		lines = append([]string{mutedStyle.Render("  ↑")}, lines...)
+	}
+	if end < len(names) {
+		lines = append(lines, mutedStyle.Render("  ↓"))
+	}
+
+	var prompt string
+	switch a.tagAction {
+	case tagActionRename:
+		prompt = textStyle.Render(i18n.Tf("Renommer %s en:", selected)) + "\n" +
+			a.styles.FormInputFocus.Render(a.tagInput.View())
+	case tagActionMerge:
+		prompt = textStyle.Render(i18n.Tf("Fusionner %s dans:", selected)) + "\n" +
+			a.styles.FormInputFocus.Render(a.tagInput.View())
+	case tagActionDelete:
+		prompt = lipgloss.NewStyle().Foreground(colorYellow).
+			Render(i18n.Nf(counts[selected], "Retirer le tag %s de %d tâche? (y/n)", "Retirer le tag %s de %d tâches? (y/n)", selected, counts[selected]))
+	}
+
+	help := mutedStyle.Render(i18n.T("r: renommer │ m: fusionner │ d: supprimer │ c: couleur │ Esc: fermer"))
+	if a.tagAction == tagActionRename || a.tagAction == tagActionMerge {
+		help = mutedStyle.Render(i18n.T("Entrée: valider │ Esc: annuler"))
+	}
+
+	content := title + "\n\n" + strings.Join(lines, "\n") + "\n\n"
+	if prompt != "" {
+		content += prompt + "\n\n"
+	}
+	content += help
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
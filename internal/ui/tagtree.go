@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxTagTreeLines limits the height of the tag tree in the tag picker
+const maxTagTreeLines = 12
+
+// renderTagTree renders the tag hierarchy with box-drawing branches.
+// Tags in selected are marked with a check.
+func renderTagTree(roots []*model.TagNode, selected map[string]bool) []string {
+	branchStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+	nameStyle := lipgloss.NewStyle().Foreground(colorTeal)
+	checkStyle := lipgloss.NewStyle().Foreground(colorGreen)
+
+	var lines []string
+	var walk func(nodes []*model.TagNode, prefix string, root bool)
+	walk = func(nodes []*model.TagNode, prefix string, root bool) {
+		for i, n := range nodes {
+			branch, childPrefix := "├─ ", prefix+"│  "
+			if i == len(nodes)-1 {
+				branch, childPrefix = "└─ ", prefix+"   "
+			}
+			if root {
+				// Roots are not connected to each other
+				branch, childPrefix = "", ""
+			}
+
+			line := branchStyle.Render(prefix+branch) + nameStyle.Render(n.Name)
+			if selected[n.Path] {
+				line += " " + checkStyle.Render("✓")
+			}
+			lines = append(lines, line)
+
+			walk(n.Children, childPrefix, false)
+		}
+	}
+	walk(roots, "", true)
+	return lines
+}
+
+// tagSuggestions returns the completions of the last tag of a comma
+// separated list ("work, ho"): the list ending with each known tag not yet
+// in it ("work, home")
+func tagSuggestions(value string, known []string) []string {
+	i := strings.LastIndex(value, ",")
+	head, last := value[:i+1], value[i+1:]
+	head += last[:len(last)-len(strings.TrimLeft(last, " "))]
+
+	var listed []string
+	for _, tag := range strings.Split(value[:i+1], ",") {
+		listed = append(listed, strings.TrimSpace(tag))
+	}
+	var suggestions []string
+	for _, tag := range known {
+		if !slices.ContainsFunc(listed, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			suggestions = append(suggestions, head+tag)
+		}
+	}
+	return suggestions
+}
@@ -2,9 +2,14 @@ package ui
 
 import (
 	"strings"
+	"time"
 
+	"lazy-todo/internal/i18n"
 	"lazy-todo/internal/model"
+	"lazy-todo/internal/parse"
 
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,7 +22,10 @@ const (
 	FieldTitle FormField = iota
 	FieldDescription
 	FieldTags
+	FieldDue
+	FieldReminders
 	FieldPriority
+	FieldSeverity
 	FieldStatus
 	FieldSubmit
 	FieldCancel
@@ -25,65 +33,123 @@ const (
 
 // TaskForm is the form for creating/editing tasks
 type TaskForm struct {
-	task          *model.Task
-	isNew         bool
-	focusedField  FormField
-	titleInput    textinput.Model
-	descInput     textinput.Model
-	tagsInput     textinput.Model
-	priorityIdx   int
-	statusIdx     int
-	styles        Styles
-	width, height int
+	task            *model.Task
+	isNew           bool
+	focusedField    FormField
+	titleInput      textinput.Model
+	descInput       textarea.Model
+	pastedRest      string // lines pasted in the title, offered for the description
+	tagsInput       textinput.Model
+	knownTags       []string // completions of the tags field
+	dueInput        textinput.Model
+	picker          *CalendarView   // due date picker, nil when closed
+	tasks           []model.Task    // shown in the picker by due date
+	remindInput     textinput.Model // comma-separated reminders
+	longDescription int             // words past which the description is warned about
+	graphics        graphics        // protocol the images of the description are drawn with
+	imageDir        string          // relative image paths are resolved from it
+	imageCache      map[string]string
+	priorityIdx     int
+	severityIdx     int
+	statusIdx       int
+	styles          Styles
+	width, height   int
 }
 
+// descHeight is the number of lines of the description field
+const descHeight = 5
+
 // NewTaskForm creates a new task form
 func NewTaskForm(styles Styles) *TaskForm {
 	titleInput := textinput.New()
-	titleInput.Placeholder = "Titre de la tâche"
+	titleInput.Placeholder = i18n.T("Titre de la tâche")
 	titleInput.Focus()
 	titleInput.CharLimit = 100
 	titleInput.Width = 40
 
-	descInput := textinput.New()
-	descInput.Placeholder = "Description (optionnel)"
-	descInput.CharLimit = 500
-	descInput.Width = 40
+	descInput := textarea.New()
+	descInput.Placeholder = i18n.T("Description en Markdown (optionnel)")
+	descInput.ShowLineNumbers = false
+	descInput.Prompt = ""
+	descInput.CharLimit = 0
+	descInput.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	descInput.SetWidth(43)
+	descInput.SetHeight(descHeight)
 
 	tagsInput := textinput.New()
-	tagsInput.Placeholder = "Tags séparés par des virgules"
+	tagsInput.Placeholder = i18n.T("Tags séparés par des virgules")
 	tagsInput.CharLimit = 100
 	tagsInput.Width = 40
+	tagsInput.ShowSuggestions = true
+
+	dueInput := textinput.New()
+	dueInput.Placeholder = i18n.T("demain, vendredi, +2w, 2026-10-20")
+	dueInput.CharLimit = 30
+	dueInput.Width = 40
+
+	remindInput := textinput.New()
+	remindInput.Placeholder = i18n.T("1d, 2h avant l'échéance, ou 2026-10-20 14:30")
+	remindInput.CharLimit = 200
+	remindInput.Width = 40
 
 	return &TaskForm{
-		titleInput:   titleInput,
-		descInput:    descInput,
-		tagsInput:    tagsInput,
-		focusedField: FieldTitle,
-		priorityIdx:  1, // Medium
-		statusIdx:    0, // Todo
-		styles:       styles,
+		titleInput:      titleInput,
+		descInput:       descInput,
+		tagsInput:       tagsInput,
+		dueInput:        dueInput,
+		remindInput:     remindInput,
+		focusedField:    FieldTitle,
+		priorityIdx:     model.DefaultPriority().Index(),
+		statusIdx:       0, // Todo
+		styles:          styles,
+		longDescription: defaultLongDescription,
 	}
 }
 
+// SetTasks sets the tasks the tags field completes the tags of, and the
+// date picker shows
+func (f *TaskForm) SetTasks(tasks []model.Task) {
+	f.tasks = tasks
+	f.knownTags = model.AllTags(tasks)
+}
+
+// canCompleteTag returns true if a completion extends the tag being typed
+func (f *TaskForm) canCompleteTag() bool {
+	value := []rune(f.tagsInput.Value())
+	return len([]rune(f.tagsInput.CurrentSuggestion())) > len(value) && f.tagsInput.Position() == len(value)
+}
+
 // SetTask sets the task to edit (nil for new task)
 func (f *TaskForm) SetTask(task *model.Task) {
 	if task == nil {
 		f.isNew = true
 		f.task = nil
 		f.titleInput.SetValue("")
-		f.descInput.SetValue("")
+		f.SetDescription("")
 		f.tagsInput.SetValue("")
-		f.priorityIdx = 1
+		f.dueInput.SetValue("")
+		f.remindInput.SetValue("")
+		f.priorityIdx = model.DefaultPriority().Index()
+		f.severityIdx = 0
 		f.statusIdx = 0
 	} else {
 		f.isNew = false
 		f.task = task
 		f.titleInput.SetValue(task.Title)
-		f.descInput.SetValue(task.Description)
+		f.SetDescription(task.Description)
 		f.tagsInput.SetValue(strings.Join(task.Tags, ", "))
+		f.dueInput.SetValue("")
+		if task.DueDate != nil {
+			f.dueInput.SetValue(task.DueDate.In(time.Local).Format(time.DateOnly))
+		}
+		reminders := make([]string, len(task.Reminders))
+		for i, r := range task.Reminders {
+			reminders[i] = r.String()
+		}
+		f.remindInput.SetValue(strings.Join(reminders, ", "))
 
-		// Set priority index
+		// Set priority index, unknown priorities fall back to the default
+		f.priorityIdx = model.DefaultPriority().Index()
 		priorities := model.AllPriorities()
 		for i, p := range priorities {
 			if p == task.Priority {
@@ -92,20 +158,43 @@ func (f *TaskForm) SetTask(task *model.Task) {
 			}
 		}
 
-		// Set status index
-		statuses := model.AllStatuses()
-		for i, s := range statuses {
-			if s == task.Status {
-				f.statusIdx = i
-				break
-			}
-		}
+		f.severityIdx = task.Severity.Index()
+
+		// Set status index, on the column showing the task for a status
+		// outside the workflow
+		f.statusIdx = task.Status.Column()
 	}
 
+	f.tagsInput.SetSuggestions(tagSuggestions(f.tagsInput.Value(), f.knownTags))
+	f.picker = nil
+	f.pastedRest = ""
 	f.focusedField = FieldTitle
 	f.titleInput.Focus()
 	f.descInput.Blur()
 	f.tagsInput.Blur()
+	f.dueInput.Blur()
+	f.remindInput.Blur()
+}
+
+// SetReducedMotion stops the cursors from blinking
+func (f *TaskForm) SetReducedMotion() {
+	staticCursor(&f.titleInput, &f.tagsInput, &f.dueInput, &f.remindInput)
+	f.descInput.Cursor.SetMode(cursor.CursorStatic)
+}
+
+// SetDescription replaces the description
+func (f *TaskForm) SetDescription(desc string) {
+	f.descInput.SetValue(desc)
+}
+
+// Description returns the current description
+func (f *TaskForm) Description() string {
+	return f.descInput.Value()
+}
+
+// IsFocusedOnDescription returns true if the description field is focused
+func (f *TaskForm) IsFocusedOnDescription() bool {
+	return f.focusedField == FieldDescription
 }
 
 // SetSize sets the form dimensions
@@ -117,8 +206,11 @@ func (f *TaskForm) SetSize(width, height int) {
 		inputWidth = 60
 	}
 	f.titleInput.Width = inputWidth
-	f.descInput.Width = inputWidth
+	// As wide as the single-line inputs with their prompt and cursor
+	f.descInput.SetWidth(inputWidth + 3)
 	f.tagsInput.Width = inputWidth
+	f.dueInput.Width = inputWidth
+	f.remindInput.Width = inputWidth
 }
 
 // Update handles input
@@ -127,6 +219,47 @@ func (f *TaskForm) Update(msg tea.Msg) (*TaskForm, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if f.HasPastePrompt() {
+			f.handlePastePrompt(msg)
+			return f, nil
+		}
+		if f.picker != nil {
+			f.handleDatePickerKeys(msg)
+			return f, nil
+		}
+		if f.focusedField == FieldDue && msg.String() == "enter" {
+			f.openDatePicker()
+			return f, nil
+		}
+		if msg.Paste && strings.ContainsAny(string(msg.Runes), "\r\n") && f.pasteLines(string(msg.Runes)) {
+			return f, nil
+		}
+
+		// Up and down move between the lines of the description, and
+		// leave it from its first and last lines
+		if f.focusedField == FieldDescription {
+			switch msg.String() {
+			case "up":
+				if f.descInput.Line() > 0 || f.descInput.LineInfo().RowOffset > 0 {
+					f.descInput, cmd = f.descInput.Update(msg)
+					return f, cmd
+				}
+			case "down":
+				info := f.descInput.LineInfo()
+				if f.descInput.Line() < f.descInput.LineCount()-1 || info.RowOffset < info.Height-1 {
+					f.descInput, cmd = f.descInput.Update(msg)
+					return f, cmd
+				}
+			}
+		}
+
+		// Tab completes the tag being typed before moving to the next field
+		if f.focusedField == FieldTags && msg.String() == "tab" && f.canCompleteTag() {
+			f.tagsInput, cmd = f.tagsInput.Update(msg)
+			f.tagsInput.SetSuggestions(tagSuggestions(f.tagsInput.Value(), f.knownTags))
+			return f, cmd
+		}
+
 		switch msg.String() {
 		case "tab", "down":
 			f.nextField()
@@ -139,6 +272,10 @@ func (f *TaskForm) Update(msg tea.Msg) (*TaskForm, tea.Cmd) {
 				if f.priorityIdx > 0 {
 					f.priorityIdx--
 				}
+			} else if f.focusedField == FieldSeverity {
+				if f.severityIdx > 0 {
+					f.severityIdx--
+				}
 			} else if f.focusedField == FieldStatus {
 				if f.statusIdx > 0 {
 					f.statusIdx--
@@ -150,6 +287,10 @@ func (f *TaskForm) Update(msg tea.Msg) (*TaskForm, tea.Cmd) {
 				if f.priorityIdx < len(model.AllPriorities())-1 {
 					f.priorityIdx++
 				}
+			} else if f.focusedField == FieldSeverity {
+				if f.severityIdx < len(model.AllSeverities())-1 {
+					f.severityIdx++
+				}
 			} else if f.focusedField == FieldStatus {
 				if f.statusIdx < len(model.AllStatuses())-1 {
 					f.statusIdx++
@@ -167,16 +308,68 @@ func (f *TaskForm) Update(msg tea.Msg) (*TaskForm, tea.Cmd) {
 		f.descInput, cmd = f.descInput.Update(msg)
 	case FieldTags:
 		f.tagsInput, cmd = f.tagsInput.Update(msg)
+		f.tagsInput.SetSuggestions(tagSuggestions(f.tagsInput.Value(), f.knownTags))
+	case FieldDue:
+		f.dueInput, cmd = f.dueInput.Update(msg)
+	case FieldReminders:
+		f.remindInput, cmd = f.remindInput.Update(msg)
 	}
 
 	return f, cmd
 }
 
+// pasteLines handles a multi-line paste, which the single-line inputs would
+// flatten. In the title, the first line is kept and the others are offered
+// for the description; in the description, the text is inserted as is.
+// It returns false if the focused field doesn't handle it.
+func (f *TaskForm) pasteLines(text string) bool {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	text = strings.Trim(text, "\n")
+
+	switch f.focusedField {
+	case FieldTitle:
+		first, rest, _ := strings.Cut(strings.TrimLeft(text, "\n "), "\n")
+		f.titleInput.SetValue(f.titleInput.Value() + strings.TrimSpace(first))
+		f.titleInput.CursorEnd()
+		f.pastedRest = strings.Trim(rest, "\n")
+		return true
+	case FieldDescription:
+		f.descInput.InsertString(text)
+		return true
+	}
+	return false
+}
+
+// HasPastePrompt returns true while asking whether to move pasted lines
+// to the description
+func (f *TaskForm) HasPastePrompt() bool {
+	return f.pastedRest != ""
+}
+
+// handlePastePrompt answers the paste prompt: y/enter appends the pasted
+// lines to the description, n/esc drops them
+func (f *TaskForm) handlePastePrompt(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		desc := f.Description()
+		if desc != "" {
+			desc += "\n"
+		}
+		f.SetDescription(desc + f.pastedRest)
+		f.pastedRest = ""
+	case "n", "N", "esc":
+		f.pastedRest = ""
+	}
+}
+
 // nextField moves focus to the next field
 func (f *TaskForm) nextField() {
 	f.titleInput.Blur()
 	f.descInput.Blur()
 	f.tagsInput.Blur()
+	f.dueInput.Blur()
+	f.remindInput.Blur()
 
 	f.focusedField++
 	if f.focusedField > FieldCancel {
@@ -190,6 +383,10 @@ func (f *TaskForm) nextField() {
 		f.descInput.Focus()
 	case FieldTags:
 		f.tagsInput.Focus()
+	case FieldDue:
+		f.dueInput.Focus()
+	case FieldReminders:
+		f.remindInput.Focus()
 	}
 }
 
@@ -198,6 +395,8 @@ func (f *TaskForm) prevField() {
 	f.titleInput.Blur()
 	f.descInput.Blur()
 	f.tagsInput.Blur()
+	f.dueInput.Blur()
+	f.remindInput.Blur()
 
 	if f.focusedField == FieldTitle {
 		f.focusedField = FieldCancel
@@ -212,6 +411,10 @@ func (f *TaskForm) prevField() {
 		f.descInput.Focus()
 	case FieldTags:
 		f.tagsInput.Focus()
+	case FieldDue:
+		f.dueInput.Focus()
+	case FieldReminders:
+		f.remindInput.Focus()
 	}
 }
 
@@ -225,7 +428,7 @@ func (f *TaskForm) GetTask() model.Task {
 	}
 
 	task.Title = f.titleInput.Value()
-	task.Description = f.descInput.Value()
+	task.Description = f.Description()
 
 	// Parse tags
 	tagStr := f.tagsInput.Value()
@@ -242,15 +445,51 @@ func (f *TaskForm) GetTask() model.Task {
 		task.Tags = []string{}
 	}
 
+	task.DueDate = f.dueDate()
+	task.Reminders, _ = f.reminders()
+
 	priorities := model.AllPriorities()
 	task.Priority = priorities[f.priorityIdx]
 
+	task.Severity = model.AllSeverities()[f.severityIdx]
+
 	statuses := model.AllStatuses()
 	task.Status = statuses[f.statusIdx]
 
 	return task
 }
 
+// reminders parses the reminders field, keeping the state of the
+// reminders left unchanged. It also returns the entries that don't parse,
+// which are dropped.
+func (f *TaskForm) reminders() ([]model.Reminder, []string) {
+	var previous []model.Reminder
+	if f.task != nil {
+		previous = f.task.Reminders
+	}
+	var reminders []model.Reminder
+	var invalid []string
+	for _, entry := range strings.Split(f.remindInput.Value(), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		r, ok := parse.ParseReminder(entry, time.Now())
+		if !ok {
+			invalid = append(invalid, entry)
+			continue
+		}
+		for _, p := range previous {
+			if p.String() == r.String() {
+				r = p
+				break
+			}
+		}
+		reminders = append(reminders, r)
+	}
+	return reminders, invalid
+}
+
 // IsValid returns true if the form is valid
 func (f *TaskForm) IsValid() bool {
 	return strings.TrimSpace(f.titleInput.Value()) != ""
@@ -268,9 +507,9 @@ func (f *TaskForm) IsFocusedOnCancel() bool {
 
 // Render renders the form
 func (f *TaskForm) Render() string {
-	title := "Nouvelle tâche"
+	title := i18n.T("Nouvelle tâche")
 	if !f.isNew {
-		title = "Modifier la tâche"
+		title = i18n.T("Modifier la tâche")
 	}
 
 	titleStyle := f.styles.DialogTitle
@@ -283,25 +522,75 @@ func (f *TaskForm) Render() string {
 	sections = append(sections, "")
 
 	// Title field
-	sections = append(sections, labelStyle.Render("Titre:"))
+	sections = append(sections, labelStyle.Render(i18n.T("Titre:")))
 	sections = append(sections, f.renderInput(f.titleInput.View(), f.focusedField == FieldTitle))
+	if f.HasPastePrompt() {
+		lines := strings.Count(f.pastedRest, "\n") + 1
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(colorYellow).
+			Render(i18n.N(lines, "Mettre la ligne suivante dans la description? (y/n)", "Mettre les %d lignes suivantes dans la description? (y/n)")))
+	}
 
 	// Description field
-	sections = append(sections, labelStyle.Render("Description:"))
-	sections = append(sections, f.renderInput(f.descInput.View(), f.focusedField == FieldDescription))
+	sections = append(sections, labelStyle.Render(i18n.T("Description:")))
+	sections = append(sections, f.renderInput(f.renderDescription(), f.focusedField == FieldDescription))
+	if warning := f.renderLongDescription(); warning != "" {
+		sections = append(sections, warning)
+	}
+
+	// Images linked in the description
+	if images := f.renderImages(); len(images) > 0 {
+		sections = append(sections, labelStyle.Render(i18n.T("Images:")))
+		sections = append(sections, images...)
+	}
 
 	// Tags field
-	sections = append(sections, labelStyle.Render("Tags:"))
+	sections = append(sections, labelStyle.Render(i18n.T("Tags:")))
 	sections = append(sections, f.renderInput(f.tagsInput.View(), f.focusedField == FieldTags))
 
+	// Due date field, with the picker below it when open
+	sections = append(sections, labelStyle.Render(i18n.T("Échéance:")))
+	sections = append(sections, f.renderInput(f.dueInput.View(), f.focusedField == FieldDue))
+	if hint := f.renderDueHint(); hint != "" {
+		sections = append(sections, hint)
+	}
+	if f.picker != nil {
+		sections = append(sections, f.renderDatePicker())
+	}
+
+	// Reminders field
+	sections = append(sections, labelStyle.Render(i18n.T("Rappels:")))
+	sections = append(sections, f.renderInput(f.remindInput.View(), f.focusedField == FieldReminders))
+	if _, invalid := f.reminders(); len(invalid) > 0 {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(colorYellow).
+			Render(i18n.Nf(len(invalid), "Rappel non reconnu, ignoré: %s", "Rappels non reconnus, ignorés: %s", strings.Join(invalid, ", "))))
+	}
+
 	// Priority selector
-	sections = append(sections, labelStyle.Render("Priorité:"))
+	sections = append(sections, labelStyle.Render(i18n.T("Priorité:")))
 	sections = append(sections, f.renderPrioritySelector())
 
+	// Severity selector
+	sections = append(sections, labelStyle.Render(i18n.T("Sévérité:")))
+	sections = append(sections, f.renderSeveritySelector())
+
 	// Status selector
-	sections = append(sections, labelStyle.Render("État:"))
+	sections = append(sections, labelStyle.Render(i18n.T("État:")))
 	sections = append(sections, f.renderStatusSelector())
 
+	// Notes log, added with n from the task list
+	if f.task != nil && len(f.task.Notes) > 0 {
+		sections = append(sections, labelStyle.Render(i18n.T("Notes:")))
+		sections = append(sections, renderNotes(f.task.Notes, f.titleInput.Width+4)...)
+	}
+
+	// Status and priority changes, recorded when saved
+	if f.task != nil && len(f.task.History) > 0 {
+		sections = append(sections, labelStyle.Render(i18n.T("Historique:")))
+		sections = append(sections, renderHistory(f.task.History, f.titleInput.Width+4, time.Now())...)
+	}
+
 	// Buttons
 	sections = append(sections, "")
 	sections = append(sections, f.renderButtons())
@@ -319,6 +608,29 @@ func (f *TaskForm) renderInput(view string, focused bool) string {
 	return f.styles.FormInput.Render(view)
 }
 
+// renderDescription renders the description textarea when focused, and the
+// description rendered as Markdown otherwise, cut to the field's height
+func (f *TaskForm) renderDescription() string {
+	hint := lipgloss.NewStyle().Foreground(colorOverlay0).Italic(true)
+	if f.focusedField == FieldDescription {
+		return f.descInput.View() + "\n" + hint.Render(i18n.T("ctrl+o: éditeur, ctrl+f: zen"))
+	}
+
+	box := lipgloss.NewStyle().Width(f.descInput.Width())
+	desc := strings.TrimSpace(f.Description())
+	if desc == "" {
+		return box.Render(hint.Render(i18n.T("Aucune description")))
+	}
+	// A description too long to be shown whole gets its reading time
+	lines := strings.Split(renderMarkdown(desc, f.descInput.Width()), "\n")
+	if len(lines) > descHeight {
+		more := hint.Render(i18n.N(len(lines)-descHeight+1, "+%d ligne", "+%d lignes") +
+			" · " + renderReadingTime(wordCount(desc)))
+		lines = append(lines[:descHeight-1], more)
+	}
+	return box.Render(strings.Join(lines, "\n"))
+}
+
 // renderPrioritySelector renders the priority selector
 func (f *TaskForm) renderPrioritySelector() string {
 	priorities := model.AllPriorities()
@@ -344,6 +656,32 @@ func (f *TaskForm) renderPrioritySelector() string {
 	return strings.Join(items, "  ")
 }
 
+// renderSeveritySelector renders the severity selector
+func (f *TaskForm) renderSeveritySelector() string {
+	var items []string
+
+	for i, s := range model.AllSeverities() {
+		label := s.Label()
+		if s != model.SeverityNone {
+			label = SeverityIcon(s) + " " + label
+		}
+		style := f.styles.SeverityStyle(s)
+
+		item := style.Render(label)
+		if i == f.severityIdx && f.focusedField == FieldSeverity {
+			item = lipgloss.NewStyle().
+				Background(lipgloss.Color("#45475a")).
+				Render("[" + label + "]")
+		} else if i == f.severityIdx {
+			item = "[" + item + "]"
+		}
+
+		items = append(items, item)
+	}
+
+	return strings.Join(items, "  ")
+}
+
 // renderStatusSelector renders the status selector
 func (f *TaskForm) renderStatusSelector() string {
 	statuses := model.AllStatuses()
@@ -381,8 +719,8 @@ func (f *TaskForm) renderButtons() string {
 		cancelStyle = f.styles.FormButtonFocus
 	}
 
-	submit := submitStyle.Render("Valider")
-	cancel := cancelStyle.Render("Annuler")
+	submit := submitStyle.Render(i18n.T("Valider"))
+	cancel := cancelStyle.Render(i18n.T("Annuler"))
 
 	return submit + "  " + cancel
 }
@@ -0,0 +1,331 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// timeStatsBarWidth is the width of the longest bar of the time stats
+	timeStatsBarWidth = 24
+	// timeStatsTags is how many tags the time stats show, the longest
+	timeStatsTags = 8
+)
+
+// timeStatsPeriods are the days the time stats cover, switched with Tab
+var timeStatsPeriods = [...]int{7, 30}
+
+// runningTask returns the task being timed, nil when none is
+func (a *App) runningTask() *model.Task {
+	for i := range a.tasks {
+		if a.tasks[i].RunningSession() != nil {
+			return &a.tasks[i]
+		}
+	}
+	return nil
+}
+
+// toggleTimer starts timing the selected task, stopping the task timed
+// until then, or stops it when it is the one timed
+func (a *App) toggleTimer() tea.Cmd {
+	task := a.selectedTask()
+	if task == nil {
+		return nil
+	}
+	now := time.Now()
+	var changes storage.Changes
+	running := a.runningTask()
+	if running != nil {
+		t := *running
+		s := t.StopSession(now)
+		changes.Updated = append(changes.Updated, t)
+		a.setMessage(i18n.Tf("Chrono arrêté: %s sur « %s »", workDuration(s.Duration(now)), truncate(t.Title, 30)))
+		if running.ID == task.ID {
+			return a.commit(changes)
+		}
+	}
+	t := *task
+	t.StartSession(now)
+	changes.Updated = append(changes.Updated, t)
+	a.setMessage(i18n.Tf("Chrono démarré sur « %s »", truncate(t.Title, 30)))
+	return a.commit(changes)
+}
+
+// renderTimer renders the task being timed and for how long, for the
+// header; empty when none is
+func (a *App) renderTimer(now time.Time) string {
+	task := a.runningTask()
+	if task == nil {
+		return ""
+	}
+	label := "⏱ " + workDuration(task.RunningSession().Duration(now))
+	if id := task.ShortID(); id != "" {
+		label += " " + id
+	}
+	return lipgloss.NewStyle().Foreground(colorGreen).Render(" " + label)
+}
+
+// workDuration formats a time worked to the minute, like "2 h 05" or
+// "35 min"
+func workDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes < 60 {
+		return i18n.Tf("%d min", minutes)
+	}
+	return i18n.Tf("%d h %02d", minutes/60, minutes%60)
+}
+
+// handleTimeStatsKeys switches the period of the time stats, or closes
+// them
+func (a *App) handleTimeStatsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, a.keys.TimeStats):
+		a.state = StateNormal
+	case msg.String() == "tab":
+		a.statsPeriod = (a.statsPeriod + 1) % len(timeStatsPeriods)
+	}
+	return a, nil
+}
+
+// renderTimeStats renders the time worked on the tasks over the period,
+// per day and per tag, with the pomodoros it holds, then the time they
+// were blocked per reason
+func (a *App) renderTimeStats() string {
+	now := time.Now()
+	days := timeStatsPeriods[a.statsPeriod]
+	y, m, d := now.Date()
+	since := time.Date(y, m, d-days+1, 0, 0, 0, 0, now.Location())
+	log := model.WorkLog(a.tasks, since, now)
+
+	title := a.styles.DialogTitle.Render(i18n.Nf(days, "Temps suivi, dernier jour", "Temps suivi, %d derniers jours", days))
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+	help := mutedStyle.Render(i18n.T("Tab: 7 ou 30 jours · Esc: fermer")) + "\n" +
+		mutedStyle.Render(i18n.T("CSV: lazy-todo export --time --format csv"))
+
+	sectionStyle := lipgloss.NewStyle().Foreground(colorSubtext0).Bold(true)
+	var sections []string
+	if len(log) == 0 {
+		sections = append(sections, lipgloss.NewStyle().Foreground(colorText).Render(i18n.T("Aucun temps suivi sur la période")))
+	} else {
+		byDay := model.WorkByDay(log)
+		var total model.WorkTotal
+		for _, t := range byDay {
+			total.Duration += t.Duration
+			total.Pomodoros += t.Pomodoros
+		}
+		for i, t := range byDay {
+			if day, err := time.ParseInLocation(time.DateOnly, t.Key, now.Location()); err == nil {
+				byDay[i].Key = capitalize(shortLabel(weekdayLabel(day.Weekday()))) + " " + day.Format("01-02")
+			}
+		}
+		byTag := model.WorkByTag(log)
+		for i := range byTag {
+			if byTag[i].Key == "" {
+				byTag[i].Key = i18n.T("Sans tag")
+			}
+		}
+		if len(byTag) > timeStatsTags {
+			byTag = byTag[:timeStatsTags]
+		}
+
+		sections = append(sections,
+			sectionStyle.Render(i18n.T("Par jour"))+"\n"+renderWorkTotals(byDay, colorGreen),
+			sectionStyle.Render(i18n.T("Par tag"))+"\n"+renderWorkTotals(byTag, colorGreen),
+			lipgloss.NewStyle().Foreground(colorYellow).Render(i18n.Tf("Total: %s", renderWorkTotal(total))))
+	}
+
+	// Where the time blocked went, to the minute
+	var blocked []model.WorkTotal
+	for _, t := range model.BlockedByReason(a.tasks, since, now) {
+		if t.Duration >= time.Minute {
+			blocked = append(blocked, model.WorkTotal{Key: model.BlockReasonLabel(t.Key), Duration: t.Duration})
+		}
+	}
+	if len(blocked) > 0 {
+		sections = append(sections, sectionStyle.Render(i18n.T("Temps bloqué, par raison"))+"\n"+renderWorkTotals(blocked, colorRed))
+	}
+	content := title + "\n\n" + strings.Join(sections, "\n\n") + "\n\n" + help
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
+
+// renderWorkTotals renders times as a bar chart of a color, scaled to the
+// longest
+func renderWorkTotals(totals []model.WorkTotal, color lipgloss.Color) string {
+	keyWidth, scale := 0, time.Duration(1)
+	for _, t := range totals {
+		keyWidth = max(keyWidth, lipgloss.Width(t.Key))
+		scale = max(scale, t.Duration)
+	}
+
+	textStyle := lipgloss.NewStyle().Foreground(colorText)
+	barStyle := lipgloss.NewStyle().Foreground(color)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay1)
+	var lines []string
+	for _, t := range totals {
+		width := int(t.Duration * timeStatsBarWidth / scale)
+		bar := barStyle.Render(strings.Repeat("█", width)) + strings.Repeat(" ", timeStatsBarWidth-width)
+		key := t.Key + strings.Repeat(" ", keyWidth-lipgloss.Width(t.Key))
+		lines = append(lines, textStyle.Render(key)+"  "+bar+"  "+mutedStyle.Render(renderWorkTotal(t)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderWorkTotal renders a time worked with its pomodoros, like
+// "2 h 05 · 4 🍅"
+func renderWorkTotal(t model.WorkTotal) string {
+	label := workDuration(t.Duration)
+	if t.Pomodoros > 0 {
+		label += " · " + itoa(t.Pomodoros) + " 🍅"
+	}
+	return label
+}
+
+// checkIdle asks what to do with the time idle once the timer has run for
+// the configured time without a key pressed
+func (a *App) checkIdle(now time.Time) {
+	if a.noteIdle(now) && a.idleUntil.IsZero() {
+		a.askIdle()
+	}
+}
+
+// endIdle records a key pressed at now, which ends the time idle if there
+// was one. It returns true when the key brings up the question of what to
+// do with it, rather than being one of its answers.
+func (a *App) endIdle(now time.Time) bool {
+	defer func() { a.lastInput = now }()
+	if a.state == StateIdle {
+		if a.idleUntil.IsZero() {
+			a.idleUntil = now
+		}
+		return false
+	}
+	if !a.noteIdle(now) {
+		return false
+	}
+	if a.idleUntil.IsZero() {
+		a.idleUntil = now
+	}
+	return a.askIdle()
+}
+
+// noteIdle returns true when the timer has been idle: it ran for the
+// configured time without a key pressed, from a.idleSince
+func (a *App) noteIdle(now time.Time) bool {
+	running := a.runningTask()
+	if running == nil {
+		a.idleSince, a.idleUntil = time.Time{}, time.Time{}
+		return false
+	}
+	if !a.idleSince.IsZero() {
+		return true
+	}
+	after := a.config.Timer.IdleAfter
+	if after == 0 {
+		after = config.DefaultIdleAfter
+	}
+	since := a.lastInput
+	if start := running.RunningSession().Start; since.Before(start) {
+		since = start
+	}
+	if after < 0 || now.Sub(since) < after {
+		return false
+	}
+	a.idleSince = since
+	return true
+}
+
+// askIdle asks what to do with the time idle, unless a task is being
+// edited: the form holds a copy of its sessions. It returns true when it
+// does.
+func (a *App) askIdle() bool {
+	switch a.state {
+	case StateIdle, StateForm, StateDescEditor:
+		return false
+	}
+	a.idleReturn = a.state
+	a.state = StateIdle
+	return true
+}
+
+// handleIdleKeys keeps the time idle in the session of the timer, or
+// leaves it out, the timer going on or stopped
+func (a *App) handleIdleKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "k", "K", "esc":
+		a.setMessage(i18n.T("Temps inactif gardé"))
+	case "t", "T":
+		cmd = a.trimIdle(true)
+	case "d", "D":
+		cmd = a.trimIdle(false)
+	default:
+		return a, nil
+	}
+	a.state = a.idleReturn
+	a.idleSince, a.idleUntil = time.Time{}, time.Time{}
+	return a, cmd
+}
+
+// trimIdle leaves the time idle out of the session of the timer, then
+// times the task again from the end of it when resume is true
+func (a *App) trimIdle(resume bool) tea.Cmd {
+	running := a.runningTask()
+	if running == nil {
+		return nil
+	}
+	until := a.idleUntil
+	if until.IsZero() {
+		until = time.Now()
+	}
+	t := *running
+	t.TrimSession(a.idleSince, until, resume)
+	if resume {
+		a.setMessage(i18n.Tf("Temps inactif retiré: %s", workDuration(until.Sub(a.idleSince))))
+	} else {
+		a.setMessage(i18n.Tf("Chrono arrêté à %s, temps inactif retiré", a.idleSince.Format("15:04")))
+	}
+	return a.updateTask(t)
+}
+
+// renderIdlePrompt asks what to do with the time the timer ran without a
+// key pressed
+func (a *App) renderIdlePrompt() string {
+	until := a.idleUntil
+	if until.IsZero() {
+		until = time.Now()
+	}
+	title := a.styles.DialogTitle.Render(i18n.T("Inactivité pendant le chrono"))
+	text := i18n.Tf("Aucune touche de %s à %s (%s)", a.idleSince.Format("15:04"), until.Format("15:04"), workDuration(until.Sub(a.idleSince)))
+	if task := a.runningTask(); task != nil {
+		text += "\n" + i18n.Tf("Chrono de « %s »", truncate(task.Title, 40))
+	}
+	textStyle := lipgloss.NewStyle().Foreground(colorText)
+	keyStyle := lipgloss.NewStyle().Foreground(colorBlue).Bold(true)
+	choices := []string{
+		keyStyle.Render("k") + textStyle.Render(" "+i18n.T("garder ce temps")),
+		keyStyle.Render("t") + textStyle.Render(" "+i18n.T("le retirer, le chrono continue")),
+		keyStyle.Render("d") + textStyle.Render(" "+i18n.T("le retirer et arrêter le chrono")),
+	}
+	help := lipgloss.NewStyle().Foreground(colorOverlay0).Render(i18n.T("Esc: garder"))
+
+	content := title + "\n\n" + textStyle.Render(text) + "\n\n" + strings.Join(choices, "\n") + "\n\n" + help
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
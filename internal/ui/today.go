@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetToday narrows the list to the working set of the day (see
+// model.Task.InToday), for the today view, or shows the whole backlog
+func (l *ListView) SetToday(today bool) {
+	if l.today == today {
+		return
+	}
+	id := l.selectedID()
+	l.today = today
+	l.applyFilter()
+	l.organizeItems()
+	if !l.SelectTask(id) {
+		l.cursor = 0
+		l.adjustCursor()
+	}
+}
+
+// pinToday pins the selected task to the today view, or unpins it
+func (a *App) pinToday() tea.Cmd {
+	task := a.selectedTask()
+	if task == nil {
+		return nil
+	}
+	now := time.Now()
+	task.PinToday(now)
+	if task.IsPinnedToday(now) {
+		a.setMessage(i18n.T("Ajoutée à aujourd'hui"))
+	} else {
+		a.setMessage(i18n.T("Retirée d'aujourd'hui"))
+	}
+	return a.updateTask(*task)
+}
+
+// todayBadge marks a task pinned to the today view
+func todayBadge(task model.Task, now time.Time) string {
+	if !task.IsPinnedToday(now) {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(colorYellow).Render("☀")
+}
+
+// forToday returns the hints of an empty today view: how to pin tasks to it
+func (h *emptyHints) forToday() []string {
+	if h == nil {
+		return nil
+	}
+	return []string{
+		i18n.Tf("%s: ajouter la tâche sélectionnée à aujourd'hui, depuis la liste", h.pinToday),
+		i18n.Tf("%s: ajouter une tâche", h.add),
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"slices"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// undoDelay is how long a deleted task can be brought back before its
+// deletion is queued to the storage
+const undoDelay = 5 * time.Second
+
+// pendingDelete is a deleted task hidden from the views but not deleted
+// from the file yet, while its toast is shown
+type pendingDelete struct {
+	task model.Task
+	at   int // position in App.tasks, to put it back in place
+	seq  int // of the toast, to ignore the timers of earlier ones
+}
+
+// deleteExpiredMsg is sent when the toast of a deletion times out
+type deleteExpiredMsg struct{ seq int }
+
+// deleteLater hides a task and shows the toast offering to undo its
+// deletion, which is committed when the toast times out. An earlier
+// deletion still pending is committed first.
+func (a *App) deleteLater(task model.Task) tea.Cmd {
+	if a.readOnly {
+		a.setMessage(i18n.T(storage.ErrReadOnly.Error()))
+		return nil
+	}
+	cmd := a.commitDelete()
+
+	at := slices.IndexFunc(a.tasks, func(t model.Task) bool { return t.ID == task.ID })
+	if at < 0 {
+		return cmd
+	}
+	a.tasks = slices.Delete(slices.Clone(a.tasks), at, at+1)
+	a.refreshViews()
+	a.checklist.Refresh(a.tasks)
+
+	a.deleteSeq++
+	seq := a.deleteSeq
+	a.trash = &pendingDelete{task: task, at: at, seq: seq}
+	expire := tea.Tick(undoDelay, func(time.Time) tea.Msg { return deleteExpiredMsg{seq} })
+	return tea.Batch(cmd, expire)
+}
+
+// commitDelete commits the pending deletion, if any
+func (a *App) commitDelete() tea.Cmd {
+	if a.trash == nil {
+		return nil
+	}
+	id := a.trash.task.ID
+	a.trash = nil
+	return a.commit(storage.Changes{Deleted: []string{id}})
+}
+
+// undoDelete puts the task of the pending deletion back and selects it
+func (a *App) undoDelete() {
+	if a.trash == nil {
+		return
+	}
+	task, at := a.trash.task, min(a.trash.at, len(a.tasks))
+	a.trash = nil
+	a.tasks = slices.Insert(slices.Clone(a.tasks), at, task)
+	a.refreshViews()
+	a.checklist.Refresh(a.tasks)
+	a.currentView().SelectTask(task.ID)
+}
+
+// hideTrash removes the task of the pending deletion from tasks loaded
+// from the file
+func (a *App) hideTrash(tasks []model.Task) []model.Task {
+	if a.trash == nil {
+		return tasks
+	}
+	return storage.Changes{Deleted: []string{a.trash.task.ID}}.Apply(tasks)
+}
+
+// renderDeleteToast renders the footer shown while a deletion can be
+// undone
+func (a *App) renderDeleteToast() string {
+	text := "🗑 " + i18n.Tf("Tâche supprimée: %s", truncate(a.trash.task.Title, 40)) +
+		"  " + i18n.Tf("%s pour annuler", a.keys.Undo.Help().Key)
+	return lipgloss.NewStyle().
+		Padding(0, 1).
+		Bold(true).
+		Background(colorMantle).
+		Foreground(colorPeach).
+		Width(a.width).
+		Render(text)
+}
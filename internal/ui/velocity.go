@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// velocityBarWidth is the width of the longest bar of the chart
+	velocityBarWidth = 30
+	// velocitySprints is how many sprints the chart shows, the latest
+	velocitySprints = 8
+	// velocityAverage is how many ended sprints the suggestion averages
+	velocityAverage = 3
+)
+
+// estimateBadge renders the estimate of a task in points
+func estimateBadge(task model.Task) string {
+	if task.Estimate == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(colorOverlay1).Render(i18n.N(task.Estimate, "%d pt", "%d pts"))
+}
+
+// handleVelocityKeys closes the velocity chart
+func (a *App) handleVelocityKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, a.keys.Velocity):
+		a.state = StateNormal
+	}
+	return a, nil
+}
+
+// renderVelocity renders the done points (or tasks, without estimates) of
+// the latest sprints as a bar chart, with the average to commit to next
+func (a *App) renderVelocity() string {
+	now := time.Now()
+	title := a.styles.DialogTitle.Render(i18n.T("Vélocité par sprint"))
+	textStyle := lipgloss.NewStyle().Foreground(colorText)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+
+	velocities := model.SprintVelocities(a.tasks, now)
+	if len(velocities) == 0 {
+		content := title + "\n\n" + textStyle.Render(i18n.T("Aucun sprint commencé (sprints dans la configuration)")) +
+			"\n\n" + mutedStyle.Render(i18n.T("Esc: fermer"))
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.styles.Dialog.Render(content))
+	}
+
+	// Charts points once tasks are estimated, tasks otherwise
+	usePoints := false
+	for _, v := range velocities {
+		if v.PlannedPoints > 0 {
+			usePoints = true
+		}
+	}
+	value := func(v model.Velocity) (done, planned int) {
+		if usePoints {
+			return v.DonePoints, v.PlannedPoints
+		}
+		return v.Done, v.Planned
+	}
+
+	shown := velocities
+	if len(shown) > velocitySprints {
+		shown = shown[len(shown)-velocitySprints:]
+	}
+	nameWidth, scale := 0, 1
+	for _, v := range shown {
+		nameWidth = max(nameWidth, lipgloss.Width(v.Sprint.Name))
+		_, planned := value(v)
+		scale = max(scale, planned)
+	}
+
+	doneStyle := lipgloss.NewStyle().Foreground(colorGreen)
+	leftStyle := lipgloss.NewStyle().Foreground(colorSurface2)
+	var lines []string
+	for _, v := range shown {
+		done, planned := value(v)
+		doneWidth := done * velocityBarWidth / scale
+		plannedWidth := planned * velocityBarWidth / scale
+		bar := doneStyle.Render(strings.Repeat("█", doneWidth)) +
+			leftStyle.Render(strings.Repeat("░", plannedWidth-doneWidth)) +
+			strings.Repeat(" ", velocityBarWidth-plannedWidth)
+
+		label := i18n.Nf(planned, "%d/%d tâche", "%d/%d tâches", done, planned)
+		if usePoints {
+			label = i18n.Nf(planned, "%d/%d pt", "%d/%d pts", done, planned)
+		}
+		if v.Sprint.Contains(now) {
+			label += " " + i18n.T("(en cours)")
+		}
+		name := v.Sprint.Name + strings.Repeat(" ", nameWidth-lipgloss.Width(v.Sprint.Name))
+		lines = append(lines, textStyle.Render(name)+"  "+bar+"  "+mutedStyle.Render(label))
+	}
+
+	summary := i18n.T("Aucun sprint terminé pour estimer la vélocité")
+	if done, points, count := model.AverageVelocity(velocities, now, velocityAverage); count > 0 {
+		avg := i18n.Nf(done, "%s tâche", "%s tâches", formatAverage(done))
+		if usePoints {
+			avg = i18n.Nf(points, "%s pt", "%s pts", formatAverage(points))
+		}
+		summary = i18n.Nf(count, "Moyenne du dernier sprint: %[2]s par sprint", "Moyenne des %d derniers sprints: %s par sprint", count, avg)
+		if next, ok := model.NextSprint(now); ok {
+			summary += "\n" + i18n.Tf("À engager pour « %s »: environ %s", next.Name, avg)
+		}
+	}
+
+	content := title + "\n\n" + strings.Join(lines, "\n") + "\n\n" +
+		lipgloss.NewStyle().Foreground(colorYellow).Render(summary) + "\n\n" +
+		mutedStyle.Render(i18n.T("Esc: fermer"))
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
+
+// formatAverage formats an average with at most one decimal
+func formatAverage(f float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", f), ".0")
+}
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// planDays is how many days the week planning schedules tasks on,
+	// from today
+	planDays = 7
+	// planBarWidth is the width of the bar of the busiest day of the load
+	planBarWidth = 20
+)
+
+// planKeys are the keys of the weekdays in the week planning, Sunday first
+// like time.Weekday; digits pick the days in order as well
+var planKeys = [...]string{"u", "m", "t", "w", "h", "f", "a"}
+
+// weekPlan is the week planning: the open tasks without a due date,
+// stepped through to give each a day of the coming week
+type weekPlan struct {
+	start time.Time    // midnight today, the first day
+	tasks []model.Task // to schedule, highest priority first
+	days  []int        // day given to each task, from start; -1 when skipped
+	at    int          // task being scheduled; len(tasks) on the summary
+	load  []int        // open tasks already due on each day
+}
+
+// openWeekPlan starts the week planning on the open tasks without a due
+// date
+func (a *App) openWeekPlan() {
+	now := time.Now()
+	var indices []int
+	for i, t := range a.tasks {
+		if t.DueDate == nil && !t.Status.IsDone() {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) == 0 {
+		a.setMessage(i18n.T("Aucune tâche ouverte sans échéance à planifier"))
+		return
+	}
+	model.SortIndices(a.tasks, indices, model.SortByPriority)
+
+	y, m, d := now.Date()
+	plan := &weekPlan{start: time.Date(y, m, d, 0, 0, 0, 0, now.Location())}
+	for _, i := range indices {
+		plan.tasks = append(plan.tasks, a.tasks[i])
+		plan.days = append(plan.days, -1)
+	}
+	plan.load, _ = a.index.DueLoad(now, planDays)
+	a.plan = plan
+	a.state = StateWeekPlan
+}
+
+// dayOfKey returns the day of the plan a key picks, from start
+func (p *weekPlan) dayOfKey(k string) (int, bool) {
+	if len(k) == 1 && k[0] >= '1' && k[0] < '1'+planDays {
+		return int(k[0] - '1'), true
+	}
+	weekday := slices.Index(planKeys[:], k)
+	if weekday < 0 {
+		return 0, false
+	}
+	return (weekday - int(p.start.Weekday()) + 7) % 7, true
+}
+
+// date returns the date of a day of the plan
+func (p *weekPlan) date(day int) time.Time {
+	return p.start.AddDate(0, 0, day)
+}
+
+// planned returns the number of tasks given each day of the plan
+func (p *weekPlan) planned() []int {
+	planned := make([]int, planDays)
+	for _, day := range p.days {
+		if day >= 0 {
+			planned[day]++
+		}
+	}
+	return planned
+}
+
+// handleWeekPlanKeys gives the task being scheduled a day, skips it or
+// goes back to the previous one, and saves the schedule from the summary
+func (a *App) handleWeekPlanKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := a.plan
+	switch k := msg.String(); k {
+	case "esc", "q":
+		a.plan = nil
+		a.state = StateNormal
+	case "backspace", "left":
+		p.at = max(p.at-1, 0)
+	case " ", "right":
+		p.at = min(p.at+1, len(p.tasks))
+	case "enter":
+		if p.at == len(p.tasks) {
+			a.plan = nil
+			a.state = StateNormal
+			return a, a.saveWeekPlan(p)
+		}
+	default:
+		if day, ok := p.dayOfKey(k); ok && p.at < len(p.tasks) {
+			p.days[p.at] = day
+			p.at++
+		}
+	}
+	return a, nil
+}
+
+// saveWeekPlan sets the due dates of the scheduled tasks, on the current
+// version of the tasks
+func (a *App) saveWeekPlan(p *weekPlan) tea.Cmd {
+	var changes storage.Changes
+	for i, day := range p.days {
+		j := a.index.Find(p.tasks[i].ID)
+		if day < 0 || j < 0 {
+			continue
+		}
+		task := a.tasks[j]
+		due := p.date(day)
+		task.DueDate = &due
+		changes.Updated = append(changes.Updated, task)
+	}
+	if len(changes.Updated) == 0 {
+		return nil
+	}
+	a.setMessage(i18n.N(len(changes.Updated), "%d tâche planifiée", "%d tâches planifiées"))
+	return a.commit(changes)
+}
+
+// renderWeekPlan renders the task being scheduled with the days to pick
+// from, or the load of the week once all of them were seen
+func (a *App) renderWeekPlan() string {
+	p := a.plan
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay0)
+
+	var content string
+	if p.at < len(p.tasks) {
+		title := a.styles.DialogTitle.Render(i18n.Tf("Planifier la semaine (%d/%d)", p.at+1, len(p.tasks)))
+		content = title + "\n\n" + a.renderPlanTask(p.tasks[p.at]) + "\n\n" + a.renderPlanDays(p) + "\n\n" +
+			mutedStyle.Render(i18n.T("lettre ou chiffre: jour · espace: passer · backspace: précédente · Esc: annuler"))
+	} else {
+		title := a.styles.DialogTitle.Render(i18n.T("Charge de la semaine"))
+		content = title + "\n\n" + a.renderPlanLoad(p) + "\n\n" +
+			mutedStyle.Render(i18n.T("Entrée: enregistrer · backspace: revenir · Esc: annuler"))
+	}
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
+
+// renderPlanTask renders the task being scheduled
+func (a *App) renderPlanTask(task model.Task) string {
+	line := a.styles.PriorityStyle(task.Priority).Render(PriorityIcon(task.Priority)) + " "
+	if task.Number > 0 {
+		line += lipgloss.NewStyle().Foreground(colorOverlay1).Render(task.ShortID()) + " "
+	}
+	line += lipgloss.NewStyle().Foreground(colorText).Bold(true).Render(truncate(task.Title, 50))
+	for _, tag := range task.Tags {
+		line += " " + a.styles.TagStyle(tag).Render(tag)
+	}
+	return line
+}
+
+// renderPlanDays renders the days to pick from with their keys and the
+// tasks due on them, the one given to the current task highlighted
+func (a *App) renderPlanDays(p *weekPlan) string {
+	keyStyle := a.styles.HelpKey
+	planned := p.planned()
+	var cells []string
+	for day := range planDays {
+		date := p.date(day)
+		name := capitalize(shortLabel(weekdayLabel(date.Weekday()))) + " " + itoa(date.Day())
+		style := lipgloss.NewStyle().Foreground(colorSubtext0).Padding(0, 1)
+		if p.days[p.at] == day {
+			style = style.Background(colorSurface1).Foreground(colorText)
+		}
+		count := lipgloss.NewStyle().Foreground(colorOverlay1).Render(itoa(p.load[day] + planned[day]))
+		cells = append(cells, keyStyle.Render(planKeys[date.Weekday()])+style.Render(name)+count)
+	}
+	return strings.Join(cells, "  ")
+}
+
+// renderPlanLoad renders the tasks due each day of the week once the
+// plan is saved, the planned ones in a brighter color
+func (a *App) renderPlanLoad(p *weekPlan) string {
+	planned := p.planned()
+	scale := 1
+	for day := range planDays {
+		scale = max(scale, p.load[day]+planned[day])
+	}
+
+	dayStyle := lipgloss.NewStyle().Foreground(colorText).Width(8)
+	dueStyle := lipgloss.NewStyle().Foreground(colorSurface2)
+	plannedStyle := lipgloss.NewStyle().Foreground(colorBlue)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorOverlay1)
+	var lines []string
+	for day := range planDays {
+		date := p.date(day)
+		name := capitalize(shortLabel(weekdayLabel(date.Weekday()))) + " " + itoa(date.Day())
+		dueWidth := p.load[day] * planBarWidth / scale
+		plannedWidth := (p.load[day]+planned[day])*planBarWidth/scale - dueWidth
+		bar := dueStyle.Render(strings.Repeat("█", dueWidth)) +
+			plannedStyle.Render(strings.Repeat("█", plannedWidth)) +
+			strings.Repeat(" ", planBarWidth-dueWidth-plannedWidth)
+		label := itoa(p.load[day] + planned[day])
+		if planned[day] > 0 {
+			label += " (+" + itoa(planned[day]) + ")"
+		}
+		lines = append(lines, dayStyle.Render(name)+bar+" "+mutedStyle.Render(label))
+	}
+
+	skipped := 0
+	for _, day := range p.days {
+		if day < 0 {
+			skipped++
+		}
+	}
+	if skipped > 0 {
+		lines = append(lines, "", mutedStyle.Render(i18n.N(skipped, "%d tâche laissée sans échéance", "%d tâches laissées sans échéance")))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// moveTask saves a task moved to another status, asking first when that
+// moves it into a column at its WIP limit
+func (a *App) moveTask(task model.Task) tea.Cmd {
+	for _, t := range a.tasks {
+		if t.ID == task.ID && t.Status.Column() != task.Status.Column() && a.kanbanView.IsFull(task.Status) {
+			a.wipMove = &task
+			a.state = StateConfirmWIP
+			return nil
+		}
+	}
+	return a.updateTask(task)
+}
+
+// handleWIPConfirmKeys handles the prompt shown before exceeding a WIP
+// limit
+func (a *App) handleWIPConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		a.state = StateNormal
+		task := *a.wipMove
+		a.wipMove = nil
+		return a, a.updateTask(task)
+	case "n", "N", "esc":
+		a.state = StateNormal
+		a.wipMove = nil
+	}
+	return a, nil
+}
+
+// renderWIPConfirm renders the prompt shown before exceeding a WIP limit
+func (a *App) renderWIPConfirm() string {
+	status := a.wipMove.Status
+	load := a.index.ColumnLoad(status.Column())
+	title := a.styles.DialogTitle.Render(i18n.T("Limite de travail en cours atteinte"))
+	text := lipgloss.NewStyle().
+		Foreground(colorText).
+		Render(i18n.Nf(load, "« %s » a déjà %d tâche pour une limite de %d.\nDéplacer « %s » quand même?",
+			"« %s » a déjà %d tâches pour une limite de %d.\nDéplacer « %s » quand même?",
+			status.Label(), load, a.kanbanView.WIPLimit(status), a.wipMove.Title))
+
+	buttons := a.styles.FormButton.Render("(Y)es") + "  " +
+		a.styles.FormButtonFocus.Render("(N)o")
+
+	content := title + "\n\n" + text + "\n\n" + buttons
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		a.styles.Dialog.Render(content),
+	)
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+
+	"lazy-todo/internal/i18n"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// readingSpeed is the words read in a minute, for the reading time
+const readingSpeed = 200
+
+// defaultLongDescription is the number of words past which the form warns
+// that a description is long, when the config doesn't set it
+const defaultLongDescription = 300
+
+// wordCount returns the number of words of a text
+func wordCount(text string) int {
+	return len(strings.Fields(text))
+}
+
+// readingMinutes returns the minutes it takes to read words, rounded up
+func readingMinutes(words int) int {
+	return (words + readingSpeed - 1) / readingSpeed
+}
+
+// renderReadingTime renders the words of a text and the time to read them,
+// like "450 mots · 3 min de lecture"
+func renderReadingTime(words int) string {
+	return i18n.N(words, "%d mot", "%d mots") + " · " +
+		i18n.N(readingMinutes(words), "%d min de lecture", "%d min de lecture")
+}
+
+// SetLongDescription sets the number of words past which the form warns
+// that the description is long; defaultLongDescription when zero, never
+// when negative
+func (f *TaskForm) SetLongDescription(words int) {
+	if words == 0 {
+		words = defaultLongDescription
+	}
+	f.longDescription = words
+}
+
+// renderLongDescription renders the warning shown under a description
+// longer than the limit, empty otherwise
+func (f *TaskForm) renderLongDescription() string {
+	words := wordCount(f.Description())
+	if f.longDescription < 0 || words <= f.longDescription {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Foreground(colorYellow).
+		Width(f.descInput.Width() + 2).
+		Render(i18n.N(words, "Description longue (%d mot): un lien vers un document serait plus lisible",
+			"Description longue (%d mots): un lien vers un document serait plus lisible"))
+}
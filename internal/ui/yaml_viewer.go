@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"strings"
+
+	"lazy-todo/internal/i18n"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// YAMLViewer displays read-only, highlighted YAML content
+type YAMLViewer struct {
+	styles   Styles
+	viewport viewport.Model
+	title    string
+	raw      string
+	width    int
+	height   int
+}
+
+// NewYAMLViewer creates a new YAML viewer
+func NewYAMLViewer(styles Styles) *YAMLViewer {
+	return &YAMLViewer{
+		styles:   styles,
+		viewport: viewport.New(0, 0),
+	}
+}
+
+// SetSize sets the viewer dimensions
+func (v *YAMLViewer) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	// Account for borders, padding, title and help line
+	v.viewport.Width = width - 8
+	v.viewport.Height = height - 8
+	if v.viewport.Height < 1 {
+		v.viewport.Height = 1
+	}
+}
+
+// SetContent sets the YAML content and title to display
+func (v *YAMLViewer) SetContent(title, raw string) {
+	v.title = title
+	v.raw = raw
+	v.viewport.SetContent(v.highlight(raw))
+	v.viewport.GotoTop()
+}
+
+// Raw returns the unhighlighted content
+func (v *YAMLViewer) Raw() string {
+	return v.raw
+}
+
+// Update handles scrolling
+func (v *YAMLViewer) Update(msg tea.Msg) (*YAMLViewer, tea.Cmd) {
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+// Render renders the viewer
+func (v *YAMLViewer) Render() string {
+	title := v.styles.DialogTitle.Render(v.title)
+
+	help := lipgloss.NewStyle().
+		Foreground(colorOverlay0).
+		Render(i18n.T("j/k: défiler, y: copier, Esc: fermer"))
+
+	content := title + "\n\n" + v.viewport.View() + "\n\n" + help
+
+	return v.styles.HelpPanel.
+		Width(v.width - 4).
+		Height(v.height - 4).
+		Render(content)
+}
+
+// highlight applies syntax highlighting to YAML content line by line
+func (v *YAMLViewer) highlight(raw string) string {
+	lines := strings.Split(strings.TrimRight(raw, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = v.highlightLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// highlightLine highlights a single YAML line
+func (v *YAMLViewer) highlightLine(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(trimmed)]
+
+	if trimmed == "" {
+		return line
+	}
+	if strings.HasPrefix(trimmed, "#") {
+		return indent + v.styles.YAMLComment.Render(trimmed)
+	}
+
+	var prefix string
+	if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+		prefix = v.styles.YAMLPunct.Render("-")
+		trimmed = strings.TrimPrefix(strings.TrimPrefix(trimmed, "-"), " ")
+		if trimmed == "" {
+			return indent + prefix
+		}
+		prefix += " "
+	}
+
+	// Key: value pair (ignore colons inside quoted scalars)
+	if idx := strings.Index(trimmed, ":"); idx > 0 && !strings.HasPrefix(trimmed, "\"") && !strings.HasPrefix(trimmed, "'") {
+		if idx == len(trimmed)-1 || trimmed[idx+1] == ' ' {
+			key := trimmed[:idx]
+			value := strings.TrimPrefix(trimmed[idx+1:], " ")
+			out := indent + prefix + v.styles.YAMLKey.Render(key) + v.styles.YAMLPunct.Render(":")
+			if value != "" {
+				out += " " + v.highlightScalar(value)
+			}
+			return out
+		}
+	}
+
+	return indent + prefix + v.highlightScalar(trimmed)
+}
+
+// highlightScalar highlights a YAML scalar value
+func (v *YAMLViewer) highlightScalar(value string) string {
+	switch {
+	case strings.HasPrefix(value, "\"") || strings.HasPrefix(value, "'"):
+		return v.styles.YAMLString.Render(value)
+	case value == "true" || value == "false" || value == "null" || value == "~":
+		return v.styles.YAMLScalar.Render(value)
+	case value == "[]" || value == "{}" || value == "|" || value == ">" || value == "|-" || value == ">-":
+		return v.styles.YAMLPunct.Render(value)
+	case isNumeric(value):
+		return v.styles.YAMLScalar.Render(value)
+	default:
+		return v.styles.YAMLString.Render(value)
+	}
+}
+
+// isNumeric returns true if the string only contains digits and number punctuation
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && r != '.' && r != '-' && r != '+' {
+			return false
+		}
+	}
+	return true
+}
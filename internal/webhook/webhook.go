@@ -0,0 +1,348 @@
+// Package webhook posts task events (created, completed, deleted...) to
+// HTTP endpoints, for automation tools like n8n or Zapier. Requests are
+// retried with a doubling delay, and the ones that still fail are kept in
+// a dead-letter log to be sent again later.
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+)
+
+// Events sent to webhooks
+const (
+	EventCreated       = "created"
+	EventUpdated       = "updated"
+	EventCompleted     = "completed"
+	EventReopened      = "reopened"
+	EventStatusChanged = "status_changed"
+	EventDeleted       = "deleted"
+)
+
+// AllEvents lists the events, in the order they're sent for a change
+func AllEvents() []string {
+	return []string{EventCreated, EventUpdated, EventStatusChanged, EventCompleted, EventReopened, EventDeleted}
+}
+
+// Defaults of the webhook settings
+const (
+	DefaultRetries = 3
+	DefaultTimeout = 10 * time.Second
+	firstDelay     = time.Second
+)
+
+// Errors returned when webhooks can't be used
+var (
+	ErrNoURL  = errors.New("webhook sans URL (webhooks.url)")
+	ErrFailed = errors.New("webhook en échec, gardé pour un nouvel envoi (lazy-todo webhooks --replay)")
+)
+
+// Event is a change of a task, as sent to webhooks
+type Event struct {
+	Event string     `json:"event"`
+	Time  time.Time  `json:"time"`
+	File  string     `json:"file,omitempty"`
+	Task  model.Task `json:"task"`
+	// Previous is the status before a status change
+	Previous model.Status `json:"previous_status,omitempty"`
+}
+
+// Events returns the events of a batch of changes, compared with the tasks
+// before it: a status change sends status_changed, and completed or
+// reopened when the task crosses the done line, instead of updated
+func Events(c storage.Changes, before []model.Task, file string, now time.Time) []Event {
+	old := make(map[string]model.Task, len(before))
+	for _, t := range before {
+		old[t.ID] = t
+	}
+
+	var events []Event
+	for _, t := range c.Added {
+		events = append(events, Event{Event: EventCreated, Time: now, File: file, Task: t})
+	}
+	for _, t := range c.Updated {
+		prev, ok := old[t.ID]
+		if !ok || prev.Status == t.Status {
+			events = append(events, Event{Event: EventUpdated, Time: now, File: file, Task: t})
+			continue
+		}
+		e := Event{Event: EventStatusChanged, Time: now, File: file, Task: t, Previous: prev.Status}
+		events = append(events, e)
+		switch {
+		case t.Status.IsDone() && !prev.Status.IsDone():
+			e.Event = EventCompleted
+			events = append(events, e)
+		case !t.Status.IsDone() && prev.Status.IsDone():
+			e.Event = EventReopened
+			events = append(events, e)
+		}
+	}
+	for _, id := range c.Deleted {
+		if t, ok := old[id]; ok {
+			events = append(events, Event{Event: EventDeleted, Time: now, File: file, Task: t})
+		}
+	}
+	return events
+}
+
+// hook is a configured webhook, with its parsed template
+type hook struct {
+	cfg      config.WebhookConfig
+	template *template.Template
+}
+
+// Sender delivers events to the configured webhooks
+type Sender struct {
+	hooks      []hook
+	deadLetter string
+}
+
+// New returns a sender for the configured webhooks, failing on a missing
+// URL, an unknown event or a template that doesn't parse
+func New(cfgs []config.WebhookConfig) (*Sender, error) {
+	s := &Sender{deadLetter: DeadLetterPath()}
+	for _, cfg := range cfgs {
+		if cfg.URL == "" {
+			return nil, ErrNoURL
+		}
+		for _, e := range cfg.Events {
+			if !slices.Contains(AllEvents(), e) {
+				return nil, errors.New(i18n.Tf("événement de webhook inconnu: %s", e))
+			}
+		}
+		h := hook{cfg: cfg}
+		if cfg.Template != "" {
+			t, err := template.New(cfg.URL).Funcs(template.FuncMap{"json": toJSON}).Parse(cfg.Template)
+			if err != nil {
+				return nil, err
+			}
+			h.template = t
+		}
+		s.hooks = append(s.hooks, h)
+	}
+	return s, nil
+}
+
+// toJSON writes a value of a template as JSON, to embed titles safely
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// DeadLetterPath returns the log of the failed deliveries, in the data
+// directory next to the default tasks file
+func DeadLetterPath() string {
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "webhooks-failed.jsonl"
+		}
+		dataDir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataDir, "lazy-todo", "webhooks-failed.jsonl")
+}
+
+// Send delivers the events to the webhooks subscribed to them, retrying
+// each request; the failed ones are added to the dead-letter log and
+// ErrFailed is returned. It blocks until done, so it runs in the
+// background.
+func (s *Sender) Send(events []Event) error {
+	var failed bool
+	for _, e := range events {
+		for _, h := range s.hooks {
+			if len(h.cfg.Events) > 0 && !slices.Contains(h.cfg.Events, e.Event) {
+				continue
+			}
+			body, err := h.body(e)
+			if err == nil {
+				err = s.deliver(h.cfg, body)
+			}
+			if err != nil {
+				failed = true
+				if err := s.bury(h.cfg, e.Event, body, err); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if failed {
+		return ErrFailed
+	}
+	return nil
+}
+
+// body renders the body of an event for a webhook
+func (h hook) body(e Event) ([]byte, error) {
+	if h.template == nil {
+		return json.Marshal(e)
+	}
+	var b bytes.Buffer
+	if err := h.template.Execute(&b, e); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// deliver sends a request, retrying with a doubling delay
+func (s *Sender) deliver(cfg config.WebhookConfig, body []byte) error {
+	retries := cfg.Retries
+	if retries == 0 {
+		retries = DefaultRetries
+	}
+	delay := firstDelay
+	var err error
+	for attempt := 0; attempt <= max(retries, 0); attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+		if err = request(cfg, body); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// request sends the body once. Header values can use environment variables
+// ("Bearer $N8N_TOKEN").
+func request(cfg config.WebhookConfig, body []byte) error {
+	method := cfg.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	req, err := http.NewRequest(strings.ToUpper(method), cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "lazy-todo")
+	for name, value := range cfg.Headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 300 {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
+
+// Failure is a delivery kept in the dead-letter log
+type Failure struct {
+	Time   time.Time `json:"time"`
+	URL    string    `json:"url"`
+	Method string    `json:"method,omitempty"`
+	Event  string    `json:"event"`
+	Body   string    `json:"body"`
+	Error  string    `json:"error"`
+}
+
+// bury adds a failed delivery to the dead-letter log
+func (s *Sender) bury(cfg config.WebhookConfig, event string, body []byte, cause error) error {
+	data, err := json.Marshal(Failure{
+		Time:   time.Now(),
+		URL:    cfg.URL,
+		Method: cfg.Method,
+		Event:  event,
+		Body:   string(body),
+		Error:  cause.Error(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.deadLetter), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.deadLetter, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// Failures returns the deliveries of the dead-letter log, oldest first
+func (s *Sender) Failures() ([]Failure, error) {
+	f, err := os.Open(s.deadLetter)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var failures []Failure
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var failure Failure
+		if json.Unmarshal(scanner.Bytes(), &failure) == nil {
+			failures = append(failures, failure)
+		}
+	}
+	return failures, scanner.Err()
+}
+
+// Replay sends the deliveries of the dead-letter log again, once each,
+// with the headers of the webhook of the same URL, and keeps the ones that
+// still fail
+func (s *Sender) Replay() (sent int, failed []Failure, err error) {
+	failures, err := s.Failures()
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, f := range failures {
+		cfg := config.WebhookConfig{URL: f.URL, Method: f.Method, Retries: -1}
+		if i := slices.IndexFunc(s.hooks, func(h hook) bool { return h.cfg.URL == f.URL }); i >= 0 {
+			cfg.Headers = s.hooks[i].cfg.Headers
+			cfg.Timeout = s.hooks[i].cfg.Timeout
+		}
+		if err := request(cfg, []byte(f.Body)); err != nil {
+			f.Error = err.Error()
+			failed = append(failed, f)
+			continue
+		}
+		sent++
+	}
+
+	var b bytes.Buffer
+	for _, f := range failed {
+		data, err := json.Marshal(f)
+		if err != nil {
+			return sent, failed, err
+		}
+		b.Write(append(data, '\n'))
+	}
+	if len(failures) == 0 {
+		return 0, nil, nil
+	}
+	return sent, failed, os.WriteFile(s.deadLetter, b.Bytes(), 0600)
+}
@@ -0,0 +1,291 @@
+// Package websocket is the server side of the WebSocket protocol (RFC
+// 6455), enough to push messages to browsers: the handshake, text
+// messages, pings and the closing handshake. Messages from the client are
+// read only to answer its pings and its close.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// acceptGUID is appended to the key of the client to compute the accept
+// header of the handshake
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// MaxMessageSize is the largest message read from a client
+const MaxMessageSize = 64 << 10
+
+// writeTimeout is how long a frame can take to be written
+const writeTimeout = 10 * time.Second
+
+// Opcodes of the frames
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// Errors of the connections
+var (
+	ErrHandshake = errors.New("requête de connexion WebSocket invalide")
+	ErrOrigin    = errors.New("origine de la connexion WebSocket non autorisée")
+	ErrClosed    = errors.New("connexion WebSocket fermée")
+	ErrTooLarge  = errors.New("message WebSocket trop grand")
+	ErrProtocol  = errors.New("trame WebSocket invalide")
+)
+
+// Conn is a WebSocket connection accepted by Upgrade
+type Conn struct {
+	conn net.Conn
+	r    *bufio.Reader
+
+	mu     sync.Mutex // held while writing a frame
+	closed bool       // the close frame was sent
+}
+
+// Upgrade completes the handshake of a WebSocket request and takes over
+// its connection. It fails with ErrHandshake, or ErrOrigin for a page of
+// another site (see CheckOrigin), before writing anything, so the caller
+// can answer with an error.
+func Upgrade(w http.ResponseWriter, r *http.Request, origins []string) (*Conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet ||
+		!hasToken(r.Header.Get("Connection"), "upgrade") ||
+		!hasToken(r.Header.Get("Upgrade"), "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, ErrHandshake
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, ErrHandshake
+	}
+	if !CheckOrigin(r, origins) {
+		return nil, ErrOrigin
+	}
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, err
+	}
+	// No deadline set by the server remains on the connection
+	conn.SetDeadline(time.Time{})
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := io.WriteString(conn, response); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Conn{conn: conn, r: rw.Reader}, nil
+}
+
+// CheckOrigin returns true when a request may be upgraded from where it
+// comes from: browsers send the page opening the connection as Origin,
+// which must be served by the same host or be one of origins ("*" for
+// any). Clients other than browsers send none.
+func CheckOrigin(r *http.Request, origins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range origins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, r.Host)
+}
+
+// hasToken returns true if a comma separated header value holds a token,
+// ignoring case
+func hasToken(value, token string) bool {
+	for _, t := range strings.Split(value, ",") {
+		if strings.EqualFold(strings.TrimSpace(t), token) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptKey returns the accept header answering the key of a client
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// WriteText sends a text message
+func (c *Conn) WriteText(data []byte) error {
+	return c.writeFrame(opText, data)
+}
+
+// Ping sends a ping, which the client answers; it keeps idle connections
+// open through proxies
+func (c *Conn) Ping() error {
+	return c.writeFrame(opPing, nil)
+}
+
+// Close sends a normal close frame, if not sent yet, and closes the
+// connection
+func (c *Conn) Close() error {
+	c.writeFrame(opClose, closePayload(1000))
+	return c.conn.Close()
+}
+
+// closePayload returns the payload of a close frame with a status code
+func closePayload(code uint16) []byte {
+	return binary.BigEndian.AppendUint16(nil, code)
+}
+
+// writeFrame writes an unfragmented, unmasked frame; nothing can be sent
+// after a close frame
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	if opcode == opClose {
+		c.closed = true
+	}
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err := (&net.Buffers{header, payload}).WriteTo(c.conn)
+	return err
+}
+
+// Read returns the next text or binary message of the client, answering
+// pings meanwhile. Once the client closes the connection, its close is
+// answered and ErrClosed returned.
+func (c *Conn) Read() ([]byte, error) {
+	var message []byte
+	fragmented := false
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			code := uint16(1000)
+			if len(payload) >= 2 {
+				code = binary.BigEndian.Uint16(payload)
+			}
+			c.writeFrame(opClose, closePayload(code))
+			return nil, ErrClosed
+		case opText, opBinary:
+			if fragmented {
+				return nil, c.fail(ErrProtocol)
+			}
+		case opContinuation:
+			if !fragmented {
+				return nil, c.fail(ErrProtocol)
+			}
+		default:
+			return nil, c.fail(ErrProtocol)
+		}
+
+		if len(message)+len(payload) > MaxMessageSize {
+			return nil, c.fail(ErrTooLarge)
+		}
+		message = append(message, payload...)
+		if fin {
+			return message, nil
+		}
+		fragmented = true
+	}
+}
+
+// readFrame reads a frame, which clients must mask
+func (c *Conn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.r, head[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin = head[0]&0x80 != 0
+	opcode = head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	if head[0]&0x70 != 0 || !masked {
+		// No extension was negotiated
+		return false, 0, nil, c.fail(ErrProtocol)
+	}
+
+	n := uint64(head[1] & 0x7F)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if opcode >= opClose && (n > 125 || !fin) {
+		return false, 0, nil, c.fail(ErrProtocol)
+	}
+	if n > MaxMessageSize {
+		return false, 0, nil, c.fail(ErrTooLarge)
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload = make([]byte, n)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// fail closes the connection for a client that broke the protocol
+func (c *Conn) fail(err error) error {
+	code := uint16(1002)
+	if err == ErrTooLarge {
+		code = 1009
+	}
+	c.writeFrame(opClose, closePayload(code))
+	c.conn.Close()
+	return err
+}
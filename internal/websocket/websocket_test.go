@@ -0,0 +1,53 @@
+package websocket
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// handshake returns a WebSocket handshake request to host from origin
+func handshake(host, origin string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "http://"+host+"/ws", nil)
+	r.Header.Set("Connection", "Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Sec-WebSocket-Version", "13")
+	r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	if origin != "" {
+		r.Header.Set("Origin", origin)
+	}
+	return r
+}
+
+func TestCheckOrigin(t *testing.T) {
+	tests := []struct {
+		origin  string
+		allowed []string
+		want    bool
+	}{
+		{"", nil, true},
+		{"http://127.0.0.1:8765", nil, true},
+		{"http://evil.example", nil, false},
+		{"http://127.0.0.1:9999", nil, false},
+		{"null", nil, false},
+		{"http://overlay.example", []string{"http://overlay.example/"}, true},
+		{"http://evil.example", []string{"http://overlay.example"}, false},
+		{"http://evil.example", []string{"*"}, true},
+	}
+	for _, tt := range tests {
+		if got := CheckOrigin(handshake("127.0.0.1:8765", tt.origin), tt.allowed); got != tt.want {
+			t.Errorf("CheckOrigin(%q, %v) = %v, want %v", tt.origin, tt.allowed, got, tt.want)
+		}
+	}
+}
+
+func TestUpgradeCrossOrigin(t *testing.T) {
+	w := httptest.NewRecorder()
+	if _, err := Upgrade(w, handshake("127.0.0.1:8765", "http://evil.example"), nil); !errors.Is(err, ErrOrigin) {
+		t.Fatalf("Upgrade from another site: %v, want ErrOrigin", err)
+	}
+	if w.Body.Len() > 0 {
+		t.Errorf("wrote %q before refusing", w.Body.String())
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/integrations/jira"
+	"lazy-todo/internal/storage"
+)
+
+// runJira implements `lazy-todo jira`: it imports the issues of the
+// configured JQL query and updates the tasks imported before
+func runJira(args []string) {
+	fs := flag.NewFlagSet("jira", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	jql := fs.String("jql", "", i18n.T("Requête JQL, à la place de celle de la configuration"))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+	if *jql != "" {
+		cfg.Jira.JQL = *jql
+	}
+
+	client, err := jira.New(cfg.Jira)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de configuration: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	store.SetRotation(localRotation(cfg))
+	result, err := client.Sync(store)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	fmt.Println(i18n.N(result.Imported, "%d ticket importé", "%d tickets importés") + ", " +
+		i18n.N(result.Updated, "%d tâche mise à jour", "%d tâches mises à jour"))
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+)
+
+// runLint implements `lazy-todo lint`: it lists the problems of the tasks
+// file, like missing or duplicate IDs and unknown statuses, and saves
+// their repairs with --fix. It exits with 1 when problems are left, like
+// unknown priorities which are only reported.
+func runLint(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	fix := fs.Bool("fix", false, i18n.T("Enregistrer les réparations dans le fichier"))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	store.SetRotation(localRotation(cfg))
+	var problems []model.Problem
+	var err error
+	if *fix {
+		problems, err = store.Repair()
+	} else {
+		_, err = store.Load()
+		problems = store.Problems()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de chargement: %v\n"), err)
+		os.Exit(1)
+	}
+
+	for _, p := range problems {
+		fmt.Printf(i18n.T("tâche %d (%s): %s\n"), p.Index+1, p.Title, p)
+	}
+	// Unknown priorities are kept, --fix leaves them
+	kept := 0
+	for _, p := range problems {
+		if !p.Repaired() {
+			kept++
+		}
+	}
+	repaired := len(problems) - kept
+	switch {
+	case len(problems) == 0:
+		fmt.Println(i18n.T("Aucun problème"))
+	case repaired == 0:
+	case *fix:
+		fmt.Println(i18n.N(repaired, "%d problème réparé", "%d problèmes réparés"))
+	default:
+		fmt.Println(i18n.N(repaired, "%d problème, à réparer avec --fix", "%d problèmes, à réparer avec --fix"))
+	}
+	if kept > 0 {
+		fmt.Println(i18n.N(kept, "%d priorité inconnue gardée, à ajouter aux priorités de la configuration ou à changer", "%d priorités inconnues gardées, à ajouter aux priorités de la configuration ou à changer"))
+	}
+	if kept > 0 || (repaired > 0 && !*fix) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/model"
+	"lazy-todo/internal/storage"
+	"lazy-todo/internal/ui"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// runList implements `lazy-todo list`
+func runList(args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	filter := fs.String("filter", "", i18n.T("N'afficher que les tâches correspondant à cette recherche"))
+	all := fs.Bool("all", false, i18n.T("Afficher aussi les tâches terminées"))
+	watch := fs.Bool("watch", false, i18n.T("Réafficher la liste à chaque modification du fichier"))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+	ui.ApplyIcons(cfg)
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	render := func() {
+		tasks, err := store.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Erreur de chargement: %v\n"), err)
+			if !*watch {
+				os.Exit(1)
+			}
+			return
+		}
+
+		query := model.ParseQuery(*filter).WithTasks(tasks)
+		var selected []model.Task
+		for _, t := range tasks {
+			if (*all || !t.Status.IsDone()) && query.Matches(t) {
+				selected = append(selected, t)
+			}
+		}
+
+		now := time.Now()
+		if *watch {
+			// Clear the screen and show when the list was refreshed
+			fmt.Print("\x1b[H\x1b[2J")
+			fmt.Printf("%s — %s\n\n", store.GetFilePath(), now.Format("15:04:05"))
+		}
+		fmt.Print(ui.RenderTaskList(selected, terminalWidth(), now))
+	}
+
+	render()
+	if !*watch {
+		return
+	}
+
+	w, err := store.Watch()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), err)
+		os.Exit(1)
+	}
+	defer w.Close()
+	for range w.Changes() {
+		render()
+	}
+}
+
+// terminalWidth returns the width of the terminal, or 80 when the output
+// isn't one
+func terminalWidth() int {
+	if width, _, err := term.GetSize(os.Stdout.Fd()); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
@@ -4,7 +4,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
 	"lazy-todo/internal/storage"
 	"lazy-todo/internal/ui"
 
@@ -14,9 +18,74 @@ import (
 var version = "0.2.0"
 
 func main() {
+	// The language from the environment applies until the config and
+	// flags are read
+	i18n.SetLocale(i18n.FromEnv())
+
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			runExport(os.Args[2:])
+			return
+		case "import":
+			runImport(os.Args[2:])
+			return
+		case "list":
+			runList(os.Args[2:])
+			return
+		case "capture":
+			runCapture(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "backup":
+			runBackup(os.Args[2:])
+			return
+		case "restore":
+			runRestore(os.Args[2:])
+			return
+		case "github":
+			runGitHub(os.Args[2:])
+			return
+		case "jira":
+			runJira(os.Args[2:])
+			return
+		case "remind":
+			runRemind(os.Args[2:])
+			return
+		case "webhooks":
+			runWebhooks(os.Args[2:])
+			return
+		case "sync":
+			runSync(os.Args[2:])
+			return
+		case "lint":
+			runLint(os.Args[2:])
+			return
+		case "eod":
+			runEOD(os.Args[2:])
+			return
+		case "doctor":
+			runDoctor(os.Args[2:])
+			return
+		}
+	}
+
 	// Command line flags
-	filePath := flag.String("file", "", "Chemin vers le fichier de tâches (défaut: ~/.local/share/lazy-todo/tasks.yaml)")
-	showVersion := flag.Bool("version", false, "Afficher la version")
+	filePath := flag.String("file", "", i18n.T("Chemin vers le fichier de tâches (défaut: ~/.local/share/lazy-todo/tasks.yaml)"))
+	format := flag.String("format", "", i18n.T("Format du fichier de tâches: yaml, json ou markdown (défaut: selon l'extension)"))
+	configPath := flag.String("config", "", i18n.T("Chemin vers le fichier de configuration (défaut: ~/.config/lazy-todo/config.yaml)"))
+	lang := flag.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	readOnly := flag.Bool("read-only", false, i18n.T("Ouvrir le fichier en lecture seule (réunions, montage partagé)"))
+	showVersion := flag.Bool("version", false, i18n.T("Afficher la version"))
+	// Hidden: for screenshots and snapshots of the views
+	renderOnce := flag.Bool("render-once", false, i18n.T("Afficher une seule image de l'interface et quitter"))
+	width := flag.Int("width", 120, i18n.T("Largeur de l'image de --render-once"))
+	height := flag.Int("height", 40, i18n.T("Hauteur de l'image de --render-once"))
+	view := flag.String("view", "list", i18n.T("Vue de l'image de --render-once: list, kanban, calendar ou today"))
+	flag.Usage = usage
 	flag.Parse()
 
 	if *showVersion {
@@ -24,22 +93,163 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Determine file path
-	path := *filePath
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	// Create and run the app, a tab per tasks file
+	boards := openBoards(*filePath, flag.Args(), cfg)
+	for _, b := range boards {
+		b.Storage.SetRotation(localRotation(cfg))
+		b.Storage.SetReadOnly(*readOnly)
+		if *format == "" {
+			continue
+		}
+		if err := b.Storage.SetFormat(*format); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+			os.Exit(1)
+		}
+	}
+	app := ui.NewBoards(boards, cfg)
+
+	if *renderOnce {
+		printFrame(app, *width, *height, *view)
+		return
+	}
+
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if cfg.Display.FPS > 0 {
+		opts = append(opts, tea.WithFPS(cfg.Display.FPS))
+	}
+	p := tea.NewProgram(app, opts...)
+
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), err)
+		os.Exit(1)
+	}
+}
+
+// hiddenFlags aren't listed by -help, being meant for scripts
+var hiddenFlags = map[string]bool{"render-once": true, "width": true, "height": true, "view": true}
+
+// usage prints the flags of the command line, except the hidden ones
+func usage() {
+	visible := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	flag.VisitAll(func(f *flag.Flag) {
+		if !hiddenFlags[f.Name] {
+			visible.Var(f.Value, f.Name, f.Usage)
+			visible.Lookup(f.Name).DefValue = f.DefValue
+		}
+	})
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, i18n.T("Utilisation de %s:\n"), os.Args[0])
+	visible.SetOutput(out)
+	visible.PrintDefaults()
+}
+
+// printFrame prints a single frame of the interface and exits, colored
+// only when stdout is a terminal or CLICOLOR_FORCE is set
+func printFrame(app *ui.Boards, width, height int, view string) {
+	mode, ok := ui.ParseViewMode(view)
+	if !ok {
+		fmt.Fprintf(os.Stderr, i18n.T("Vue inconnue: %q (list, kanban, calendar ou today)\n"), view)
+		os.Exit(2)
+	}
+	if width <= 0 || height <= 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("--width et --height doivent être positifs"))
+		os.Exit(2)
+	}
+	frame, err := app.Frame(width, height, mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	fmt.Println(frame)
+}
+
+// resolveFilePath returns the tasks file path, falling back to the default
+func resolveFilePath(path string) string {
 	if path == "" {
-		path = storage.DefaultFilePath()
+		return storage.DefaultFilePath()
+	}
+	return path
+}
+
+// openBoards returns the boards to open: the files given on the command
+// line, else the boards of the config, else the default tasks file
+func openBoards(file string, args []string, cfg *config.Config) []ui.Board {
+	var boards []ui.Board
+	if file != "" {
+		args = append([]string{file}, args...)
+	}
+	for _, path := range args {
+		board := ui.NewBoard("", storage.NewStorage(path))
+		board.Theme = boardTheme(cfg, path)
+		boards = append(boards, board)
 	}
+	if len(boards) > 0 {
+		return boards
+	}
+
+	for _, b := range cfg.Boards {
+		board := ui.NewBoard(b.Name, storage.NewStorage(expandHome(b.File)))
+		board.Theme = b.ColumnTheme
+		boards = append(boards, board)
+	}
+	if len(boards) > 0 {
+		return boards
+	}
+	return []ui.Board{ui.NewBoard("", storage.NewStorage(storage.DefaultFilePath()))}
+}
 
-	// Create storage
-	store := storage.NewStorage(path)
+// boardTheme returns the column theme of the config board of a file, for
+// the files given on the command line
+func boardTheme(cfg *config.Config, path string) config.ColumnTheme {
+	abs, _ := filepath.Abs(path)
+	for _, b := range cfg.Boards {
+		if file, _ := filepath.Abs(expandHome(b.File)); file == abs {
+			return b.ColumnTheme
+		}
+	}
+	return config.ColumnTheme{}
+}
 
-	// Create and run the app
-	app := ui.NewApp(store)
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
 
-	p := tea.NewProgram(app, tea.WithAltScreen())
+// setLang selects the interface language given on the command line,
+// overriding the config and environment
+func setLang(lang string) {
+	if lang == "" {
+		return
+	}
+	l, ok := i18n.ParseLocale(lang)
+	if !ok {
+		fmt.Fprintf(os.Stderr, i18n.T("Langue inconnue: %q\n"), lang)
+		os.Exit(2)
+	}
+	i18n.SetLocale(l)
+}
 
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Erreur: %v\n", err)
+// loadConfig loads and applies the configuration, exiting on error
+func loadConfig(path string) *config.Config {
+	if path == "" {
+		path = config.DefaultConfigPath()
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de configuration: %v\n"), err)
 		os.Exit(1)
 	}
+	cfg.Apply()
+	return cfg
 }
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/notify"
+	"lazy-todo/internal/storage"
+)
+
+// runRemind implements `lazy-todo remind`: it shows the reminders of the
+// tasks as desktop notifications as they go off, until interrupted, or
+// once with --once (for cron)
+func runRemind(args []string) {
+	fs := flag.NewFlagSet("remind", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	once := fs.Bool("once", false, i18n.T("Afficher les rappels dus puis quitter"))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	notifier, err := notify.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	reminders := notify.NewReminders(storage.NewStorage(resolveFilePath(*filePath)), notifier, cfg.Reminders.Snooze)
+
+	check := func() {
+		if _, err := reminders.Check(time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+		}
+	}
+	if *once {
+		check()
+		// Give the notifications time to be snoozed
+		reminders.Wait()
+		return
+	}
+
+	interval := cfg.Reminders.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		check()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
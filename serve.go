@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"lazy-todo/internal/config"
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/server"
+	"lazy-todo/internal/storage"
+)
+
+// runServe implements `lazy-todo serve`: it serves the tasks file over
+// HTTP until interrupted
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	addr := fs.String("addr", "", i18n.Tf("Adresse d'écoute (défaut: %s)", config.DefaultServerAddr))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	if *addr == "" {
+		*addr = cfg.Server.Addr
+	}
+	if *addr == "" {
+		*addr = config.DefaultServerAddr
+	}
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	store.SetRotation(localRotation(cfg))
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(store, cfg).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	if cfg.Server.Token == "" && len(cfg.Server.Tokens) == 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("Attention: aucun jeton configuré (server.token), les requêtes ne sont pas authentifiées"))
+	}
+	fmt.Fprintf(os.Stderr, i18n.T("Écoute sur http://%s\n"), *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), err)
+		os.Exit(1)
+	}
+}
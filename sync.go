@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/integrations/caldav"
+	"lazy-todo/internal/storage"
+)
+
+// runSync implements `lazy-todo sync`: it syncs the tasks both ways with
+// the configured CalDAV task list
+func runSync(args []string) {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	filePath := fs.String("file", "", i18n.T("Chemin vers le fichier de tâches"))
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	client, err := caldav.New(cfg.CalDAV, cfg.ICS)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de configuration: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+
+	store := storage.NewStorage(resolveFilePath(*filePath))
+	store.SetRotation(localRotation(cfg))
+	result, err := client.Sync(store)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	fmt.Println(i18n.T("Local: ") + strings.Join([]string{
+		i18n.N(result.Imported, "%d tâche importée", "%d tâches importées"),
+		i18n.N(result.Updated, "%d tâche mise à jour", "%d tâches mises à jour"),
+		i18n.N(result.Deleted, "%d tâche supprimée", "%d tâches supprimées"),
+	}, ", "))
+	fmt.Println(i18n.T("Serveur: ") + strings.Join([]string{
+		i18n.N(result.Sent, "%d tâche envoyée", "%d tâches envoyées"),
+		i18n.N(result.Pushed, "%d tâche mise à jour", "%d tâches mises à jour"),
+		i18n.N(result.Removed, "%d tâche supprimée", "%d tâches supprimées"),
+	}, ", "))
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"lazy-todo/internal/i18n"
+	"lazy-todo/internal/webhook"
+)
+
+// runWebhooks implements `lazy-todo webhooks [--replay]`: it lists the
+// webhook deliveries that failed after their retries, or sends them again
+func runWebhooks(args []string) {
+	fs := flag.NewFlagSet("webhooks", flag.ExitOnError)
+	configPath := fs.String("config", "", i18n.T("Chemin vers le fichier de configuration"))
+	lang := fs.String("lang", "", i18n.T("Langue de l'interface (fr, en)"))
+	replay := fs.Bool("replay", false, i18n.T("Renvoyer les appels en échec"))
+	fs.Parse(args)
+
+	cfg := loadConfig(*configPath)
+	setLang(*lang)
+
+	sender, err := webhook.New(cfg.Webhooks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur de configuration: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+
+	if *replay {
+		sent, failed, err := sender.Replay()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+			os.Exit(1)
+		}
+		fmt.Println(i18n.N(sent, "%d appel renvoyé", "%d appels renvoyés") + ", " +
+			i18n.N(len(failed), "%d toujours en échec", "%d toujours en échec"))
+		if len(failed) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	failures, err := sender.Failures()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("Erreur: %v\n"), i18n.T(err.Error()))
+		os.Exit(1)
+	}
+	if len(failures) == 0 {
+		fmt.Println(i18n.T("Aucun appel de webhook en échec"))
+		return
+	}
+	for _, f := range failures {
+		fmt.Printf("%s  %-14s  %s  %s\n", f.Time.Local().Format("2006-01-02 15:04:05"), f.Event, f.URL, f.Error)
+	}
+}